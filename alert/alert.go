@@ -48,7 +48,7 @@ type Alert struct {
 	Builder *sdk.Alert
 
 	// For internal use only
-	Datasource   string
+	Datasource   *sdk.DatasourceRef
 	DashboardUID string
 	PanelID      string
 }
@@ -111,7 +111,7 @@ func (alert *Alert) HookDatasourceUID(uid string) {
 		for i := range rule.GrafanaAlert.Data {
 			query := &rule.GrafanaAlert.Data[i]
 
-			if query.RefID == alertConditionRef {
+			if query.RefID == alertConditionRef || IsExpression(query) {
 				continue
 			}
 
@@ -121,6 +121,12 @@ func (alert *Alert) HookDatasourceUID(uid string) {
 	}
 }
 
+// IsExpression tells if a query is a server-side expression (math, reduce,
+// classic condition, ...) rather than a query made to a datasource.
+func IsExpression(query *sdk.AlertQuery) bool {
+	return query.DatasourceUID == "-100" || query.DatasourceUID == "__expr__"
+}
+
 func (alert *Alert) HookDashboardUID(uid string) {
 	for _, rule := range alert.Builder.Rules {
 		rule.Annotations["__dashboardUid__"] = uid
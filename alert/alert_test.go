@@ -3,6 +3,7 @@ package alert
 import (
 	"testing"
 
+	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -84,6 +85,20 @@ func TestDatasourceUIDCanBeHooked(t *testing.T) {
 	req.True(hooked)
 }
 
+func TestHookingDatasourceUIDLeavesExpressionsUntouched(t *testing.T) {
+	req := require.New(t)
+
+	a := New("")
+	a.Builder.Rules[0].GrafanaAlert.Data = []sdk.AlertQuery{
+		{RefID: "A", DatasourceUID: "old-uid"},
+		{RefID: "B", DatasourceUID: "__expr__"},
+	}
+	a.HookDatasourceUID("ds-uid")
+
+	req.Equal("ds-uid", a.Builder.Rules[0].GrafanaAlert.Data[0].DatasourceUID)
+	req.Equal("__expr__", a.Builder.Rules[0].GrafanaAlert.Data[1].DatasourceUID)
+}
+
 func TestSummaryCanBeSet(t *testing.T) {
 	req := require.New(t)
 
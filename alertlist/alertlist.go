@@ -0,0 +1,311 @@
+package alertlist
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure an alert list panel.
+type Option func(alertList *AlertList) error
+
+// State represents an alert state that can be displayed by the panel.
+type State string
+
+const (
+	Firing  State = "firing"
+	Pending State = "pending"
+	NoData  State = "noData"
+	Normal  State = "normal"
+	Error   State = "error"
+)
+
+// GroupMode controls how alert instances are grouped.
+type GroupMode string
+
+const (
+	DefaultGrouping GroupMode = "default"
+	CustomGrouping  GroupMode = "custom"
+)
+
+// ViewMode controls how alerts are displayed.
+type ViewMode string
+
+const (
+	List ViewMode = "list"
+	Stat ViewMode = "stat"
+)
+
+// SortOrder controls the order in which alerts are displayed.
+type SortOrder int
+
+const (
+	AlphaAsc   SortOrder = 1
+	AlphaDesc  SortOrder = 2
+	Importance SortOrder = 3
+	TimeAsc    SortOrder = 4
+	TimeDesc   SortOrder = 5
+)
+
+type folderFilter struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+type stateFilter struct {
+	Firing  bool `json:"firing"`
+	Pending bool `json:"pending"`
+	NoData  bool `json:"noData"`
+	Normal  bool `json:"normal"`
+	Error   bool `json:"error"`
+}
+
+type options struct {
+	MaxItems                 int           `json:"maxItems"`
+	SortOrder                SortOrder     `json:"sortOrder"`
+	DashboardAlerts          bool          `json:"dashboardAlerts"`
+	GroupMode                GroupMode     `json:"groupMode"`
+	GroupBy                  []string      `json:"groupBy"`
+	AlertName                string        `json:"alertName"`
+	AlertInstanceLabelFilter string        `json:"alertInstanceLabelFilter"`
+	Folder                   *folderFilter `json:"folder,omitempty"`
+	StateFilter              stateFilter   `json:"stateFilter"`
+	ViewMode                 ViewMode      `json:"viewMode"`
+}
+
+// AlertList represents an alert list panel.
+type AlertList struct {
+	Builder *sdk.Panel
+
+	options *options
+}
+
+// New creates a new alert list panel.
+func New(title string, options ...Option) (*AlertList, error) {
+	panel := &AlertList{
+		Builder: sdk.NewCustom(title),
+		options: defaultOptions(),
+	}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Type = "alertlist"
+	panel.Builder.Renderer = nil
+	(*panel.Builder.CustomPanel)["options"] = panel.options
+
+	for _, opt := range append(defaults(), options...) {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+func defaultOptions() *options {
+	return &options{
+		GroupBy: []string{},
+		StateFilter: stateFilter{
+			Firing:  true,
+			Pending: true,
+		},
+	}
+}
+
+func defaults() []Option {
+	return []Option{
+		Span(6),
+		MaxItems(20),
+		Sort(AlphaAsc),
+		View(List),
+		Group(DefaultGrouping),
+	}
+}
+
+// Links adds links to be displayed on this panel.
+func Links(panelLinks ...links.Link) Option {
+	return func(alertList *AlertList) error {
+		alertList.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+
+		for _, link := range panelLinks {
+			alertList.Builder.Links = append(alertList.Builder.Links, link.Builder)
+		}
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(alertList *AlertList) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		alertList.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(alertList *AlertList) error {
+		alertList.Builder.Height = &height
+
+		return nil
+	}
+}
+
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(alertList *AlertList) error {
+		return layout.At(alertList.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(alertList *AlertList) error {
+		return layout.Size(alertList.Builder, width, height)
+	}
+}
+
+// Description annotates the current visualization with a human-readable description.
+func Description(content string) Option {
+	return func(alertList *AlertList) error {
+		alertList.Builder.Description = &content
+
+		return nil
+	}
+}
+
+// Transparent makes the background transparent.
+func Transparent() Option {
+	return func(alertList *AlertList) error {
+		alertList.Builder.Transparent = true
+
+		return nil
+	}
+}
+
+// States restricts the list to alerts in one of the given states.
+func States(states ...State) Option {
+	return func(alertList *AlertList) error {
+		filter := stateFilter{}
+
+		for _, state := range states {
+			switch state {
+			case Firing:
+				filter.Firing = true
+			case Pending:
+				filter.Pending = true
+			case NoData:
+				filter.NoData = true
+			case Normal:
+				filter.Normal = true
+			case Error:
+				filter.Error = true
+			default:
+				return fmt.Errorf("unknown alert state '%s': %w", state, errors.ErrInvalidArgument)
+			}
+		}
+
+		alertList.options.StateFilter = filter
+
+		return nil
+	}
+}
+
+// Folder only displays alerts defined in the given folder.
+func Folder(id uint, title string) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.Folder = &folderFilter{ID: id, Title: title}
+
+		return nil
+	}
+}
+
+// LabelFilter only displays alert instances matching the given label
+// matchers. Example: `severity="critical", team=~"infra|platform"`.
+func LabelFilter(matchers string) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.AlertInstanceLabelFilter = matchers
+
+		return nil
+	}
+}
+
+// AlertName only displays alerts whose name matches the given query.
+func AlertName(name string) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.AlertName = name
+
+		return nil
+	}
+}
+
+// OnlyDashboardAlerts only displays alerts linked to the current dashboard.
+func OnlyDashboardAlerts() Option {
+	return func(alertList *AlertList) error {
+		alertList.options.DashboardAlerts = true
+
+		return nil
+	}
+}
+
+// Group sets the grouping mode used for alert instances.
+func Group(mode GroupMode) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.GroupMode = mode
+
+		return nil
+	}
+}
+
+// GroupBy groups alert instances by the given labels. It implies a custom
+// group mode.
+func GroupBy(labels ...string) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.GroupMode = CustomGrouping
+		alertList.options.GroupBy = labels
+
+		return nil
+	}
+}
+
+// MaxItems sets the maximum number of alerts to display.
+func MaxItems(max int) Option {
+	return func(alertList *AlertList) error {
+		if max < 1 {
+			return fmt.Errorf("max items must be greater than zero: %w", errors.ErrInvalidArgument)
+		}
+
+		alertList.options.MaxItems = max
+
+		return nil
+	}
+}
+
+// Sort sets the order in which alerts are displayed.
+func Sort(order SortOrder) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.SortOrder = order
+
+		return nil
+	}
+}
+
+// View sets the way alerts are displayed.
+func View(mode ViewMode) Option {
+	return func(alertList *AlertList) error {
+		alertList.options.ViewMode = mode
+
+		return nil
+	}
+}
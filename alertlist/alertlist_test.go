@@ -0,0 +1,207 @@
+package alertlist
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertListPanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("Alerts")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("Alerts", panel.Builder.Title)
+	req.Equal("alertlist", panel.Builder.Type)
+	req.Equal(float32(6), panel.Builder.Span)
+	req.Equal(20, panel.options.MaxItems)
+	req.Equal(AlphaAsc, panel.options.SortOrder)
+	req.Equal(List, panel.options.ViewMode)
+	req.Equal(DefaultGrouping, panel.options.GroupMode)
+	req.True(panel.options.StateFilter.Firing)
+	req.True(panel.options.StateFilter.Pending)
+	req.Same(panel.options, (*panel.Builder.CustomPanel)["options"])
+}
+
+func TestAlertListPanelCanHaveLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Links(links.New("", "")))
+
+	req.NoError(err)
+	req.Len(panel.Builder.Links, 1)
+}
+
+func TestAlertListPanelWidthCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Span(8))
+
+	req.NoError(err)
+	req.Equal(float32(8), panel.Builder.Span)
+}
+
+func TestAlertListPanelRejectIncorrectWidth(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Span(-8))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestAlertListPanelHeightCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
+
+func TestAlertListPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidAlertListPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestAlertListPanelBackgroundCanBeTransparent(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transparent())
+
+	req.NoError(err)
+	req.True(panel.Builder.Transparent)
+}
+
+func TestAlertListPanelDescriptionCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Description("lala"))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.Description)
+	req.Equal("lala", *panel.Builder.Description)
+}
+
+func TestStatesCanBeFiltered(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", States(Firing, NoData, Error))
+
+	req.NoError(err)
+	req.Equal(stateFilter{Firing: true, NoData: true, Error: true}, panel.options.StateFilter)
+}
+
+func TestUnknownStatesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", States("unknown"))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestFolderCanBeFiltered(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Folder(42, "Infra"))
+
+	req.NoError(err)
+	req.NotNil(panel.options.Folder)
+	req.Equal(uint(42), panel.options.Folder.ID)
+	req.Equal("Infra", panel.options.Folder.Title)
+}
+
+func TestLabelMatchersCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", LabelFilter(`severity="critical"`))
+
+	req.NoError(err)
+	req.Equal(`severity="critical"`, panel.options.AlertInstanceLabelFilter)
+}
+
+func TestAlertNameCanBeFiltered(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", AlertName("latency"))
+
+	req.NoError(err)
+	req.Equal("latency", panel.options.AlertName)
+}
+
+func TestAlertsCanBeRestrictedToTheCurrentDashboard(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", OnlyDashboardAlerts())
+
+	req.NoError(err)
+	req.True(panel.options.DashboardAlerts)
+}
+
+func TestAlertsCanBeGroupedByLabels(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", GroupBy("team", "severity"))
+
+	req.NoError(err)
+	req.Equal(CustomGrouping, panel.options.GroupMode)
+	req.ElementsMatch([]string{"team", "severity"}, panel.options.GroupBy)
+}
+
+func TestMaxItemsCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", MaxItems(5))
+
+	req.NoError(err)
+	req.Equal(5, panel.options.MaxItems)
+}
+
+func TestMaxItemsMustBePositive(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", MaxItems(0))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestSortOrderCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Sort(Importance))
+
+	req.NoError(err)
+	req.Equal(Importance, panel.options.SortOrder)
+}
+
+func TestViewModeCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", View(Stat))
+
+	req.NoError(err)
+	req.Equal(Stat, panel.options.ViewMode)
+}
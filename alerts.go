@@ -21,6 +21,85 @@ type alertRef struct {
 	RuleGroup string
 }
 
+// ruleGroup is an alert rule group, as exposed by Grafana's ruler API. Rules
+// are kept as raw JSON so that settings unknown to the sdk survive being
+// copied from an instance to another.
+type ruleGroup struct {
+	Name     string            `json:"name"`
+	Interval string            `json:"interval,omitempty"`
+	Rules    []json.RawMessage `json:"rules"`
+}
+
+// ruleSummary holds the few settings of a rule telling where it belongs.
+type ruleSummary struct {
+	Annotations  map[string]string `json:"annotations"`
+	GrafanaAlert struct {
+		Title        string `json:"title"`
+		UID          string `json:"uid"`
+		NamespaceUID string `json:"namespace_uid"`
+	} `json:"grafana_alert"`
+}
+
+func summarizeRule(rule json.RawMessage) ruleSummary {
+	summary := ruleSummary{}
+	_ = json.Unmarshal(rule, &summary)
+
+	return summary
+}
+
+// namespaceUID returns the UID of the folder holding the group, as reported
+// by its rules.
+func (group ruleGroup) namespaceUID() string {
+	for _, rule := range group.Rules {
+		if uid := summarizeRule(rule).GrafanaAlert.NamespaceUID; uid != "" {
+			return uid
+		}
+	}
+
+	return ""
+}
+
+// ruleIdentifierFields lists the settings of a rule that are specific to the
+// instance it was read from.
+var ruleIdentifierFields = []string{"id", "uid", "namespace_id", "namespace_uid"}
+
+// portableRuleGroup removes the settings of the rules of a group that are
+// specific to the instance they were read from. Rules saved without UID
+// update the rules of the same dashboard and title, if any.
+func portableRuleGroup(group ruleGroup) (ruleGroup, error) {
+	portable := ruleGroup{Name: group.Name, Interval: group.Interval}
+
+	for _, rule := range group.Rules {
+		rule, err := editGrafanaRule(rule, func(grafanaRule map[string]json.RawMessage) error {
+			for _, field := range ruleIdentifierFields {
+				delete(grafanaRule, field)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return ruleGroup{}, err
+		}
+
+		portable.Rules = append(portable.Rules, rule)
+	}
+
+	return portable, nil
+}
+
+// dashboardRules keeps the rules of a group belonging to the given dashboard.
+func dashboardRules(group ruleGroup, dashboardUID string) ruleGroup {
+	filtered := ruleGroup{Name: group.Name, Interval: group.Interval}
+
+	for _, rule := range group.Rules {
+		if summarizeRule(rule).Annotations["__dashboardUid__"] == dashboardUID {
+			filtered.Rules = append(filtered.Rules, rule)
+		}
+	}
+
+	return filtered
+}
+
 // ConfigureAlertManager updates the alert manager configuration.
 func (client *Client) ConfigureAlertManager(ctx context.Context, manager *alertmanager.Manager) error {
 	buf, err := manager.MarshalIndentJSON()
@@ -28,7 +107,11 @@ func (client *Client) ConfigureAlertManager(ctx context.Context, manager *alertm
 		return err
 	}
 
-	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/alertmanager/grafana/config/api/v1/alerts", buf)
+	return client.setAlertManagerConfig(ctx, buf)
+}
+
+func (client *Client) setAlertManagerConfig(ctx context.Context, config []byte) error {
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, "/api/alertmanager/grafana/config/api/v1/alerts", config)
 	if err != nil {
 		return err
 	}
@@ -43,11 +126,19 @@ func (client *Client) ConfigureAlertManager(ctx context.Context, manager *alertm
 }
 
 // AddAlert creates an alert group within a given namespace.
+// The UID of the datasource used by the alert is looked up by name in the
+// given map.
+//
+// Deprecated: use AddAlertGroup() instead, which resolves datasources on its own.
 func (client *Client) AddAlert(ctx context.Context, namespace string, alertDefinition alert.Alert, datasourcesMap map[string]string) error {
-	// Find out which datasource the alert depends on, and inject its UID into the sdk definition
 	datasource := defaultDatasourceKey
-	if alertDefinition.Datasource != "" {
-		datasource = alertDefinition.Datasource
+	if ref := alertDefinition.Datasource; ref != nil {
+		switch {
+		case ref.LegacyName != "":
+			datasource = ref.LegacyName
+		case ref.UID != "":
+			return client.saveAlert(ctx, namespace, alertDefinition, ref.UID)
+		}
 	}
 
 	datasourceUID := datasourcesMap[datasource]
@@ -55,6 +146,38 @@ func (client *Client) AddAlert(ctx context.Context, namespace string, alertDefin
 		return fmt.Errorf("could not infer datasource UID from its name: %s", datasource)
 	}
 
+	return client.saveAlert(ctx, namespace, alertDefinition, datasourceUID)
+}
+
+// AddAlertGroup creates an alert group within a given namespace.
+// The datasource used by the alert is resolved against the datasources
+// known by the Grafana instance.
+func (client *Client) AddAlertGroup(ctx context.Context, namespace string, alertDefinition alert.Alert) error {
+	resolver, err := client.datasourceResolver(ctx)
+	if err != nil {
+		return err
+	}
+
+	return client.addAlert(ctx, namespace, alertDefinition, resolver)
+}
+
+func (client *Client) addAlert(ctx context.Context, namespace string, alertDefinition alert.Alert, resolver *datasourceResolver) error {
+	// Find out which datasource the alert depends on
+	datasourceUID, err := resolver.alertDatasourceUID(alertDefinition.Datasource)
+	if err != nil {
+		return fmt.Errorf("could not resolve datasource for alert '%s': %w", alertDefinition.Builder.Name, err)
+	}
+
+	return client.saveAlert(ctx, namespace, alertDefinition, datasourceUID)
+}
+
+func (client *Client) saveAlert(ctx context.Context, namespace string, alertDefinition alert.Alert, datasourceUID string) error {
+	alertDefinition, err := copyAlert(alertDefinition)
+	if err != nil {
+		return err
+	}
+
+	// Inject the UID of the datasource into the sdk definition
 	alertDefinition.HookDatasourceUID(datasourceUID)
 
 	// Before we can add this alert, we need to delete any other alert that might exist for this dashboard and panel
@@ -68,7 +191,24 @@ func (client *Client) AddAlert(ctx context.Context, namespace string, alertDefin
 	}
 
 	// Save the alert!
-	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/ruler/grafana/api/v1/rules/"+url.PathEscape(namespace), buf)
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, "/api/ruler/grafana/api/v1/rules/"+url.PathEscape(namespace), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// setRuleGroup creates or replaces an alert rule group within the folder
+// identified by the given UID.
+func (client *Client) setRuleGroup(ctx context.Context, folderUID string, group []byte) error {
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, "/api/ruler/grafana/api/v1/rules/"+url.PathEscape(folderUID), group)
 	if err != nil {
 		return err
 	}
@@ -82,6 +222,32 @@ func (client *Client) AddAlert(ctx context.Context, namespace string, alertDefin
 	return nil
 }
 
+// copyAlert deeply copies an alert, so that hooking it to a datasource or a
+// dashboard leaves the original untouched.
+func copyAlert(source alert.Alert) (alert.Alert, error) {
+	buf, err := json.Marshal(source.Builder)
+	if err != nil {
+		return alert.Alert{}, err
+	}
+
+	builder := &sdk.Alert{}
+	if err := json.Unmarshal(buf, builder); err != nil {
+		return alert.Alert{}, err
+	}
+
+	// empty annotations are omitted from the JSON model
+	for i := range builder.Rules {
+		if builder.Rules[i].Annotations == nil {
+			builder.Rules[i].Annotations = map[string]string{}
+		}
+	}
+
+	copied := source
+	copied.Builder = builder
+
+	return copied, nil
+}
+
 // DeleteAlertGroup deletes an alert group.
 func (client *Client) DeleteAlertGroup(ctx context.Context, namespace string, groupName string) error {
 	deleteURL := fmt.Sprintf("/api/ruler/grafana/api/v1/rules/%s/%s", url.PathEscape(namespace), url.PathEscape(groupName))
@@ -104,6 +270,28 @@ func (client *Client) DeleteAlertGroup(ctx context.Context, namespace string, gr
 
 // listAlertsForDashboard fetches a list of alerts linked to the given dashboard.
 func (client *Client) listAlertsForDashboard(ctx context.Context, dashboardUID string) ([]alertRef, error) {
+	alerts, err := client.alertGroupsForDashboard(ctx, dashboardUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []alertRef
+
+	for namespace := range alerts {
+		for _, a := range alerts[namespace] {
+			refs = append(refs, alertRef{
+				Namespace: namespace,
+				RuleGroup: a.Name,
+			})
+		}
+	}
+
+	return refs, nil
+}
+
+// alertGroupsForDashboard fetches the alert groups linked to the given
+// dashboard, by namespace.
+func (client *Client) alertGroupsForDashboard(ctx context.Context, dashboardUID string) (map[string][]sdk.Alert, error) {
 	resp, err := client.get(ctx, "/api/ruler/grafana/api/v1/rules?dashboard_uid="+url.QueryEscape(dashboardUID))
 	if err != nil {
 		return nil, err
@@ -120,16 +308,241 @@ func (client *Client) listAlertsForDashboard(ctx context.Context, dashboardUID s
 		return nil, err
 	}
 
-	var refs []alertRef
+	return alerts, nil
+}
 
-	for namespace := range alerts {
-		for _, a := range alerts[namespace] {
-			refs = append(refs, alertRef{
-				Namespace: namespace,
-				RuleGroup: a.Name,
+// dashboardRuleGroups fetches the alert rule groups holding rules of the given
+// dashboard, by namespace. Only the rules of the dashboard are part of the
+// returned groups.
+func (client *Client) dashboardRuleGroups(ctx context.Context, dashboardUID string) (map[string][]ruleGroup, error) {
+	body, err := client.getJSON(ctx, "/api/ruler/grafana/api/v1/rules?dashboard_uid="+url.QueryEscape(dashboardUID), ErrNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]ruleGroup{}
+	if err := json.Unmarshal(body, &namespaces); err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// ruleGroupByName fetches an alert rule group, given the UID of its folder.
+func (client *Client) ruleGroupByName(ctx context.Context, folderUID string, name string) (*ruleGroup, error) {
+	body, err := client.getJSON(ctx, fmt.Sprintf("/api/ruler/grafana/api/v1/rules/%s/%s", url.PathEscape(folderUID), url.PathEscape(name)), ErrAlertNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &ruleGroup{}
+	if err := json.Unmarshal(body, group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// replaceDashboardRules replaces the alert rules of a dashboard by the given
+// groups, within the folder identified by the given UID. Rules of other
+// dashboards sharing these groups are kept: the rules of the dashboard are
+// merged into existing groups, and removed from the groups it no longer uses.
+func (client *Client) replaceDashboardRules(ctx context.Context, folderUID string, dashboardUID string, groups []ruleGroup) error {
+	current, err := client.dashboardRuleGroups(ctx, dashboardUID)
+	if err != nil {
+		return fmt.Errorf("could not list alerts of dashboard: %w", err)
+	}
+
+	wanted := map[string]bool{}
+	for _, group := range groups {
+		wanted[group.Name] = true
+	}
+
+	for namespace, namespaceGroups := range current {
+		for _, group := range namespaceGroups {
+			groupFolderUID := group.namespaceUID()
+			if groupFolderUID == "" {
+				groupFolderUID = namespace
+			}
+
+			if groupFolderUID == folderUID && wanted[group.Name] {
+				continue
+			}
+
+			if err := client.mergeDashboardRules(ctx, groupFolderUID, dashboardUID, ruleGroup{Name: group.Name}); err != nil {
+				return fmt.Errorf("could not remove previous alerts of dashboard from group '%s': %w", group.Name, err)
+			}
+		}
+	}
+
+	for _, group := range groups {
+		if err := client.mergeDashboardRules(ctx, folderUID, dashboardUID, group); err != nil {
+			return fmt.Errorf("could not save alert group '%s': %w", group.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// mergeDashboardRules replaces the rules of a dashboard within an alert rule
+// group by the ones of the given group, leaving the rules of other dashboards
+// untouched. Groups left empty are deleted.
+func (client *Client) mergeDashboardRules(ctx context.Context, folderUID string, dashboardUID string, group ruleGroup) error {
+	existing, err := client.ruleGroupByName(ctx, folderUID, group.Name)
+	if err != nil && !errors.Is(err, ErrAlertNotFound) {
+		return err
+	}
+	if existing == nil {
+		existing = &ruleGroup{Name: group.Name}
+	}
+
+	merged := ruleGroup{Name: group.Name, Interval: existing.Interval}
+	if group.Interval != "" {
+		merged.Interval = group.Interval
+	}
+
+	// rules of the dashboard keep their UID, so that Grafana updates them
+	// instead of creating new ones
+	previousUIDs := map[string]string{}
+	for _, rule := range existing.Rules {
+		summary := summarizeRule(rule)
+		if summary.Annotations["__dashboardUid__"] == dashboardUID {
+			previousUIDs[summary.GrafanaAlert.Title] = summary.GrafanaAlert.UID
+			continue
+		}
+
+		merged.Rules = append(merged.Rules, rule)
+	}
+
+	for _, rule := range group.Rules {
+		summary := summarizeRule(rule)
+		previousUID := previousUIDs[summary.GrafanaAlert.Title]
+		if summary.GrafanaAlert.UID == "" && previousUID != "" {
+			rule, err = editGrafanaRule(rule, func(grafanaRule map[string]json.RawMessage) error {
+				uid, err := json.Marshal(previousUID)
+				grafanaRule["uid"] = uid
+
+				return err
 			})
+			if err != nil {
+				return err
+			}
 		}
+
+		merged.Rules = append(merged.Rules, rule)
 	}
 
-	return refs, nil
+	if len(merged.Rules) == 0 {
+		if len(existing.Rules) == 0 {
+			return nil
+		}
+
+		return client.DeleteAlertGroup(ctx, folderUID, group.Name)
+	}
+
+	buf, err := json.Marshal(merged)
+	if err != nil {
+		return err
+	}
+
+	return client.setRuleGroup(ctx, folderUID, buf)
+}
+
+// ruleUIDs lists the UIDs of the alert rules of the organization.
+func (client *Client) ruleUIDs(ctx context.Context) (map[string]bool, error) {
+	body, err := client.getJSON(ctx, "/api/ruler/grafana/api/v1/rules", ErrNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]ruleGroup{}
+	if err := json.Unmarshal(body, &namespaces); err != nil {
+		return nil, err
+	}
+
+	uids := map[string]bool{}
+	for _, groups := range namespaces {
+		for _, group := range groups {
+			for _, rule := range group.Rules {
+				if uid := summarizeRule(rule).GrafanaAlert.UID; uid != "" {
+					uids[uid] = true
+				}
+			}
+		}
+	}
+
+	return uids, nil
+}
+
+// editGrafanaRules calls the given function on the "grafana_alert" part of
+// each rule of a group. Everything else is left untouched.
+func editGrafanaRules(group json.RawMessage, edit func(rule map[string]json.RawMessage) error) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(group, &fields); err != nil {
+		return nil, err
+	}
+
+	if len(fields["rules"]) == 0 {
+		return group, nil
+	}
+
+	var rules []json.RawMessage
+	if err := json.Unmarshal(fields["rules"], &rules); err != nil {
+		return nil, err
+	}
+
+	for i := range rules {
+		var err error
+		if rules[i], err = editGrafanaRule(rules[i], edit); err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
+	}
+	fields["rules"] = buf
+
+	return json.Marshal(fields)
+}
+
+// editGrafanaRule calls the given function on the "grafana_alert" part of a
+// rule. Everything else is left untouched.
+func editGrafanaRule(rule json.RawMessage, edit func(rule map[string]json.RawMessage) error) (json.RawMessage, error) {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rule, &fields); err != nil {
+		return nil, err
+	}
+
+	var grafanaRule map[string]json.RawMessage
+	if len(fields["grafana_alert"]) != 0 {
+		if err := json.Unmarshal(fields["grafana_alert"], &grafanaRule); err != nil {
+			return nil, err
+		}
+	}
+	if grafanaRule == nil {
+		return rule, nil
+	}
+
+	if err := edit(grafanaRule); err != nil {
+		return nil, err
+	}
+
+	buf, err := json.Marshal(grafanaRule)
+	if err != nil {
+		return nil, err
+	}
+	fields["grafana_alert"] = buf
+
+	return json.Marshal(fields)
+}
+
+// rawString decodes a JSON string, returning an empty string for anything
+// else.
+func rawString(raw json.RawMessage) string {
+	var value string
+	_ = json.Unmarshal(raw, &value)
+
+	return value
 }
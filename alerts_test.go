@@ -3,11 +3,14 @@ package grabana
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/K-Phoen/grabana/alert"
 	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -95,3 +98,44 @@ func TestDeleteAlertGroupThatDoesNotExist(t *testing.T) {
 	req.ErrorIs(err, ErrAlertNotFound)
 	req.True(groupDeleted)
 }
+
+func TestAddAlertUsesTheGivenDatasourcesMap(t *testing.T) {
+	req := require.New(t)
+	var savedRule string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.NotEqual("/api/datasources", r.URL.Path)
+
+		if r.Method == http.MethodPost {
+			body, err := io.ReadAll(r.Body)
+			req.NoError(err)
+			savedRule = string(body)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	definition := alert.New("some alert", alert.WithPrometheusQuery("A", "up"))
+	definition.Datasource = &sdk.DatasourceRef{LegacyName: "Prometheus"}
+
+	err := client.AddAlert(context.TODO(), "ns", *definition, map[string]string{"Prometheus": "prom-uid"})
+
+	req.NoError(err)
+	req.Contains(savedRule, `"datasourceUid":"prom-uid"`)
+	req.Equal("Prometheus", definition.Datasource.LegacyName)
+}
+
+func TestAddAlertFailsWhenTheDatasourceIsNotInTheMap(t *testing.T) {
+	req := require.New(t)
+
+	client := NewClient(http.DefaultClient, "http://localhost")
+	definition := alert.New("some alert", alert.WithPrometheusQuery("A", "up"))
+
+	err := client.AddAlert(context.TODO(), "ns", *definition, map[string]string{})
+
+	req.Error(err)
+	req.Contains(err.Error(), "could not infer datasource UID")
+}
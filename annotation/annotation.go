@@ -0,0 +1,383 @@
+// Package annotation describes sources of annotations: events overlaid on
+// the panels of a dashboard, like deployments or error bursts.
+//
+// See https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/annotate-visualizations/
+package annotation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure an annotation.
+type Option func(annotation *Annotation) error
+
+// Source represents the kind of datasource queried for annotations.
+type Source string
+
+const (
+	// PrometheusSource queries annotations from Prometheus.
+	PrometheusSource Source = "prometheus"
+
+	// LokiSource queries annotations from Loki.
+	LokiSource Source = "loki"
+
+	// GrafanaSource queries annotations stored in Grafana itself.
+	GrafanaSource Source = "grafana"
+
+	// GraphiteSource queries annotations from Graphite.
+	GraphiteSource Source = "graphite"
+)
+
+// GrafanaDatasource is the name of Grafana's built-in datasource.
+const GrafanaDatasource = "-- Grafana --"
+
+// Annotation represents a source of annotations.
+type Annotation struct {
+	Builder *sdk.Annotation
+	Source  Source
+
+	hidden        bool
+	panels        []string
+	excludePanels bool
+
+	// settings not modelled by the sdk, merged into the JSON representation
+	// of the annotation.
+	settings map[string]interface{}
+}
+
+// Prometheus creates an annotation source querying Prometheus. Every series
+// returned by the given expression generates annotations.
+func Prometheus(name string, datasource string, expr string, options ...Option) (*Annotation, error) {
+	annotation := newAnnotation(PrometheusSource, name, datasource)
+	annotation.Builder.Expr = expr
+
+	return annotation.apply(options...)
+}
+
+// Loki creates an annotation source querying Loki. Every log line returned
+// by the given LogQL query generates an annotation.
+func Loki(name string, datasource string, query string, options ...Option) (*Annotation, error) {
+	annotation := newAnnotation(LokiSource, name, datasource)
+	annotation.Builder.Expr = query
+
+	return annotation.apply(options...)
+}
+
+// Grafana creates an annotation source querying the annotations stored in
+// Grafana itself. By default, only the annotations of the current dashboard
+// are shown.
+func Grafana(name string, options ...Option) (*Annotation, error) {
+	annotation := newAnnotation(GrafanaSource, name, GrafanaDatasource)
+	annotation.Builder.Type = "dashboard"
+
+	return annotation.apply(options...)
+}
+
+// Graphite creates an annotation source querying Graphite. Either Query()
+// or EventTags() should be used to define what generates annotations.
+func Graphite(name string, datasource string, options ...Option) (*Annotation, error) {
+	annotation := newAnnotation(GraphiteSource, name, datasource)
+
+	return annotation.apply(options...)
+}
+
+func newAnnotation(source Source, name string, datasource string) *Annotation {
+	return &Annotation{
+		Builder: &sdk.Annotation{
+			Name:       name,
+			Datasource: &sdk.DatasourceRef{LegacyName: datasource},
+			Enable:     true,
+			IconColor:  "rgba(0, 211, 255, 1)",
+		},
+		Source:   source,
+		settings: map[string]interface{}{},
+	}
+}
+
+func (annotation *Annotation) apply(options ...Option) (*Annotation, error) {
+	for _, opt := range options {
+		if err := opt(annotation); err != nil {
+			return nil, err
+		}
+	}
+
+	return annotation, nil
+}
+
+// Disabled shows the annotations only once toggled from the dashboard.
+func Disabled() Option {
+	return func(annotation *Annotation) error {
+		annotation.Builder.Enable = false
+
+		return nil
+	}
+}
+
+// DataSourceRef sets the datasource queried for annotations, referenced by
+// UID, name or type. It replaces the datasource given by name.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(annotation *Annotation) error {
+		annotation.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
+// Hidden hides the toggle used to show or hide the annotations from the
+// dashboard controls.
+func Hidden() Option {
+	return func(annotation *Annotation) error {
+		annotation.hidden = true
+
+		return nil
+	}
+}
+
+// Color sets the color used to display the annotations.
+// Example: "#5794F2" or "red".
+func Color(color string) Option {
+	return func(annotation *Annotation) error {
+		annotation.Builder.IconColor = color
+
+		return nil
+	}
+}
+
+// OnlyPanels shows the annotations on the given panels only, identified by
+// their titles.
+func OnlyPanels(titles ...string) Option {
+	return func(annotation *Annotation) error {
+		annotation.panels = titles
+		annotation.excludePanels = false
+
+		return nil
+	}
+}
+
+// ExceptPanels shows the annotations on every panel but the given ones,
+// identified by their titles.
+func ExceptPanels(titles ...string) Option {
+	return func(annotation *Annotation) error {
+		annotation.panels = titles
+		annotation.excludePanels = true
+
+		return nil
+	}
+}
+
+// Step defines the resolution of the query used to generate annotations.
+// Only supported by Prometheus annotations.
+// Example: "60s".
+func Step(step string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("step", PrometheusSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.Step = step
+
+		return nil
+	}
+}
+
+// TitleFormat defines the title of the generated annotations. Labels can be
+// used as templates.
+// Only supported by Prometheus and Loki annotations.
+// Example: "{{ service }} deployed".
+func TitleFormat(format string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("title format", PrometheusSource, LokiSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.TitleFormat = format
+
+		return nil
+	}
+}
+
+// TextFormat defines the text of the generated annotations. Labels can be
+// used as templates.
+// Only supported by Prometheus and Loki annotations.
+// Example: "{{ version }}".
+func TextFormat(format string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("text format", PrometheusSource, LokiSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.TextFormat = format
+
+		return nil
+	}
+}
+
+// TagKeys defines the labels used as tags for the generated annotations.
+// Only supported by Prometheus and Loki annotations.
+func TagKeys(labels ...string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("tag keys", PrometheusSource, LokiSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.TagKeys = strings.Join(labels, ",")
+
+		return nil
+	}
+}
+
+// SeriesValueAsTimestamp uses the value of the series as the timestamp of
+// the annotations, instead of the time at which they were sampled.
+// Only supported by Prometheus annotations.
+func SeriesValueAsTimestamp() Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("series value as timestamp", PrometheusSource); err != nil {
+			return err
+		}
+
+		annotation.settings["useValueForTime"] = true
+
+		return nil
+	}
+}
+
+// FilterByTags shows annotations from the whole organization, matching the
+// given tags. Without tags, every annotation of the organization is shown.
+// Only supported by Grafana annotations.
+func FilterByTags(tags ...string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("filter by tags", GrafanaSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.Type = "tags"
+		annotation.Builder.Tags = tags
+
+		return nil
+	}
+}
+
+// MatchAnyTag shows annotations matching any of the tags given to
+// FilterByTags(), instead of all of them.
+// Only supported by Grafana annotations.
+func MatchAnyTag() Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("match any tag", GrafanaSource); err != nil {
+			return err
+		}
+
+		annotation.settings["matchAny"] = true
+
+		return nil
+	}
+}
+
+// Limit sets the maximum number of annotations to show.
+// Only supported by Grafana annotations.
+func Limit(limit int) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("limit", GrafanaSource); err != nil {
+			return err
+		}
+		if limit < 1 {
+			return fmt.Errorf("limit must be positive: %w", errors.ErrInvalidArgument)
+		}
+
+		annotation.settings["limit"] = limit
+
+		return nil
+	}
+}
+
+// Query defines the Graphite query used to generate annotations.
+// Only supported by Graphite annotations.
+// Example: "alias(deploys.*, 'deploys')".
+func Query(query string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("query", GraphiteSource); err != nil {
+			return err
+		}
+
+		annotation.settings["target"] = query
+
+		return nil
+	}
+}
+
+// EventTags shows the Graphite events matching the given tags.
+// Only supported by Graphite annotations.
+func EventTags(tags ...string) Option {
+	return func(annotation *Annotation) error {
+		if err := annotation.supportedBy("event tags", GraphiteSource); err != nil {
+			return err
+		}
+
+		annotation.Builder.Tags = tags
+
+		return nil
+	}
+}
+
+// Panels returns the titles of the panels the annotations are restricted
+// to, or excluded from. Empty if the annotations are shown on every panel.
+func (annotation *Annotation) Panels() []string {
+	return annotation.panels
+}
+
+// Model returns the representation of the annotation expected by Grafana.
+// Panels are referenced by IDs: the given map translates their titles into
+// IDs.
+func (annotation *Annotation) Model(panelIDs map[string]uint) (map[string]interface{}, error) {
+	content, err := json.Marshal(annotation.Builder)
+	if err != nil {
+		return nil, err
+	}
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, err
+	}
+
+	for key, value := range annotation.settings {
+		model[key] = value
+	}
+
+	model["hide"] = annotation.hidden
+
+	if len(annotation.panels) == 0 {
+		return model, nil
+	}
+
+	ids := make([]uint, 0, len(annotation.panels))
+	for _, title := range annotation.panels {
+		id, ok := panelIDs[title]
+		if !ok {
+			return nil, fmt.Errorf("annotation '%s' refers to unknown panel '%s': %w", annotation.Builder.Name, title, errors.ErrInvalidArgument)
+		}
+
+		ids = append(ids, id)
+	}
+
+	model["filter"] = map[string]interface{}{
+		"exclude": annotation.excludePanels,
+		"ids":     ids,
+	}
+
+	return model, nil
+}
+
+func (annotation *Annotation) supportedBy(option string, sources ...Source) error {
+	for _, source := range sources {
+		if annotation.Source == source {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not supported by %s annotations: %w", option, annotation.Source, errors.ErrInvalidArgument)
+}
@@ -0,0 +1,186 @@
+package annotation
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrometheusAnnotationsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Prometheus(
+		"Deployments",
+		"prometheus",
+		"changes(deployed_version[1m]) > 0",
+		Step("60s"),
+		TitleFormat("{{ service }} deployed"),
+		TextFormat("{{ version }}"),
+		TagKeys("service", "env"),
+		SeriesValueAsTimestamp(),
+	)
+
+	req.NoError(err)
+	req.Equal(PrometheusSource, annotation.Source)
+	req.Equal("Deployments", annotation.Builder.Name)
+	req.Equal("prometheus", annotation.Builder.Datasource.LegacyName)
+	req.True(annotation.Builder.Enable)
+	req.Equal("changes(deployed_version[1m]) > 0", annotation.Builder.Expr)
+	req.Equal("60s", annotation.Builder.Step)
+	req.Equal("{{ service }} deployed", annotation.Builder.TitleFormat)
+	req.Equal("{{ version }}", annotation.Builder.TextFormat)
+	req.Equal("service,env", annotation.Builder.TagKeys)
+
+	model, err := annotation.Model(nil)
+	req.NoError(err)
+	req.Equal(true, model["useValueForTime"])
+}
+
+func TestLokiAnnotationsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Loki(
+		"Errors",
+		"loki",
+		`{app="api"} |= "error"`,
+		TagKeys("level"),
+		TitleFormat("{{ app }}"),
+	)
+
+	req.NoError(err)
+	req.Equal(LokiSource, annotation.Source)
+	req.Equal(`{app="api"} |= "error"`, annotation.Builder.Expr)
+	req.Equal("level", annotation.Builder.TagKeys)
+	req.Equal("{{ app }}", annotation.Builder.TitleFormat)
+}
+
+func TestGrafanaAnnotationsShowTheCurrentDashboardByDefault(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations")
+
+	req.NoError(err)
+	req.Equal(GrafanaDatasource, annotation.Builder.Datasource.LegacyName)
+	req.Equal("dashboard", annotation.Builder.Type)
+}
+
+func TestGrafanaAnnotationsCanBeFilteredByTags(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations", FilterByTags("deploy", "prod"), MatchAnyTag(), Limit(50))
+
+	req.NoError(err)
+	req.Equal("tags", annotation.Builder.Type)
+	req.ElementsMatch([]string{"deploy", "prod"}, annotation.Builder.Tags)
+
+	model, err := annotation.Model(nil)
+	req.NoError(err)
+	req.Equal(true, model["matchAny"])
+	req.Equal(50, model["limit"])
+}
+
+func TestInvalidLimitIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := Grafana("Annotations", Limit(0))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestGraphiteAnnotationsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	fromQuery, err := Graphite("Deploys", "graphite", Query("events.deploys"))
+	req.NoError(err)
+
+	model, err := fromQuery.Model(nil)
+	req.NoError(err)
+	req.Equal("events.deploys", model["target"])
+
+	fromEvents, err := Graphite("Deploys", "graphite", EventTags("deploy"))
+	req.NoError(err)
+	req.Equal([]string{"deploy"}, fromEvents.Builder.Tags)
+}
+
+func TestOptionsSpecificToASourceAreRejectedForOthers(t *testing.T) {
+	req := require.New(t)
+
+	_, err := Loki("Errors", "loki", "{}", Step("1m"))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = Prometheus("Deploys", "prometheus", "up", FilterByTags("deploy"))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = Grafana("Annotations", Query("events.deploys"))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestAnnotationsCanBeDisabledAndHidden(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations", Disabled(), Hidden(), Color("#5794F2"))
+	req.NoError(err)
+
+	model, err := annotation.Model(nil)
+	req.NoError(err)
+
+	req.Equal(false, model["enable"])
+	req.Equal(true, model["hide"])
+	req.Equal("#5794F2", model["iconColor"])
+}
+
+func TestAnnotationsDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Loki("Deployments", "", `{app="deployer"}`, DataSourceRef(datasource.ByUID("loki-uid")))
+	req.NoError(err)
+
+	model, err := annotation.Model(nil)
+	req.NoError(err)
+
+	req.Equal(map[string]interface{}{"UID": "loki-uid", "type": ""}, model["datasource"])
+}
+
+func TestAnnotationsCanBeRestrictedToSomePanels(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations", OnlyPanels("Latency", "Errors"))
+	req.NoError(err)
+	req.Equal([]string{"Latency", "Errors"}, annotation.Panels())
+
+	model, err := annotation.Model(map[string]uint{"Latency": 2, "Errors": 4})
+	req.NoError(err)
+
+	req.Equal(map[string]interface{}{
+		"exclude": false,
+		"ids":     []uint{2, 4},
+	}, model["filter"])
+}
+
+func TestAnnotationsCanBeExcludedFromSomePanels(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations", ExceptPanels("Latency"))
+	req.NoError(err)
+
+	model, err := annotation.Model(map[string]uint{"Latency": 2})
+	req.NoError(err)
+
+	req.Equal(map[string]interface{}{
+		"exclude": true,
+		"ids":     []uint{2},
+	}, model["filter"])
+}
+
+func TestAnnotationsReferringToUnknownPanelsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	annotation, err := Grafana("Annotations", OnlyPanels("Unknown"))
+	req.NoError(err)
+
+	_, err = annotation.Model(map[string]uint{})
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
@@ -0,0 +1,95 @@
+package grabana
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnauthorized is matched by API errors with a 401 status: credentials
+// are missing or invalid.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is matched by API errors with a 403 status: credentials are
+// valid but lack the required permissions.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrNotFound is matched by API errors with a 404 status.
+var ErrNotFound = errors.New("not found")
+
+// ErrConflict is matched by API errors with a 409 status.
+var ErrConflict = errors.New("conflict")
+
+// ErrPreconditionFailed is matched by API errors with a 412 status, like
+// the ones returned when a dashboard was changed by someone else.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// APIError represents an error returned by Grafana's HTTP API.
+// It can be checked against ErrUnauthorized, ErrForbidden, ErrNotFound,
+// ErrConflict and ErrPreconditionFailed with errors.Is().
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Message is the error message returned by Grafana, or the raw response
+	// body when it isn't a JSON error.
+	Message string
+	// MessageID is the stable identifier of the error, for Grafana versions
+	// returning one. Example: "dashboards.notFound"
+	MessageID string
+	// Method is the HTTP method of the failed request.
+	Method string
+	// Path is the path of the failed request.
+	Path string
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(bytes.TrimSpace(body)),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	var payload struct {
+		Message   string `json:"message"`
+		MessageID string `json:"messageId"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.Message != "" {
+		apiErr.Message = payload.Message
+		apiErr.MessageID = payload.MessageID
+	}
+
+	return apiErr
+}
+
+// Error implements the error interface.
+func (err *APIError) Error() string {
+	if err.Path == "" {
+		return fmt.Sprintf("could not query grafana: %s (HTTP status %d)", err.Message, err.StatusCode)
+	}
+
+	return fmt.Sprintf("could not query grafana: %s %s: %s (HTTP status %d)", err.Method, err.Path, err.Message, err.StatusCode)
+}
+
+// Is tells if the error matches the given target, based on its HTTP status.
+func (err *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return err.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return err.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return err.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return err.StatusCode == http.StatusConflict
+	case ErrPreconditionFailed:
+		return err.StatusCode == http.StatusPreconditionFailed
+	}
+
+	return false
+}
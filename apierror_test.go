@@ -0,0 +1,85 @@
+package grabana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorsDescribeGrafanaErrors(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "Permission denied", "messageId": "auth.forbidden"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.APIKeys(context.TODO())
+	req.Error(err)
+
+	apiErr := &APIError{}
+	req.True(errors.As(err, &apiErr))
+	req.Equal(http.StatusForbidden, apiErr.StatusCode)
+	req.Equal("Permission denied", apiErr.Message)
+	req.Equal("auth.forbidden", apiErr.MessageID)
+	req.Equal(http.MethodGet, apiErr.Method)
+	req.Equal("/api/auth/keys", apiErr.Path)
+	req.Equal("could not query grafana: GET /api/auth/keys: Permission denied (HTTP status 403)", err.Error())
+}
+
+func TestAPIErrorsKeepNonJSONBodies(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprintln(w, `upstream went away`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.APIKeys(context.TODO())
+	req.Error(err)
+
+	apiErr := &APIError{}
+	req.True(errors.As(err, &apiErr))
+	req.Equal("upstream went away", apiErr.Message)
+	req.Empty(apiErr.MessageID)
+}
+
+func TestAPIErrorsCanBeMatchedByStatus(t *testing.T) {
+	testCases := []struct {
+		status   int
+		expected error
+	}{
+		{status: http.StatusUnauthorized, expected: ErrUnauthorized},
+		{status: http.StatusForbidden, expected: ErrForbidden},
+		{status: http.StatusNotFound, expected: ErrNotFound},
+		{status: http.StatusConflict, expected: ErrConflict},
+		{status: http.StatusPreconditionFailed, expected: ErrPreconditionFailed},
+	}
+
+	sentinels := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrPreconditionFailed}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.expected.Error(), func(t *testing.T) {
+			req := require.New(t)
+
+			err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tc.status})
+
+			for _, sentinel := range sentinels {
+				req.Equal(sentinel == tc.expected, errors.Is(err, sentinel), sentinel.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,790 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/K-Phoen/grabana/librarypanel"
+)
+
+// ErrNotABackupDir is returned when writing a backup into a directory that
+// already holds files that are not part of a previous backup.
+var ErrNotABackupDir = errors.New("directory does not hold a backup")
+
+const libraryPanelsPerPage = 100
+
+// Files and directories making up a backup on disk.
+const (
+	backupMarkerFile       = ".grabana-backup"
+	backupDatasourcesDir   = "datasources"
+	backupFoldersFile      = "folders.json"
+	backupLibraryPanelsDir = "library-panels"
+	backupDashboardsDir    = "dashboards"
+	backupAlertRulesDir    = "alert-rules"
+	backupAlertManagerFile = "alertmanager.json"
+)
+
+// backupDatasourceIgnoredFields lists the settings of datasources left out of
+// backups: identifiers specific to an instance, and passwords that older
+// versions of Grafana expose in plain text.
+var backupDatasourceIgnoredFields = []string{"id", "orgId", "version", "readOnly", "password", "basicAuthPassword"}
+
+// Backup holds the resources of a Grafana organization, as saved by
+// Client.Backup().
+// Secrets are not part of backups: secure settings of datasources and contact
+// points must be provided again once restored.
+type Backup struct {
+	Datasources   []BackupDatasource
+	Folders       []BackupFolder
+	LibraryPanels []BackupLibraryPanel
+	Dashboards    []BackupDashboard
+	AlertGroups   []BackupAlertGroup
+	// AlertManager is the configuration of the alert manager, as exposed by
+	// Grafana.
+	AlertManager json.RawMessage
+}
+
+// BackupDatasource is the JSON model of a datasource, as exposed by Grafana,
+// without its identifiers and passwords.
+type BackupDatasource json.RawMessage
+
+// BackupFolder is a folder, saved along with the UID of its parent.
+type BackupFolder struct {
+	UID       string `json:"uid"`
+	ParentUID string `json:"parentUid,omitempty"`
+	Title     string `json:"title"`
+}
+
+// BackupLibraryPanel is a library panel, saved along with the UID of its
+// folder.
+type BackupLibraryPanel struct {
+	UID       string          `json:"uid"`
+	Name      string          `json:"name"`
+	FolderUID string          `json:"folderUid,omitempty"`
+	Model     json.RawMessage `json:"model"`
+}
+
+// BackupDashboard is the JSON model of a dashboard, saved along with the UID
+// of its folder.
+type BackupDashboard struct {
+	UID       string          `json:"uid"`
+	FolderUID string          `json:"folderUid,omitempty"`
+	Dashboard json.RawMessage `json:"dashboard"`
+}
+
+// BackupAlertGroup is the JSON model of an alert rule group, as exposed by
+// Grafana, saved along with the UID of the folder holding it.
+type BackupAlertGroup struct {
+	FolderUID string
+	Group     json.RawMessage
+}
+
+// Name returns the name of the alert rule group.
+func (group BackupAlertGroup) Name() string {
+	return group.ruleGroup().Name
+}
+
+func (group BackupAlertGroup) ruleGroup() ruleGroup {
+	decoded := ruleGroup{}
+	_ = json.Unmarshal(group.Group, &decoded)
+
+	return decoded
+}
+
+// UID returns the UID of the datasource.
+func (datasource BackupDatasource) UID() string {
+	return datasource.field("uid")
+}
+
+// Name returns the name of the datasource.
+func (datasource BackupDatasource) Name() string {
+	return datasource.field("name")
+}
+
+// MarshalJSON implements the encoding/json.Marshaler interface.
+func (datasource BackupDatasource) MarshalJSON() ([]byte, error) {
+	return datasource, nil
+}
+
+func (datasource BackupDatasource) field(name string) string {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(datasource, &fields); err != nil {
+		return ""
+	}
+
+	value, _ := fields[name].(string)
+
+	return value
+}
+
+// Backup saves the datasources, folders, library panels, dashboards, alert
+// rule groups, contact points and routing tree of the organization.
+func (client *Client) Backup(ctx context.Context) (*Backup, error) {
+	var err error
+	backup := &Backup{}
+
+	if backup.Datasources, err = client.backupDatasources(ctx); err != nil {
+		return nil, fmt.Errorf("could not back up datasources: %w", err)
+	}
+	if backup.Folders, err = client.backupFolders(ctx); err != nil {
+		return nil, fmt.Errorf("could not back up folders: %w", err)
+	}
+	if backup.LibraryPanels, err = client.backupLibraryPanels(ctx); err != nil {
+		return nil, fmt.Errorf("could not back up library panels: %w", err)
+	}
+	if backup.Dashboards, err = client.backupDashboards(ctx); err != nil {
+		return nil, fmt.Errorf("could not back up dashboards: %w", err)
+	}
+	if backup.AlertGroups, err = client.backupAlertGroups(ctx, backup.Folders); err != nil {
+		return nil, fmt.Errorf("could not back up alerts: %w", err)
+	}
+	if backup.AlertManager, err = client.backupAlertManager(ctx); err != nil {
+		return nil, fmt.Errorf("could not back up alert manager configuration: %w", err)
+	}
+
+	return backup, nil
+}
+
+func (client *Client) backupDatasources(ctx context.Context) ([]BackupDatasource, error) {
+	resolver, err := client.datasourceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	datasources := make([]BackupDatasource, 0, len(resolver.datasources))
+	for _, summary := range resolver.datasources {
+		datasource, err := client.getJSON(ctx, "/api/datasources/uid/"+url.PathEscape(summary.UID), ErrDatasourceNotFound)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch datasource '%s': %w", summary.Name, err)
+		}
+
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(datasource, &fields); err != nil {
+			return nil, fmt.Errorf("could not decode datasource '%s': %w", summary.Name, err)
+		}
+		for _, field := range backupDatasourceIgnoredFields {
+			delete(fields, field)
+		}
+
+		if datasource, err = json.Marshal(fields); err != nil {
+			return nil, err
+		}
+
+		datasources = append(datasources, BackupDatasource(datasource))
+	}
+
+	sort.Slice(datasources, func(i, j int) bool {
+		return datasources[i].UID() < datasources[j].UID()
+	})
+
+	return datasources, nil
+}
+
+func (client *Client) backupFolders(ctx context.Context) ([]BackupFolder, error) {
+	var folders []BackupFolder
+
+	seen := map[string]bool{}
+	parents := []string{""}
+
+	for len(parents) != 0 {
+		parentUID := parents[0]
+		parents = parents[1:]
+
+		subfolders, err := client.Subfolders(ctx, parentUID)
+		// instances without nested folders only have top level folders
+		if errors.Is(err, ErrNestedFoldersUnsupported) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, folder := range subfolders {
+			if seen[folder.UID] {
+				continue
+			}
+
+			seen[folder.UID] = true
+			parents = append(parents, folder.UID)
+			folders = append(folders, BackupFolder{
+				UID:       folder.UID,
+				ParentUID: folder.ParentUID,
+				Title:     folder.Title,
+			})
+		}
+	}
+
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].UID < folders[j].UID
+	})
+
+	return folders, nil
+}
+
+func (client *Client) backupLibraryPanels(ctx context.Context) ([]BackupLibraryPanel, error) {
+	var panels []BackupLibraryPanel
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("kind", fmt.Sprint(libraryPanelKind))
+		params.Set("perPage", fmt.Sprint(libraryPanelsPerPage))
+		params.Set("page", fmt.Sprint(page))
+
+		var response struct {
+			Result struct {
+				TotalCount int                  `json:"totalCount"`
+				Elements   []BackupLibraryPanel `json:"elements"`
+			} `json:"result"`
+		}
+
+		body, err := client.getJSON(ctx, "/api/library-elements?"+params.Encode(), ErrLibraryPanelNotFound)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+
+		panels = append(panels, response.Result.Elements...)
+
+		if len(response.Result.Elements) == 0 || len(panels) >= response.Result.TotalCount {
+			break
+		}
+	}
+
+	sort.Slice(panels, func(i, j int) bool {
+		return panels[i].UID < panels[j].UID
+	})
+
+	return panels, nil
+}
+
+func (client *Client) backupDashboards(ctx context.Context) ([]BackupDashboard, error) {
+	hits, err := client.SearchDashboards(ctx, SearchQuery{})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboards := make([]BackupDashboard, 0, len(hits))
+	for _, hit := range hits {
+		body, err := client.getJSON(ctx, "/api/dashboards/uid/"+url.PathEscape(hit.UID), ErrDashboardNotFound)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch dashboard '%s': %w", hit.Title, err)
+		}
+
+		var response struct {
+			Dashboard json.RawMessage `json:"dashboard"`
+			Meta      struct {
+				FolderUID string `json:"folderUid"`
+			} `json:"meta"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return nil, err
+		}
+
+		dashboards = append(dashboards, BackupDashboard{
+			UID:       hit.UID,
+			FolderUID: response.Meta.FolderUID,
+			Dashboard: response.Dashboard,
+		})
+	}
+
+	sort.Slice(dashboards, func(i, j int) bool {
+		return dashboards[i].UID < dashboards[j].UID
+	})
+
+	return dashboards, nil
+}
+
+func (client *Client) backupAlertGroups(ctx context.Context, folders []BackupFolder) ([]BackupAlertGroup, error) {
+	body, err := client.getJSON(ctx, "/api/ruler/grafana/api/v1/rules", ErrNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]json.RawMessage{}
+	if err := json.Unmarshal(body, &namespaces); err != nil {
+		return nil, err
+	}
+
+	knownFolders := map[string]bool{}
+	foldersByTitle := map[string][]string{}
+	for _, folder := range folders {
+		knownFolders[folder.UID] = true
+		foldersByTitle[folder.Title] = append(foldersByTitle[folder.Title], folder.UID)
+	}
+
+	var groups []BackupAlertGroup
+	for namespace, namespaceGroups := range namespaces {
+		for _, rawGroup := range namespaceGroups {
+			group := BackupAlertGroup{Group: rawGroup}
+
+			// namespaces are listed by folder title, which isn't unique:
+			// rules know the UID of their folder.
+			group.FolderUID = group.ruleGroup().namespaceUID()
+			if group.FolderUID == "" {
+				candidates := foldersByTitle[namespace]
+				if len(candidates) > 1 {
+					return nil, fmt.Errorf("could not tell which of the %d folders titled '%s' holds alert group '%s'", len(candidates), namespace, group.Name())
+				}
+				if len(candidates) == 1 {
+					group.FolderUID = candidates[0]
+				}
+			}
+
+			if !knownFolders[group.FolderUID] {
+				return nil, fmt.Errorf("could not find folder '%s' of alert group '%s': %w", namespace, group.Name(), ErrFolderNotFound)
+			}
+
+			groups = append(groups, group)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].FolderUID != groups[j].FolderUID {
+			return groups[i].FolderUID < groups[j].FolderUID
+		}
+
+		return groups[i].Name() < groups[j].Name()
+	})
+
+	return groups, nil
+}
+
+func (client *Client) backupAlertManager(ctx context.Context) (json.RawMessage, error) {
+	return client.getJSON(ctx, "/api/alertmanager/grafana/config/api/v1/alerts", ErrNotFound)
+}
+
+// getJSON fetches the raw JSON found at the given path. A 404 is reported as
+// notFoundErr.
+func (client *Client) getJSON(ctx context.Context, path string, notFoundErr error) (json.RawMessage, error) {
+	resp, err := client.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, notFoundErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var body json.RawMessage
+	if err := decodeJSON(resp.Body, &body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// Restore replays a backup in dependency order: datasources, folders, library
+// panels, dashboards, contact points and routing tree, and finally alert rule
+// groups.
+// Existing resources are updated. Folders are matched by title within their
+// parent, other resources by UID or name. Alert rule groups are restored
+// within the folder matching the one they were saved from.
+func (client *Client) Restore(ctx context.Context, backup *Backup) error {
+	for _, datasource := range backup.Datasources {
+		if err := client.UpsertDatasource(ctx, datasource); err != nil {
+			return fmt.Errorf("could not restore datasource '%s': %w", datasource.Name(), err)
+		}
+	}
+
+	folders, err := client.restoreFolders(ctx, backup.Folders)
+	if err != nil {
+		return err
+	}
+
+	folderByUID := func(uid string) (*Folder, error) {
+		folder, ok := folders[uid]
+		if !ok {
+			return nil, fmt.Errorf("folder '%s' is not part of the backup: %w", uid, ErrFolderNotFound)
+		}
+
+		return folder, nil
+	}
+
+	for _, panel := range backup.LibraryPanels {
+		folder, err := folderByUID(panel.FolderUID)
+		if err != nil {
+			return fmt.Errorf("could not restore library panel '%s': %w", panel.UID, err)
+		}
+
+		// models are restored as they were saved: they reference datasources
+		// by UID, and Grafana copes with the ones that no longer exist
+		definition := &librarypanel.Definition{UID: panel.UID, Name: panel.Name}
+		if _, err := client.upsertLibraryPanel(ctx, folder, definition, panel.Model); err != nil {
+			return fmt.Errorf("could not restore library panel '%s': %w", panel.UID, err)
+		}
+	}
+
+	for _, backupDashboard := range backup.Dashboards {
+		folder, err := folderByUID(backupDashboard.FolderUID)
+		if err != nil {
+			return fmt.Errorf("could not restore dashboard '%s': %w", backupDashboard.UID, err)
+		}
+
+		board, err := restoredDashboardModel(backupDashboard, backup.AlertGroups)
+		if err != nil {
+			return fmt.Errorf("could not restore dashboard '%s': %w", backupDashboard.UID, err)
+		}
+
+		if _, err := client.persistDashboard(ctx, folder, board); err != nil {
+			return fmt.Errorf("could not restore dashboard '%s': %w", backupDashboard.UID, err)
+		}
+	}
+
+	if len(backup.AlertManager) != 0 {
+		if err := client.setAlertManagerConfig(ctx, backup.AlertManager); err != nil {
+			return fmt.Errorf("could not restore alert manager configuration: %w", err)
+		}
+	}
+
+	if len(backup.AlertGroups) == 0 {
+		return nil
+	}
+
+	existingRules, err := client.ruleUIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list existing alerts: %w", err)
+	}
+
+	for _, group := range backup.AlertGroups {
+		folder, err := folderByUID(group.FolderUID)
+		if err != nil {
+			return fmt.Errorf("could not restore alert '%s': %w", group.Name(), err)
+		}
+
+		// Grafana refuses to update rules it doesn't know: rules missing from
+		// this instance are created instead.
+		payload, err := editGrafanaRules(group.Group, func(rule map[string]json.RawMessage) error {
+			if !existingRules[rawString(rule["uid"])] {
+				delete(rule, "uid")
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("could not restore alert '%s': %w", group.Name(), err)
+		}
+
+		if err := client.setRuleGroup(ctx, folder.UID, payload); err != nil {
+			return fmt.Errorf("could not restore alert '%s': %w", group.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// restoredDashboardModel prepares the JSON model of a backed up dashboard to
+// be saved as is: datasources are not resolved, so that datasources missing
+// from the instance don't prevent the rest of the backup from being restored.
+func restoredDashboardModel(backupDashboard BackupDashboard, groups []BackupAlertGroup) ([]byte, error) {
+	model := map[string]json.RawMessage{}
+	if err := json.Unmarshal(backupDashboard.Dashboard, &model); err != nil {
+		return nil, err
+	}
+
+	// IDs are specific to the instance the dashboard was saved from, and so
+	// are the alerts it snapshotted
+	delete(model, "id")
+	delete(model, alertsSnapshotKey)
+
+	board, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+
+	// rolling back to this version restores the alerts of the backup
+	var ruleGroups []ruleGroup
+	for _, group := range groups {
+		if rules := dashboardRules(group.ruleGroup(), backupDashboard.UID); len(rules.Rules) != 0 {
+			ruleGroups = append(ruleGroups, rules)
+		}
+	}
+	if len(ruleGroups) == 0 {
+		return board, nil
+	}
+
+	return withAlertsSnapshot(board, nil, ruleGroups)
+}
+
+// restoreFolders finds or creates the given folders, parents first. The
+// folders are returned by their UID within the backup.
+func (client *Client) restoreFolders(ctx context.Context, folders []BackupFolder) (map[string]*Folder, error) {
+	backupFolders := map[string]BackupFolder{}
+	for _, folder := range folders {
+		backupFolders[folder.UID] = folder
+	}
+
+	restored := map[string]*Folder{
+		"": {Title: "General"},
+	}
+
+	var restore func(folder BackupFolder) (*Folder, error)
+	restore = func(folder BackupFolder) (*Folder, error) {
+		if restoredFolder, ok := restored[folder.UID]; ok {
+			return restoredFolder, nil
+		}
+
+		parent, ok := restored[folder.ParentUID]
+		if !ok {
+			parentFolder, ok := backupFolders[folder.ParentUID]
+			if !ok {
+				return nil, fmt.Errorf("could not restore folder '%s': parent '%s': %w", folder.Title, folder.ParentUID, ErrFolderNotFound)
+			}
+
+			var err error
+			if parent, err = restore(parentFolder); err != nil {
+				return nil, err
+			}
+		}
+
+		restoredFolder, err := client.findSubfolder(ctx, parent.UID, folder.Title)
+		if errors.Is(err, ErrFolderNotFound) {
+			restoredFolder, err = client.CreateSubfolder(ctx, parent.UID, folder.Title)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not restore folder '%s': %w", folder.Title, err)
+		}
+
+		restored[folder.UID] = restoredFolder
+
+		return restoredFolder, nil
+	}
+
+	for _, folder := range folders {
+		if _, err := restore(folder); err != nil {
+			return nil, err
+		}
+	}
+
+	return restored, nil
+}
+
+// WriteDir saves the backup within the given directory, using a layout that
+// only changes when the backed up resources do:
+//
+//	datasources/<uid>.json
+//	folders.json
+//	library-panels/<uid>.json
+//	dashboards/<uid>.json
+//	alert-rules/<folder uid>.json
+//	alertmanager.json
+//
+// Files left by a previous backup in the same directory are replaced. To
+// avoid deleting unrelated files, the directory must either be empty or hold
+// a previous backup: ErrNotABackupDir is returned otherwise.
+func (backup *Backup) WriteDir(dir string) error {
+	if err := ensureBackupDir(dir); err != nil {
+		return err
+	}
+
+	for _, subdir := range []string{backupDatasourcesDir, backupLibraryPanelsDir, backupDashboardsDir, backupAlertRulesDir} {
+		if err := os.RemoveAll(filepath.Join(dir, subdir)); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Join(dir, subdir), 0o755); err != nil {
+			return err
+		}
+	}
+
+	for _, datasource := range backup.Datasources {
+		if err := writeBackupFile(filepath.Join(dir, backupDatasourcesDir, datasource.UID()+".json"), datasource); err != nil {
+			return err
+		}
+	}
+
+	folders := backup.Folders
+	if folders == nil {
+		folders = []BackupFolder{}
+	}
+	if err := writeBackupFile(filepath.Join(dir, backupFoldersFile), folders); err != nil {
+		return err
+	}
+
+	for _, panel := range backup.LibraryPanels {
+		if err := writeBackupFile(filepath.Join(dir, backupLibraryPanelsDir, panel.UID+".json"), panel); err != nil {
+			return err
+		}
+	}
+
+	for _, backupDashboard := range backup.Dashboards {
+		if err := writeBackupFile(filepath.Join(dir, backupDashboardsDir, backupDashboard.UID+".json"), backupDashboard); err != nil {
+			return err
+		}
+	}
+
+	groupsByFolder := map[string][]json.RawMessage{}
+	for _, group := range backup.AlertGroups {
+		groupsByFolder[group.FolderUID] = append(groupsByFolder[group.FolderUID], group.Group)
+	}
+	for folderUID, groups := range groupsByFolder {
+		if err := writeBackupFile(filepath.Join(dir, backupAlertRulesDir, folderUID+".json"), groups); err != nil {
+			return err
+		}
+	}
+
+	if len(backup.AlertManager) != 0 {
+		if err := writeBackupFile(filepath.Join(dir, backupAlertManagerFile), backup.AlertManager); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureBackupDir creates the given directory if needed, and ensures that it
+// is either empty or holds a backup, as flagged by a marker file.
+func ensureBackupDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if len(entries) != 0 {
+		if _, err := os.Stat(filepath.Join(dir, backupMarkerFile)); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("'%s' is not empty: %w", dir, ErrNotABackupDir)
+			}
+
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, backupMarkerFile), nil, 0o644)
+}
+
+func writeBackupFile(path string, value interface{}) error {
+	buf, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode '%s': %w", path, err)
+	}
+
+	return os.WriteFile(path, append(buf, '\n'), 0o644)
+}
+
+// ReadBackupDir loads a backup saved by Backup.WriteDir().
+func ReadBackupDir(dir string) (*Backup, error) {
+	backup := &Backup{}
+
+	err := readBackupDir(filepath.Join(dir, backupDatasourcesDir), func(_ string, content []byte) error {
+		backup.Datasources = append(backup.Datasources, BackupDatasource(content))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := readBackupFile(filepath.Join(dir, backupFoldersFile), &backup.Folders); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	err = readBackupDir(filepath.Join(dir, backupLibraryPanelsDir), func(path string, content []byte) error {
+		var panel BackupLibraryPanel
+		if err := json.Unmarshal(content, &panel); err != nil {
+			return fmt.Errorf("could not decode '%s': %w", path, err)
+		}
+
+		backup.LibraryPanels = append(backup.LibraryPanels, panel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readBackupDir(filepath.Join(dir, backupDashboardsDir), func(path string, content []byte) error {
+		var backupDashboard BackupDashboard
+		if err := json.Unmarshal(content, &backupDashboard); err != nil {
+			return fmt.Errorf("could not decode '%s': %w", path, err)
+		}
+
+		backup.Dashboards = append(backup.Dashboards, backupDashboard)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readBackupDir(filepath.Join(dir, backupAlertRulesDir), func(path string, content []byte) error {
+		var groups []json.RawMessage
+		if err := json.Unmarshal(content, &groups); err != nil {
+			return fmt.Errorf("could not decode '%s': %w", path, err)
+		}
+
+		folderUID := strings.TrimSuffix(filepath.Base(path), ".json")
+		for _, group := range groups {
+			backup.AlertGroups = append(backup.AlertGroups, BackupAlertGroup{FolderUID: folderUID, Group: group})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = readBackupFile(filepath.Join(dir, backupAlertManagerFile), &backup.AlertManager)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return backup, nil
+}
+
+// readBackupDir calls the given function for each JSON file of a directory,
+// in lexical order. Missing directories are considered empty.
+func readBackupDir(dir string, read func(path string, content []byte) error) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := read(path, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readBackupFile(path string, value interface{}) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(content, value); err != nil {
+		return fmt.Errorf("could not decode '%s': %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupSavesEveryResource(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom", "name": "Prometheus", "type": "prometheus"}]`)
+		case "/api/datasources/uid/prom":
+			_, _ = fmt.Fprintln(w, `{"id": 3, "orgId": 1, "version": 4, "readOnly": false, "uid": "prom", "name": "Prometheus", "type": "prometheus", "url": "http://prometheus", "password": "secret", "basicAuthPassword": "secret", "secureJsonFields": {"basicAuthPassword": true}}`)
+		case "/api/folders":
+			switch r.URL.Query().Get("parentUid") {
+			case "":
+				_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "infra", "title": "Infra"}]`)
+			case "infra":
+				_, _ = fmt.Fprintln(w, `[{"id": 2, "uid": "k8s", "title": "Kubernetes"}]`)
+			default:
+				_, _ = fmt.Fprintln(w, `[]`)
+			}
+		case "/api/library-elements":
+			req.Equal("1", r.URL.Query().Get("kind"))
+			_, _ = fmt.Fprintln(w, `{"result": {"totalCount": 1, "elements": [{"uid": "lib", "name": "Notes", "folderUid": "infra", "model": {"type": "text", "title": "Notes"}}]}}`)
+		case "/api/search":
+			req.Equal("dash-db", r.URL.Query().Get("type"))
+			_, _ = fmt.Fprintln(w, `[{"uid": "nodes", "title": "Nodes"}]`)
+		case "/api/dashboards/uid/nodes":
+			_, _ = fmt.Fprintln(w, `{"meta": {"folderUid": "k8s"}, "dashboard": {"uid": "nodes", "title": "Nodes"}}`)
+		case "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{"Kubernetes": [{"name": "Nodes down", "interval": "1m", "rules": [{"grafana_alert": {"uid": "rule-1", "title": "Nodes down", "namespace_uid": "k8s", "is_paused": true}}]}]}`)
+		case "/api/alertmanager/grafana/config/api/v1/alerts":
+			_, _ = fmt.Fprintln(w, `{"template_files": {}, "alertmanager_config": {"route": {"receiver": "team-a", "routes": [{"receiver": "team-b", "object_matchers": [["team", "=", "b"]], "mute_time_intervals": ["weekends"]}]}, "receivers": [{"name": "team-a"}, {"name": "team-b"}]}}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	backup, err := client.Backup(context.TODO())
+
+	req.NoError(err)
+
+	req.Len(backup.Datasources, 1)
+	req.Equal("prom", backup.Datasources[0].UID())
+	req.Equal("Prometheus", backup.Datasources[0].Name())
+	req.JSONEq(`{"uid": "prom", "name": "Prometheus", "type": "prometheus", "url": "http://prometheus", "secureJsonFields": {"basicAuthPassword": true}}`, string(backup.Datasources[0]))
+
+	req.Equal([]BackupFolder{
+		{UID: "infra", Title: "Infra"},
+		{UID: "k8s", ParentUID: "infra", Title: "Kubernetes"},
+	}, backup.Folders)
+
+	req.Len(backup.LibraryPanels, 1)
+	req.Equal("infra", backup.LibraryPanels[0].FolderUID)
+
+	req.Len(backup.Dashboards, 1)
+	req.Equal("nodes", backup.Dashboards[0].UID)
+	req.Equal("k8s", backup.Dashboards[0].FolderUID)
+
+	req.Len(backup.AlertGroups, 1)
+	req.Equal("k8s", backup.AlertGroups[0].FolderUID)
+	req.Equal("Nodes down", backup.AlertGroups[0].Name())
+	req.JSONEq(`{"name": "Nodes down", "interval": "1m", "rules": [{"grafana_alert": {"uid": "rule-1", "title": "Nodes down", "namespace_uid": "k8s", "is_paused": true}}]}`, string(backup.AlertGroups[0].Group))
+
+	req.JSONEq(`{"template_files": {}, "alertmanager_config": {"route": {"receiver": "team-a", "routes": [{"receiver": "team-b", "object_matchers": [["team", "=", "b"]], "mute_time_intervals": ["weekends"]}]}, "receivers": [{"name": "team-a"}, {"name": "team-b"}]}}`, string(backup.AlertManager))
+}
+
+func TestBackupFailsForAlertsOfFoldersSharingTheirTitle(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasources", "/api/search":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case "/api/folders":
+			switch r.URL.Query().Get("parentUid") {
+			case "":
+				_, _ = fmt.Fprintln(w, `[{"uid": "infra", "title": "Infra"}, {"uid": "apps", "title": "Apps"}]`)
+			case "infra":
+				_, _ = fmt.Fprintln(w, `[{"uid": "infra-k8s", "parentUid": "infra", "title": "Kubernetes"}]`)
+			case "apps":
+				_, _ = fmt.Fprintln(w, `[{"uid": "apps-k8s", "parentUid": "apps", "title": "Kubernetes"}]`)
+			default:
+				_, _ = fmt.Fprintln(w, `[]`)
+			}
+		case "/api/library-elements":
+			_, _ = fmt.Fprintln(w, `{"result": {"totalCount": 0, "elements": []}}`)
+		case "/api/ruler/grafana/api/v1/rules":
+			// rules of the first group know their folder, the second one can't be told apart
+			_, _ = fmt.Fprintln(w, `{"Kubernetes": [{"name": "Nodes down", "rules": [{"grafana_alert": {"namespace_uid": "apps-k8s"}}]}, {"name": "Pods down", "rules": [{"grafana_alert": {}}]}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.Backup(context.TODO())
+
+	req.ErrorContains(err, "could not tell which of the 2 folders titled 'Kubernetes' holds alert group 'Pods down'")
+}
+
+func TestBackupFailsForAlertsOutsideOfKnownFolders(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasources", "/api/folders", "/api/search":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case "/api/library-elements":
+			_, _ = fmt.Fprintln(w, `{"result": {"totalCount": 0, "elements": []}}`)
+		case "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{"Unknown": [{"name": "Nodes down"}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.Backup(context.TODO())
+
+	req.ErrorIs(err, ErrFolderNotFound)
+}
+
+func testBackup() *Backup {
+	return &Backup{
+		Datasources: []BackupDatasource{
+			BackupDatasource(`{"uid": "prom", "name": "Prometheus", "type": "prometheus"}`),
+		},
+		Folders: []BackupFolder{
+			{UID: "infra", Title: "Infra"},
+			{UID: "k8s", ParentUID: "infra", Title: "Kubernetes"},
+		},
+		LibraryPanels: []BackupLibraryPanel{
+			{UID: "lib", Name: "Notes", FolderUID: "infra", Model: json.RawMessage(`{"type": "text", "title": "Notes"}`)},
+		},
+		Dashboards: []BackupDashboard{
+			{UID: "nodes", FolderUID: "k8s", Dashboard: json.RawMessage(`{"id": 12, "uid": "nodes", "title": "Nodes"}`)},
+		},
+		AlertGroups: []BackupAlertGroup{
+			{
+				FolderUID: "k8s",
+				Group:     json.RawMessage(`{"name": "Nodes down", "interval": "1m", "rules": [{"for": "5m", "annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "rule-1", "title": "Nodes down", "is_paused": true, "data": [{"refId": "A", "datasourceUid": "prom"}, {"refId": "B", "datasourceUid": "__expr__"}]}}]}`),
+			},
+		},
+		AlertManager: json.RawMessage(`{"alertmanager_config": {"route": {"receiver": "team-a", "routes": [{"receiver": "team-b", "mute_time_intervals": ["weekends"]}]}}}`),
+	}
+}
+
+func TestBackupsCanBeWrittenAndReadBack(t *testing.T) {
+	req := require.New(t)
+	dir := t.TempDir()
+
+	req.NoError(testBackup().WriteDir(dir))
+
+	for _, file := range []string{
+		"datasources/prom.json",
+		"folders.json",
+		"library-panels/lib.json",
+		"dashboards/nodes.json",
+		"alert-rules/k8s.json",
+		"alertmanager.json",
+	} {
+		req.FileExists(filepath.Join(dir, file))
+	}
+
+	backup, err := ReadBackupDir(dir)
+	req.NoError(err)
+
+	req.Len(backup.Datasources, 1)
+	req.Equal("Prometheus", backup.Datasources[0].Name())
+	req.Equal(testBackup().Folders, backup.Folders)
+	req.Len(backup.LibraryPanels, 1)
+	req.Equal("infra", backup.LibraryPanels[0].FolderUID)
+	req.Len(backup.Dashboards, 1)
+	req.JSONEq(`{"id": 12, "uid": "nodes", "title": "Nodes"}`, string(backup.Dashboards[0].Dashboard))
+	req.Len(backup.AlertGroups, 1)
+	req.Equal("k8s", backup.AlertGroups[0].FolderUID)
+	req.JSONEq(string(testBackup().AlertGroups[0].Group), string(backup.AlertGroups[0].Group))
+	req.JSONEq(string(testBackup().AlertManager), string(backup.AlertManager))
+}
+
+func TestWritingABackupIsDeterministic(t *testing.T) {
+	req := require.New(t)
+	dir := t.TempDir()
+
+	req.NoError(testBackup().WriteDir(dir))
+	first, err := os.ReadFile(filepath.Join(dir, "alert-rules/k8s.json"))
+	req.NoError(err)
+
+	// leftovers of previous backups are removed
+	req.NoError(os.WriteFile(filepath.Join(dir, "dashboards/deleted.json"), []byte(`{}`), 0o644))
+
+	req.NoError(testBackup().WriteDir(dir))
+	second, err := os.ReadFile(filepath.Join(dir, "alert-rules/k8s.json"))
+	req.NoError(err)
+
+	req.Equal(first, second)
+	req.NoFileExists(filepath.Join(dir, "dashboards/deleted.json"))
+}
+
+func TestBackupsAreNotWrittenIntoUnrelatedDirectories(t *testing.T) {
+	req := require.New(t)
+	dir := t.TempDir()
+
+	req.NoError(os.MkdirAll(filepath.Join(dir, "dashboards"), 0o755))
+	req.NoError(os.WriteFile(filepath.Join(dir, "dashboards/unrelated.yaml"), []byte(`title: Unrelated`), 0o644))
+
+	err := testBackup().WriteDir(dir)
+
+	req.ErrorIs(err, ErrNotABackupDir)
+	req.FileExists(filepath.Join(dir, "dashboards/unrelated.yaml"))
+}
+
+func TestRestoreReplaysABackupInDependencyOrder(t *testing.T) {
+	req := require.New(t)
+	var writes []string
+	var restoredAlert, restoredAlertManager map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources/id/Prometheus":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `{}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom", "name": "Prometheus", "type": "prometheus"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/folders":
+			payload := map[string]string{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintf(w, `{"uid": "new-%s", "title": "%s", "parentUid": "%s"}`, strings.ToLower(payload["title"]), payload["title"], payload["parentUid"])
+		case r.Method == http.MethodGet && r.URL.Path == "/api/library-elements/lib":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/library-elements":
+			payload := map[string]interface{}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			req.Equal("new-infra", payload["folderUid"])
+			_, _ = fmt.Fprintln(w, `{"result": {"uid": "lib"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			payload := struct {
+				Dashboard map[string]interface{} `json:"dashboard"`
+				FolderUID string                 `json:"folderUid"`
+			}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			req.Equal("new-kubernetes", payload.FolderUID)
+			req.Equal("nodes", payload.Dashboard["uid"])
+			req.NotContains(payload.Dashboard, "id")
+			// rolling back to the restored version restores its alerts
+			req.Contains(payload.Dashboard, alertsSnapshotKey)
+			_, _ = fmt.Fprintln(w, `{"uid": "nodes"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/nodes":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "nodes"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/alertmanager/grafana/config/api/v1/alerts":
+			req.NoError(json.NewDecoder(r.Body).Decode(&restoredAlertManager))
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/ruler/grafana/api/v1/rules/new-kubernetes":
+			req.NoError(json.NewDecoder(r.Body).Decode(&restoredAlert))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.Restore(context.TODO(), testBackup())
+
+	req.NoError(err)
+	req.Equal([]string{
+		"POST /api/datasources",
+		"POST /api/folders",
+		"POST /api/folders",
+		"POST /api/library-elements",
+		"POST /api/dashboards/db",
+		"POST /api/alertmanager/grafana/config/api/v1/alerts",
+		"POST /api/ruler/grafana/api/v1/rules/new-kubernetes",
+	}, writes)
+
+	restoredJSON, err := json.Marshal(restoredAlert)
+	req.NoError(err)
+	// the rule doesn't exist on this instance: it is created, with every setting
+	req.JSONEq(`{"name": "Nodes down", "interval": "1m", "rules": [{"for": "5m", "annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"title": "Nodes down", "is_paused": true, "data": [{"refId": "A", "datasourceUid": "prom"}, {"refId": "B", "datasourceUid": "__expr__"}]}}]}`, string(restoredJSON))
+
+	restoredAlertManagerJSON, err := json.Marshal(restoredAlertManager)
+	req.NoError(err)
+	req.JSONEq(string(testBackup().AlertManager), string(restoredAlertManagerJSON))
+}
+
+func TestRestoreFailsForResourcesInUnknownFolders(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.Restore(context.TODO(), &Backup{
+		Dashboards: []BackupDashboard{
+			{UID: "nodes", FolderUID: "unknown", Dashboard: json.RawMessage(`{"uid": "nodes"}`)},
+		},
+	})
+
+	req.ErrorIs(err, ErrFolderNotFound)
+}
+
+func TestRestoreKeepsReferencesToMissingDatasources(t *testing.T) {
+	req := require.New(t)
+	var restoredPanel, restoredDashboard string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/library-elements/lib":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/library-elements":
+			body, _ := io.ReadAll(r.Body)
+			restoredPanel = string(body)
+			_, _ = fmt.Fprintln(w, `{"result": {"uid": "lib"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			body, _ := io.ReadAll(r.Body)
+			restoredDashboard = string(body)
+			_, _ = fmt.Fprintln(w, `{"uid": "nodes"}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.Restore(context.TODO(), &Backup{
+		LibraryPanels: []BackupLibraryPanel{
+			{UID: "lib", Name: "Lib", Model: json.RawMessage(`{"type": "timeseries", "datasource": {"type": "prometheus", "uid": "deleted-prom"}}`)},
+		},
+		Dashboards: []BackupDashboard{
+			{UID: "nodes", Dashboard: json.RawMessage(`{"id": 12, "uid": "nodes", "panels": [{"type": "timeseries", "datasource": {"type": "prometheus", "uid": "deleted-prom"}}], "grabanaAlerts": []}`)},
+		},
+	})
+
+	req.NoError(err)
+	req.Contains(restoredPanel, "deleted-prom")
+	req.Contains(restoredDashboard, "deleted-prom")
+	// alerts snapshotted on the instance the backup comes from don't apply here
+	req.NotContains(restoredDashboard, alertsSnapshotKey)
+}
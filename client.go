@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 )
 
 // Option represents an option that can be used to configure a client.
@@ -19,6 +19,9 @@ type Client struct {
 	http             *http.Client
 	host             string
 	requestModifiers []requestModifier
+	orgID            uint
+	retry            retryPolicy
+	rateLimiter      *rateLimiter
 }
 
 // NewClient creates a new Grafana HTTP client, using an API token.
@@ -36,6 +39,7 @@ func NewClient(http *http.Client, host string, options ...Option) *Client {
 }
 
 // WithAPIToken sets up the client to use the given token to authenticate.
+// The token can be an API key or a service account token.
 func WithAPIToken(token string) Option {
 	return func(client *Client) {
 		client.requestModifiers = append(client.requestModifiers, func(request *http.Request) {
@@ -44,6 +48,13 @@ func WithAPIToken(token string) Option {
 	}
 }
 
+// WithServiceAccountToken is an alias of WithAPIToken: service account tokens
+// are sent as bearer tokens, exactly like API keys.
+// See https://grafana.com/docs/grafana/latest/administration/service-accounts/
+func WithServiceAccountToken(token string) Option {
+	return WithAPIToken(token)
+}
+
 // WithBasicAuth sets up the client to use the given credentials to authenticate.
 func WithBasicAuth(username string, password string) Option {
 	return func(client *Client) {
@@ -53,19 +64,43 @@ func WithBasicAuth(username string, password string) Option {
 	}
 }
 
+// WithOrgID sets up the client to act within the given organization,
+// instead of the default organization of the user it authenticates as.
+// Note: API keys and service account tokens belong to a single organization.
+// Acting on several organizations requires basic authentication.
+func WithOrgID(orgID uint) Option {
+	return func(client *Client) {
+		client.orgID = orgID
+	}
+}
+
+// ForOrg returns a copy of the client acting within the given organization.
+// Both clients share their HTTP client, credentials and rate limit.
+func (client *Client) ForOrg(orgID uint) *Client {
+	orgClient := *client
+	orgClient.orgID = orgID
+
+	return &orgClient
+}
+
 func (client *Client) modifyRequest(request *http.Request) {
 	for _, modifier := range client.requestModifiers {
 		modifier(request)
 	}
+
+	if client.orgID != 0 {
+		request.Header.Set("X-Grafana-Org-Id", strconv.FormatUint(uint64(client.orgID), 10))
+	}
 }
 
+// httpError describes the error returned by Grafana as an *APIError.
 func (client Client) httpError(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
 
-	return fmt.Errorf("could not query grafana: %s (HTTP status %d)", body, resp.StatusCode)
+	return newAPIError(resp, body)
 }
 
 func (client Client) delete(ctx context.Context, path string) (*http.Response, error) {
@@ -74,21 +109,28 @@ func (client Client) delete(ctx context.Context, path string) (*http.Response, e
 		return nil, err
 	}
 
-	client.modifyRequest(request)
-
-	return client.http.Do(request)
+	return client.do(request, true)
 }
 
 func (client Client) sendJSON(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	return client.sendJSONRequest(ctx, method, path, body, isIdempotent(method))
+}
+
+// sendIdempotentJSON sends a request that can safely be retried, regardless
+// of its method. Example: POST requests overwriting a dashboard.
+func (client Client) sendIdempotentJSON(ctx context.Context, method string, path string, body []byte) (*http.Response, error) {
+	return client.sendJSONRequest(ctx, method, path, body, true)
+}
+
+func (client Client) sendJSONRequest(ctx context.Context, method string, path string, body []byte, idempotent bool) (*http.Response, error) {
 	request, err := http.NewRequestWithContext(ctx, method, client.url(path), bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 
 	request.Header.Add("Content-Type", "application/json")
-	client.modifyRequest(request)
 
-	return client.http.Do(request)
+	return client.do(request, idempotent)
 }
 
 func (client Client) get(ctx context.Context, path string) (*http.Response, error) {
@@ -97,9 +139,7 @@ func (client Client) get(ctx context.Context, path string) (*http.Response, erro
 		return nil, err
 	}
 
-	client.modifyRequest(request)
-
-	return client.http.Do(request)
+	return client.do(request, true)
 }
 
 func (client Client) url(path string) string {
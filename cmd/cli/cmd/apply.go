@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/K-Phoen/grabana"
 	"github.com/K-Phoen/grabana/decoder"
@@ -16,6 +19,8 @@ type applyOpts struct {
 	destinationFolder string
 	grafanaHost       string
 	grafanaToken      string
+	grafanaBasicAuth  string
+	orgs              []string
 }
 
 func Apply() *cobra.Command {
@@ -30,9 +35,11 @@ func Apply() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
-	cmd.Flags().StringVarP(&opts.destinationFolder, "folder", "f", "", "Folder in which the dashboard will be created")
+	cmd.Flags().StringVarP(&opts.destinationFolder, "folder", "f", "", "Folder in which the dashboard will be created. Nested folders are given as a path. Example: Infra/Kubernetes")
 	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
-	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana API token")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.grafanaBasicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringSliceVar(&opts.orgs, "org", nil, "ID or name of the organization(s) in which the dashboard will be applied. Defaults to the organization of the credentials")
 
 	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
 
@@ -51,29 +58,83 @@ func applyYAML(opts applyOpts) error {
 	if err != nil {
 		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
 	}
+	defer func() { _ = file.Close() }()
 
 	dashboard, err := decoder.UnmarshalYAML(file)
 	if err != nil {
 		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
 	}
 
-	folder, err := client.FindOrCreateFolder(ctx, opts.destinationFolder)
+	orgClients, err := orgClients(ctx, client, opts.orgs)
 	if err != nil {
-		return fmt.Errorf("could not find or create folder '%s': %w", opts.destinationFolder, err)
+		return err
 	}
 
-	if _, err := client.UpsertDashboard(ctx, folder, dashboard); err != nil {
-		return fmt.Errorf("could not apply dashboard: %w", err)
+	for _, orgClient := range orgClients {
+		folder, err := orgClient.FindOrCreateFolderPath(ctx, opts.destinationFolder)
+		if err != nil {
+			return orgClient.errorf("could not find or create folder '%s': %w", opts.destinationFolder, err)
+		}
+
+		if _, err := orgClient.UpsertDashboard(ctx, folder, dashboard); err != nil {
+			return orgClient.errorf("could not apply dashboard: %w", err)
+		}
 	}
 
 	return nil
 }
 
 func grabanaClient(opts applyOpts) *grabana.Client {
-	var clientOpts []grabana.Option
+	clientOpts := []grabana.Option{
+		grabana.WithRetries(3, 500*time.Millisecond, 5*time.Second),
+	}
 	if len(opts.grafanaToken) != 0 {
-		clientOpts = append(clientOpts, grabana.WithAPIToken(opts.grafanaToken))
+		clientOpts = append(clientOpts, grabana.WithServiceAccountToken(opts.grafanaToken))
+	}
+	if len(opts.grafanaBasicAuth) != 0 {
+		username, password, _ := strings.Cut(opts.grafanaBasicAuth, ":")
+		clientOpts = append(clientOpts, grabana.WithBasicAuth(username, password))
 	}
 
 	return grabana.NewClient(&http.Client{}, opts.grafanaHost, clientOpts...)
 }
+
+// scopedClient is a client acting within a specific organization.
+type scopedClient struct {
+	*grabana.Client
+	org string
+}
+
+func (client scopedClient) errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	if client.org == "" {
+		return err
+	}
+
+	return fmt.Errorf("org '%s': %w", client.org, err)
+}
+
+// orgClients returns a client for each of the given organizations, identified
+// by ID or name. Without organization, the client is returned as is.
+func orgClients(ctx context.Context, client *grabana.Client, orgs []string) ([]scopedClient, error) {
+	if len(orgs) == 0 {
+		return []scopedClient{{Client: client}}, nil
+	}
+
+	clients := make([]scopedClient, 0, len(orgs))
+	for _, org := range orgs {
+		orgID, err := strconv.ParseUint(org, 10, 0)
+		if err != nil {
+			found, err := client.GetOrgByName(ctx, org)
+			if err != nil {
+				return nil, fmt.Errorf("could not find org '%s': %w", org, err)
+			}
+
+			orgID = uint64(found.ID)
+		}
+
+		clients = append(clients, scopedClient{Client: client.ForOrg(uint(orgID)), org: org})
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/spf13/cobra"
+)
+
+type applyDatasourcesOpts struct {
+	inputYAML    string
+	grafanaHost  string
+	grafanaToken string
+	basicAuth    string
+	orgs         []string
+}
+
+func ApplyDatasources() *cobra.Command {
+	opts := applyDatasourcesOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "apply-datasources",
+		Short: "Apply YAML datasources",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return applyDatasourcesYAML(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.basicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringSliceVar(&opts.orgs, "org", nil, "ID or name of the organization(s) in which the datasources will be applied. Defaults to the organization of the credentials")
+
+	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("input")
+	_ = cmd.MarkFlagRequired("grafana")
+
+	return cmd
+}
+
+func applyDatasourcesYAML(opts applyDatasourcesOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(applyOpts{grafanaHost: opts.grafanaHost, grafanaToken: opts.grafanaToken, grafanaBasicAuth: opts.basicAuth})
+
+	file, err := os.Open(opts.inputYAML)
+	if err != nil {
+		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
+	}
+
+	datasources, err := decoder.UnmarshalDatasourcesYAML(file)
+	if err != nil {
+		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
+	}
+
+	orgClients, err := orgClients(ctx, client, opts.orgs)
+	if err != nil {
+		return err
+	}
+
+	for _, orgClient := range orgClients {
+		for _, datasource := range datasources {
+			if err := orgClient.UpsertDatasource(ctx, datasource); err != nil {
+				return orgClient.errorf("could not apply datasource '%s': %w", datasource.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
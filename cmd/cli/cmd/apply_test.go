@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestADashboardCanBeAppliedToOrgsWithDifferentDatasourceUIDs(t *testing.T) {
+	req := require.New(t)
+
+	input := filepath.Join(t.TempDir(), "dashboard.yaml")
+	req.NoError(os.WriteFile(input, []byte(`title: Awesome dashboard
+uid: awesome
+
+rows:
+  - name: Test row
+    panels:
+    - timeseries:
+        title: Heap
+        datasource: Prometheus
+        targets:
+        - prometheus:
+            query: "go_memstats_heap_alloc_bytes"
+`), 0600))
+
+	datasourceUIDs := map[string]string{"1": "prom-org-1", "2": "prom-org-2"}
+	savedDashboards := map[string]string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		org := r.Header.Get("X-Grafana-Org-Id")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			_, _ = fmt.Fprintln(w, `[{"id": 1, "uid": "infra", "title": "Infra"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintf(w, `[{"uid": "%s", "name": "Prometheus", "type": "prometheus"}]`, datasourceUIDs[org])
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			body, _ := io.ReadAll(r.Body)
+			savedDashboards[org] = string(body)
+			_, _ = fmt.Fprintln(w, `{"uid": "awesome"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/awesome":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "awesome", "panels": []}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	err := applyYAML(applyOpts{
+		inputYAML:         input,
+		destinationFolder: "Infra",
+		grafanaHost:       ts.URL,
+		orgs:              []string{"1", "2"},
+	})
+
+	req.NoError(err)
+	req.Len(savedDashboards, 2)
+	req.Contains(savedDashboards["1"], "prom-org-1")
+	req.NotContains(savedDashboards["1"], "prom-org-2")
+	req.Contains(savedDashboards["2"], "prom-org-2")
+	req.NotContains(savedDashboards["2"], "prom-org-1")
+}
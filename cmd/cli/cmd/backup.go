@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type backupOpts struct {
+	grafanaHost  string
+	grafanaToken string
+	basicAuth    string
+	org          string
+	outputDir    string
+}
+
+func Backup() *cobra.Command {
+	opts := backupOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Save the folders, dashboards, alerts, datasources and library panels of a Grafana instance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return backupInstance(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.basicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringVar(&opts.org, "org", "", "ID or name of the organization to back up. Defaults to the organization of the credentials")
+	cmd.Flags().StringVarP(&opts.outputDir, "out", "o", "", "Directory in which the backup will be written")
+
+	_ = cmd.MarkFlagDirname("out")
+
+	_ = cmd.MarkFlagRequired("grafana")
+	_ = cmd.MarkFlagRequired("out")
+
+	return cmd
+}
+
+func backupInstance(opts backupOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(applyOpts{grafanaHost: opts.grafanaHost, grafanaToken: opts.grafanaToken, grafanaBasicAuth: opts.basicAuth})
+
+	if opts.org != "" {
+		orgClients, err := orgClients(ctx, client, []string{opts.org})
+		if err != nil {
+			return err
+		}
+
+		client = orgClients[0].Client
+	}
+
+	backup, err := client.Backup(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := backup.WriteDir(opts.outputDir); err != nil {
+		return fmt.Errorf("could not write backup to '%s': %w", opts.outputDir, err)
+	}
+
+	fmt.Printf(
+		"Saved %d datasources, %d folders, %d library panels, %d dashboards and %d alert groups to %s\n",
+		len(backup.Datasources), len(backup.Folders), len(backup.LibraryPanels), len(backup.Dashboards), len(backup.AlertGroups), opts.outputDir,
+	)
+
+	return nil
+}
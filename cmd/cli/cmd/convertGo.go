@@ -50,6 +50,9 @@ func convertGo(logger *zap.Logger, opts convertGoOpts) error {
 	if err := json.Unmarshal(content, dashboard); err != nil {
 		return fmt.Errorf("could not unmarshall dashboard from JSON: %w", err)
 	}
+	if err := restoreRawPanels(content, dashboard); err != nil {
+		return fmt.Errorf("could not unmarshall dashboard from JSON: %w", err)
+	}
 
 	golangDashboard, err := encoder.ToGolang(logger, *dashboard)
 	if err != nil {
@@ -60,3 +63,58 @@ func convertGo(logger *zap.Logger, opts convertGoOpts) error {
 
 	return nil
 }
+
+// restoreRawPanels keeps the raw definition of typed panels around, so that
+// the encoder has access to the settings that the sdk doesn't model (like
+// transformations).
+func restoreRawPanels(content []byte, dashboard *sdk.Board) error {
+	raw := struct {
+		Panels []sdk.CustomPanel `json:"panels"`
+	}{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return err
+	}
+
+	for i, panel := range dashboard.Panels {
+		if i >= len(raw.Panels) || panel.OfType == sdk.CustomType {
+			continue
+		}
+
+		// collapsed rows hold their panels
+		if panel.RowPanel != nil {
+			if err := restoreRawRowPanels(raw.Panels[i], panel.RowPanel); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		panel.CustomPanel = &raw.Panels[i]
+	}
+
+	return nil
+}
+
+func restoreRawRowPanels(rawRow sdk.CustomPanel, row *sdk.RowPanel) error {
+	content, err := json.Marshal(rawRow)
+	if err != nil {
+		return err
+	}
+
+	raw := struct {
+		Panels []sdk.CustomPanel `json:"panels"`
+	}{}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return err
+	}
+
+	for i := range row.Panels {
+		if i >= len(raw.Panels) || row.Panels[i].OfType == sdk.CustomType {
+			continue
+		}
+
+		row.Panels[i].CustomPanel = &raw.Panels[i]
+	}
+
+	return nil
+}
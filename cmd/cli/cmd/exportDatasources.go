@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/spf13/cobra"
+)
+
+type exportDatasourcesOpts struct {
+	inputYAML string
+}
+
+func ExportDatasources() *cobra.Command {
+	opts := exportDatasourcesOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "export-datasources",
+		Short: "Render YAML datasources as a Grafana provisioning file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportDatasourcesYAML(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
+
+	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+func exportDatasourcesYAML(opts exportDatasourcesOpts) error {
+	file, err := os.Open(opts.inputYAML)
+	if err != nil {
+		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
+	}
+
+	datasources, err := decoder.UnmarshalDatasourcesYAMLForProvisioning(file)
+	if err != nil {
+		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
+	}
+
+	provisioning, err := datasource.Provisioning(datasources...)
+	if err != nil {
+		return err
+	}
+
+	buf, err := provisioning.ToYAML()
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(string(buf))
+
+	return nil
+}
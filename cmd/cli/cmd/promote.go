@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/spf13/cobra"
+)
+
+type promoteOpts struct {
+	folder    string
+	rulesYAML string
+	dryRun    bool
+	source    applyOpts
+	target    applyOpts
+}
+
+func Promote() *cobra.Command {
+	opts := promoteOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Copy the dashboards of a folder, along with their alerts, from a Grafana instance to another",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return promoteFolder(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.folder, "folder", "f", "", "Path of the folder to promote. Example: Infra/Kubernetes")
+	cmd.Flags().StringVarP(&opts.rulesYAML, "rules", "r", "", "YAML file describing how dashboards are rewritten")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Only describe the changes that would be made")
+	cmd.Flags().StringVar(&opts.source.grafanaHost, "from", "", "Grafana host to promote from. Example: http://grafana-staging:3000")
+	cmd.Flags().StringVar(&opts.source.grafanaToken, "from-token", "", "Service account token or API key of the Grafana to promote from")
+	cmd.Flags().StringVar(&opts.source.grafanaBasicAuth, "from-basic-auth", "", "Credentials of the Grafana to promote from, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringVar(&opts.target.grafanaHost, "to", "", "Grafana host to promote to. Example: http://grafana:3000")
+	cmd.Flags().StringVar(&opts.target.grafanaToken, "to-token", "", "Service account token or API key of the Grafana to promote to")
+	cmd.Flags().StringVar(&opts.target.grafanaBasicAuth, "to-basic-auth", "", "Credentials of the Grafana to promote to, used instead of a token. Example: admin:secret")
+
+	_ = cmd.MarkFlagFilename("rules", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("folder")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func promoteFolder(opts promoteOpts) error {
+	ctx := context.Background()
+	source := grabanaClient(opts.source)
+	target := grabanaClient(opts.target)
+
+	rules := grabana.PromotionRules{}
+	if opts.rulesYAML != "" {
+		file, err := os.Open(opts.rulesYAML)
+		if err != nil {
+			return fmt.Errorf("could not open rules file '%s': %w", opts.rulesYAML, err)
+		}
+		defer func() { _ = file.Close() }()
+
+		rules, err = decoder.UnmarshalPromotionRulesYAML(file)
+		if err != nil {
+			return fmt.Errorf("could not decode rules file '%s': %w", opts.rulesYAML, err)
+		}
+	}
+
+	plan, err := grabana.PlanPromotion(ctx, source, target, opts.folder, rules)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(plan.String())
+
+	if opts.dryRun {
+		return nil
+	}
+
+	return plan.Apply(ctx)
+}
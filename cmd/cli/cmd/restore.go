@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/spf13/cobra"
+)
+
+type restoreOpts struct {
+	grafanaHost  string
+	grafanaToken string
+	basicAuth    string
+	org          string
+}
+
+func Restore() *cobra.Command {
+	opts := restoreOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "restore DIRECTORY",
+		Short: "Restore a backup made by the backup command",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restoreInstance(opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.basicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringVar(&opts.org, "org", "", "ID or name of the organization in which the backup will be restored. Defaults to the organization of the credentials")
+
+	_ = cmd.MarkFlagRequired("grafana")
+
+	return cmd
+}
+
+func restoreInstance(opts restoreOpts, dir string) error {
+	ctx := context.Background()
+	client := grabanaClient(applyOpts{grafanaHost: opts.grafanaHost, grafanaToken: opts.grafanaToken, grafanaBasicAuth: opts.basicAuth})
+
+	if opts.org != "" {
+		orgClients, err := orgClients(ctx, client, []string{opts.org})
+		if err != nil {
+			return err
+		}
+
+		client = orgClients[0].Client
+	}
+
+	backup, err := grabana.ReadBackupDir(dir)
+	if err != nil {
+		return fmt.Errorf("could not read backup '%s': %w", dir, err)
+	}
+
+	return client.Restore(ctx, backup)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+type rollbackOpts struct {
+	grafanaHost  string
+	grafanaToken string
+	basicAuth    string
+	org          string
+	uid          string
+	toVersion    uint
+}
+
+func Rollback() *cobra.Command {
+	opts := rollbackOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore a dashboard and its alerts to a previous version",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return rollbackDashboard(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.basicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringVar(&opts.org, "org", "", "ID or name of the organization of the dashboard. Defaults to the organization of the credentials")
+	cmd.Flags().StringVar(&opts.uid, "uid", "", "UID of the dashboard to roll back")
+	cmd.Flags().UintVar(&opts.toVersion, "to-version", 0, "Version of the dashboard to restore")
+
+	_ = cmd.MarkFlagRequired("grafana")
+	_ = cmd.MarkFlagRequired("uid")
+	_ = cmd.MarkFlagRequired("to-version")
+
+	return cmd
+}
+
+func rollbackDashboard(opts rollbackOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(applyOpts{grafanaHost: opts.grafanaHost, grafanaToken: opts.grafanaToken, grafanaBasicAuth: opts.basicAuth})
+
+	if opts.org != "" {
+		orgClients, err := orgClients(ctx, client, []string{opts.org})
+		if err != nil {
+			return err
+		}
+
+		client = orgClients[0].Client
+	}
+
+	dashboard, err := client.RestoreDashboardVersion(ctx, opts.uid, opts.toVersion)
+	if err != nil {
+		return fmt.Errorf("could not roll back dashboard '%s' to version %d: %w", opts.uid, opts.toVersion, err)
+	}
+
+	fmt.Printf("Dashboard '%s' restored to version %d: %s\n", opts.uid, opts.toVersion, dashboard.URL)
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/K-Phoen/grabana"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/decoder"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/sdk"
+	"github.com/spf13/cobra"
+)
+
+type smokeTestOpts struct {
+	inputYAML    string
+	grafanaHost  string
+	grafanaToken string
+	basicAuth    string
+	org          string
+	from         string
+	to           string
+	variables    map[string]string
+}
+
+func SmokeTest() *cobra.Command {
+	opts := smokeTestOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "smoke-test",
+		Short: "Run every query of a YAML dashboard and report the ones failing or returning no data",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return smokeTestYAML(opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.inputYAML, "input", "i", "", "YAML file used as input")
+	cmd.Flags().StringVarP(&opts.grafanaHost, "grafana", "g", "", "Grafana host. Example: http://grafana-host:3000")
+	cmd.Flags().StringVarP(&opts.grafanaToken, "token", "t", "", "Grafana service account token or API key")
+	cmd.Flags().StringVar(&opts.basicAuth, "basic-auth", "", "Grafana credentials, used instead of a token. Example: admin:secret")
+	cmd.Flags().StringVar(&opts.org, "org", "", "ID or name of the organization in which the queries will run. Defaults to the organization of the credentials")
+	cmd.Flags().StringVar(&opts.from, "from", "now-1h", "Start of the queried time range")
+	cmd.Flags().StringVar(&opts.to, "to", "now", "End of the queried time range")
+	cmd.Flags().StringToStringVar(&opts.variables, "var", nil, "Value of a dashboard variable, overriding its current value. Example: --var job=api")
+
+	_ = cmd.MarkFlagFilename("input", "yaml", "yml")
+
+	_ = cmd.MarkFlagRequired("input")
+	_ = cmd.MarkFlagRequired("grafana")
+
+	return cmd
+}
+
+// smokeTestQuery is a query made by a panel. Panels whose queries can not be
+// found are described by an error.
+type smokeTestQuery struct {
+	panel      string
+	datasource datasource.Ref
+	target     sdk.Target
+	err        error
+}
+
+func (query smokeTestQuery) String() string {
+	if query.target.RefID == "" {
+		return query.panel
+	}
+
+	return fmt.Sprintf("%s (%s)", query.panel, query.target.RefID)
+}
+
+func smokeTestYAML(opts smokeTestOpts) error {
+	ctx := context.Background()
+	client := grabanaClient(applyOpts{grafanaHost: opts.grafanaHost, grafanaToken: opts.grafanaToken, grafanaBasicAuth: opts.basicAuth})
+
+	if opts.org != "" {
+		orgClients, err := orgClients(ctx, client, []string{opts.org})
+		if err != nil {
+			return err
+		}
+
+		client = orgClients[0].Client
+	}
+
+	file, err := os.Open(opts.inputYAML)
+	if err != nil {
+		return fmt.Errorf("could not open input file '%s': %w", opts.inputYAML, err)
+	}
+
+	dashboard, err := decoder.UnmarshalYAML(file)
+	if err != nil {
+		return fmt.Errorf("could not decode input file '%s': %w", opts.inputYAML, err)
+	}
+
+	board := dashboard.Internal()
+	variables := variableValues(board, opts.variables)
+	timeRange := grabana.TimeRange{From: opts.from, To: opts.to}
+
+	failures := 0
+	for _, query := range boardQueries(board, dashboard.LibraryPanels(), variables) {
+		if query.err != nil {
+			failures++
+			fmt.Printf("[ERROR]   %s: %s\n", query, query.err)
+			continue
+		}
+
+		results, err := client.QueryDatasource(ctx, query.datasource, []*sdk.Target{&query.target}, timeRange)
+		if err != nil {
+			failures++
+			fmt.Printf("[ERROR]   %s: %s\n", query, err)
+			continue
+		}
+
+		result := results[query.target.RefID]
+
+		switch {
+		case result.Failed():
+			failures++
+			fmt.Printf("[ERROR]   %s: %s\n", query, result.Error)
+		case result.Empty():
+			failures++
+			fmt.Printf("[NO DATA] %s\n", query)
+		default:
+			fmt.Printf("[OK]      %s\n", query)
+		}
+	}
+
+	if failures != 0 {
+		return fmt.Errorf("%d queries failed or returned no data", failures)
+	}
+
+	return nil
+}
+
+// boardQueries lists the queries made by the panels of a board, with
+// dashboard variables replaced by their value. Hidden queries are skipped.
+// Library panels are looked for among the given definitions.
+func boardQueries(board *sdk.Board, libraryPanels []*librarypanel.Definition, variables map[string]string) []smokeTestQuery {
+	var queries []smokeTestQuery
+
+	models := make(map[string]*sdk.Panel, len(libraryPanels))
+	for _, definition := range libraryPanels {
+		models[definition.UID] = definition.Model
+	}
+
+	panelQueries := func(panel *sdk.Panel) {
+		title := panel.Title
+
+		panel, err := libraryPanelModel(panel, models)
+		if err != nil {
+			queries = append(queries, smokeTestQuery{panel: title, err: err})
+			return
+		}
+
+		targets, err := panelTargets(panel)
+		if err != nil {
+			queries = append(queries, smokeTestQuery{panel: title, err: err})
+			return
+		}
+
+		for i, target := range targets {
+			if target.Hide {
+				continue
+			}
+
+			ref := panel.Datasource
+			if target.Datasource != nil {
+				ref = target.Datasource
+			}
+
+			if target.RefID == "" {
+				target.RefID = string(rune('A' + i%26))
+			}
+
+			target.Expr = interpolate(target.Expr, variables)
+			target.Query = interpolate(target.Query, variables)
+			target.RawSql = interpolate(target.RawSql, variables)
+			target.Target = interpolate(target.Target, variables)
+
+			queries = append(queries, smokeTestQuery{
+				panel:      panel.Title,
+				datasource: datasourceRef(ref, variables),
+				target:     target,
+			})
+		}
+	}
+
+	for _, panel := range board.Panels {
+		panelQueries(panel)
+
+		// collapsed rows hold their panels
+		if panel.RowPanel == nil {
+			continue
+		}
+
+		for i := range panel.RowPanel.Panels {
+			panelQueries(&panel.RowPanel.Panels[i])
+		}
+	}
+
+	for _, row := range board.Rows {
+		for i := range row.Panels {
+			panelQueries(&row.Panels[i])
+		}
+	}
+
+	return queries
+}
+
+// libraryPanelModel returns the model of the library panel referenced by the
+// given panel, or the panel itself if it isn't a library panel.
+func libraryPanelModel(panel *sdk.Panel, models map[string]*sdk.Panel) (*sdk.Panel, error) {
+	if panel.CustomPanel == nil {
+		return panel, nil
+	}
+
+	reference, ok := (*panel.CustomPanel)["libraryPanel"].(map[string]interface{})
+	if !ok {
+		return panel, nil
+	}
+
+	uid, _ := reference["uid"].(string)
+
+	model, ok := models[uid]
+	if !ok {
+		return nil, fmt.Errorf("library panel '%s' is not defined by the dashboard, its queries can not be run", uid)
+	}
+
+	return model, nil
+}
+
+// panelTargets returns the targets of a panel. The targets of custom panels
+// are read from their settings.
+func panelTargets(panel *sdk.Panel) ([]sdk.Target, error) {
+	if panel.CustomPanel == nil {
+		targets := panel.GetTargets()
+		if targets == nil {
+			return nil, nil
+		}
+
+		return *targets, nil
+	}
+
+	switch targets := (*panel.CustomPanel)["targets"].(type) {
+	case nil:
+		return nil, nil
+	case []sdk.Target:
+		return targets, nil
+	default:
+		raw, err := json.Marshal(targets)
+		if err != nil {
+			return nil, fmt.Errorf("could not read the queries of the panel: %w", err)
+		}
+
+		var decoded []sdk.Target
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return nil, fmt.Errorf("could not read the queries of the panel: %w", err)
+		}
+
+		return decoded, nil
+	}
+}
+
+func datasourceRef(ref *sdk.DatasourceRef, variables map[string]string) datasource.Ref {
+	if ref == nil {
+		return datasource.Ref{}
+	}
+
+	return datasource.Ref{
+		UID:  interpolate(ref.UID, variables),
+		Type: ref.Type,
+		Name: interpolate(ref.LegacyName, variables),
+	}
+}
+
+// variableValues returns the current value of the variables of a board,
+// formatted to be used in queries.
+func variableValues(board *sdk.Board, overrides map[string]string) map[string]string {
+	values := map[string]string{}
+
+	for _, variable := range board.Templating.List {
+		var current []string
+
+		switch value := variable.Current.Value.(type) {
+		case string:
+			current = []string{value}
+		case []string:
+			current = value
+		case []interface{}:
+			for _, item := range value {
+				current = append(current, fmt.Sprint(item))
+			}
+		}
+
+		switch {
+		case len(current) == 0:
+			continue
+		case len(current) == 1 && current[0] == "$__all":
+			values[variable.Name] = ".*"
+		case len(current) == 1:
+			values[variable.Name] = current[0]
+		default:
+			values[variable.Name] = "(" + strings.Join(current, "|") + ")"
+		}
+	}
+
+	for name, value := range overrides {
+		values[name] = value
+	}
+
+	return values
+}
+
+// interpolate replaces the variables used in the input by their value.
+// Unknown variables, like Grafana's global ones, are left untouched.
+func interpolate(input string, variables map[string]string) string {
+	return dashboard.ReplaceVariableReferences(input, func(name string, reference string) string {
+		value, ok := variables[name]
+		if !ok {
+			return reference
+		}
+
+		return value
+	})
+}
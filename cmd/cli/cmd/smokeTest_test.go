@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/table"
+	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/timeseries"
+	"github.com/K-Phoen/grabana/transformation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesOfTablePanelsAreSmokeTested(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.Row(
+			"Row",
+			row.WithTable(
+				"Threads",
+				table.DataSource("Prometheus"),
+				table.WithPrometheusTarget("go_threads{job=\"$job\"}"),
+				table.WithPrometheusTarget("go_goroutines", prometheus.Hide()),
+			),
+		),
+	)
+	req.NoError(err)
+
+	queries := boardQueries(builder.Internal(), nil, map[string]string{"job": "api"})
+
+	req.Len(queries, 1)
+	req.NoError(queries[0].err)
+	req.Equal("Threads", queries[0].panel)
+	req.Equal("Prometheus", queries[0].datasource.Name)
+	req.Equal(`go_threads{job="api"}`, queries[0].target.Expr)
+}
+
+func TestQueriesOfPanelsWithTransformationsAreSmokeTested(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"Goroutines",
+				timeseries.Transformations(transformation.Limit(10)),
+				timeseries.WithPrometheusTarget("go_goroutines"),
+			),
+		),
+	)
+	req.NoError(err)
+
+	queries := boardQueries(builder.Internal(), nil, nil)
+
+	req.Len(queries, 1)
+	req.NoError(queries[0].err)
+	req.Equal("go_goroutines", queries[0].target.Expr)
+}
+
+func TestQueriesOfLibraryPanelsAreSmokeTested(t *testing.T) {
+	req := require.New(t)
+
+	libraryPanel, err := timeseries.New("Goroutines", timeseries.WithPrometheusTarget("go_goroutines"))
+	req.NoError(err)
+
+	definition, err := librarypanel.Define("goroutines", libraryPanel.Builder)
+	req.NoError(err)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.LibraryPanels(definition),
+		dashboard.Row(
+			"Row",
+			row.WithLibraryPanel("goroutines"),
+			row.WithLibraryPanel("undefined"),
+		),
+	)
+	req.NoError(err)
+
+	queries := boardQueries(builder.Internal(), builder.LibraryPanels(), nil)
+
+	req.Len(queries, 2)
+	req.NoError(queries[0].err)
+	req.Equal("go_goroutines", queries[0].target.Expr)
+	req.ErrorContains(queries[1].err, "library panel 'undefined' is not defined")
+}
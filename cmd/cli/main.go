@@ -24,6 +24,13 @@ func main() {
 	root.SilenceUsage = true
 
 	root.AddCommand(cmd.Apply())
+	root.AddCommand(cmd.ApplyDatasources())
+	root.AddCommand(cmd.ExportDatasources())
+	root.AddCommand(cmd.SmokeTest())
+	root.AddCommand(cmd.Rollback())
+	root.AddCommand(cmd.Backup())
+	root.AddCommand(cmd.Restore())
+	root.AddCommand(cmd.Promote())
 	root.AddCommand(cmd.Validate())
 	root.AddCommand(cmd.SelfUpdate(version))
 	root.AddCommand(cmd.Render())
@@ -19,6 +19,14 @@ func main() {
 			name:  "dashboard",
 			input: &decoder.DashboardModel{},
 		},
+		{
+			name:  "datasources",
+			input: &decoder.DatasourcesModel{},
+		},
+		{
+			name:  "promotion-rules",
+			input: &decoder.PromotionRulesModel{},
+		},
 	}
 
 	for _, t := range types {
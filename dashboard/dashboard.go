@@ -8,7 +8,11 @@ import (
 	"encoding/json"
 
 	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/annotation"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/permission"
 	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/variable/adhoc"
 	"github.com/K-Phoen/grabana/variable/constant"
 	"github.com/K-Phoen/grabana/variable/custom"
 	"github.com/K-Phoen/grabana/variable/datasource"
@@ -47,8 +51,26 @@ const Browser TimezoneOption = "browser"
 
 // Builder is the main builder used to configure dashboards.
 type Builder struct {
-	board  *sdk.Board
-	alerts []*alert.Alert
+	board         *sdk.Board
+	alerts        []*alert.Alert
+	libraryPanels []*librarypanel.Definition
+
+	permissions       []permission.Item
+	folderPermissions []permission.Item
+
+	// annotations not modelled by the sdk, indexed by their position in the
+	// board's list of annotations.
+	annotations map[int]*annotation.Annotation
+
+	// variables not fully modelled by the sdk, indexed by their position in
+	// the board's list of variables.
+	variables map[int]variableModel
+}
+
+// variableModel is implemented by variables holding settings that the sdk
+// doesn't model.
+type variableModel interface {
+	Model() (map[string]interface{}, error)
 }
 
 // New creates a new dashboard builder.
@@ -64,9 +86,19 @@ func New(title string, options ...Option) (Builder, error) {
 		}
 	}
 
+	if err := validateVariables(builder.board.Templating.List); err != nil {
+		return *builder, err
+	}
+
 	return *builder, nil
 }
 
+// FromBoard creates a dashboard builder from an existing board, like the ones
+// exported by Grafana.
+func FromBoard(board *sdk.Board) Builder {
+	return Builder{board: board}
+}
+
 func defaults() []Option {
 	return []Option{
 		defaultTimePicker(),
@@ -94,7 +126,12 @@ func defaultTimePicker() Option {
 // Grafana's dashboard via its provisioning support.
 // See https://grafana.com/docs/grafana/latest/administration/provisioning/#dashboards
 func (builder *Builder) MarshalJSON() ([]byte, error) {
-	return json.Marshal(builder.board)
+	board, err := builder.boardJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(board)
 }
 
 // MarshalIndentJSON renders the dashboard as indented JSON
@@ -102,7 +139,86 @@ func (builder *Builder) MarshalJSON() ([]byte, error) {
 // Grafana's dashboard via its provisioning support.
 // See https://grafana.com/docs/grafana/latest/administration/provisioning/#dashboards
 func (builder *Builder) MarshalIndentJSON() ([]byte, error) {
-	return json.MarshalIndent(builder.board, "", "  ")
+	board, err := builder.boardJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(board, "", "  ")
+}
+
+type boardJSON struct {
+	*sdk.Board
+
+	// shadow the annotations and variables of the board
+	Annotations struct {
+		List []interface{} `json:"list"`
+	} `json:"annotations"`
+	Templating struct {
+		List []interface{} `json:"list"`
+	} `json:"templating"`
+}
+
+// boardJSON describes the board as expected by Grafana, including the
+// settings of annotations and variables that the sdk doesn't model.
+func (builder *Builder) boardJSON() (*boardJSON, error) {
+	board := &boardJSON{Board: builder.board}
+
+	for i := range builder.board.Templating.List {
+		variable, ok := builder.variables[i]
+		if !ok {
+			board.Templating.List = append(board.Templating.List, builder.board.Templating.List[i])
+			continue
+		}
+
+		model, err := variable.Model()
+		if err != nil {
+			return nil, err
+		}
+
+		board.Templating.List = append(board.Templating.List, model)
+	}
+
+	var panelIDs map[string]uint
+	if len(builder.annotations) != 0 {
+		panelIDs = panelIDsByTitle(builder.board)
+	}
+
+	for i := range builder.board.Annotations.List {
+		source, ok := builder.annotations[i]
+		if !ok {
+			board.Annotations.List = append(board.Annotations.List, builder.board.Annotations.List[i])
+			continue
+		}
+
+		model, err := source.Model(panelIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		board.Annotations.List = append(board.Annotations.List, model)
+	}
+
+	return board, nil
+}
+
+func panelIDsByTitle(board *sdk.Board) map[string]uint {
+	ids := map[string]uint{}
+
+	for _, panel := range board.Panels {
+		ids[panel.Title] = panel.ID
+
+		// collapsed rows hold their panels
+		if panel.RowPanel == nil {
+			continue
+		}
+
+		for _, child := range panel.RowPanel.Panels {
+			ids[child.Title] = child.ID
+		}
+	}
+
+	return ids
 }
 
 // Alerts returns all the alerts defined in this dashboard.
@@ -110,6 +226,24 @@ func (builder *Builder) Alerts() []*alert.Alert {
 	return builder.alerts
 }
 
+// LibraryPanels returns the library panels that must exist for this
+// dashboard to be displayed.
+func (builder *Builder) LibraryPanels() []*librarypanel.Definition {
+	return builder.libraryPanels
+}
+
+// Permissions returns the permissions enforced on this dashboard. Without
+// permission, the ones already set are left untouched.
+func (builder *Builder) Permissions() []permission.Item {
+	return builder.permissions
+}
+
+// FolderPermissions returns the permissions enforced on the folder holding
+// this dashboard. Without permission, the ones already set are left untouched.
+func (builder *Builder) FolderPermissions() []permission.Item {
+	return builder.folderPermissions
+}
+
 // Internal.
 func (builder *Builder) Internal() *sdk.Board {
 	return builder.board
@@ -195,7 +329,7 @@ func VariableAsQuery(name string, options ...query.Option) Option {
 	return func(builder *Builder) error {
 		templatedVar := query.New(name, options...)
 
-		builder.board.Templating.List = append(builder.board.Templating.List, templatedVar.Builder)
+		builder.addVariable(templatedVar.Builder, templatedVar)
 
 		return nil
 	}
@@ -225,6 +359,28 @@ func VariableAsText(name string, options ...text.Option) Option {
 	}
 }
 
+// VariableAsAdHoc adds an ad hoc filters variable: the filters it holds are
+// automatically added to every query made to its datasource.
+// See https://grafana.com/docs/grafana/latest/dashboards/variables/add-template-variables/#add-ad-hoc-filters
+func VariableAsAdHoc(name string, options ...adhoc.Option) Option {
+	return func(builder *Builder) error {
+		templatedVar := adhoc.New(name, options...)
+
+		builder.addVariable(templatedVar.Builder, templatedVar)
+
+		return nil
+	}
+}
+
+func (builder *Builder) addVariable(variable sdk.TemplateVar, model variableModel) {
+	if builder.variables == nil {
+		builder.variables = map[int]variableModel{}
+	}
+
+	builder.variables[len(builder.board.Templating.List)] = model
+	builder.board.Templating.List = append(builder.board.Templating.List, variable)
+}
+
 // ExternalLinks adds a dashboard-level external links.
 // See https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-dashboard-links/#add-a-url-link-to-a-dashboard
 func ExternalLinks(links ...ExternalLink) Option {
@@ -263,6 +419,38 @@ func Row(title string, options ...row.Option) Option {
 	}
 }
 
+// LibraryPanels declares library panels used by the dashboard. They will be
+// created or updated before the dashboard itself.
+func LibraryPanels(definitions ...*librarypanel.Definition) Option {
+	return func(builder *Builder) error {
+		builder.libraryPanels = append(builder.libraryPanels, definitions...)
+
+		return nil
+	}
+}
+
+// Permissions defines who can access the dashboard. They replace the
+// permissions already set on the dashboard, but not the ones inherited from
+// its folder.
+func Permissions(items ...permission.Item) Option {
+	return func(builder *Builder) error {
+		builder.permissions = append(builder.permissions, items...)
+
+		return nil
+	}
+}
+
+// FolderPermissions defines who can access the folder holding the dashboard,
+// and the dashboards within it. They replace the permissions already set on
+// the folder.
+func FolderPermissions(items ...permission.Item) Option {
+	return func(builder *Builder) error {
+		builder.folderPermissions = append(builder.folderPermissions, items...)
+
+		return nil
+	}
+}
+
 // TagsAnnotation adds a new source of annotation for the dashboard.
 func TagsAnnotation(annotation TagAnnotation) Option {
 	return func(builder *Builder) error {
@@ -279,6 +467,75 @@ func TagsAnnotation(annotation TagAnnotation) Option {
 	}
 }
 
+// PrometheusAnnotation adds a new source of annotations for the dashboard,
+// querying Prometheus with the given expression.
+func PrometheusAnnotation(name string, datasource string, expr string, options ...annotation.Option) Option {
+	return func(builder *Builder) error {
+		source, err := annotation.Prometheus(name, datasource, expr, options...)
+		if err != nil {
+			return err
+		}
+
+		builder.addAnnotation(source)
+
+		return nil
+	}
+}
+
+// LokiAnnotation adds a new source of annotations for the dashboard,
+// querying Loki with the given LogQL query.
+func LokiAnnotation(name string, datasource string, query string, options ...annotation.Option) Option {
+	return func(builder *Builder) error {
+		source, err := annotation.Loki(name, datasource, query, options...)
+		if err != nil {
+			return err
+		}
+
+		builder.addAnnotation(source)
+
+		return nil
+	}
+}
+
+// GrafanaAnnotation adds a new source of annotations for the dashboard,
+// showing annotations stored in Grafana itself.
+func GrafanaAnnotation(name string, options ...annotation.Option) Option {
+	return func(builder *Builder) error {
+		source, err := annotation.Grafana(name, options...)
+		if err != nil {
+			return err
+		}
+
+		builder.addAnnotation(source)
+
+		return nil
+	}
+}
+
+// GraphiteAnnotation adds a new source of annotations for the dashboard,
+// querying Graphite.
+func GraphiteAnnotation(name string, datasource string, options ...annotation.Option) Option {
+	return func(builder *Builder) error {
+		source, err := annotation.Graphite(name, datasource, options...)
+		if err != nil {
+			return err
+		}
+
+		builder.addAnnotation(source)
+
+		return nil
+	}
+}
+
+func (builder *Builder) addAnnotation(source *annotation.Annotation) {
+	if builder.annotations == nil {
+		builder.annotations = map[int]*annotation.Annotation{}
+	}
+
+	builder.annotations[len(builder.board.Annotations.List)] = source
+	builder.board.Annotations.List = append(builder.board.Annotations.List, *source.Builder)
+}
+
 // Editable marks the dashboard as editable.
 func Editable() Option {
 	return func(builder *Builder) error {
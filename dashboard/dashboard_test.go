@@ -4,8 +4,13 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/annotation"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/permission"
+	"github.com/K-Phoen/grabana/row"
 	"github.com/K-Phoen/grabana/variable/datasource"
 	"github.com/K-Phoen/grabana/variable/text"
+	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,6 +39,16 @@ func TestNewDashboardsCanBeCreated(t *testing.T) {
 	req.NotEmpty(panel.board.Time.To)
 }
 
+func TestDashboardsCanBeCreatedFromExistingBoards(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("Existing dashboard")
+
+	builder := FromBoard(board)
+
+	req.Same(board, builder.Internal())
+	req.Empty(builder.Alerts())
+}
+
 func TestDashboardCanBeMarshalledIntoJSON(t *testing.T) {
 	req := require.New(t)
 
@@ -232,7 +247,36 @@ func TestDashboardCanHaveRows(t *testing.T) {
 	panel, err := New("", Row("Prometheus"))
 
 	req.NoError(err)
-	req.Len(panel.board.Rows, 1)
+	req.Empty(panel.board.Rows)
+	req.Len(panel.board.Panels, 1)
+	req.Equal("row", panel.board.Panels[0].Type)
+	req.Equal("Prometheus", panel.board.Panels[0].Title)
+}
+
+func TestDashboardCanDeclareLibraryPanels(t *testing.T) {
+	req := require.New(t)
+	definition, err := librarypanel.Define("requests", sdk.NewText("Requests"))
+	req.NoError(err)
+
+	panel, err := New("", LibraryPanels(definition))
+
+	req.NoError(err)
+	req.Len(panel.LibraryPanels(), 1)
+	req.Equal("requests", panel.LibraryPanels()[0].UID)
+}
+
+func TestDashboardCanDeclarePermissions(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New(
+		"",
+		Permissions(permission.ForRole(permission.Viewer, permission.View)),
+		FolderPermissions(permission.ForTeam("SRE", permission.Admin)),
+	)
+
+	req.NoError(err)
+	req.Equal([]permission.Item{permission.ForRole(permission.Viewer, permission.View)}, panel.Permissions())
+	req.Equal([]permission.Item{permission.ForTeam("SRE", permission.Admin)}, panel.FolderPermissions())
 }
 
 func TestDashboardCanHaveAnnotationsFromTags(t *testing.T) {
@@ -244,6 +288,76 @@ func TestDashboardCanHaveAnnotationsFromTags(t *testing.T) {
 	req.Len(panel.board.Annotations.List, 1)
 }
 
+func TestDashboardCanHaveQueryBasedAnnotations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New(
+		"",
+		PrometheusAnnotation("Deployments", "prometheus", "changes(version[1m]) > 0"),
+		LokiAnnotation("Errors", "loki", `{app="api"} |= "error"`),
+		GrafanaAnnotation("Annotations"),
+		GraphiteAnnotation("Events", "graphite", annotation.EventTags("deploy")),
+	)
+
+	req.NoError(err)
+	req.Len(panel.board.Annotations.List, 4)
+	req.Equal("Deployments", panel.board.Annotations.List[0].Name)
+	req.Equal("Events", panel.board.Annotations.List[3].Name)
+}
+
+func TestInvalidAnnotationsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", LokiAnnotation("Errors", "loki", "{}", annotation.Step("1m")))
+
+	req.Error(err)
+}
+
+func TestAnnotationsCanBeFilteredByPanel(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New(
+		"",
+		TagsAnnotation(TagAnnotation{Name: "Tags"}),
+		PrometheusAnnotation(
+			"Deployments", "prometheus", "changes(version[1m]) > 0",
+			annotation.Hidden(),
+			annotation.OnlyPanels("Latency"),
+		),
+		Row("Row", row.WithTimeSeries("Latency")),
+	)
+	req.NoError(err)
+
+	content, err := panel.MarshalJSON()
+	req.NoError(err)
+
+	board := struct {
+		Annotations struct {
+			List []map[string]interface{} `json:"list"`
+		} `json:"annotations"`
+	}{}
+	req.NoError(json.Unmarshal(content, &board))
+
+	req.Len(board.Annotations.List, 2)
+	req.Equal("tags", board.Annotations.List[0]["type"])
+	req.Equal(true, board.Annotations.List[1]["hide"])
+	req.Equal(map[string]interface{}{
+		"exclude": false,
+		"ids":     []interface{}{float64(2)},
+	}, board.Annotations.List[1]["filter"])
+}
+
+func TestAnnotationsFilteredByUnknownPanelsCanNotBeMarshalled(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", GrafanaAnnotation("Annotations", annotation.OnlyPanels("Unknown")))
+	req.NoError(err)
+
+	_, err = panel.MarshalJSON()
+
+	req.Error(err)
+}
+
 func TestDashboardCanHaveExternalLinks(t *testing.T) {
 	req := require.New(t)
 
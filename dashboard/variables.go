@@ -0,0 +1,148 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// variableReference matches the syntaxes supported by Grafana to reference
+// variables: $name, ${name}, ${name:format}, [[name]] and [[name:format]].
+var variableReference = regexp.MustCompile(`\$(\w+)|\$\{(\w+)(?::[^}]*)?}|\[\[(\w+)(?::[^\]]*)?]]`)
+
+// ReplaceVariableReferences replaces each reference to a variable found in the
+// input by the value returned by the given function, which is called with the
+// name of the variable and the reference as written in the input.
+func ReplaceVariableReferences(input string, replace func(name string, reference string) string) string {
+	return variableReference.ReplaceAllStringFunc(input, func(reference string) string {
+		return replace(variableName(reference), reference)
+	})
+}
+
+func variableName(reference string) string {
+	match := variableReference.FindStringSubmatch(reference)
+
+	return match[1] + match[2] + match[3]
+}
+
+// validateVariables ensures that variables referencing other variables can be
+// resolved by Grafana: variables are resolved in the order they are declared,
+// so they can only depend on the ones declared before them.
+func validateVariables(variables []sdk.TemplateVar) error {
+	declaredAt := make(map[string]int, len(variables))
+	for i, variable := range variables {
+		declaredAt[variable.Name] = i
+	}
+
+	dependencies := make(map[string][]string, len(variables))
+	for _, variable := range variables {
+		for _, name := range variableReferences(variable) {
+			if _, ok := declaredAt[name]; ok {
+				dependencies[variable.Name] = append(dependencies[variable.Name], name)
+			}
+		}
+	}
+
+	if cycle := findCycle(variables, dependencies); cycle != nil {
+		return fmt.Errorf("variables depend on each other: %s: %w", strings.Join(cycle, " -> "), errors.ErrInvalidArgument)
+	}
+
+	for i, variable := range variables {
+		for _, dependency := range dependencies[variable.Name] {
+			if declaredAt[dependency] > i {
+				return fmt.Errorf("variable '%s' uses variable '%s' before it is declared: %w", variable.Name, dependency, errors.ErrInvalidArgument)
+			}
+		}
+	}
+
+	return nil
+}
+
+// variableReferences lists the names of the variables referenced by the
+// given one. Grafana's global variables, like $__interval, are ignored.
+func variableReferences(variable sdk.TemplateVar) []string {
+	sources := []string{variable.Regex}
+
+	switch query := variable.Query.(type) {
+	case string:
+		sources = append(sources, query)
+	case nil:
+	default:
+		if content, err := json.Marshal(query); err == nil {
+			sources = append(sources, string(content))
+		}
+	}
+
+	if variable.Datasource != nil {
+		sources = append(sources, variable.Datasource.LegacyName, variable.Datasource.UID)
+	}
+
+	var names []string
+	for _, source := range sources {
+		for _, reference := range variableReference.FindAllString(source, -1) {
+			name := variableName(reference)
+			if strings.HasPrefix(name, "__") {
+				continue
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func findCycle(variables []sdk.TemplateVar, dependencies map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(variables))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i := range path {
+				if path[i] == name {
+					return append(append([]string{}, path[i:]...), name)
+				}
+			}
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, dependency := range dependencies[name] {
+			if cycle := visit(dependency); cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, variable := range variables {
+		if state[variable.Name] != unvisited {
+			continue
+		}
+
+		if cycle := visit(variable.Name); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package dashboard
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/variable/adhoc"
+	"github.com/K-Phoen/grabana/variable/datasource"
+	"github.com/K-Phoen/grabana/variable/query"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardCanHaveAdHocVariables(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New(
+		"",
+		VariableAsAdHoc(
+			"filters",
+			adhoc.DataSource("prometheus"),
+			adhoc.Filters(adhoc.Filter{Key: "job", Operator: adhoc.Equal, Value: "api"}),
+		),
+		VariableAsQuery("job", query.Request("label_values(job)")),
+	)
+	req.NoError(err)
+	req.Len(panel.board.Templating.List, 2)
+	req.Equal("adhoc", panel.board.Templating.List[0].Type)
+
+	content, err := panel.MarshalJSON()
+	req.NoError(err)
+
+	board := struct {
+		Templating struct {
+			List []map[string]interface{} `json:"list"`
+		} `json:"templating"`
+	}{}
+	req.NoError(json.Unmarshal(content, &board))
+
+	req.Len(board.Templating.List, 2)
+	req.Equal([]interface{}{
+		map[string]interface{}{"key": "job", "operator": "=", "value": "api"},
+	}, board.Templating.List[0]["filters"])
+	req.Equal("job", board.Templating.List[1]["name"])
+	req.NotContains(board.Templating.List[1], "filters")
+}
+
+func TestVariablesCanDependOnPreviouslyDeclaredOnes(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(
+		"",
+		VariableAsDatasource("ds", datasource.Type("prometheus")),
+		VariableAsQuery("job", query.DataSource("$ds"), query.Request("label_values(up, job)")),
+		VariableAsQuery("instance", query.DataSource("${ds}"), query.Request(`label_values(up{job=~"[[job]]"}, instance)`)),
+		VariableAsQuery("port", query.Request(`label_values(up{instance="${instance:regex}", job="$job"}, port)`)),
+	)
+
+	req.NoError(err)
+}
+
+func TestVariablesUsedBeforeBeingDeclaredAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(
+		"",
+		VariableAsQuery("instance", query.Request(`label_values(up{job="$job"}, instance)`)),
+		VariableAsQuery("job", query.Request("label_values(up, job)")),
+	)
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+	req.Contains(err.Error(), "variable 'instance' uses variable 'job' before it is declared")
+}
+
+func TestVariablesDependingOnEachOtherAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(
+		"",
+		VariableAsQuery("job", query.Request(`label_values(up{instance="$instance"}, job)`)),
+		VariableAsQuery("instance", query.Request(`label_values(up{job="$job"}, instance)`)),
+	)
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+	req.Contains(err.Error(), "job -> instance -> job")
+}
+
+func TestVariablesDependingOnThemselvesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", VariableAsQuery("job", query.Regex("$job")))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestGlobalAndUnknownVariablesAreIgnored(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(
+		"",
+		VariableAsQuery("job", query.Request(`query_result(rate(up[$__interval]) * $unknown)`)),
+	)
+
+	req.NoError(err)
+}
+
+func TestVariableReferencesCanBeReplaced(t *testing.T) {
+	req := require.New(t)
+
+	replaced := ReplaceVariableReferences(
+		"$job ${job} ${job:csv} [[job]] [[job:regex]] $unknown",
+		func(name string, reference string) string {
+			if name != "job" {
+				return reference
+			}
+
+			return "api"
+		},
+	)
+
+	req.Equal("api api api api api $unknown", replaced)
+}
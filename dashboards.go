@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/K-Phoen/grabana/alert"
 	"github.com/K-Phoen/grabana/dashboard"
 	"github.com/K-Phoen/sdk"
 )
@@ -89,9 +90,15 @@ func (client *Client) rawDashboardByUID(ctx context.Context, uid string) (*sdk.B
 }
 
 // UpsertDashboard creates or replaces a dashboard, in the given folder.
+// Library panels declared by the dashboard are created or updated first, and
+// the permissions it declares are enforced on the dashboard and its folder.
+//
+// References to datasources by name or by type are resolved into references
+// by UID beforehand, and the dashboard is rejected if one of them matches no
+// datasource or several datasources.
 func (client *Client) UpsertDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder) (*Dashboard, error) {
 	// first pass: save the new dashboard
-	dashboardModel, err := client.persistDashboard(ctx, folder, builder)
+	dashboardModel, resolver, err := client.saveDashboard(ctx, folder, builder, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -101,59 +108,206 @@ func (client *Client) UpsertDashboard(ctx context.Context, folder *Folder, build
 		return nil, err
 	}
 
-	// second pass: delete existing alerts associated to that dashboard
-	alertRefs, err := client.listAlertsForDashboard(ctx, dashboardModel.UID)
+	// second pass: replace the alerts associated to that dashboard
+	if err := client.replaceDashboardAlerts(ctx, folder.Title, dashboardFromGrafana, builder.Alerts(), resolver); err != nil {
+		return nil, err
+	}
+
+	return dashboardModel, nil
+}
+
+// saveDashboard saves a dashboard along with its library panels and
+// permissions, leaving its alerts untouched. The given rule groups are saved
+// within the dashboard, next to its alerts, so that rolling back to this
+// version restores them. The snapshot is saved even if it is empty, so that
+// rolling back to this version deletes the alerts added since. The
+// datasource resolver used to save it is returned, if one was needed.
+func (client *Client) saveDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder, ruleGroups []ruleGroup) (*Dashboard, *datasourceResolver, error) {
+	resolved, err := client.resolveDatasources(ctx, builder)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// library panels must exist before the dashboards referencing them
+	for i, libraryPanel := range builder.LibraryPanels() {
+		if _, err := client.upsertLibraryPanel(ctx, folder, libraryPanel, resolved.libraryPanels[i]); err != nil {
+			return nil, nil, fmt.Errorf("could not upsert library panel '%s': %w", libraryPanel.UID, err)
+		}
+	}
+
+	board, err := withAlertsSnapshot(resolved.board, builder.Alerts(), ruleGroups)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dashboardModel, err := client.persistDashboard(ctx, folder, board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := client.enforcePermissions(ctx, folder, dashboardModel.UID, builder); err != nil {
+		return nil, nil, err
+	}
+
+	return dashboardModel, resolved.resolver, nil
+}
+
+// replaceDashboardAlerts deletes the alerts associated to the given
+// dashboard, and creates new ones within the given namespace.
+func (client *Client) replaceDashboardAlerts(ctx context.Context, namespace string, board *sdk.Board, alerts []*alert.Alert, resolver *datasourceResolver) error {
+	alertRefs, err := client.listAlertsForDashboard(ctx, board.UID)
 	if err != nil {
-		return nil, fmt.Errorf("could not prepare deletion of previous alerts for dashboard: %w", err)
+		return fmt.Errorf("could not prepare deletion of previous alerts for dashboard: %w", err)
 	}
 	for _, ref := range alertRefs {
 		if err := client.DeleteAlertGroup(ctx, ref.Namespace, ref.RuleGroup); err != nil {
-			return nil, fmt.Errorf("could not delete previous alerts for dashboard: %w", err)
+			return fmt.Errorf("could not delete previous alerts for dashboard: %w", err)
 		}
 	}
 
-	// third pass: create new alerts
-	alerts := builder.Alerts()
-
 	// If there are no alerts to create, we can return early
 	if len(alerts) == 0 {
-		return dashboardModel, nil
+		return nil
+	}
+
+	if resolver == nil {
+		var err error
+		if resolver, err = client.datasourceResolver(ctx); err != nil {
+			return fmt.Errorf("could not fetch datasources: %w", err)
+		}
+	}
+
+	for i := range alerts {
+		alert, err := copyAlert(*alerts[i])
+		if err != nil {
+			return err
+		}
+
+		alert.HookDashboardUID(board.UID)
+		alert.HookPanelID(panelIDByTitle(board, alert.Builder.Name))
+
+		if err := client.addAlert(ctx, namespace, alert, resolver); err != nil {
+			return fmt.Errorf("could not add new alerts for dashboard: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// enforcePermissions sets the permissions declared by the dashboard on itself
+// and on its folder.
+func (client *Client) enforcePermissions(ctx context.Context, folder *Folder, dashboardUID string, builder dashboard.Builder) error {
+	if folderPermissions := builder.FolderPermissions(); len(folderPermissions) != 0 {
+		if folder.UID == "" {
+			return fmt.Errorf("could not set permissions of folder '%s': no folder UID", folder.Title)
+		}
+
+		if err := client.SetFolderPermissions(ctx, folder.UID, folderPermissions...); err != nil {
+			return fmt.Errorf("could not set permissions of folder '%s': %w", folder.Title, err)
+		}
+	}
+
+	if permissions := builder.Permissions(); len(permissions) != 0 {
+		if err := client.SetDashboardPermissions(ctx, dashboardUID, permissions...); err != nil {
+			return fmt.Errorf("could not set permissions of dashboard: %w", err)
+		}
 	}
 
-	datasourcesMap, err := client.datasourcesUIDMap(ctx)
+	return nil
+}
+
+// resolvedDashboard is the JSON model of a dashboard and of its library
+// panels, with references to datasources resolved.
+type resolvedDashboard struct {
+	board []byte
+	// libraryPanels holds the models of the library panels of the
+	// dashboard, in the same order.
+	libraryPanels []json.RawMessage
+	// resolver is only set if something had to be resolved.
+	resolver *datasourceResolver
+}
+
+// resolveDatasources resolves the references to datasources made by the
+// dashboard, its library panels and its alerts. Resolution happens on their
+// JSON models: the builder is left untouched, so that it can be saved to
+// other instances. Datasources are only fetched if there is something to
+// resolve.
+func (client *Client) resolveDatasources(ctx context.Context, builder dashboard.Builder) (*resolvedDashboard, error) {
+	alerts := builder.Alerts()
+
+	board, err := builder.MarshalJSON()
 	if err != nil {
 		return nil, err
 	}
 
-	for i := range alerts {
-		alert := *alerts[i]
+	resolved := &resolvedDashboard{board: board}
+	models := [][]byte{board}
+
+	for _, libraryPanel := range builder.LibraryPanels() {
+		model, err := json.Marshal(libraryPanel.Model)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved.libraryPanels = append(resolved.libraryPanels, model)
+		models = append(models, model)
+	}
 
-		alert.HookDashboardUID(dashboardFromGrafana.UID)
-		alert.HookPanelID(panelIDByTitle(dashboardFromGrafana, alert.Builder.Name))
+	needed := len(alerts) != 0
+	for _, model := range models {
+		modelNeedsResolution, err := jsonNeedsResolution(model)
+		if err != nil {
+			return nil, err
+		}
+
+		needed = needed || modelNeedsResolution
+	}
+
+	if !needed {
+		return resolved, nil
+	}
+
+	if resolved.resolver, err = client.datasourceResolver(ctx); err != nil {
+		return nil, fmt.Errorf("could not fetch datasources: %w", err)
+	}
+
+	if resolved.board, err = resolved.resolver.resolveJSON(board); err != nil {
+		return nil, err
+	}
 
-		if err := client.AddAlert(ctx, folder.Title, alert, datasourcesMap); err != nil {
-			return nil, fmt.Errorf("could not add new alerts for dashboard: %w", err)
+	for i := range resolved.libraryPanels {
+		if resolved.libraryPanels[i], err = resolved.resolver.resolveJSON(resolved.libraryPanels[i]); err != nil {
+			return nil, err
 		}
 	}
 
-	return dashboardModel, nil
+	for _, alert := range alerts {
+		if _, err := resolved.resolver.alertDatasourceUID(alert.Datasource); err != nil {
+			return nil, fmt.Errorf("could not resolve datasource for alert '%s': %w", alert.Builder.Name, err)
+		}
+	}
+
+	return resolved, nil
 }
 
-func (client *Client) persistDashboard(ctx context.Context, folder *Folder, builder dashboard.Builder) (*Dashboard, error) {
+// persistDashboard saves the given JSON model of a dashboard.
+func (client *Client) persistDashboard(ctx context.Context, folder *Folder, board []byte) (*Dashboard, error) {
 	buf, err := json.Marshal(struct {
-		Dashboard *sdk.Board `json:"dashboard"`
-		FolderID  uint       `json:"folderId"`
-		Overwrite bool       `json:"overwrite"`
+		Dashboard json.RawMessage `json:"dashboard"`
+		FolderID  uint            `json:"folderId"`
+		FolderUID string          `json:"folderUid,omitempty"`
+		Overwrite bool            `json:"overwrite"`
 	}{
-		Dashboard: builder.Internal(),
+		Dashboard: board,
 		FolderID:  folder.ID,
+		FolderUID: folder.UID,
 		Overwrite: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/dashboards/db", buf)
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, "/api/dashboards/db", buf)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +370,17 @@ func panelIDByTitle(board *sdk.Board, title string) string {
 		if panel.Title == title {
 			return fmt.Sprintf("%d", panel.ID)
 		}
+
+		// collapsed rows hold their panels
+		if panel.RowPanel == nil {
+			continue
+		}
+
+		for _, rowPanel := range panel.RowPanel.Panels {
+			if rowPanel.Title == title {
+				return fmt.Sprintf("%d", rowPanel.ID)
+			}
+		}
 	}
 
 	return ""
@@ -2,6 +2,7 @@ package grabana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/K-Phoen/grabana/alert"
 	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/permission"
 	"github.com/K-Phoen/grabana/row"
 	"github.com/K-Phoen/grabana/text"
 	"github.com/K-Phoen/grabana/timeseries"
@@ -278,9 +280,14 @@ func TestDashboardsCanBeCreatedWithNoAlertAndNoPreviousAlerts(t *testing.T) {
 	)
 	req.NoError(err)
 
+	var savedDashboard struct {
+		Dashboard map[string]json.RawMessage `json:"dashboard"`
+	}
+
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Dashboard creation
 		if r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db" {
+			req.NoError(json.NewDecoder(r.Body).Decode(&savedDashboard))
 			_, _ = fmt.Fprintln(w, `{
   "id":      1,
   "uid":     "cIBgcSjkk",
@@ -422,6 +429,49 @@ func TestDashboardsCanBeCreatedWithNoAlertAndNoPreviousAlerts(t *testing.T) {
 
 	req.NoError(err)
 	req.NotNil(board)
+	// rolling back to this version deletes alerts added later on
+	req.JSONEq(`[]`, string(savedDashboard.Dashboard[alertsSnapshotKey]))
+}
+
+func TestDashboardsCanBeCreatedWithPermissions(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard with permissions",
+		dashboard.Permissions(permission.ForRole(permission.Viewer, permission.View)),
+		dashboard.FolderPermissions(permission.ForTeamID(3, permission.Admin)),
+	)
+	req.NoError(err)
+
+	permissionsSet := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "cIBgcSjkk", "url": "/d/cIBgcSjkk/permissions", "status": "success", "version": 1}`)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/permissions"):
+			body, err := io.ReadAll(r.Body)
+			req.NoError(err)
+			permissionsSet[r.URL.Path] = string(body)
+
+			_, _ = fmt.Fprintln(w, `{"message": "Permissions updated"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/cIBgcSjkk":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"id": 1, "uid": "cIBgcSjkk", "title": "Dashboard with permissions"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = fmt.Fprintf(w, `{"message": "oh noes, we should not get here", "method": "%s", "path": "%s"}\n`, r.Method, r.URL.String())
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{ID: 2, UID: "folder-uid", Title: "Folder"}, builder)
+
+	req.NoError(err)
+	req.JSONEq(`{"items": [{"role": "Viewer", "permission": 1}]}`, permissionsSet["/api/dashboards/uid/cIBgcSjkk/permissions"])
+	req.JSONEq(`{"items": [{"teamId": 3, "permission": 4}]}`, permissionsSet["/api/folders/folder-uid/permissions"])
 }
 
 func TestDashboardsCanBeCreatedWithNoAlertAndDeletesPreviousAlerts(t *testing.T) {
@@ -460,6 +510,12 @@ func TestDashboardsCanBeCreatedWithNoAlertAndDeletesPreviousAlerts(t *testing.T)
 			return
 		}
 
+		// Datasources resolution
+		if r.Method == http.MethodGet && r.URL.String() == "/api/datasources" {
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus", "isDefault": true}]`)
+			return
+		}
+
 		// Raw dashboard retrieval after creation
 		if r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/cIBgcSjkk" {
 			_, _ = fmt.Fprintln(w, `{"dashboard": {
@@ -972,3 +1028,19 @@ func TestClient_panelIDByTitle_panelInRow(t *testing.T) {
 	req.Equal("24", panelIDByTitle(board, "Heamtap panel"))
 	req.Equal("", panelIDByTitle(board, "not found"))
 }
+
+func TestClient_panelIDByTitle_panelInCollapsedRow(t *testing.T) {
+	req := require.New(t)
+
+	board := sdk.NewBoard("board title")
+	panel := sdk.NewTimeseries("Timeseries panel")
+	panel.ID = 12
+
+	board.Panels = append(board.Panels, &sdk.Panel{
+		CommonPanel: sdk.CommonPanel{OfType: sdk.RowType, Type: "row", Title: "Row title"},
+		RowPanel:    &sdk.RowPanel{Collapsed: true, Panels: []sdk.Panel{*panel}},
+	})
+
+	req.Equal("12", panelIDByTitle(board, "Timeseries panel"))
+	req.Equal("", panelIDByTitle(board, "not found"))
+}
@@ -0,0 +1,231 @@
+package dashlist
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a dashboard list
+// panel.
+type Option func(dashList *DashList) error
+
+type options struct {
+	ShowStarred        bool     `json:"showStarred"`
+	ShowRecentlyViewed bool     `json:"showRecentlyViewed"`
+	ShowSearch         bool     `json:"showSearch"`
+	ShowHeadings       bool     `json:"showHeadings"`
+	MaxItems           int      `json:"maxItems"`
+	Query              string   `json:"query"`
+	Tags               []string `json:"tags"`
+	FolderID           *uint    `json:"folderId,omitempty"`
+	IncludeVars        bool     `json:"includeVars"`
+	KeepTime           bool     `json:"keepTime"`
+}
+
+// DashList represents a dashboard list panel.
+type DashList struct {
+	Builder *sdk.Panel
+
+	options *options
+}
+
+// New creates a new dashboard list panel. By default, it doesn't list any
+// dashboard: use Starred(), Recent() or one of the search options to select
+// what should be displayed.
+func New(title string, options ...Option) (*DashList, error) {
+	panel := &DashList{
+		Builder: sdk.NewCustom(title),
+		options: defaultOptions(),
+	}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Type = "dashlist"
+	panel.Builder.Renderer = nil
+	(*panel.Builder.CustomPanel)["options"] = panel.options
+
+	for _, opt := range append(defaults(), options...) {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+func defaultOptions() *options {
+	return &options{
+		ShowHeadings: true,
+		Tags:         []string{},
+	}
+}
+
+func defaults() []Option {
+	return []Option{
+		Span(6),
+		MaxItems(10),
+	}
+}
+
+// Links adds links to be displayed on this panel.
+func Links(panelLinks ...links.Link) Option {
+	return func(dashList *DashList) error {
+		dashList.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+
+		for _, link := range panelLinks {
+			dashList.Builder.Links = append(dashList.Builder.Links, link.Builder)
+		}
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(dashList *DashList) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		dashList.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(dashList *DashList) error {
+		dashList.Builder.Height = &height
+
+		return nil
+	}
+}
+
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(dashList *DashList) error {
+		return layout.At(dashList.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(dashList *DashList) error {
+		return layout.Size(dashList.Builder, width, height)
+	}
+}
+
+// Description annotates the current visualization with a human-readable description.
+func Description(content string) Option {
+	return func(dashList *DashList) error {
+		dashList.Builder.Description = &content
+
+		return nil
+	}
+}
+
+// Transparent makes the background transparent.
+func Transparent() Option {
+	return func(dashList *DashList) error {
+		dashList.Builder.Transparent = true
+
+		return nil
+	}
+}
+
+// Starred lists the starred dashboards.
+func Starred() Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowStarred = true
+
+		return nil
+	}
+}
+
+// Recent lists the recently viewed dashboards.
+func Recent() Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowRecentlyViewed = true
+
+		return nil
+	}
+}
+
+// SearchQuery lists the dashboards matching the given query.
+func SearchQuery(query string) Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowSearch = true
+		dashList.options.Query = query
+
+		return nil
+	}
+}
+
+// SearchTags lists the dashboards having all the given tags.
+func SearchTags(tags ...string) Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowSearch = true
+		dashList.options.Tags = tags
+
+		return nil
+	}
+}
+
+// SearchFolder lists the dashboards defined in the folder with the given ID.
+func SearchFolder(folderID uint) Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowSearch = true
+		dashList.options.FolderID = &folderID
+
+		return nil
+	}
+}
+
+// IncludeCurrentTimeRange propagates the current time range to the listed
+// dashboards' links.
+func IncludeCurrentTimeRange() Option {
+	return func(dashList *DashList) error {
+		dashList.options.KeepTime = true
+
+		return nil
+	}
+}
+
+// IncludeCurrentVariables propagates the current template variables values
+// to the listed dashboards' links.
+func IncludeCurrentVariables() Option {
+	return func(dashList *DashList) error {
+		dashList.options.IncludeVars = true
+
+		return nil
+	}
+}
+
+// HideHeadings hides the headings of each section.
+func HideHeadings() Option {
+	return func(dashList *DashList) error {
+		dashList.options.ShowHeadings = false
+
+		return nil
+	}
+}
+
+// MaxItems sets the maximum number of dashboards listed per section.
+func MaxItems(max int) Option {
+	return func(dashList *DashList) error {
+		if max < 1 {
+			return fmt.Errorf("max items must be greater than zero: %w", errors.ErrInvalidArgument)
+		}
+
+		dashList.options.MaxItems = max
+
+		return nil
+	}
+}
@@ -0,0 +1,171 @@
+package dashlist
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDashListPanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("Dashboards")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("Dashboards", panel.Builder.Title)
+	req.Equal("dashlist", panel.Builder.Type)
+	req.Equal(float32(6), panel.Builder.Span)
+	req.Equal(10, panel.options.MaxItems)
+	req.True(panel.options.ShowHeadings)
+	req.False(panel.options.ShowStarred)
+	req.False(panel.options.ShowRecentlyViewed)
+	req.False(panel.options.ShowSearch)
+	req.Same(panel.options, (*panel.Builder.CustomPanel)["options"])
+}
+
+func TestDashListPanelCanHaveLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Links(links.New("", "")))
+
+	req.NoError(err)
+	req.Len(panel.Builder.Links, 1)
+}
+
+func TestDashListPanelWidthCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Span(8))
+
+	req.NoError(err)
+	req.Equal(float32(8), panel.Builder.Span)
+}
+
+func TestDashListPanelRejectIncorrectWidth(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Span(-8))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestDashListPanelHeightCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
+
+func TestDashListPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidDashListPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestDashListPanelBackgroundCanBeTransparent(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transparent())
+
+	req.NoError(err)
+	req.True(panel.Builder.Transparent)
+}
+
+func TestDashListPanelDescriptionCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Description("lala"))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.Description)
+	req.Equal("lala", *panel.Builder.Description)
+}
+
+func TestStarredDashboardsCanBeListed(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Starred())
+
+	req.NoError(err)
+	req.True(panel.options.ShowStarred)
+}
+
+func TestRecentDashboardsCanBeListed(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Recent())
+
+	req.NoError(err)
+	req.True(panel.options.ShowRecentlyViewed)
+}
+
+func TestDashboardsCanBeSearched(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", SearchQuery("kube"), SearchTags("infra", "k8s"), SearchFolder(3))
+
+	req.NoError(err)
+	req.True(panel.options.ShowSearch)
+	req.Equal("kube", panel.options.Query)
+	req.ElementsMatch([]string{"infra", "k8s"}, panel.options.Tags)
+	req.Equal(uint(3), *panel.options.FolderID)
+}
+
+func TestCurrentTimeRangeAndVariablesCanBeIncluded(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", IncludeCurrentTimeRange(), IncludeCurrentVariables())
+
+	req.NoError(err)
+	req.True(panel.options.KeepTime)
+	req.True(panel.options.IncludeVars)
+}
+
+func TestHeadingsCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", HideHeadings())
+
+	req.NoError(err)
+	req.False(panel.options.ShowHeadings)
+}
+
+func TestMaxItemsCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", MaxItems(5))
+
+	req.NoError(err)
+	req.Equal(5, panel.options.MaxItems)
+}
+
+func TestMaxItemsMustBePositive(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", MaxItems(-1))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
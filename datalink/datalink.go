@@ -0,0 +1,75 @@
+package datalink
+
+// Option represents an option that can be used to configure a data link.
+type Option func(link *Link)
+
+// Variables that can be used in data links URLs, and are interpolated by
+// Grafana using the data point the link is clicked on.
+const (
+	ValueRaw     = "${__value.raw}"
+	ValueText    = "${__value.text}"
+	ValueTime    = "${__value.time}"
+	FieldName    = "${__field.name}"
+	SeriesName   = "${__series.name}"
+	FieldLabels  = "${__field.labels}"
+	URLTimeRange = "${__url_time_range}"
+)
+
+// InternalLink describes a link to a query on a datasource, opened in
+// Grafana's explore view.
+type InternalLink struct {
+	DatasourceUID  string                 `json:"datasourceUid"`
+	DatasourceName string                 `json:"datasourceName,omitempty"`
+	Query          map[string]interface{} `json:"query"`
+}
+
+// Link represents a link attached to the values of a field.
+type Link struct {
+	Title       string        `json:"title"`
+	URL         string        `json:"url"`
+	TargetBlank bool          `json:"targetBlank,omitempty"`
+	Internal    *InternalLink `json:"internal,omitempty"`
+}
+
+// New creates a new data link pointing to the given URL. The URL can
+// reference variables interpolated with the clicked data point. Example:
+// "https://example.org/hosts/${__value.raw}".
+func New(title string, url string, options ...Option) Link {
+	link := &Link{
+		Title: title,
+		URL:   url,
+	}
+
+	for _, opt := range options {
+		opt(link)
+	}
+
+	return *link
+}
+
+// Internal creates a data link opening the given query on a datasource.
+// The query depends on the datasource. Example, for Loki:
+// map[string]interface{}{"expr": `{host="${__value.raw}"}`}.
+func Internal(title string, datasourceUID string, datasourceName string, query map[string]interface{}, options ...Option) Link {
+	link := &Link{
+		Title: title,
+		Internal: &InternalLink{
+			DatasourceUID:  datasourceUID,
+			DatasourceName: datasourceName,
+			Query:          query,
+		},
+	}
+
+	for _, opt := range options {
+		opt(link)
+	}
+
+	return *link
+}
+
+// OpenInNewTab opens the link in a new tab.
+func OpenInNewTab() Option {
+	return func(link *Link) {
+		link.TargetBlank = true
+	}
+}
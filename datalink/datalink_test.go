@@ -0,0 +1,40 @@
+package datalink
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDataLinksCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	link := New("Host details", "https://example.org/hosts/"+ValueRaw)
+
+	req.Equal("Host details", link.Title)
+	req.Equal("https://example.org/hosts/${__value.raw}", link.URL)
+	req.False(link.TargetBlank)
+	req.Nil(link.Internal)
+}
+
+func TestDataLinksCanBeOpenedInANewTab(t *testing.T) {
+	req := require.New(t)
+
+	link := New("Host details", "https://example.org", OpenInNewTab())
+
+	req.True(link.TargetBlank)
+}
+
+func TestInternalDataLinksCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	query := map[string]interface{}{"expr": `{host="${__value.raw}"}`}
+	link := Internal("Logs", "loki-uid", "Loki", query)
+
+	req.Equal("Logs", link.Title)
+	req.Empty(link.URL)
+	req.NotNil(link.Internal)
+	req.Equal("loki-uid", link.Internal.DatasourceUID)
+	req.Equal("Loki", link.Internal.DatasourceName)
+	req.Equal(query, link.Internal.Query)
+}
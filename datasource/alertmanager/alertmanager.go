@@ -0,0 +1,50 @@
+package alertmanager
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+var _ datasource.Datasource = Alertmanager{}
+
+type Alertmanager struct {
+	builder *sdk.Datasource
+}
+
+type Option func(datasource *Alertmanager) error
+
+// New creates an Alertmanager datasource.
+func New(name string, url string, options ...Option) (Alertmanager, error) {
+	alertmanager := &Alertmanager{
+		builder: &sdk.Datasource{
+			Name:           name,
+			Type:           "alertmanager",
+			Access:         "proxy",
+			URL:            url,
+			JSONData:       map[string]interface{}{},
+			SecureJSONData: map[string]interface{}{},
+		},
+	}
+
+	defaults := []Option{
+		WithImplementation(Prometheus),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(alertmanager); err != nil {
+			return *alertmanager, err
+		}
+	}
+
+	return *alertmanager, nil
+}
+
+func (datasource Alertmanager) Name() string {
+	return datasource.builder.Name
+}
+
+func (datasource Alertmanager) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datasource.builder)
+}
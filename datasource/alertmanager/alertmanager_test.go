@@ -0,0 +1,23 @@
+package alertmanager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertmanager(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("ds-alertmanager", "http://localhost:9093")
+
+	req.NoError(err)
+	req.Equal("ds-alertmanager", datasource.Name())
+	req.Equal("http://localhost:9093", datasource.builder.URL)
+	req.Equal("alertmanager", datasource.builder.Type)
+	req.Equal("prometheus", datasource.builder.JSONData.(map[string]interface{})["implementation"])
+	req.NotNil(datasource.builder.SecureJSONData)
+
+	_, err = datasource.MarshalJSON()
+	req.NoError(err)
+}
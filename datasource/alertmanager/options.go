@@ -0,0 +1,104 @@
+package alertmanager
+
+// Implementation represents the flavor of Alertmanager behind the datasource.
+type Implementation string
+
+const (
+	Prometheus Implementation = "prometheus"
+	Cortex     Implementation = "cortex"
+	Mimir      Implementation = "mimir"
+)
+
+// WithImplementation sets the flavor of Alertmanager behind the datasource.
+// Defaults to Prometheus.
+func WithImplementation(implementation Implementation) Option {
+	return setJSONData("implementation", string(implementation))
+}
+
+// HandleGrafanaManagedAlerts makes Grafana send its managed alerts to this
+// Alertmanager.
+func HandleGrafanaManagedAlerts() Option {
+	return setJSONData("handleGrafanaManagedAlerts", true)
+}
+
+// BasicAuth configures basic authentication for this datasource.
+func BasicAuth(username string, password string) Option {
+	return func(datasource *Alertmanager) error {
+		yep := true
+		datasource.builder.BasicAuth = &yep
+		datasource.builder.BasicAuthUser = &username
+		datasource.builder.BasicAuthPassword = &password
+
+		return nil
+	}
+}
+
+// WithCredentials joins credentials such as cookies or auth headers to cross-site requests.
+func WithCredentials() Option {
+	return func(datasource *Alertmanager) error {
+		datasource.builder.WithCredentials = true
+
+		return nil
+	}
+}
+
+// SkipTLSVerify disables verification of SSL certificates.
+func SkipTLSVerify() Option {
+	return setJSONData("tlsSkipVerify", true)
+}
+
+// ForwardOauthIdentity forward the user's upstream OAuth identity to the datasource.
+func ForwardOauthIdentity() Option {
+	return setJSONData("oauthPassThru", true)
+}
+
+// TLSClientAuth enables TLS client side authentication. Expects PEM encoded content.
+func TLSClientAuth(cert string, key string) Option {
+	return multiOption(
+		setJSONData("tlsAuth", true),
+		setSecureJSONData("tlsClientCert", cert),
+		setSecureJSONData("tlsClientKey", key),
+	)
+}
+
+// WithCACert allows to provide a PEM encoded CA certificate to trust for this data source.
+func WithCACert(cert string) Option {
+	return multiOption(
+		setJSONData("tlsAuthWithCACert", true),
+		setSecureJSONData("tlsCACert", cert),
+	)
+}
+
+func multiOption(opts ...Option) Option {
+	return func(datasource *Alertmanager) error {
+		for _, opt := range opts {
+			if err := opt(datasource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func setJSONData(key string, value interface{}) Option {
+	return func(datasource *Alertmanager) error {
+		datasource.builder.JSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func setSecureJSONData(key string, value interface{}) Option {
+	return func(datasource *Alertmanager) error {
+		datasource.builder.SecureJSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func invalidArgument(err error) Option {
+	return func(datasource *Alertmanager) error {
+		return err
+	}
+}
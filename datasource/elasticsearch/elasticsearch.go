@@ -0,0 +1,51 @@
+package elasticsearch
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+var _ datasource.Datasource = Elasticsearch{}
+
+type Elasticsearch struct {
+	builder *sdk.Datasource
+}
+
+type Option func(datasource *Elasticsearch) error
+
+// New creates an Elasticsearch datasource. Documents are expected to have an
+// "@timestamp" time field unless configured otherwise.
+func New(name string, url string, options ...Option) (Elasticsearch, error) {
+	elasticsearch := &Elasticsearch{
+		builder: &sdk.Datasource{
+			Name:           name,
+			Type:           "elasticsearch",
+			Access:         "proxy",
+			URL:            url,
+			JSONData:       map[string]interface{}{},
+			SecureJSONData: map[string]interface{}{},
+		},
+	}
+
+	defaults := []Option{
+		TimeField("@timestamp"),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(elasticsearch); err != nil {
+			return *elasticsearch, err
+		}
+	}
+
+	return *elasticsearch, nil
+}
+
+func (datasource Elasticsearch) Name() string {
+	return datasource.builder.Name
+}
+
+func (datasource Elasticsearch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datasource.builder)
+}
@@ -0,0 +1,24 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewElasticsearch(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("ds-es", "http://localhost:9200")
+
+	req.NoError(err)
+	req.Equal("ds-es", datasource.Name())
+	req.Equal("http://localhost:9200", datasource.builder.URL)
+	req.Equal("elasticsearch", datasource.builder.Type)
+	req.Equal("proxy", datasource.builder.Access)
+	req.Equal("@timestamp", datasource.builder.JSONData.(map[string]interface{})["timeField"])
+	req.NotNil(datasource.builder.SecureJSONData)
+
+	_, err = datasource.MarshalJSON()
+	req.NoError(err)
+}
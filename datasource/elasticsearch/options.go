@@ -0,0 +1,181 @@
+package elasticsearch
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// IndexInterval represents the interval at which time-based indices are
+// created.
+type IndexInterval string
+
+const (
+	NoInterval IndexInterval = ""
+	Hourly     IndexInterval = "Hourly"
+	Daily      IndexInterval = "Daily"
+	Weekly     IndexInterval = "Weekly"
+	Monthly    IndexInterval = "Monthly"
+	Yearly     IndexInterval = "Yearly"
+)
+
+// Index sets the index, or index pattern, to query. Patterns like
+// "[logs-]YYYY.MM.DD" must be used along with an interval.
+func Index(index string, interval IndexInterval) Option {
+	return func(datasource *Elasticsearch) error {
+		datasource.builder.Database = &index
+		datasource.builder.JSONData.(map[string]interface{})["index"] = index
+		datasource.builder.JSONData.(map[string]interface{})["interval"] = string(interval)
+
+		return nil
+	}
+}
+
+// TimeField sets the name of the time field. Defaults to "@timestamp".
+func TimeField(field string) Option {
+	return setJSONData("timeField", field)
+}
+
+// Version sets the version of Elasticsearch, as a semver string (ex: "8.0.0").
+func Version(version string) Option {
+	if strings.Count(version, ".") != 2 {
+		return invalidArgument(fmt.Errorf("version must be formatted as MAJOR.MINOR.PATCH: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("esVersion", version)
+}
+
+// OpenSearch configures this datasource to query an OpenSearch cluster,
+// using the OpenSearch plugin. The version is a semver string (ex: "2.3.0").
+func OpenSearch(version string) Option {
+	return multiOption(
+		func(datasource *Elasticsearch) error {
+			datasource.builder.Type = "grafana-opensearch-datasource"
+
+			return nil
+		},
+		setJSONData("flavor", "opensearch"),
+		setJSONData("version", version),
+	)
+}
+
+// MaxConcurrentShardRequests sets the maximum number of concurrent shard
+// requests that each sub-search request executes per node.
+func MaxConcurrentShardRequests(max int) Option {
+	if max <= 0 {
+		return invalidArgument(fmt.Errorf("max concurrent shard requests must be greater than zero: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("maxConcurrentShardRequests", max)
+}
+
+// LogMessageField sets the field holding the log message, used by the logs
+// panel and explore.
+func LogMessageField(field string) Option {
+	return setJSONData("logMessageField", field)
+}
+
+// LogLevelField sets the field holding the log level, used by the logs panel
+// and explore.
+func LogLevelField(field string) Option {
+	return setJSONData("logLevelField", field)
+}
+
+// Default configures this datasource to be the default one.
+func Default() Option {
+	return func(datasource *Elasticsearch) error {
+		datasource.builder.IsDefault = true
+
+		return nil
+	}
+}
+
+// BasicAuth configures basic authentication for this datasource.
+func BasicAuth(username string, password string) Option {
+	return func(datasource *Elasticsearch) error {
+		yep := true
+		datasource.builder.BasicAuth = &yep
+		datasource.builder.BasicAuthUser = &username
+		datasource.builder.BasicAuthPassword = &password
+
+		return nil
+	}
+}
+
+// WithCredentials joins credentials such as cookies or auth headers to cross-site requests.
+func WithCredentials() Option {
+	return func(datasource *Elasticsearch) error {
+		datasource.builder.WithCredentials = true
+
+		return nil
+	}
+}
+
+// SkipTLSVerify disables verification of SSL certificates.
+func SkipTLSVerify() Option {
+	return setJSONData("tlsSkipVerify", true)
+}
+
+// ForwardOauthIdentity forward the user's upstream OAuth identity to the datasource.
+func ForwardOauthIdentity() Option {
+	return setJSONData("oauthPassThru", true)
+}
+
+// TLSClientAuth enables TLS client side authentication. Expects PEM encoded content.
+func TLSClientAuth(cert string, key string) Option {
+	return multiOption(
+		setJSONData("tlsAuth", true),
+		setSecureJSONData("tlsClientCert", cert),
+		setSecureJSONData("tlsClientKey", key),
+	)
+}
+
+// WithCACert allows to provide a PEM encoded CA certificate to trust for this data source.
+func WithCACert(cert string) Option {
+	return multiOption(
+		setJSONData("tlsAuthWithCACert", true),
+		setSecureJSONData("tlsCACert", cert),
+	)
+}
+
+// MinTimeInterval defines a lower limit for the auto group by time interval.
+// Recommended to be set to write frequency, for example 1m if your data is written every minute.
+func MinTimeInterval(interval time.Duration) Option {
+	return setJSONData("timeInterval", interval.String())
+}
+
+func multiOption(opts ...Option) Option {
+	return func(datasource *Elasticsearch) error {
+		for _, opt := range opts {
+			if err := opt(datasource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func setJSONData(key string, value interface{}) Option {
+	return func(datasource *Elasticsearch) error {
+		datasource.builder.JSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func setSecureJSONData(key string, value interface{}) Option {
+	return func(datasource *Elasticsearch) error {
+		datasource.builder.SecureJSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func invalidArgument(err error) Option {
+	return func(datasource *Elasticsearch) error {
+		return err
+	}
+}
@@ -0,0 +1,161 @@
+package elasticsearch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Default())
+
+	req.NoError(err)
+	req.True(datasource.builder.IsDefault)
+}
+
+func TestBasicAuth(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", BasicAuth("john", "doe"))
+
+	req.NoError(err)
+	req.True(*datasource.builder.BasicAuth)
+	req.Equal("john", *datasource.builder.BasicAuthUser)
+	req.Equal("doe", *datasource.builder.BasicAuthPassword)
+}
+
+func TestWithCredentials(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", WithCredentials())
+
+	req.NoError(err)
+	req.True(datasource.builder.WithCredentials)
+}
+
+func TestSkipTLSVerify(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", SkipTLSVerify())
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsSkipVerify"])
+}
+
+func TestForwardOauthIdentity(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", ForwardOauthIdentity())
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["oauthPassThru"])
+}
+
+func TestTLSClientAuth(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", TLSClientAuth("Foo", "bar"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuth"])
+	req.Equal("Foo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientCert"])
+	req.Equal("bar", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientKey"])
+}
+
+func TestWithCACert(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", WithCACert("bozo"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuthWithCACert"])
+	req.Equal("bozo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsCACert"])
+}
+
+func TestMinTimeInterval(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", MinTimeInterval(10*time.Second))
+
+	req.NoError(err)
+	req.Equal("10s", datasource.builder.JSONData.(map[string]interface{})["timeInterval"])
+}
+
+func TestIndex(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Index("[logs-]YYYY.MM.DD", Daily))
+
+	req.NoError(err)
+	req.Equal("[logs-]YYYY.MM.DD", *datasource.builder.Database)
+	req.Equal("[logs-]YYYY.MM.DD", datasource.builder.JSONData.(map[string]interface{})["index"])
+	req.Equal("Daily", datasource.builder.JSONData.(map[string]interface{})["interval"])
+}
+
+func TestTimeField(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", TimeField("timestamp"))
+
+	req.NoError(err)
+	req.Equal("timestamp", datasource.builder.JSONData.(map[string]interface{})["timeField"])
+}
+
+func TestVersion(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Version("8.0.0"))
+
+	req.NoError(err)
+	req.Equal("8.0.0", datasource.builder.JSONData.(map[string]interface{})["esVersion"])
+}
+
+func TestVersionRejectsInvalidValues(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", "", Version("8"))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestOpenSearch(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", OpenSearch("2.3.0"))
+
+	req.NoError(err)
+	req.Equal("grafana-opensearch-datasource", datasource.builder.Type)
+	req.Equal("opensearch", datasource.builder.JSONData.(map[string]interface{})["flavor"])
+	req.Equal("2.3.0", datasource.builder.JSONData.(map[string]interface{})["version"])
+}
+
+func TestMaxConcurrentShardRequests(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", MaxConcurrentShardRequests(3))
+
+	req.NoError(err)
+	req.Equal(3, datasource.builder.JSONData.(map[string]interface{})["maxConcurrentShardRequests"])
+}
+
+func TestMaxConcurrentShardRequestsRejectsInvalidValues(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", "", MaxConcurrentShardRequests(0))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLogFields(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", LogMessageField("message"), LogLevelField("level"))
+
+	req.NoError(err)
+	req.Equal("message", datasource.builder.JSONData.(map[string]interface{})["logMessageField"])
+	req.Equal("level", datasource.builder.JSONData.(map[string]interface{})["logLevelField"])
+}
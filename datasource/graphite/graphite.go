@@ -0,0 +1,52 @@
+package graphite
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+var _ datasource.Datasource = Graphite{}
+
+type Graphite struct {
+	builder *sdk.Datasource
+}
+
+type Option func(datasource *Graphite) error
+
+// New creates a Graphite datasource.
+func New(name string, url string, options ...Option) (Graphite, error) {
+	graphite := &Graphite{
+		builder: &sdk.Datasource{
+			Name:   name,
+			Type:   "graphite",
+			Access: "proxy",
+			URL:    url,
+			JSONData: map[string]interface{}{
+				"graphiteType": "default",
+			},
+			SecureJSONData: map[string]interface{}{},
+		},
+	}
+
+	defaults := []Option{
+		Version("1.1"),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(graphite); err != nil {
+			return *graphite, err
+		}
+	}
+
+	return *graphite, nil
+}
+
+func (datasource Graphite) Name() string {
+	return datasource.builder.Name
+}
+
+func (datasource Graphite) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datasource.builder)
+}
@@ -0,0 +1,29 @@
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGraphite(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("ds-graphite", "http://localhost:8080")
+
+	req.NoError(err)
+	req.Equal("ds-graphite", datasource.Name())
+	req.Equal("http://localhost:8080", datasource.builder.URL)
+	req.Equal("graphite", datasource.builder.Type)
+	req.Equal(
+		map[string]interface{}{
+			"graphiteVersion": "1.1",
+			"graphiteType":    "default",
+		},
+		datasource.builder.JSONData,
+	)
+	req.NotNil(datasource.builder.SecureJSONData)
+
+	_, err = datasource.MarshalJSON()
+	req.NoError(err)
+}
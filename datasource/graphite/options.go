@@ -0,0 +1,115 @@
+package graphite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// Version sets the version of Graphite, which determines the functions
+// available in the query editor (ex: "1.1").
+func Version(version string) Option {
+	if strings.Count(version, ".") != 1 {
+		return invalidArgument(fmt.Errorf("version must be formatted as MAJOR.MINOR: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("graphiteVersion", version)
+}
+
+// Metrictank indicates that the backend is Metrictank, which enables its
+// specific features.
+func Metrictank() Option {
+	return setJSONData("graphiteType", "metrictank")
+}
+
+// Default configures this datasource to be the default one.
+func Default() Option {
+	return func(datasource *Graphite) error {
+		datasource.builder.IsDefault = true
+
+		return nil
+	}
+}
+
+// BasicAuth configures basic authentication for this datasource.
+func BasicAuth(username string, password string) Option {
+	return func(datasource *Graphite) error {
+		yep := true
+		datasource.builder.BasicAuth = &yep
+		datasource.builder.BasicAuthUser = &username
+		datasource.builder.BasicAuthPassword = &password
+
+		return nil
+	}
+}
+
+// WithCredentials joins credentials such as cookies or auth headers to cross-site requests.
+func WithCredentials() Option {
+	return func(datasource *Graphite) error {
+		datasource.builder.WithCredentials = true
+
+		return nil
+	}
+}
+
+// SkipTLSVerify disables verification of SSL certificates.
+func SkipTLSVerify() Option {
+	return setJSONData("tlsSkipVerify", true)
+}
+
+// ForwardOauthIdentity forward the user's upstream OAuth identity to the datasource.
+func ForwardOauthIdentity() Option {
+	return setJSONData("oauthPassThru", true)
+}
+
+// TLSClientAuth enables TLS client side authentication. Expects PEM encoded content.
+func TLSClientAuth(cert string, key string) Option {
+	return multiOption(
+		setJSONData("tlsAuth", true),
+		setSecureJSONData("tlsClientCert", cert),
+		setSecureJSONData("tlsClientKey", key),
+	)
+}
+
+// WithCACert allows to provide a PEM encoded CA certificate to trust for this data source.
+func WithCACert(cert string) Option {
+	return multiOption(
+		setJSONData("tlsAuthWithCACert", true),
+		setSecureJSONData("tlsCACert", cert),
+	)
+}
+
+func multiOption(opts ...Option) Option {
+	return func(datasource *Graphite) error {
+		for _, opt := range opts {
+			if err := opt(datasource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func setJSONData(key string, value interface{}) Option {
+	return func(datasource *Graphite) error {
+		datasource.builder.JSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func setSecureJSONData(key string, value interface{}) Option {
+	return func(datasource *Graphite) error {
+		datasource.builder.SecureJSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func invalidArgument(err error) Option {
+	return func(datasource *Graphite) error {
+		return err
+	}
+}
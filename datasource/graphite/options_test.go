@@ -0,0 +1,102 @@
+package graphite
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Default())
+
+	req.NoError(err)
+	req.True(datasource.builder.IsDefault)
+}
+
+func TestBasicAuth(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", BasicAuth("john", "doe"))
+
+	req.NoError(err)
+	req.True(*datasource.builder.BasicAuth)
+	req.Equal("john", *datasource.builder.BasicAuthUser)
+	req.Equal("doe", *datasource.builder.BasicAuthPassword)
+}
+
+func TestWithCredentials(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", WithCredentials())
+
+	req.NoError(err)
+	req.True(datasource.builder.WithCredentials)
+}
+
+func TestSkipTLSVerify(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", SkipTLSVerify())
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsSkipVerify"])
+}
+
+func TestForwardOauthIdentity(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", ForwardOauthIdentity())
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["oauthPassThru"])
+}
+
+func TestTLSClientAuth(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", TLSClientAuth("Foo", "bar"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuth"])
+	req.Equal("Foo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientCert"])
+	req.Equal("bar", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientKey"])
+}
+
+func TestWithCACert(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", WithCACert("bozo"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuthWithCACert"])
+	req.Equal("bozo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsCACert"])
+}
+
+func TestVersion(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Version("1.0"))
+
+	req.NoError(err)
+	req.Equal("1.0", datasource.builder.JSONData.(map[string]interface{})["graphiteVersion"])
+}
+
+func TestVersionRejectsInvalidValues(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", "", Version("1"))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestMetrictank(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Metrictank())
+
+	req.NoError(err)
+	req.Equal("metrictank", datasource.builder.JSONData.(map[string]interface{})["graphiteType"])
+}
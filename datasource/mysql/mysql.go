@@ -0,0 +1,47 @@
+package mysql
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+var _ datasource.Datasource = MySQL{}
+
+type MySQL struct {
+	builder *sdk.Datasource
+}
+
+type Option func(datasource *MySQL) error
+
+// New creates a MySQL datasource. The url is the address of the server,
+// like "localhost:3306".
+func New(name string, url string, options ...Option) (MySQL, error) {
+	mysql := &MySQL{
+		builder: &sdk.Datasource{
+			Name:           name,
+			Type:           "mysql",
+			Access:         "proxy",
+			URL:            url,
+			JSONData:       map[string]interface{}{},
+			SecureJSONData: map[string]interface{}{},
+		},
+	}
+
+	for _, opt := range options {
+		if err := opt(mysql); err != nil {
+			return *mysql, err
+		}
+	}
+
+	return *mysql, nil
+}
+
+func (datasource MySQL) Name() string {
+	return datasource.builder.Name
+}
+
+func (datasource MySQL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datasource.builder)
+}
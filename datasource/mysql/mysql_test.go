@@ -0,0 +1,23 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMySQL(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("ds-mysql", "localhost:3306")
+
+	req.NoError(err)
+	req.Equal("ds-mysql", datasource.Name())
+	req.Equal("localhost:3306", datasource.builder.URL)
+	req.Equal("mysql", datasource.builder.Type)
+	req.NotNil(datasource.builder.JSONData)
+	req.NotNil(datasource.builder.SecureJSONData)
+
+	_, err = datasource.MarshalJSON()
+	req.NoError(err)
+}
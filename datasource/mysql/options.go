@@ -0,0 +1,134 @@
+package mysql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// Timezone sets the timezone of the session (ex: "+02:00"). Defaults to the
+// timezone of the server.
+func Timezone(timezone string) Option {
+	return setJSONData("timezone", timezone)
+}
+
+// Default configures this datasource to be the default one.
+func Default() Option {
+	return func(datasource *MySQL) error {
+		datasource.builder.IsDefault = true
+
+		return nil
+	}
+}
+
+// Database sets the name of the database to query.
+func Database(database string) Option {
+	return func(datasource *MySQL) error {
+		datasource.builder.Database = &database
+		datasource.builder.JSONData.(map[string]interface{})["database"] = database
+
+		return nil
+	}
+}
+
+// User sets the username used to connect to the database.
+func User(user string) Option {
+	return func(datasource *MySQL) error {
+		datasource.builder.User = &user
+
+		return nil
+	}
+}
+
+// Password sets the password used to connect to the database.
+func Password(password string) Option {
+	return setSecureJSONData("password", password)
+}
+
+// MaxOpenConns sets the maximum number of open connections to the database.
+// Zero means unlimited.
+func MaxOpenConns(max int) Option {
+	if max < 0 {
+		return invalidArgument(fmt.Errorf("max open connections must be positive: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("maxOpenConns", max)
+}
+
+// MaxIdleConns sets the maximum number of connections in the idle connection
+// pool.
+func MaxIdleConns(max int) Option {
+	if max < 0 {
+		return invalidArgument(fmt.Errorf("max idle connections must be positive: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("maxIdleConns", max)
+}
+
+// ConnMaxLifetime sets the maximum amount of time a connection may be reused.
+func ConnMaxLifetime(lifetime time.Duration) Option {
+	return setJSONData("connMaxLifetime", int(lifetime.Seconds()))
+}
+
+// MinTimeInterval defines a lower limit for the auto group by time interval.
+// Recommended to be set to write frequency, for example 1m if your data is written every minute.
+func MinTimeInterval(interval time.Duration) Option {
+	return setJSONData("timeInterval", interval.String())
+}
+
+// SkipTLSVerify disables verification of SSL certificates.
+func SkipTLSVerify() Option {
+	return setJSONData("tlsSkipVerify", true)
+}
+
+// TLSClientAuth enables TLS client side authentication. Expects PEM encoded content.
+func TLSClientAuth(cert string, key string) Option {
+	return multiOption(
+		setJSONData("tlsAuth", true),
+		setSecureJSONData("tlsClientCert", cert),
+		setSecureJSONData("tlsClientKey", key),
+	)
+}
+
+// WithCACert allows to provide a PEM encoded CA certificate to trust for this data source.
+func WithCACert(cert string) Option {
+	return multiOption(
+		setJSONData("tlsAuthWithCACert", true),
+		setSecureJSONData("tlsCACert", cert),
+	)
+}
+
+func multiOption(opts ...Option) Option {
+	return func(datasource *MySQL) error {
+		for _, opt := range opts {
+			if err := opt(datasource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func setJSONData(key string, value interface{}) Option {
+	return func(datasource *MySQL) error {
+		datasource.builder.JSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func setSecureJSONData(key string, value interface{}) Option {
+	return func(datasource *MySQL) error {
+		datasource.builder.SecureJSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func invalidArgument(err error) Option {
+	return func(datasource *MySQL) error {
+		return err
+	}
+}
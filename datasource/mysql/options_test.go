@@ -0,0 +1,129 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Default())
+
+	req.NoError(err)
+	req.True(datasource.builder.IsDefault)
+}
+
+func TestDatabase(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Database("shop"))
+
+	req.NoError(err)
+	req.Equal("shop", *datasource.builder.Database)
+	req.Equal("shop", datasource.builder.JSONData.(map[string]interface{})["database"])
+}
+
+func TestUser(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", User("grafana"))
+
+	req.NoError(err)
+	req.Equal("grafana", *datasource.builder.User)
+}
+
+func TestPassword(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Password("secret"))
+
+	req.NoError(err)
+	req.Equal("secret", datasource.builder.SecureJSONData.(map[string]interface{})["password"])
+}
+
+func TestMaxOpenConns(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", MaxOpenConns(10))
+
+	req.NoError(err)
+	req.Equal(10, datasource.builder.JSONData.(map[string]interface{})["maxOpenConns"])
+}
+
+func TestMaxOpenConnsRejectsNegativeValues(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", "", MaxOpenConns(-1))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestMaxIdleConns(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", MaxIdleConns(5))
+
+	req.NoError(err)
+	req.Equal(5, datasource.builder.JSONData.(map[string]interface{})["maxIdleConns"])
+}
+
+func TestConnMaxLifetime(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", ConnMaxLifetime(4*time.Hour))
+
+	req.NoError(err)
+	req.Equal(14400, datasource.builder.JSONData.(map[string]interface{})["connMaxLifetime"])
+}
+
+func TestMinTimeInterval(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", MinTimeInterval(10*time.Second))
+
+	req.NoError(err)
+	req.Equal("10s", datasource.builder.JSONData.(map[string]interface{})["timeInterval"])
+}
+
+func TestSkipTLSVerify(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", SkipTLSVerify())
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsSkipVerify"])
+}
+
+func TestTLSClientAuth(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", TLSClientAuth("Foo", "bar"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuth"])
+	req.Equal("Foo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientCert"])
+	req.Equal("bar", datasource.builder.SecureJSONData.(map[string]interface{})["tlsClientKey"])
+}
+
+func TestWithCACert(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", WithCACert("bozo"))
+
+	req.NoError(err)
+	req.Equal(true, datasource.builder.JSONData.(map[string]interface{})["tlsAuthWithCACert"])
+	req.Equal("bozo", datasource.builder.SecureJSONData.(map[string]interface{})["tlsCACert"])
+}
+
+func TestTimezone(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("", "", Timezone("+02:00"))
+
+	req.NoError(err)
+	req.Equal("+02:00", datasource.builder.JSONData.(map[string]interface{})["timezone"])
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// SSLMode determines whether or with what priority a secure SSL TCP/IP
+// connection will be negotiated with the server.
+type SSLMode string
+
+const (
+	SSLDisable    SSLMode = "disable"
+	SSLRequire    SSLMode = "require"
+	SSLVerifyCA   SSLMode = "verify-ca"
+	SSLVerifyFull SSLMode = "verify-full"
+)
+
+// WithSSLMode sets the SSL mode used to connect to the server. Defaults to
+// "require".
+func WithSSLMode(mode SSLMode) Option {
+	return setJSONData("sslmode", string(mode))
+}
+
+// PostgresVersion sets the version of the server, as understood by Grafana:
+// 903, 904, 905, 906, 1000 (for 10+), ...
+func PostgresVersion(version int) Option {
+	return setJSONData("postgresVersion", version)
+}
+
+// TimescaleDB enables the TimescaleDB-specific features of the query builder.
+func TimescaleDB() Option {
+	return setJSONData("timescaledb", true)
+}
+
+// Default configures this datasource to be the default one.
+func Default() Option {
+	return func(datasource *Postgres) error {
+		datasource.builder.IsDefault = true
+
+		return nil
+	}
+}
+
+// Database sets the name of the database to query.
+func Database(database string) Option {
+	return func(datasource *Postgres) error {
+		datasource.builder.Database = &database
+		datasource.builder.JSONData.(map[string]interface{})["database"] = database
+
+		return nil
+	}
+}
+
+// User sets the username used to connect to the database.
+func User(user string) Option {
+	return func(datasource *Postgres) error {
+		datasource.builder.User = &user
+
+		return nil
+	}
+}
+
+// Password sets the password used to connect to the database.
+func Password(password string) Option {
+	return setSecureJSONData("password", password)
+}
+
+// MaxOpenConns sets the maximum number of open connections to the database.
+// Zero means unlimited.
+func MaxOpenConns(max int) Option {
+	if max < 0 {
+		return invalidArgument(fmt.Errorf("max open connections must be positive: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("maxOpenConns", max)
+}
+
+// MaxIdleConns sets the maximum number of connections in the idle connection
+// pool.
+func MaxIdleConns(max int) Option {
+	if max < 0 {
+		return invalidArgument(fmt.Errorf("max idle connections must be positive: %w", errors.ErrInvalidArgument))
+	}
+
+	return setJSONData("maxIdleConns", max)
+}
+
+// ConnMaxLifetime sets the maximum amount of time a connection may be reused.
+func ConnMaxLifetime(lifetime time.Duration) Option {
+	return setJSONData("connMaxLifetime", int(lifetime.Seconds()))
+}
+
+// MinTimeInterval defines a lower limit for the auto group by time interval.
+// Recommended to be set to write frequency, for example 1m if your data is written every minute.
+func MinTimeInterval(interval time.Duration) Option {
+	return setJSONData("timeInterval", interval.String())
+}
+
+func multiOption(opts ...Option) Option {
+	return func(datasource *Postgres) error {
+		for _, opt := range opts {
+			if err := opt(datasource); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func setJSONData(key string, value interface{}) Option {
+	return func(datasource *Postgres) error {
+		datasource.builder.JSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func setSecureJSONData(key string, value interface{}) Option {
+	return func(datasource *Postgres) error {
+		datasource.builder.SecureJSONData.(map[string]interface{})[key] = value
+
+		return nil
+	}
+}
+
+func invalidArgument(err error) Option {
+	return func(datasource *Postgres) error {
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+var _ datasource.Datasource = Postgres{}
+
+type Postgres struct {
+	builder *sdk.Datasource
+}
+
+type Option func(datasource *Postgres) error
+
+// New creates a PostgreSQL datasource. The url is the address of the server,
+// like "localhost:5432".
+func New(name string, url string, options ...Option) (Postgres, error) {
+	postgres := &Postgres{
+		builder: &sdk.Datasource{
+			Name:           name,
+			Type:           "postgres",
+			Access:         "proxy",
+			URL:            url,
+			JSONData:       map[string]interface{}{},
+			SecureJSONData: map[string]interface{}{},
+		},
+	}
+
+	defaults := []Option{
+		WithSSLMode(SSLRequire),
+	}
+
+	for _, opt := range append(defaults, options...) {
+		if err := opt(postgres); err != nil {
+			return *postgres, err
+		}
+	}
+
+	return *postgres, nil
+}
+
+func (datasource Postgres) Name() string {
+	return datasource.builder.Name
+}
+
+func (datasource Postgres) MarshalJSON() ([]byte, error) {
+	return json.Marshal(datasource.builder)
+}
@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPostgres(t *testing.T) {
+	req := require.New(t)
+
+	datasource, err := New("ds-postgres", "localhost:5432")
+
+	req.NoError(err)
+	req.Equal("ds-postgres", datasource.Name())
+	req.Equal("localhost:5432", datasource.builder.URL)
+	req.Equal("postgres", datasource.builder.Type)
+	req.Equal("require", datasource.builder.JSONData.(map[string]interface{})["sslmode"])
+	req.NotNil(datasource.builder.SecureJSONData)
+
+	_, err = datasource.MarshalJSON()
+	req.NoError(err)
+}
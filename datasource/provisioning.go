@@ -0,0 +1,91 @@
+package datasource
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProvisioningFile represents a Grafana datasources provisioning file.
+// See https://grafana.com/docs/grafana/latest/administration/provisioning/#data-sources
+type ProvisioningFile struct {
+	APIVersion  int                      `yaml:"apiVersion"`
+	Datasources []map[string]interface{} `yaml:"datasources"`
+}
+
+// Provisioning describes the given datasources as a Grafana provisioning
+// file, allowing them to be provisioned without relying on Grafana's API.
+func Provisioning(datasources ...Datasource) (ProvisioningFile, error) {
+	file := ProvisioningFile{
+		APIVersion:  1,
+		Datasources: make([]map[string]interface{}, 0, len(datasources)),
+	}
+
+	for _, datasource := range datasources {
+		content, err := datasource.MarshalJSON()
+		if err != nil {
+			return file, err
+		}
+
+		definition := map[string]interface{}{}
+		if err := json.Unmarshal(content, &definition); err != nil {
+			return file, err
+		}
+
+		file.Datasources = append(file.Datasources, provisioningDefinition(definition))
+	}
+
+	return file, nil
+}
+
+// ToYAML renders the provisioning file as YAML.
+func (file ProvisioningFile) ToYAML() ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(file); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// provisioningDefinition adapts the definition of a datasource, as expected
+// by Grafana's API, to the format of provisioning files.
+func provisioningDefinition(definition map[string]interface{}) map[string]interface{} {
+	// IDs are assigned by Grafana
+	delete(definition, "id")
+	delete(definition, "orgId")
+
+	// passwords are only read from secureJsonData by the provisioning
+	secureJSONData, _ := definition["secureJsonData"].(map[string]interface{})
+	if secureJSONData == nil {
+		secureJSONData = map[string]interface{}{}
+	}
+
+	if password, ok := definition["basicAuthPassword"]; ok {
+		secureJSONData["basicAuthPassword"] = password
+		delete(definition, "basicAuthPassword")
+	}
+	if password, ok := definition["password"]; ok {
+		secureJSONData["password"] = password
+		delete(definition, "password")
+	}
+
+	definition["secureJsonData"] = secureJSONData
+	if len(secureJSONData) == 0 {
+		delete(definition, "secureJsonData")
+	}
+
+	if jsonData, ok := definition["jsonData"].(map[string]interface{}); !ok || len(jsonData) == 0 {
+		delete(definition, "jsonData")
+	}
+
+	return definition
+}
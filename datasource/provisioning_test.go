@@ -0,0 +1,73 @@
+package datasource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testDatasource map[string]interface{}
+
+func (datasource testDatasource) Name() string {
+	return datasource["name"].(string)
+}
+
+func (datasource testDatasource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(datasource))
+}
+
+func TestDatasourcesCanBeDescribedAsAProvisioningFile(t *testing.T) {
+	req := require.New(t)
+
+	file, err := Provisioning(testDatasource{
+		"id":                0,
+		"orgId":             0,
+		"name":              "prometheus",
+		"type":              "prometheus",
+		"url":               "http://localhost:9090",
+		"basicAuth":         true,
+		"basicAuthUser":     "admin",
+		"basicAuthPassword": "$__env{PROMETHEUS_PASSWORD}",
+		"jsonData":          map[string]interface{}{"httpMethod": "POST"},
+		"secureJsonData":    map[string]interface{}{},
+	})
+	req.NoError(err)
+
+	req.Equal(1, file.APIVersion)
+	req.Len(file.Datasources, 1)
+	req.Equal(map[string]interface{}{
+		"name":           "prometheus",
+		"type":           "prometheus",
+		"url":            "http://localhost:9090",
+		"basicAuth":      true,
+		"basicAuthUser":  "admin",
+		"jsonData":       map[string]interface{}{"httpMethod": "POST"},
+		"secureJsonData": map[string]interface{}{"basicAuthPassword": "$__env{PROMETHEUS_PASSWORD}"},
+	}, file.Datasources[0])
+}
+
+func TestEmptySettingsAreOmittedFromProvisioningFiles(t *testing.T) {
+	req := require.New(t)
+
+	file, err := Provisioning(testDatasource{
+		"name":           "loki",
+		"jsonData":       map[string]interface{}{},
+		"secureJsonData": map[string]interface{}{},
+	})
+	req.NoError(err)
+
+	req.Equal(map[string]interface{}{"name": "loki"}, file.Datasources[0])
+}
+
+func TestProvisioningFilesCanBeRenderedAsYAML(t *testing.T) {
+	req := require.New(t)
+
+	file, err := Provisioning(testDatasource{"name": "loki", "type": "loki"})
+	req.NoError(err)
+
+	content, err := file.ToYAML()
+	req.NoError(err)
+
+	req.Equal("apiVersion: 1\ndatasources:\n  - name: loki\n    type: loki\n", string(content))
+}
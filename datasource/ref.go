@@ -0,0 +1,63 @@
+package datasource
+
+import (
+	"github.com/K-Phoen/sdk"
+)
+
+// Ref references a datasource. A datasource can be referenced by UID, by
+// name or by type, and these criteria can be combined: a reference to a
+// datasource by name and type only matches if the datasource named so is
+// of the given type.
+//
+// References by name or by type are resolved into references by UID when
+// dashboards are applied with grabana's client, which fails if no datasource
+// or several datasources match.
+type Ref struct {
+	UID  string
+	Type string
+	Name string
+}
+
+// ByUID references a datasource by its UID.
+func ByUID(uid string) Ref {
+	return Ref{UID: uid}
+}
+
+// ByName references a datasource by its name.
+func ByName(name string) Ref {
+	return Ref{Name: name}
+}
+
+// ByType references the only datasource of the given type.
+func ByType(datasourceType string) Ref {
+	return Ref{Type: datasourceType}
+}
+
+// OfType restricts the reference to datasources of the given type.
+func (ref Ref) OfType(datasourceType string) Ref {
+	ref.Type = datasourceType
+
+	return ref
+}
+
+// IsZero tells if the reference is empty, in which case the default
+// datasource is used.
+func (ref Ref) IsZero() bool {
+	return ref == Ref{}
+}
+
+// Internal returns the reference as understood by the sdk, or nil for an
+// empty reference.
+func (ref Ref) Internal() *sdk.DatasourceRef {
+	if ref.IsZero() {
+		return nil
+	}
+
+	// the sdk only marshals the name when it is set: the type and the UID
+	// are kept to resolve the reference
+	return &sdk.DatasourceRef{
+		UID:        ref.UID,
+		Type:       ref.Type,
+		LegacyName: ref.Name,
+	}
+}
@@ -0,0 +1,45 @@
+package datasource
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyRefsAreNotConverted(t *testing.T) {
+	req := require.New(t)
+
+	req.True(Ref{}.IsZero())
+	req.Nil(Ref{}.Internal())
+}
+
+func TestRefsByUIDAreMarshalledAsObjects(t *testing.T) {
+	req := require.New(t)
+
+	content, err := json.Marshal(ByUID("prom-uid").OfType("prometheus").Internal())
+
+	req.NoError(err)
+	req.JSONEq(`{"UID": "prom-uid", "type": "prometheus"}`, string(content))
+}
+
+func TestRefsByNameAreMarshalledAsNames(t *testing.T) {
+	req := require.New(t)
+
+	ref := ByName("Prometheus").OfType("prometheus").Internal()
+	content, err := json.Marshal(ref)
+
+	req.NoError(err)
+	req.JSONEq(`"Prometheus"`, string(content))
+	req.Equal("prometheus", ref.Type)
+}
+
+func TestRefsCanTargetATypeOfDatasource(t *testing.T) {
+	req := require.New(t)
+
+	ref := ByType("loki").Internal()
+
+	req.Equal("loki", ref.Type)
+	req.Empty(ref.UID)
+	req.Empty(ref.LegacyName)
+}
@@ -1,19 +1,29 @@
 package grabana
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
 )
 
 // ErrDatasourceNotFound is returned when the given datasource can not be found.
 var ErrDatasourceNotFound = errors.New("datasource not found")
 
-const defaultDatasourceKey = "$grabana_default_datasource_key$"
+// ErrDatasourceUnhealthy is returned when the health check of a datasource
+// fails.
+var ErrDatasourceUnhealthy = errors.New("datasource unhealthy")
+
+// ErrAmbiguousDatasource is returned when a reference to a datasource matches
+// several datasources.
+var ErrAmbiguousDatasource = errors.New("ambiguous datasource reference")
 
 // UpsertDatasource creates or replaces a datasource.
 func (client *Client) UpsertDatasource(ctx context.Context, datasource datasource.Datasource) error {
@@ -72,6 +82,40 @@ func (client *Client) DeleteDatasource(ctx context.Context, name string) error {
 	return nil
 }
 
+// CheckDatasourceHealth asks Grafana to check that the given datasource works.
+// A failed check is reported as an error wrapping ErrDatasourceUnhealthy.
+func (client *Client) CheckDatasourceHealth(ctx context.Context, name string) error {
+	uid, err := client.GetDatasourceUIDByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.get(ctx, "/api/datasources/uid/"+url.PathEscape(uid)+"/health")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrDatasourceNotFound
+	}
+
+	health := struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}{}
+	if err := decodeJSON(resp.Body, &health); err != nil {
+		return fmt.Errorf("could not decode health of datasource '%s' (HTTP status %d): %w", name, resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || health.Status != "OK" {
+		return fmt.Errorf("datasource '%s': %s: %w", name, health.Message, ErrDatasourceUnhealthy)
+	}
+
+	return nil
+}
+
 // GetDatasourceUIDByName finds a datasource UID given its name.
 func (client *Client) GetDatasourceUIDByName(ctx context.Context, name string) (string, error) {
 	resp, err := client.get(ctx, "/api/datasources/name/"+name)
@@ -99,8 +143,25 @@ func (client *Client) GetDatasourceUIDByName(ctx context.Context, name string) (
 	return response.UID, nil
 }
 
-// datasourcesUIDMap builds a map of datasources UIDs indexed by their name.
-func (client *Client) datasourcesUIDMap(ctx context.Context) (map[string]string, error) {
+// defaultDatasourceKey designates the default datasource in the maps of
+// datasources given to AddAlert().
+const defaultDatasourceKey = "$grabana_default_datasource_key$"
+
+// datasourceResolver resolves references to datasources into references by
+// UID, using the list of datasources known by Grafana.
+type datasourceResolver struct {
+	datasources []datasourceSummary
+}
+
+type datasourceSummary struct {
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// datasourceResolver fetches the datasources known by Grafana.
+func (client *Client) datasourceResolver(ctx context.Context) (*datasourceResolver, error) {
 	resp, err := client.get(ctx, "/api/datasources")
 	if err != nil {
 		return nil, err
@@ -112,25 +173,331 @@ func (client *Client) datasourcesUIDMap(ctx context.Context) (map[string]string,
 		return nil, client.httpError(resp)
 	}
 
-	var datasources []struct {
-		UID       string `json:"uid"`
-		Name      string `json:"name"`
-		IsDefault bool   `json:"isDefault"`
-	}
+	var datasources []datasourceSummary
 	if err := decodeJSON(resp.Body, &datasources); err != nil {
 		return nil, err
 	}
 
-	datasourcesMap := make(map[string]string, len(datasources))
-	for _, ds := range datasources {
-		datasourcesMap[ds.Name] = ds.UID
+	return &datasourceResolver{datasources: datasources}, nil
+}
 
+// resolve turns the given reference into a reference by UID and type.
+// References to built-in datasources or to dashboard variables are left
+// untouched.
+func (resolver *datasourceResolver) resolve(ref *sdk.DatasourceRef) error {
+	if !needsResolution(ref) {
+		return nil
+	}
+
+	var candidates []datasourceSummary
+	for _, ds := range resolver.datasources {
+		if ref.UID != "" && ds.UID != ref.UID {
+			continue
+		}
+		if ref.LegacyName != "" && ds.Name != ref.LegacyName {
+			continue
+		}
+		if ref.Type != "" && ds.Type != ref.Type {
+			continue
+		}
+
+		candidates = append(candidates, ds)
+	}
+
+	if len(candidates) == 0 {
+		return fmt.Errorf("%s: %w", describeDatasourceRef(ref), ErrDatasourceNotFound)
+	}
+	if len(candidates) > 1 {
+		return fmt.Errorf("%s matches %d datasources: %w", describeDatasourceRef(ref), len(candidates), ErrAmbiguousDatasource)
+	}
+
+	ref.UID = candidates[0].UID
+	ref.Type = candidates[0].Type
+	ref.LegacyName = ""
+
+	return nil
+}
+
+// defaultDatasource returns a reference to the default datasource.
+func (resolver *datasourceResolver) defaultDatasource() (*sdk.DatasourceRef, error) {
+	for _, ds := range resolver.datasources {
 		if ds.IsDefault {
-			datasourcesMap[defaultDatasourceKey] = ds.UID
+			return &sdk.DatasourceRef{UID: ds.UID, Type: ds.Type}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no default datasource: %w", ErrDatasourceNotFound)
+}
+
+// alertDatasourceUID finds the UID of the datasource queried by an alert.
+// Alerts without datasource query the default one.
+func (resolver *datasourceResolver) alertDatasourceUID(ref *sdk.DatasourceRef) (string, error) {
+	if ref == nil || *ref == (sdk.DatasourceRef{}) {
+		defaultRef, err := resolver.defaultDatasource()
+		if err != nil {
+			return "", err
+		}
+
+		return defaultRef.UID, nil
+	}
+
+	if !needsResolution(ref) {
+		return "", fmt.Errorf("alerts can not query %s: %w", describeDatasourceRef(ref), ErrDatasourceNotFound)
+	}
+
+	resolved := *ref
+	if err := resolver.resolve(&resolved); err != nil {
+		return "", err
+	}
+
+	return resolved.UID, nil
+}
+
+// resolveJSON resolves the references to datasources found anywhere within
+// the given JSON model (panels, targets, variables, annotations, …). The
+// resolved model is returned, the given one is left untouched.
+func (resolver *datasourceResolver) resolveJSON(model []byte) ([]byte, error) {
+	return rewriteJSONDatasourceRefs(model, func(ref sdk.DatasourceRef) (sdk.DatasourceRef, error) {
+		err := resolver.resolve(&ref)
+
+		return ref, err
+	})
+}
+
+// jsonNeedsResolution tells if the given JSON model references datasources
+// that must be looked up.
+func jsonNeedsResolution(model []byte) (bool, error) {
+	needed := false
+
+	_, err := rewriteJSONDatasourceRefs(model, func(ref sdk.DatasourceRef) (sdk.DatasourceRef, error) {
+		needed = true
+
+		return ref, nil
+	})
+
+	return needed, err
+}
+
+// rewriteJSONDatasourceRefs replaces the references to datasources needing
+// resolution found within a JSON model by the ones returned by the given
+// function.
+func rewriteJSONDatasourceRefs(model []byte, rewrite func(ref sdk.DatasourceRef) (sdk.DatasourceRef, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(model))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	if err := rewriteDatasourceRefsIn(decoded, rewrite); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(decoded)
+}
+
+func rewriteDatasourceRefsIn(value interface{}, rewrite func(ref sdk.DatasourceRef) (sdk.DatasourceRef, error)) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if ref, ok := decodedDatasourceRef(key, child); ok {
+				rewritten, err := rewrite(ref)
+				if err != nil {
+					return err
+				}
+
+				value[key] = rewritten
+				continue
+			}
+
+			if err := rewriteDatasourceRefsIn(child, rewrite); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if err := rewriteDatasourceRefsIn(child, rewrite); err != nil {
+				return err
+			}
 		}
 	}
 
-	return datasourcesMap, nil
+	return nil
+}
+
+// decodedDatasourceRef reads the reference to a datasource held by the given
+// key of a decoded JSON object, if it needs resolution.
+func decodedDatasourceRef(key string, value interface{}) (sdk.DatasourceRef, bool) {
+	if key != "datasource" {
+		return sdk.DatasourceRef{}, false
+	}
+
+	ref := sdk.DatasourceRef{}
+
+	switch value := value.(type) {
+	case string:
+		ref.LegacyName = value
+	case map[string]interface{}:
+		ref.UID, _ = value["uid"].(string)
+		ref.Type, _ = value["type"].(string)
+		if ref.UID == "" {
+			ref.UID, _ = value["UID"].(string)
+		}
+	default:
+		return sdk.DatasourceRef{}, false
+	}
+
+	return ref, needsResolution(&ref)
+}
+
+// boardDatasourceRefs lists the references to datasources made by the
+// panels, targets, variables and annotations of the given board.
+func boardDatasourceRefs(board *sdk.Board) []*sdk.DatasourceRef {
+	var refs []*sdk.DatasourceRef
+
+	for _, panel := range board.Panels {
+		refs = append(refs, panelDatasourceRefs(panel)...)
+
+		// collapsed rows hold their panels
+		if panel.RowPanel == nil {
+			continue
+		}
+
+		for i := range panel.RowPanel.Panels {
+			refs = append(refs, panelDatasourceRefs(&panel.RowPanel.Panels[i])...)
+		}
+	}
+
+	for _, row := range board.Rows {
+		for i := range row.Panels {
+			refs = append(refs, panelDatasourceRefs(&row.Panels[i])...)
+		}
+	}
+
+	for i := range board.Templating.List {
+		refs = append(refs, board.Templating.List[i].Datasource)
+	}
+
+	for i := range board.Annotations.List {
+		refs = append(refs, board.Annotations.List[i].Datasource)
+	}
+
+	return refs
+}
+
+// panelDatasourceRefs lists the references to datasources made by a panel
+// and its targets.
+func panelDatasourceRefs(panel *sdk.Panel) []*sdk.DatasourceRef {
+	refs := []*sdk.DatasourceRef{panel.Datasource}
+
+	if panel.CustomPanel != nil {
+		return append(refs, customPanelTargetRefs(panel.CustomPanel)...)
+	}
+
+	targets := panel.GetTargets()
+	if targets == nil {
+		return refs
+	}
+
+	for i := range *targets {
+		refs = append(refs, (*targets)[i].Datasource)
+	}
+
+	return refs
+}
+
+// customPanelTargetRefs lists the references to datasources made by the
+// targets of a custom panel. Targets described by maps see their reference
+// replaced by a *sdk.DatasourceRef, so that it can be resolved in place.
+func customPanelTargetRefs(panel *sdk.CustomPanel) []*sdk.DatasourceRef {
+	var refs []*sdk.DatasourceRef
+
+	switch targets := (*panel)["targets"].(type) {
+	case []sdk.Target:
+		for i := range targets {
+			refs = append(refs, targets[i].Datasource)
+		}
+	case []interface{}:
+		for _, target := range targets {
+			target, ok := target.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if ref := targetMapDatasourceRef(target); ref != nil {
+				refs = append(refs, ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+func targetMapDatasourceRef(target map[string]interface{}) *sdk.DatasourceRef {
+	switch value := target["datasource"].(type) {
+	case nil:
+		return nil
+	case *sdk.DatasourceRef:
+		return value
+	case sdk.DatasourceRef:
+		ref := value
+		target["datasource"] = &ref
+
+		return &ref
+	default:
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil
+		}
+
+		ref := &sdk.DatasourceRef{}
+		if err := json.Unmarshal(raw, ref); err != nil {
+			return nil
+		}
+
+		target["datasource"] = ref
+
+		return ref
+	}
+}
+
+// needsResolution tells if a reference designates a datasource that must be
+// looked up. Empty references, built-in datasources and dashboard variables
+// are handled by Grafana itself.
+func needsResolution(ref *sdk.DatasourceRef) bool {
+	if ref == nil || *ref == (sdk.DatasourceRef{}) {
+		return false
+	}
+
+	for _, value := range []string{ref.UID, ref.LegacyName} {
+		if strings.HasPrefix(value, "$") || strings.HasPrefix(value, "-- ") {
+			return false
+		}
+	}
+
+	switch ref.Type {
+	case "datasource", "grafana", "__expr__":
+		return false
+	}
+
+	return ref.UID != "grafana"
+}
+
+func describeDatasourceRef(ref *sdk.DatasourceRef) string {
+	var criteria []string
+
+	if ref.UID != "" {
+		criteria = append(criteria, fmt.Sprintf("uid '%s'", ref.UID))
+	}
+	if ref.LegacyName != "" {
+		criteria = append(criteria, fmt.Sprintf("name '%s'", ref.LegacyName))
+	}
+	if ref.Type != "" {
+		criteria = append(criteria, fmt.Sprintf("type '%s'", ref.Type))
+	}
+
+	return "datasource with " + strings.Join(criteria, ", ")
 }
 
 // getDatasourceIDByName finds a datasource, given its name.
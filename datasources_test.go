@@ -3,12 +3,22 @@ package grabana
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/datasource/prometheus"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/row"
+	prometheusTarget "github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/timeseries"
+	"github.com/K-Phoen/grabana/transformation"
+	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -218,3 +228,428 @@ func TestGetDatasourceUIDByNameReturnsASpecificErrorIfDatasourceIsNotFound(t *te
 	req.Equal(ErrDatasourceNotFound, err)
 	req.Empty(uid)
 }
+
+func testDatasourceResolver() *datasourceResolver {
+	return &datasourceResolver{
+		datasources: []datasourceSummary{
+			{UID: "prom-uid", Name: "Prometheus", Type: "prometheus", IsDefault: true},
+			{UID: "prom-long-term-uid", Name: "Prometheus long term", Type: "prometheus"},
+			{UID: "loki-uid", Name: "Loki", Type: "loki"},
+		},
+	}
+}
+
+func TestDatasourceReferencesCanBeResolved(t *testing.T) {
+	testCases := map[string]sdk.DatasourceRef{
+		"by name":          {LegacyName: "Loki"},
+		"by name and type": {LegacyName: "Loki", Type: "loki"},
+		"by uid":           {UID: "loki-uid"},
+		"by type":          {Type: "loki"},
+	}
+
+	for name, testCase := range testCases {
+		ref := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := require.New(t)
+
+			err := testDatasourceResolver().resolve(&ref)
+
+			req.NoError(err)
+			req.Equal(sdk.DatasourceRef{UID: "loki-uid", Type: "loki"}, ref)
+		})
+	}
+}
+
+func TestUnknownDatasourceReferencesCanNotBeResolved(t *testing.T) {
+	testCases := map[string]sdk.DatasourceRef{
+		"unknown name":  {LegacyName: "Graphite"},
+		"unknown uid":   {UID: "graphite-uid"},
+		"unknown type":  {Type: "graphite"},
+		"type mismatch": {LegacyName: "Loki", Type: "prometheus"},
+	}
+
+	for name, testCase := range testCases {
+		ref := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := require.New(t)
+
+			err := testDatasourceResolver().resolve(&ref)
+
+			req.ErrorIs(err, ErrDatasourceNotFound)
+		})
+	}
+}
+
+func TestAmbiguousDatasourceReferencesCanNotBeResolved(t *testing.T) {
+	req := require.New(t)
+
+	err := testDatasourceResolver().resolve(&sdk.DatasourceRef{Type: "prometheus"})
+
+	req.ErrorIs(err, ErrAmbiguousDatasource)
+}
+
+func TestBuiltinDatasourcesAndVariablesAreNotResolved(t *testing.T) {
+	refs := []sdk.DatasourceRef{
+		{LegacyName: "-- Grafana --"},
+		{LegacyName: "-- Mixed --"},
+		{LegacyName: "$datasource"},
+		{UID: "${datasource}"},
+		{UID: "grafana", Type: "datasource"},
+	}
+
+	for _, testCase := range refs {
+		ref := testCase
+
+		t.Run(ref.LegacyName+ref.UID, func(t *testing.T) {
+			req := require.New(t)
+			original := ref
+
+			err := testDatasourceResolver().resolve(&ref)
+
+			req.NoError(err)
+			req.Equal(original, ref)
+		})
+	}
+}
+
+func TestAlertsWithoutDatasourceQueryTheDefaultOne(t *testing.T) {
+	req := require.New(t)
+
+	uid, err := testDatasourceResolver().alertDatasourceUID(nil)
+
+	req.NoError(err)
+	req.Equal("prom-uid", uid)
+}
+
+func TestAlertsCanNotQueryDashboardVariables(t *testing.T) {
+	req := require.New(t)
+
+	_, err := testDatasourceResolver().alertDatasourceUID(&sdk.DatasourceRef{LegacyName: "$datasource"})
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+}
+
+func TestDashboardsReferencingUnknownDatasourcesAreRejectedBeforeBeingPersisted(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"HTTP Rate",
+				timeseries.DataSourceRef(datasource.ByName("Prometheus")),
+				timeseries.WithPrometheusTarget("rate(http_requests_total[5m])"),
+			),
+			row.WithTimeSeries(
+				"Logs rate",
+				timeseries.DataSourceRef(datasource.ByName("Loki").OfType("loki")),
+				timeseries.WithLokiTarget(`rate({app="api"}[5m])`),
+			),
+		),
+	)
+	req.NoError(err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+		req.Equal("/api/datasources", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{}, builder)
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+	req.ErrorContains(err, "name 'Loki'")
+}
+
+func TestDashboardsArePersistedWithResolvedDatasources(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"HTTP Rate",
+				timeseries.DataSource("Prometheus"),
+				timeseries.WithPrometheusTarget("rate(http_requests_total[5m])"),
+			),
+		),
+	)
+	req.NoError(err)
+
+	var persistedDashboard string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/datasources" {
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus"}]`)
+			return
+		}
+
+		if r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db" {
+			body, _ := io.ReadAll(r.Body)
+			persistedDashboard = string(body)
+
+			_, _ = fmt.Fprintln(w, `{"uid": "dashboard-uid"}`)
+			return
+		}
+
+		// raw dashboard retrieval and alerts listing
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{}, builder)
+
+	req.NoError(err)
+	req.Contains(persistedDashboard, `"datasource":{"type":"prometheus","UID":"prom-uid"}`)
+}
+
+func TestCustomPanelsReferencingUnknownDatasourcesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"HTTP Rate",
+				timeseries.Transformations(transformation.Limit(10)),
+				timeseries.WithPrometheusTarget(
+					"rate(http_requests_total[5m])",
+					prometheusTarget.DataSourceRef(datasource.ByName("missing")),
+				),
+			),
+		),
+	)
+	req.NoError(err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+		req.Equal("/api/datasources", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{}, builder)
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+	req.ErrorContains(err, "name 'missing'")
+}
+
+func TestCustomPanelsAndLibraryPanelsArePersistedWithResolvedDatasources(t *testing.T) {
+	req := require.New(t)
+
+	libraryPanel, err := timeseries.New(
+		"Errors rate",
+		timeseries.Transformations(transformation.Limit(10)),
+		timeseries.WithPrometheusTarget(
+			"rate(http_errors_total[5m])",
+			prometheusTarget.DataSourceRef(datasource.ByName("Prometheus")),
+		),
+	)
+	req.NoError(err)
+
+	definition, err := librarypanel.Define("errors-rate", libraryPanel.Builder)
+	req.NoError(err)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.LibraryPanels(definition),
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"HTTP Rate",
+				timeseries.Transformations(transformation.Limit(10)),
+				timeseries.WithPrometheusTarget(
+					"rate(http_requests_total[5m])",
+					prometheusTarget.DataSourceRef(datasource.ByName("Prometheus")),
+				),
+			),
+		),
+	)
+	req.NoError(err)
+
+	var persistedDashboard, persistedLibraryPanel string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/library-elements/errors-rate":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/library-elements":
+			body, _ := io.ReadAll(r.Body)
+			persistedLibraryPanel = string(body)
+
+			_, _ = fmt.Fprintln(w, `{"result": {"uid": "errors-rate"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			body, _ := io.ReadAll(r.Body)
+			persistedDashboard = string(body)
+
+			_, _ = fmt.Fprintln(w, `{"uid": "dashboard-uid"}`)
+		default:
+			// raw dashboard retrieval and alerts listing
+			_, _ = fmt.Fprintln(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{}, builder)
+
+	req.NoError(err)
+	req.Contains(persistedDashboard, `"datasource":{"type":"prometheus","UID":"prom-uid"}`)
+	req.NotContains(persistedDashboard, `"datasource":"Prometheus"`)
+	req.Contains(persistedLibraryPanel, `"datasource":{"type":"prometheus","UID":"prom-uid"}`)
+	req.NotContains(persistedLibraryPanel, `"datasource":"Prometheus"`)
+}
+
+func TestADashboardCanBeUpsertedToInstancesWithDifferentDatasourceUIDs(t *testing.T) {
+	req := require.New(t)
+
+	libraryPanel, err := timeseries.New(
+		"Errors rate",
+		timeseries.DataSource("Prometheus"),
+		timeseries.WithPrometheusTarget("rate(http_errors_total[5m])"),
+	)
+	req.NoError(err)
+
+	definition, err := librarypanel.Define("errors-rate", libraryPanel.Builder)
+	req.NoError(err)
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.UID("dashboard-uid"),
+		dashboard.LibraryPanels(definition),
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"HTTP Rate",
+				timeseries.DataSource("Prometheus"),
+				timeseries.WithPrometheusTarget("rate(http_requests_total[5m])"),
+				timeseries.Alert(
+					"Too many requests",
+					alert.WithPrometheusQuery("A", "sum(rate(http_requests_total[5m]))"),
+					alert.If(alert.Avg, "A", alert.IsAbove(3)),
+				),
+			),
+		),
+	)
+	req.NoError(err)
+
+	instance := func(datasourceUID string) (*httptest.Server, *[]string) {
+		var payloads []string
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+				_, _ = fmt.Fprintf(w, `[{"uid": "%s", "name": "Prometheus", "type": "prometheus"}]`, datasourceUID)
+			case r.Method == http.MethodGet && r.URL.Path == "/api/library-elements/errors-rate":
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodPost && r.URL.Path == "/api/library-elements":
+				body, _ := io.ReadAll(r.Body)
+				payloads = append(payloads, string(body))
+				_, _ = fmt.Fprintln(w, `{"result": {"uid": "errors-rate"}}`)
+			case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+				body, _ := io.ReadAll(r.Body)
+				payloads = append(payloads, string(body))
+				_, _ = fmt.Fprintln(w, `{"uid": "dashboard-uid"}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid":
+				_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "dashboard-uid", "panels": [{"id": 1, "title": "HTTP Rate"}]}}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+				_, _ = fmt.Fprintln(w, `{}`)
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusNotFound)
+			case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/api/ruler/grafana/api/v1/rules/"):
+				body, _ := io.ReadAll(r.Body)
+				payloads = append(payloads, string(body))
+				w.WriteHeader(http.StatusAccepted)
+			default:
+				t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+			}
+		})), &payloads
+	}
+
+	first, firstPayloads := instance("prom-first")
+	defer first.Close()
+	second, secondPayloads := instance("prom-second")
+	defer second.Close()
+
+	_, err = NewClient(http.DefaultClient, first.URL).UpsertDashboard(context.TODO(), &Folder{Title: "Infra"}, builder)
+	req.NoError(err)
+	_, err = NewClient(http.DefaultClient, second.URL).UpsertDashboard(context.TODO(), &Folder{Title: "Infra"}, builder)
+	req.NoError(err)
+
+	// library panel, dashboard and alert
+	req.Len(*firstPayloads, 3)
+	req.Len(*secondPayloads, 3)
+	for _, payload := range *firstPayloads {
+		req.Contains(payload, "prom-first")
+	}
+	for _, payload := range *secondPayloads {
+		req.Contains(payload, "prom-second")
+		req.NotContains(payload, "prom-first")
+	}
+
+	// the builder itself still references datasources by name
+	for _, ref := range boardDatasourceRefs(builder.Internal()) {
+		if ref != nil && *ref != (sdk.DatasourceRef{}) {
+			req.Equal("Prometheus", ref.LegacyName)
+		}
+	}
+	req.Equal("Prometheus", definition.Model.Datasource.LegacyName)
+}
+
+func TestCheckDatasourceHealth(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/datasources/name/Prometheus" {
+			_, _ = fmt.Fprintln(w, `{"uid": "prom-uid"}`)
+			return
+		}
+
+		req.Equal("/api/datasources/uid/prom-uid/health", r.URL.Path)
+		_, _ = fmt.Fprintln(w, `{"status": "OK", "message": "Successfully queried the Prometheus API."}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.CheckDatasourceHealth(context.TODO(), "Prometheus")
+
+	req.NoError(err)
+}
+
+func TestCheckDatasourceHealthReportsUnhealthyDatasources(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/datasources/name/Prometheus" {
+			_, _ = fmt.Fprintln(w, `{"uid": "prom-uid"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintln(w, `{"status": "ERROR", "message": "connection refused"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.CheckDatasourceHealth(context.TODO(), "Prometheus")
+
+	req.ErrorIs(err, ErrDatasourceUnhealthy)
+	req.ErrorContains(err, "connection refused")
+}
@@ -0,0 +1,168 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/alertlist"
+	"github.com/K-Phoen/grabana/row"
+)
+
+var ErrInvalidAlertListState = fmt.Errorf("invalid alert list state")
+var ErrInvalidAlertListSortOrder = fmt.Errorf("invalid alert list sort order")
+var ErrInvalidAlertListView = fmt.Errorf("invalid alert list view mode")
+
+type DashboardAlertList struct {
+	Title       string
+	Description string              `yaml:",omitempty"`
+	Span        float32             `yaml:",omitempty"`
+	Height      string              `yaml:",omitempty"`
+	Transparent bool                `yaml:",omitempty"`
+	Links       DashboardPanelLinks `yaml:",omitempty"`
+
+	// States lists the states of the alerts to display. Valid values are:
+	// firing, pending, no_data, normal, error
+	States []string `yaml:",omitempty,flow"`
+	// Folder only displays alerts defined in this folder.
+	Folder *AlertListFolder `yaml:",omitempty"`
+	// LabelFilter only displays alert instances matching these label matchers.
+	LabelFilter string `yaml:"label_filter,omitempty"`
+	AlertName   string `yaml:"alert_name,omitempty"`
+	// OnlyDashboardAlerts only displays alerts linked to the current dashboard.
+	OnlyDashboardAlerts bool     `yaml:"only_dashboard_alerts,omitempty"`
+	GroupBy             []string `yaml:"group_by,omitempty,flow"`
+	MaxItems            int      `yaml:"max_items,omitempty"`
+	// Sort sets the order in which alerts are displayed. Valid values are:
+	// alpha_asc, alpha_desc, importance, time_asc, time_desc
+	Sort string `yaml:",omitempty"`
+	// View sets the way alerts are displayed. Valid values are: list, stat
+	View string `yaml:",omitempty"`
+}
+
+type AlertListFolder struct {
+	ID    uint
+	Title string
+}
+
+func (panel DashboardAlertList) toOption() (row.Option, error) {
+	opts, err := panel.toOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return row.WithAlertList(panel.Title, opts...), nil
+}
+
+func (panel DashboardAlertList) toOptions() ([]alertlist.Option, error) {
+	opts := []alertlist.Option{}
+
+	if panel.Description != "" {
+		opts = append(opts, alertlist.Description(panel.Description))
+	}
+	if panel.Span != 0 {
+		opts = append(opts, alertlist.Span(panel.Span))
+	}
+	if panel.Height != "" {
+		opts = append(opts, alertlist.Height(panel.Height))
+	}
+	if panel.Transparent {
+		opts = append(opts, alertlist.Transparent())
+	}
+	if len(panel.Links) != 0 {
+		opts = append(opts, alertlist.Links(panel.Links.toModel()...))
+	}
+	if len(panel.States) != 0 {
+		opt, err := panel.states()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if panel.Folder != nil {
+		opts = append(opts, alertlist.Folder(panel.Folder.ID, panel.Folder.Title))
+	}
+	if panel.LabelFilter != "" {
+		opts = append(opts, alertlist.LabelFilter(panel.LabelFilter))
+	}
+	if panel.AlertName != "" {
+		opts = append(opts, alertlist.AlertName(panel.AlertName))
+	}
+	if panel.OnlyDashboardAlerts {
+		opts = append(opts, alertlist.OnlyDashboardAlerts())
+	}
+	if len(panel.GroupBy) != 0 {
+		opts = append(opts, alertlist.GroupBy(panel.GroupBy...))
+	}
+	if panel.MaxItems != 0 {
+		opts = append(opts, alertlist.MaxItems(panel.MaxItems))
+	}
+	if panel.Sort != "" {
+		opt, err := panel.sortOrder()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if panel.View != "" {
+		opt, err := panel.view()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
+}
+
+func (panel DashboardAlertList) states() (alertlist.Option, error) {
+	states := make([]alertlist.State, 0, len(panel.States))
+
+	for _, state := range panel.States {
+		switch state {
+		case "firing":
+			states = append(states, alertlist.Firing)
+		case "pending":
+			states = append(states, alertlist.Pending)
+		case "no_data":
+			states = append(states, alertlist.NoData)
+		case "normal":
+			states = append(states, alertlist.Normal)
+		case "error":
+			states = append(states, alertlist.Error)
+		default:
+			return nil, ErrInvalidAlertListState
+		}
+	}
+
+	return alertlist.States(states...), nil
+}
+
+func (panel DashboardAlertList) sortOrder() (alertlist.Option, error) {
+	switch panel.Sort {
+	case "alpha_asc":
+		return alertlist.Sort(alertlist.AlphaAsc), nil
+	case "alpha_desc":
+		return alertlist.Sort(alertlist.AlphaDesc), nil
+	case "importance":
+		return alertlist.Sort(alertlist.Importance), nil
+	case "time_asc":
+		return alertlist.Sort(alertlist.TimeAsc), nil
+	case "time_desc":
+		return alertlist.Sort(alertlist.TimeDesc), nil
+	}
+
+	return nil, ErrInvalidAlertListSortOrder
+}
+
+func (panel DashboardAlertList) view() (alertlist.Option, error) {
+	switch panel.View {
+	case "list":
+		return alertlist.View(alertlist.List), nil
+	case "stat":
+		return alertlist.View(alertlist.Stat), nil
+	}
+
+	return nil, ErrInvalidAlertListView
+}
@@ -0,0 +1,91 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/alertlist"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertListPanelsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardAlertList{
+		Title:               "Alerts",
+		Description:         "awesome description",
+		Span:                12,
+		Height:              "300px",
+		Transparent:         true,
+		States:              []string{"firing", "no_data", "error"},
+		Folder:              &AlertListFolder{ID: 2, Title: "Infra"},
+		LabelFilter:         `severity="critical"`,
+		AlertName:           "latency",
+		OnlyDashboardAlerts: true,
+		GroupBy:             []string{"team"},
+		MaxItems:            5,
+		Sort:                "importance",
+		View:                "stat",
+	}
+
+	opts, err := panel.toOptions()
+	req.NoError(err)
+
+	alertListPanel, err := alertlist.New(panel.Title, opts...)
+	req.NoError(err)
+
+	sdkPanel := alertListPanel.Builder
+	req.Equal("alertlist", sdkPanel.Type)
+	req.Equal(panel.Title, sdkPanel.Title)
+	req.Equal(panel.Description, *sdkPanel.Description)
+	req.Equal(panel.Span, sdkPanel.Span)
+	req.True(sdkPanel.Transparent)
+
+	options, err := json.Marshal((*sdkPanel.CustomPanel)["options"])
+	req.NoError(err)
+	req.JSONEq(`{
+		"maxItems": 5,
+		"sortOrder": 3,
+		"dashboardAlerts": true,
+		"groupMode": "custom",
+		"groupBy": ["team"],
+		"alertName": "latency",
+		"alertInstanceLabelFilter": "severity=\"critical\"",
+		"folder": {"id": 2, "title": "Infra"},
+		"stateFilter": {"firing": true, "pending": false, "noData": true, "normal": false, "error": true},
+		"viewMode": "stat"
+	}`, string(options))
+}
+
+func TestAlertListPanelsWithInvalidState(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardAlertList{States: []string{"invalid"}}
+
+	_, err := panel.toOption()
+
+	req.Error(err)
+	req.ErrorIs(err, ErrInvalidAlertListState)
+}
+
+func TestAlertListPanelsWithInvalidSortOrder(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardAlertList{Sort: "invalid"}
+
+	_, err := panel.toOption()
+
+	req.Error(err)
+	req.ErrorIs(err, ErrInvalidAlertListSortOrder)
+}
+
+func TestAlertListPanelsWithInvalidView(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardAlertList{View: "invalid"}
+
+	_, err := panel.toOption()
+
+	req.Error(err)
+	req.ErrorIs(err, ErrInvalidAlertListView)
+}
@@ -0,0 +1,210 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/annotation"
+	"github.com/K-Phoen/grabana/dashboard"
+)
+
+var ErrAnnotationNotConfigured = fmt.Errorf("annotation not configured")
+var ErrInvalidAnnotationFilter = fmt.Errorf("invalid annotation filter")
+
+// DashboardAnnotation represents a source of annotations.
+type DashboardAnnotation struct {
+	Prometheus *PrometheusAnnotation `yaml:",omitempty"`
+	Loki       *LokiAnnotation       `yaml:",omitempty"`
+	Grafana    *GrafanaAnnotation    `yaml:",omitempty"`
+	Graphite   *GraphiteAnnotation   `yaml:",omitempty"`
+}
+
+func (a DashboardAnnotation) toOption() (dashboard.Option, error) {
+	if a.Prometheus != nil {
+		return a.Prometheus.toOption(), nil
+	}
+	if a.Loki != nil {
+		return a.Loki.toOption(), nil
+	}
+	if a.Grafana != nil {
+		return a.Grafana.toOption()
+	}
+	if a.Graphite != nil {
+		return a.Graphite.toOption(), nil
+	}
+
+	return nil, ErrAnnotationNotConfigured
+}
+
+// AnnotationSettings holds the settings shared by every source of
+// annotations.
+type AnnotationSettings struct {
+	Name     string
+	Color    string            `yaml:",omitempty"`
+	Disabled bool              `yaml:",omitempty"`
+	Hidden   bool              `yaml:",omitempty"`
+	Filter   *AnnotationFilter `yaml:",omitempty"`
+}
+
+// AnnotationFilter restricts the panels on which annotations are shown.
+type AnnotationFilter struct {
+	// Titles of the panels on which annotations are shown.
+	Only []string `yaml:",omitempty"`
+	// Titles of the panels on which annotations are not shown.
+	Except []string `yaml:",omitempty"`
+}
+
+func (settings AnnotationSettings) toOptions() []annotation.Option {
+	opts := []annotation.Option{}
+
+	if settings.Color != "" {
+		opts = append(opts, annotation.Color(settings.Color))
+	}
+	if settings.Disabled {
+		opts = append(opts, annotation.Disabled())
+	}
+	if settings.Hidden {
+		opts = append(opts, annotation.Hidden())
+	}
+
+	if settings.Filter != nil {
+		opts = append(opts, settings.Filter.toOption())
+	}
+
+	return opts
+}
+
+func (filter AnnotationFilter) toOption() annotation.Option {
+	if len(filter.Only) != 0 && len(filter.Except) != 0 {
+		return func(*annotation.Annotation) error {
+			return fmt.Errorf("only one of 'only' and 'except' can be used: %w", ErrInvalidAnnotationFilter)
+		}
+	}
+
+	if len(filter.Except) != 0 {
+		return annotation.ExceptPanels(filter.Except...)
+	}
+
+	return annotation.OnlyPanels(filter.Only...)
+}
+
+// PrometheusAnnotation describes annotations generated by a Prometheus query.
+type PrometheusAnnotation struct {
+	AnnotationSettings `yaml:",inline"`
+
+	Datasource  string
+	Expr        string
+	Step        string   `yaml:",omitempty"`
+	TitleFormat string   `yaml:"title_format,omitempty"`
+	TextFormat  string   `yaml:"text_format,omitempty"`
+	TagKeys     []string `yaml:"tag_keys,omitempty,flow"`
+
+	SeriesValueAsTimestamp bool `yaml:"series_value_as_timestamp,omitempty"`
+}
+
+func (a PrometheusAnnotation) toOption() dashboard.Option {
+	opts := a.AnnotationSettings.toOptions()
+
+	if a.Step != "" {
+		opts = append(opts, annotation.Step(a.Step))
+	}
+	if a.TitleFormat != "" {
+		opts = append(opts, annotation.TitleFormat(a.TitleFormat))
+	}
+	if a.TextFormat != "" {
+		opts = append(opts, annotation.TextFormat(a.TextFormat))
+	}
+	if len(a.TagKeys) != 0 {
+		opts = append(opts, annotation.TagKeys(a.TagKeys...))
+	}
+	if a.SeriesValueAsTimestamp {
+		opts = append(opts, annotation.SeriesValueAsTimestamp())
+	}
+
+	return dashboard.PrometheusAnnotation(a.Name, a.Datasource, a.Expr, opts...)
+}
+
+// LokiAnnotation describes annotations generated by a LogQL query.
+type LokiAnnotation struct {
+	AnnotationSettings `yaml:",inline"`
+
+	Datasource  string
+	Query       string
+	TitleFormat string   `yaml:"title_format,omitempty"`
+	TextFormat  string   `yaml:"text_format,omitempty"`
+	TagKeys     []string `yaml:"tag_keys,omitempty,flow"`
+}
+
+func (a LokiAnnotation) toOption() dashboard.Option {
+	opts := a.AnnotationSettings.toOptions()
+
+	if a.TitleFormat != "" {
+		opts = append(opts, annotation.TitleFormat(a.TitleFormat))
+	}
+	if a.TextFormat != "" {
+		opts = append(opts, annotation.TextFormat(a.TextFormat))
+	}
+	if len(a.TagKeys) != 0 {
+		opts = append(opts, annotation.TagKeys(a.TagKeys...))
+	}
+
+	return dashboard.LokiAnnotation(a.Name, a.Datasource, a.Query, opts...)
+}
+
+// GrafanaAnnotation describes annotations stored in Grafana.
+type GrafanaAnnotation struct {
+	AnnotationSettings `yaml:",inline"`
+
+	// Valid values are: dashboard, organization. Defaults to dashboard.
+	Scope    string   `yaml:",omitempty"`
+	Tags     []string `yaml:",omitempty,flow"`
+	MatchAny bool     `yaml:"match_any,omitempty"`
+	Limit    int      `yaml:",omitempty"`
+}
+
+func (a GrafanaAnnotation) toOption() (dashboard.Option, error) {
+	opts := a.AnnotationSettings.toOptions()
+
+	switch a.Scope {
+	case "", "dashboard":
+		if len(a.Tags) != 0 || a.MatchAny {
+			return nil, fmt.Errorf("tags can only be used to filter annotations from the organization: %w", ErrInvalidAnnotationFilter)
+		}
+	case "organization":
+		opts = append(opts, annotation.FilterByTags(a.Tags...))
+
+		if a.MatchAny {
+			opts = append(opts, annotation.MatchAnyTag())
+		}
+	default:
+		return nil, fmt.Errorf("unknown scope '%s': %w", a.Scope, ErrInvalidAnnotationFilter)
+	}
+
+	if a.Limit != 0 {
+		opts = append(opts, annotation.Limit(a.Limit))
+	}
+
+	return dashboard.GrafanaAnnotation(a.Name, opts...), nil
+}
+
+// GraphiteAnnotation describes annotations generated by a Graphite query, or
+// by Graphite events.
+type GraphiteAnnotation struct {
+	AnnotationSettings `yaml:",inline"`
+
+	Datasource string
+	Query      string   `yaml:",omitempty"`
+	EventTags  []string `yaml:"event_tags,omitempty,flow"`
+}
+
+func (a GraphiteAnnotation) toOption() dashboard.Option {
+	opts := a.AnnotationSettings.toOptions()
+
+	if a.Query != "" {
+		opts = append(opts, annotation.Query(a.Query))
+	}
+	if len(a.EventTags) != 0 {
+		opts = append(opts, annotation.EventTags(a.EventTags...))
+	}
+
+	return dashboard.GraphiteAnnotation(a.Name, a.Datasource, opts...)
+}
@@ -0,0 +1,135 @@
+package decoder
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotationsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := UnmarshalYAML(strings.NewReader(`
+title: Awesome dashboard
+
+annotations:
+  - prometheus:
+      name: Deployments
+      datasource: prometheus
+      expr: changes(deployed_version[1m]) > 0
+      step: 60s
+      title_format: "{{ service }} deployed"
+      tag_keys: [service]
+      color: "#5794F2"
+      filter: {only: [Latency]}
+  - loki:
+      name: Errors
+      datasource: loki
+      query: '{app="api"} |= "error"'
+      tag_keys: [level]
+      hidden: true
+  - grafana:
+      name: Releases
+      scope: organization
+      tags: [release]
+      match_any: true
+      limit: 20
+  - graphite:
+      name: Events
+      datasource: graphite
+      event_tags: [deploy]
+      disabled: true
+
+rows:
+  - name: Overview
+    panels:
+      - timeseries:
+          title: Latency
+          targets:
+            - prometheus: {query: "up"}
+`))
+	req.NoError(err)
+
+	annotations := builder.Internal().Annotations.List
+	req.Len(annotations, 4)
+
+	req.Equal("Deployments", annotations[0].Name)
+	req.Equal("changes(deployed_version[1m]) > 0", annotations[0].Expr)
+	req.Equal("60s", annotations[0].Step)
+	req.Equal("service", annotations[0].TagKeys)
+	req.Equal("#5794F2", annotations[0].IconColor)
+
+	req.Equal(`{app="api"} |= "error"`, annotations[1].Expr)
+
+	req.Equal("tags", annotations[2].Type)
+	req.Equal([]string{"release"}, annotations[2].Tags)
+
+	req.False(annotations[3].Enable)
+
+	content, err := builder.MarshalJSON()
+	req.NoError(err)
+
+	board := struct {
+		Annotations struct {
+			List []map[string]interface{} `json:"list"`
+		} `json:"annotations"`
+	}{}
+	req.NoError(json.Unmarshal(content, &board))
+
+	req.NotNil(board.Annotations.List[0]["filter"])
+	req.Equal(true, board.Annotations.List[1]["hide"])
+	req.Equal(true, board.Annotations.List[2]["matchAny"])
+	req.Equal(float64(20), board.Annotations.List[2]["limit"])
+}
+
+func TestUnconfiguredAnnotationsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := DashboardAnnotation{}.toOption()
+
+	req.ErrorIs(err, ErrAnnotationNotConfigured)
+}
+
+func TestAnnotationFiltersCanNotIncludeAndExcludePanels(t *testing.T) {
+	req := require.New(t)
+
+	source := DashboardAnnotation{
+		Grafana: &GrafanaAnnotation{
+			AnnotationSettings: AnnotationSettings{
+				Name:   "Annotations",
+				Filter: &AnnotationFilter{Only: []string{"a"}, Except: []string{"b"}},
+			},
+		},
+	}
+
+	_, err := decodeDashboardWithAnnotation(source)
+
+	req.ErrorIs(err, ErrInvalidAnnotationFilter)
+}
+
+func TestGrafanaAnnotationsScopedToTheDashboardCanNotFilterByTags(t *testing.T) {
+	req := require.New(t)
+
+	_, err := GrafanaAnnotation{Tags: []string{"deploy"}}.toOption()
+
+	req.ErrorIs(err, ErrInvalidAnnotationFilter)
+}
+
+func TestGrafanaAnnotationsWithAnUnknownScopeAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := GrafanaAnnotation{Scope: "universe"}.toOption()
+
+	req.ErrorIs(err, ErrInvalidAnnotationFilter)
+}
+
+func decodeDashboardWithAnnotation(source DashboardAnnotation) (interface{}, error) {
+	model := DashboardModel{
+		Title:       "Dashboard",
+		Annotations: []DashboardAnnotation{source},
+	}
+
+	return model.ToBuilder()
+}
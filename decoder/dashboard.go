@@ -23,10 +23,15 @@ type DashboardModel struct {
 	Timezone string `yaml:",omitempty"`
 
 	TagsAnnotation []dashboard.TagAnnotation `yaml:"tags_annotations,omitempty"`
+	Annotations    []DashboardAnnotation     `yaml:",omitempty"`
 	Variables      []DashboardVariable       `yaml:",omitempty"`
 	ExternalLinks  []DashboardExternalLink   `yaml:"external_links,omitempty"`
 	DashboardLinks []DashboardInternalLink   `yaml:"dashboard_links,omitempty"`
 
+	LibraryPanels []LibraryPanelDefinition `yaml:"library_panels,omitempty"`
+
+	Permissions *DashboardPermissions `yaml:",omitempty"`
+
 	Rows []DashboardRow
 }
 
@@ -65,6 +70,15 @@ func (d *DashboardModel) ToBuilder() (dashboard.Builder, error) {
 		opts = append(opts, dashboard.TagsAnnotation(tagAnnotation))
 	}
 
+	for _, annotation := range d.Annotations {
+		opt, err := annotation.toOption()
+		if err != nil {
+			return emptyDashboard, err
+		}
+
+		opts = append(opts, opt)
+	}
+
 	if d.Time[0] != "" && d.Time[1] != "" {
 		opts = append(opts, dashboard.Time(d.Time[0], d.Time[1]))
 	}
@@ -90,6 +104,24 @@ func (d *DashboardModel) ToBuilder() (dashboard.Builder, error) {
 		opts = append(opts, opt)
 	}
 
+	for _, definition := range d.LibraryPanels {
+		libraryPanel, err := definition.toModel()
+		if err != nil {
+			return emptyDashboard, err
+		}
+
+		opts = append(opts, dashboard.LibraryPanels(libraryPanel))
+	}
+
+	if d.Permissions != nil {
+		permissionOpts, err := d.Permissions.toOptions()
+		if err != nil {
+			return emptyDashboard, err
+		}
+
+		opts = append(opts, permissionOpts...)
+	}
+
 	for _, r := range d.Rows {
 		opt, err := r.toOption()
 		if err != nil {
@@ -128,6 +160,12 @@ type DashboardPanel struct {
 	TimeSeries *DashboardTimeSeries `yaml:"timeseries,omitempty"`
 	Logs       *DashboardLogs       `yaml:"logs,omitempty"`
 	Gauge      *DashboardGauge      `yaml:"gauge,omitempty"`
+	Geomap     *DashboardGeomap     `yaml:"geomap,omitempty"`
+	AlertList  *DashboardAlertList  `yaml:"alert_list,omitempty"`
+	DashList   *DashboardDashList   `yaml:"dashboard_list,omitempty"`
+	News       *DashboardNews       `yaml:"news,omitempty"`
+
+	LibraryPanel *DashboardLibraryPanel `yaml:"library_panel,omitempty"`
 }
 
 func (panel DashboardPanel) toOption() (row.Option, error) {
@@ -158,6 +196,21 @@ func (panel DashboardPanel) toOption() (row.Option, error) {
 	if panel.Gauge != nil {
 		return panel.Gauge.toOption()
 	}
+	if panel.Geomap != nil {
+		return panel.Geomap.toOption()
+	}
+	if panel.AlertList != nil {
+		return panel.AlertList.toOption()
+	}
+	if panel.DashList != nil {
+		return panel.DashList.toOption(), nil
+	}
+	if panel.News != nil {
+		return panel.News.toOption(), nil
+	}
+	if panel.LibraryPanel != nil {
+		return panel.LibraryPanel.toOption(), nil
+	}
 
 	return nil, ErrPanelNotConfigured
 }
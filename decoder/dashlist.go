@@ -0,0 +1,92 @@
+package decoder
+
+import (
+	"github.com/K-Phoen/grabana/dashlist"
+	"github.com/K-Phoen/grabana/row"
+)
+
+type DashboardDashList struct {
+	Title       string
+	Description string              `yaml:",omitempty"`
+	Span        float32             `yaml:",omitempty"`
+	Height      string              `yaml:",omitempty"`
+	Transparent bool                `yaml:",omitempty"`
+	Links       DashboardPanelLinks `yaml:",omitempty"`
+
+	Starred bool             `yaml:",omitempty"`
+	Recent  bool             `yaml:",omitempty"`
+	Search  *DashListSearch  `yaml:",omitempty"`
+	Include *DashListInclude `yaml:",omitempty"`
+
+	HideHeadings bool `yaml:"hide_headings,omitempty"`
+	MaxItems     int  `yaml:"max_items,omitempty"`
+}
+
+type DashListSearch struct {
+	Query    string   `yaml:",omitempty"`
+	Tags     []string `yaml:",omitempty,flow"`
+	FolderID *uint    `yaml:"folder_id,omitempty"`
+}
+
+type DashListInclude struct {
+	TimeRange bool `yaml:"time_range,omitempty"`
+	Variables bool `yaml:",omitempty"`
+}
+
+func (panel DashboardDashList) toOption() row.Option {
+	opts := []dashlist.Option{}
+
+	if panel.Description != "" {
+		opts = append(opts, dashlist.Description(panel.Description))
+	}
+	if panel.Span != 0 {
+		opts = append(opts, dashlist.Span(panel.Span))
+	}
+	if panel.Height != "" {
+		opts = append(opts, dashlist.Height(panel.Height))
+	}
+	if panel.Transparent {
+		opts = append(opts, dashlist.Transparent())
+	}
+	if len(panel.Links) != 0 {
+		opts = append(opts, dashlist.Links(panel.Links.toModel()...))
+	}
+	if panel.Starred {
+		opts = append(opts, dashlist.Starred())
+	}
+	if panel.Recent {
+		opts = append(opts, dashlist.Recent())
+	}
+	if panel.Search != nil {
+		opts = append(opts, panel.Search.toOptions()...)
+	}
+	if panel.Include != nil && panel.Include.TimeRange {
+		opts = append(opts, dashlist.IncludeCurrentTimeRange())
+	}
+	if panel.Include != nil && panel.Include.Variables {
+		opts = append(opts, dashlist.IncludeCurrentVariables())
+	}
+	if panel.HideHeadings {
+		opts = append(opts, dashlist.HideHeadings())
+	}
+	if panel.MaxItems != 0 {
+		opts = append(opts, dashlist.MaxItems(panel.MaxItems))
+	}
+
+	return row.WithDashboardList(panel.Title, opts...)
+}
+
+func (search DashListSearch) toOptions() []dashlist.Option {
+	opts := []dashlist.Option{
+		dashlist.SearchQuery(search.Query),
+	}
+
+	if len(search.Tags) != 0 {
+		opts = append(opts, dashlist.SearchTags(search.Tags...))
+	}
+	if search.FolderID != nil {
+		opts = append(opts, dashlist.SearchFolder(*search.FolderID))
+	}
+
+	return opts
+}
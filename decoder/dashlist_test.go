@@ -0,0 +1,64 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashListPanelsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+	folderID := uint(4)
+
+	panel := DashboardDashList{
+		Title:       "Dashboards",
+		Description: "awesome description",
+		Span:        4,
+		Transparent: true,
+		Starred:     true,
+		Recent:      true,
+		Search: &DashListSearch{
+			Query:    "kube",
+			Tags:     []string{"infra"},
+			FolderID: &folderID,
+		},
+		Include: &DashListInclude{
+			TimeRange: true,
+			Variables: true,
+		},
+		HideHeadings: true,
+		MaxItems:     15,
+	}
+
+	testBoard, err := dashboard.New("", dashboard.Row("", panel.toOption()))
+	req.NoError(err)
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+
+	panels := testBoard.Internal().Panels[1:]
+	req.Len(panels, 1)
+
+	sdkPanel := panels[0]
+	req.Equal("dashlist", sdkPanel.Type)
+	req.Equal(panel.Title, sdkPanel.Title)
+	req.Equal(panel.Description, *sdkPanel.Description)
+	req.Equal(int(panel.Span*2), *sdkPanel.GridPos.W)
+	req.True(sdkPanel.Transparent)
+
+	options, err := json.Marshal((*sdkPanel.CustomPanel)["options"])
+	req.NoError(err)
+	req.JSONEq(`{
+		"showStarred": true,
+		"showRecentlyViewed": true,
+		"showSearch": true,
+		"showHeadings": false,
+		"maxItems": 15,
+		"query": "kube",
+		"tags": ["infra"],
+		"folderId": 4,
+		"includeVars": true,
+		"keepTime": true
+	}`, string(options))
+}
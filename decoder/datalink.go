@@ -0,0 +1,57 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/datalink"
+)
+
+var ErrInvalidDataLink = fmt.Errorf("invalid data link")
+
+type DashboardDataLinks []DashboardDataLink
+
+type DashboardDataLink struct {
+	Title string
+	// URL of the link. Variables like ${__value.raw} are interpolated by Grafana.
+	URL      string            `yaml:"url,omitempty"`
+	Internal *InternalDataLink `yaml:",omitempty"`
+	NewTab   bool              `yaml:"new_tab,omitempty"`
+}
+
+type InternalDataLink struct {
+	DatasourceUID  string                 `yaml:"datasource_uid"`
+	DatasourceName string                 `yaml:"datasource_name,omitempty"`
+	Query          map[string]interface{} `yaml:",omitempty"`
+}
+
+func (links DashboardDataLinks) toModel() ([]datalink.Link, error) {
+	models := make([]datalink.Link, 0, len(links))
+
+	for _, link := range links {
+		model, err := link.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (link DashboardDataLink) toModel() (datalink.Link, error) {
+	opts := []datalink.Option{}
+
+	if link.NewTab {
+		opts = append(opts, datalink.OpenInNewTab())
+	}
+
+	if link.Internal != nil {
+		return datalink.Internal(link.Title, link.Internal.DatasourceUID, link.Internal.DatasourceName, link.Internal.Query, opts...), nil
+	}
+	if link.URL != "" {
+		return datalink.New(link.Title, link.URL, opts...), nil
+	}
+
+	return datalink.Link{}, ErrInvalidDataLink
+}
@@ -0,0 +1,43 @@
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataLinksCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	links := DashboardDataLinks{
+		{Title: "Details", URL: "https://example.org/${__value.raw}", NewTab: true},
+		{
+			Title: "Logs",
+			Internal: &InternalDataLink{
+				DatasourceUID:  "loki-uid",
+				DatasourceName: "Loki",
+				Query:          map[string]interface{}{"expr": `{host="${__value.raw}"}`},
+			},
+		},
+	}
+
+	models, err := links.toModel()
+	req.NoError(err)
+	req.Len(models, 2)
+
+	req.Equal("Details", models[0].Title)
+	req.Equal("https://example.org/${__value.raw}", models[0].URL)
+	req.True(models[0].TargetBlank)
+
+	req.Equal("Logs", models[1].Title)
+	req.NotNil(models[1].Internal)
+	req.Equal("loki-uid", models[1].Internal.DatasourceUID)
+	req.Equal("Loki", models[1].Internal.DatasourceName)
+}
+
+func TestDataLinksWithoutTargetAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := DashboardDataLinks{{Title: "Nowhere"}}.toModel()
+	req.ErrorIs(err, ErrInvalidDataLink)
+}
@@ -0,0 +1,1045 @@
+package decoder
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/datasource/alertmanager"
+	"github.com/K-Phoen/grabana/datasource/cloudwatch"
+	"github.com/K-Phoen/grabana/datasource/elasticsearch"
+	"github.com/K-Phoen/grabana/datasource/graphite"
+	"github.com/K-Phoen/grabana/datasource/influxdb"
+	"github.com/K-Phoen/grabana/datasource/jaeger"
+	"github.com/K-Phoen/grabana/datasource/loki"
+	"github.com/K-Phoen/grabana/datasource/mysql"
+	"github.com/K-Phoen/grabana/datasource/postgres"
+	"github.com/K-Phoen/grabana/datasource/prometheus"
+	"github.com/K-Phoen/grabana/datasource/stackdriver"
+	"github.com/K-Phoen/grabana/datasource/tempo"
+)
+
+var ErrDatasourceNotConfigured = fmt.Errorf("datasource not configured")
+var ErrInvalidSecret = fmt.Errorf("a secret must be read from exactly one of: env, file")
+var ErrSecretNotFound = fmt.Errorf("secret not found")
+var ErrSecretNotReferenceable = fmt.Errorf("secret can not be referenced")
+var ErrInvalidSSLMode = fmt.Errorf("invalid SSL mode. Valid values are: 'disable', 'require', 'verify-ca', 'verify-full'")
+var ErrInvalidIndexInterval = fmt.Errorf("invalid index interval. Valid values are: 'hourly', 'daily', 'weekly', 'monthly', 'yearly', empty")
+var ErrInvalidAlertmanagerImplementation = fmt.Errorf("invalid alertmanager implementation. Valid values are: 'prometheus', 'cortex', 'mimir'")
+var ErrInvalidDatasource = fmt.Errorf("invalid datasource")
+
+// secretResolver turns a secret into the value given to a datasource.
+type secretResolver func(secret Secret) (string, error)
+
+// Secret describes where the value of a secret can be found. Secrets are
+// never written inline.
+type Secret struct {
+	Env  string `yaml:",omitempty"`
+	File string `yaml:",omitempty"`
+}
+
+func (secret Secret) validate() error {
+	if (secret.Env == "") == (secret.File == "") {
+		return ErrInvalidSecret
+	}
+
+	return nil
+}
+
+// resolveSecret reads the value of the secret.
+func resolveSecret(secret Secret) (string, error) {
+	if err := secret.validate(); err != nil {
+		return "", err
+	}
+
+	if secret.Env != "" {
+		value, ok := os.LookupEnv(secret.Env)
+		if !ok {
+			return "", fmt.Errorf("environment variable '%s' is not set: %w", secret.Env, ErrSecretNotFound)
+		}
+
+		return value, nil
+	}
+
+	content, err := os.ReadFile(secret.File)
+	if err != nil {
+		return "", fmt.Errorf("could not read secret file '%s': %w", secret.File, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// referenceSecret references the secret using the syntax understood by
+// Grafana's provisioning files, leaving Grafana in charge of reading it.
+func referenceSecret(secret Secret) (string, error) {
+	if err := secret.validate(); err != nil {
+		return "", err
+	}
+
+	if secret.Env != "" {
+		return fmt.Sprintf("$__env{%s}", secret.Env), nil
+	}
+
+	return fmt.Sprintf("$__file{%s}", secret.File), nil
+}
+
+// isSecretReference tells if the value was returned by referenceSecret()
+// instead of being read.
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, "$__env{") || strings.HasPrefix(value, "$__file{")
+}
+
+type DatasourcesModel struct {
+	Datasources []DatasourceModel
+}
+
+func (model *DatasourcesModel) toDatasources(secrets secretResolver) ([]datasource.Datasource, error) {
+	datasources := make([]datasource.Datasource, 0, len(model.Datasources))
+
+	for _, datasourceModel := range model.Datasources {
+		ds, err := datasourceModel.toDatasource(secrets)
+		if err != nil {
+			return nil, err
+		}
+
+		datasources = append(datasources, ds)
+	}
+
+	return datasources, nil
+}
+
+type DatasourceModel struct {
+	Prometheus    *PrometheusDatasource    `yaml:",omitempty"`
+	Loki          *LokiDatasource          `yaml:",omitempty"`
+	Tempo         *TempoDatasource         `yaml:",omitempty"`
+	Jaeger        *JaegerDatasource        `yaml:",omitempty"`
+	InfluxDB      *InfluxDBDatasource      `yaml:"influxdb,omitempty"`
+	CloudWatch    *CloudWatchDatasource    `yaml:"cloudwatch,omitempty"`
+	Stackdriver   *StackdriverDatasource   `yaml:",omitempty"`
+	Elasticsearch *ElasticsearchDatasource `yaml:",omitempty"`
+	Postgres      *PostgresDatasource      `yaml:",omitempty"`
+	MySQL         *MySQLDatasource         `yaml:"mysql,omitempty"`
+	Graphite      *GraphiteDatasource      `yaml:",omitempty"`
+	Alertmanager  *AlertmanagerDatasource  `yaml:",omitempty"`
+}
+
+func (model DatasourceModel) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	if model.Prometheus != nil {
+		return model.Prometheus.toDatasource(secrets)
+	}
+	if model.Loki != nil {
+		return model.Loki.toDatasource(secrets)
+	}
+	if model.Tempo != nil {
+		return model.Tempo.toDatasource(secrets)
+	}
+	if model.Jaeger != nil {
+		return model.Jaeger.toDatasource(secrets)
+	}
+	if model.InfluxDB != nil {
+		return model.InfluxDB.toDatasource(secrets)
+	}
+	if model.CloudWatch != nil {
+		return model.CloudWatch.toDatasource(secrets)
+	}
+	if model.Stackdriver != nil {
+		return model.Stackdriver.toDatasource(secrets)
+	}
+	if model.Elasticsearch != nil {
+		return model.Elasticsearch.toDatasource(secrets)
+	}
+	if model.Postgres != nil {
+		return model.Postgres.toDatasource(secrets)
+	}
+	if model.MySQL != nil {
+		return model.MySQL.toDatasource(secrets)
+	}
+	if model.Graphite != nil {
+		return model.Graphite.toDatasource(secrets)
+	}
+	if model.Alertmanager != nil {
+		return model.Alertmanager.toDatasource(secrets)
+	}
+
+	return nil, ErrDatasourceNotConfigured
+}
+
+// DatasourceHTTPSettings holds the settings shared by datasources queried
+// over HTTP.
+type DatasourceHTTPSettings struct {
+	Name                 string
+	URL                  string               `yaml:"url"`
+	Default              bool                 `yaml:",omitempty"`
+	BasicAuth            *DatasourceBasicAuth `yaml:"basic_auth,omitempty"`
+	SkipTLSVerify        bool                 `yaml:"skip_tls_verify,omitempty"`
+	CACert               *Secret              `yaml:"ca_cert,omitempty"`
+	WithCredentials      bool                 `yaml:"with_credentials,omitempty"`
+	ForwardOauthIdentity bool                 `yaml:"forward_oauth_identity,omitempty"`
+}
+
+type DatasourceBasicAuth struct {
+	Username string
+	Password Secret
+}
+
+type httpSettings struct {
+	basicAuth         bool
+	basicAuthUser     string
+	basicAuthPassword string
+	caCert            string
+}
+
+func (settings DatasourceHTTPSettings) resolve(secrets secretResolver) (httpSettings, error) {
+	resolved := httpSettings{}
+
+	if settings.BasicAuth != nil {
+		password, err := secrets(settings.BasicAuth.Password)
+		if err != nil {
+			return resolved, err
+		}
+
+		resolved.basicAuth = true
+		resolved.basicAuthUser = settings.BasicAuth.Username
+		resolved.basicAuthPassword = password
+	}
+
+	if settings.CACert != nil {
+		cert, err := secrets(*settings.CACert)
+		if err != nil {
+			return resolved, err
+		}
+
+		resolved.caCert = cert
+	}
+
+	return resolved, nil
+}
+
+type PrometheusDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	// Valid values are: GET, POST
+	HTTPMethod     string `yaml:"http_method,omitempty"`
+	ScrapeInterval string `yaml:"scrape_interval,omitempty"`
+	QueryTimeout   string `yaml:"query_timeout,omitempty"`
+}
+
+func (ds *PrometheusDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []prometheus.Option
+	if ds.Default {
+		opts = append(opts, prometheus.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, prometheus.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, prometheus.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, prometheus.WithCertificate(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, prometheus.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, prometheus.ForwardOauthIdentity())
+	}
+	if ds.HTTPMethod != "" {
+		opts = append(opts, prometheus.HTTPMethod(ds.HTTPMethod))
+	}
+	if ds.ScrapeInterval != "" {
+		interval, err := parseDuration(ds.ScrapeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, prometheus.ScrapeInterval(interval))
+	}
+	if ds.QueryTimeout != "" {
+		timeout, err := parseDuration(ds.QueryTimeout)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, prometheus.QueryTimeout(timeout))
+	}
+
+	return prometheus.New(ds.Name, ds.URL, opts...)
+}
+
+type LokiDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	Timeout       string             `yaml:",omitempty"`
+	MaximumLines  int                `yaml:"maximum_lines,omitempty"`
+	DerivedFields []LokiDerivedField `yaml:"derived_fields,omitempty"`
+}
+
+type LokiDerivedField struct {
+	Name  string
+	URL   string `yaml:"url"`
+	Regex string
+	// Optional
+	URLLabel string `yaml:"url_label,omitempty"`
+	// Optional, for internal links
+	DatasourceUID string `yaml:"datasource_uid,omitempty"`
+}
+
+func (ds *LokiDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []loki.Option
+	if ds.Default {
+		opts = append(opts, loki.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, loki.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, loki.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, loki.WithCertificate(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, loki.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, loki.ForwardOauthIdentity())
+	}
+	if ds.Timeout != "" {
+		timeout, err := parseDuration(ds.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, loki.Timeout(timeout))
+	}
+	if ds.MaximumLines != 0 {
+		opts = append(opts, loki.MaximumLines(ds.MaximumLines))
+	}
+	if len(ds.DerivedFields) != 0 {
+		fields := make([]loki.DerivedField, 0, len(ds.DerivedFields))
+		for _, field := range ds.DerivedFields {
+			fields = append(fields, loki.DerivedField{
+				Name:            field.Name,
+				URL:             field.URL,
+				Regex:           field.Regex,
+				URLDisplayLabel: field.URLLabel,
+				DatasourceUID:   field.DatasourceUID,
+			})
+		}
+
+		opts = append(opts, loki.DerivedFields(fields...))
+	}
+
+	return loki.New(ds.Name, ds.URL, opts...), nil
+}
+
+type TracingDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	Timeout     string                 `yaml:",omitempty"`
+	NodeGraph   bool                   `yaml:"node_graph,omitempty"`
+	TraceToLogs *DatasourceTraceToLogs `yaml:"trace_to_logs,omitempty"`
+}
+
+type DatasourceTraceToLogs struct {
+	DatasourceUID  string   `yaml:"datasource_uid"`
+	Tags           []string `yaml:",omitempty,flow"`
+	SpanStartShift string   `yaml:"span_start_shift,omitempty"`
+	SpanEndShift   string   `yaml:"span_end_shift,omitempty"`
+	FilterByTrace  bool     `yaml:"filter_by_trace,omitempty"`
+	FilterBySpan   bool     `yaml:"filter_by_span,omitempty"`
+}
+
+type TempoDatasource struct {
+	TracingDatasource `yaml:",inline"`
+}
+
+func (ds *TempoDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []tempo.Option
+	if ds.Default {
+		opts = append(opts, tempo.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, tempo.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, tempo.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, tempo.WithCertificate(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, tempo.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, tempo.ForwardOauthIdentity())
+	}
+	if ds.Timeout != "" {
+		timeout, err := parseDuration(ds.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, tempo.Timeout(timeout))
+	}
+	if ds.NodeGraph {
+		opts = append(opts, tempo.WithNodeGraph())
+	}
+	if ds.TraceToLogs != nil {
+		var traceOpts []tempo.TraceToLogsOption
+
+		if len(ds.TraceToLogs.Tags) != 0 {
+			traceOpts = append(traceOpts, tempo.Tags(ds.TraceToLogs.Tags...))
+		}
+		if ds.TraceToLogs.SpanStartShift != "" {
+			shift, err := parseDuration(ds.TraceToLogs.SpanStartShift)
+			if err != nil {
+				return nil, err
+			}
+
+			traceOpts = append(traceOpts, tempo.SpanStartShift(shift))
+		}
+		if ds.TraceToLogs.SpanEndShift != "" {
+			shift, err := parseDuration(ds.TraceToLogs.SpanEndShift)
+			if err != nil {
+				return nil, err
+			}
+
+			traceOpts = append(traceOpts, tempo.SpanEndShift(shift))
+		}
+		if ds.TraceToLogs.FilterByTrace {
+			traceOpts = append(traceOpts, tempo.FilterByTrace())
+		}
+		if ds.TraceToLogs.FilterBySpan {
+			traceOpts = append(traceOpts, tempo.FilterBySpan())
+		}
+
+		opts = append(opts, tempo.TraceToLogs(ds.TraceToLogs.DatasourceUID, traceOpts...))
+	}
+
+	return tempo.New(ds.Name, ds.URL, opts...), nil
+}
+
+type JaegerDatasource struct {
+	TracingDatasource `yaml:",inline"`
+}
+
+func (ds *JaegerDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []jaeger.Option
+	if ds.Default {
+		opts = append(opts, jaeger.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, jaeger.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, jaeger.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, jaeger.WithCertificate(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, jaeger.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, jaeger.ForwardOauthIdentity())
+	}
+	if ds.Timeout != "" {
+		timeout, err := parseDuration(ds.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, jaeger.Timeout(timeout))
+	}
+	if ds.NodeGraph {
+		opts = append(opts, jaeger.WithNodeGraph())
+	}
+	if ds.TraceToLogs != nil {
+		var traceOpts []jaeger.TraceToLogsOption
+
+		if len(ds.TraceToLogs.Tags) != 0 {
+			traceOpts = append(traceOpts, jaeger.Tags(ds.TraceToLogs.Tags...))
+		}
+		if ds.TraceToLogs.SpanStartShift != "" {
+			shift, err := parseDuration(ds.TraceToLogs.SpanStartShift)
+			if err != nil {
+				return nil, err
+			}
+
+			traceOpts = append(traceOpts, jaeger.SpanStartShift(shift))
+		}
+		if ds.TraceToLogs.SpanEndShift != "" {
+			shift, err := parseDuration(ds.TraceToLogs.SpanEndShift)
+			if err != nil {
+				return nil, err
+			}
+
+			traceOpts = append(traceOpts, jaeger.SpanEndShift(shift))
+		}
+		if ds.TraceToLogs.FilterByTrace {
+			traceOpts = append(traceOpts, jaeger.FilterByTrace())
+		}
+		if ds.TraceToLogs.FilterBySpan {
+			traceOpts = append(traceOpts, jaeger.FilterBySpan())
+		}
+
+		opts = append(opts, jaeger.TraceToLogs(ds.TraceToLogs.DatasourceUID, traceOpts...))
+	}
+
+	return jaeger.New(ds.Name, ds.URL, opts...), nil
+}
+
+type InfluxDBDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	Database string  `yaml:",omitempty"`
+	User     string  `yaml:",omitempty"`
+	Password *Secret `yaml:",omitempty"`
+	// Valid values are: GET, POST
+	HTTPMethod      string `yaml:"http_method,omitempty"`
+	MaxSeries       int    `yaml:"max_series,omitempty"`
+	MinTimeInterval string `yaml:"min_time_interval,omitempty"`
+	Timeout         string `yaml:",omitempty"`
+}
+
+func (ds *InfluxDBDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []influxdb.Option
+	if ds.Default {
+		opts = append(opts, influxdb.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, influxdb.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, influxdb.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, influxdb.WithCACert(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, influxdb.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, influxdb.ForwardOauthIdentity())
+	}
+	if ds.Database != "" {
+		opts = append(opts, influxdb.Database(ds.Database))
+	}
+	if ds.User != "" {
+		opts = append(opts, influxdb.User(ds.User))
+	}
+	if ds.Password != nil {
+		password, err := secrets(*ds.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, influxdb.Password(password))
+	}
+	if ds.HTTPMethod != "" {
+		opts = append(opts, influxdb.HTTPMethod(ds.HTTPMethod))
+	}
+	if ds.MaxSeries != 0 {
+		opts = append(opts, influxdb.MaxSeries(ds.MaxSeries))
+	}
+	if ds.MinTimeInterval != "" {
+		interval, err := parseDuration(ds.MinTimeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, influxdb.MinTimeInterval(interval))
+	}
+	if ds.Timeout != "" {
+		timeout, err := parseDuration(ds.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, influxdb.Timeout(timeout))
+	}
+
+	return influxdb.New(ds.Name, ds.URL, opts...)
+}
+
+type CloudWatchDatasource struct {
+	Name    string
+	Default bool `yaml:",omitempty"`
+
+	// Authenticates using the AWS SDK default credentials chain if not set.
+	AccessKey *Secret `yaml:"access_key,omitempty"`
+	SecretKey *Secret `yaml:"secret_key,omitempty"`
+
+	Region                  string   `yaml:",omitempty"`
+	AssumeRoleARN           string   `yaml:"assume_role_arn,omitempty"`
+	ExternalID              string   `yaml:"external_id,omitempty"`
+	Endpoint                string   `yaml:",omitempty"`
+	CustomMetricsNamespaces []string `yaml:"custom_metrics_namespaces,omitempty,flow"`
+}
+
+func (ds *CloudWatchDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	var opts []cloudwatch.Option
+
+	if ds.Default {
+		opts = append(opts, cloudwatch.Default())
+	}
+
+	if (ds.AccessKey == nil) != (ds.SecretKey == nil) {
+		return nil, fmt.Errorf("cloudwatch access and secret keys must be set together: %w", ErrInvalidSecret)
+	}
+	if ds.AccessKey != nil {
+		accessKey, err := secrets(*ds.AccessKey)
+		if err != nil {
+			return nil, err
+		}
+		secretKey, err := secrets(*ds.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, cloudwatch.AccessSecretAuth(accessKey, secretKey))
+	}
+
+	if ds.Region != "" {
+		opts = append(opts, cloudwatch.DefaultRegion(ds.Region))
+	}
+	if ds.AssumeRoleARN != "" {
+		opts = append(opts, cloudwatch.AssumeRoleARN(ds.AssumeRoleARN))
+	}
+	if ds.ExternalID != "" {
+		opts = append(opts, cloudwatch.ExternalID(ds.ExternalID))
+	}
+	if ds.Endpoint != "" {
+		opts = append(opts, cloudwatch.Endpoint(ds.Endpoint))
+	}
+	if len(ds.CustomMetricsNamespaces) != 0 {
+		opts = append(opts, cloudwatch.CustomMetricsNamespaces(ds.CustomMetricsNamespaces...))
+	}
+
+	return cloudwatch.New(ds.Name, opts...)
+}
+
+type StackdriverDatasource struct {
+	Name    string
+	Default bool `yaml:",omitempty"`
+
+	// Service account key. Uses the GCE default service account if not set.
+	// The content of the key is needed to configure the datasource: it can
+	// not be referenced in provisioning files.
+	JWT *Secret `yaml:"jwt,omitempty"`
+}
+
+func (ds *StackdriverDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	var opts []stackdriver.Option
+
+	if ds.Default {
+		opts = append(opts, stackdriver.Default())
+	}
+
+	if ds.JWT != nil {
+		jwt, err := secrets(*ds.JWT)
+		if err != nil {
+			return nil, err
+		}
+		if isSecretReference(jwt) {
+			return nil, fmt.Errorf("the service account key of stackdriver datasource '%s' is needed to configure it: %w", ds.Name, ErrSecretNotReferenceable)
+		}
+
+		opts = append(opts, stackdriver.JWTAuthentication(jwt))
+	}
+
+	return stackdriver.New(ds.Name, opts...)
+}
+
+type ElasticsearchDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	Index string `yaml:",omitempty"`
+	// Valid values are: hourly, daily, weekly, monthly, yearly
+	IndexInterval              string `yaml:"index_interval,omitempty"`
+	TimeField                  string `yaml:"time_field,omitempty"`
+	Version                    string `yaml:",omitempty"`
+	OpenSearchVersion          string `yaml:"opensearch_version,omitempty"`
+	MaxConcurrentShardRequests int    `yaml:"max_concurrent_shard_requests,omitempty"`
+	LogMessageField            string `yaml:"log_message_field,omitempty"`
+	LogLevelField              string `yaml:"log_level_field,omitempty"`
+	MinTimeInterval            string `yaml:"min_time_interval,omitempty"`
+}
+
+func (ds *ElasticsearchDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []elasticsearch.Option
+	if ds.Default {
+		opts = append(opts, elasticsearch.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, elasticsearch.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, elasticsearch.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, elasticsearch.WithCACert(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, elasticsearch.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, elasticsearch.ForwardOauthIdentity())
+	}
+	if ds.Index != "" {
+		interval, err := ds.indexInterval()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, elasticsearch.Index(ds.Index, interval))
+	}
+	if ds.TimeField != "" {
+		opts = append(opts, elasticsearch.TimeField(ds.TimeField))
+	}
+	if ds.Version != "" {
+		opts = append(opts, elasticsearch.Version(ds.Version))
+	}
+	if ds.OpenSearchVersion != "" {
+		opts = append(opts, elasticsearch.OpenSearch(ds.OpenSearchVersion))
+	}
+	if ds.MaxConcurrentShardRequests != 0 {
+		opts = append(opts, elasticsearch.MaxConcurrentShardRequests(ds.MaxConcurrentShardRequests))
+	}
+	if ds.LogMessageField != "" {
+		opts = append(opts, elasticsearch.LogMessageField(ds.LogMessageField))
+	}
+	if ds.LogLevelField != "" {
+		opts = append(opts, elasticsearch.LogLevelField(ds.LogLevelField))
+	}
+	if ds.MinTimeInterval != "" {
+		interval, err := parseDuration(ds.MinTimeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, elasticsearch.MinTimeInterval(interval))
+	}
+
+	return elasticsearch.New(ds.Name, ds.URL, opts...)
+}
+
+func (ds *ElasticsearchDatasource) indexInterval() (elasticsearch.IndexInterval, error) {
+	switch ds.IndexInterval {
+	case "":
+		return elasticsearch.NoInterval, nil
+	case "hourly":
+		return elasticsearch.Hourly, nil
+	case "daily":
+		return elasticsearch.Daily, nil
+	case "weekly":
+		return elasticsearch.Weekly, nil
+	case "monthly":
+		return elasticsearch.Monthly, nil
+	case "yearly":
+		return elasticsearch.Yearly, nil
+	default:
+		return elasticsearch.NoInterval, ErrInvalidIndexInterval
+	}
+}
+
+// DatasourceSQLSettings holds the settings shared by SQL datasources.
+type DatasourceSQLSettings struct {
+	Name            string
+	URL             string  `yaml:"url"`
+	Default         bool    `yaml:",omitempty"`
+	Database        string  `yaml:",omitempty"`
+	User            string  `yaml:",omitempty"`
+	Password        *Secret `yaml:",omitempty"`
+	MaxOpenConns    *int    `yaml:"max_open_conns,omitempty"`
+	MaxIdleConns    *int    `yaml:"max_idle_conns,omitempty"`
+	ConnMaxLifetime string  `yaml:"conn_max_lifetime,omitempty"`
+	MinTimeInterval string  `yaml:"min_time_interval,omitempty"`
+}
+
+type PostgresDatasource struct {
+	DatasourceSQLSettings `yaml:",inline"`
+
+	// Valid values are: disable, require, verify-ca, verify-full
+	SSLMode     string `yaml:"ssl_mode,omitempty"`
+	Version     int    `yaml:",omitempty"`
+	TimescaleDB bool   `yaml:"timescaledb,omitempty"`
+}
+
+func (ds *PostgresDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	var opts []postgres.Option
+
+	if ds.Default {
+		opts = append(opts, postgres.Default())
+	}
+	if ds.Database != "" {
+		opts = append(opts, postgres.Database(ds.Database))
+	}
+	if ds.User != "" {
+		opts = append(opts, postgres.User(ds.User))
+	}
+	if ds.Password != nil {
+		password, err := secrets(*ds.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, postgres.Password(password))
+	}
+	if ds.MaxOpenConns != nil {
+		opts = append(opts, postgres.MaxOpenConns(*ds.MaxOpenConns))
+	}
+	if ds.MaxIdleConns != nil {
+		opts = append(opts, postgres.MaxIdleConns(*ds.MaxIdleConns))
+	}
+	if ds.ConnMaxLifetime != "" {
+		lifetime, err := parseDuration(ds.ConnMaxLifetime)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, postgres.ConnMaxLifetime(lifetime))
+	}
+	if ds.MinTimeInterval != "" {
+		interval, err := parseDuration(ds.MinTimeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, postgres.MinTimeInterval(interval))
+	}
+
+	switch ds.SSLMode {
+	case "":
+		// Nothing to do
+		break
+	case "disable":
+		opts = append(opts, postgres.WithSSLMode(postgres.SSLDisable))
+	case "require":
+		opts = append(opts, postgres.WithSSLMode(postgres.SSLRequire))
+	case "verify-ca":
+		opts = append(opts, postgres.WithSSLMode(postgres.SSLVerifyCA))
+	case "verify-full":
+		opts = append(opts, postgres.WithSSLMode(postgres.SSLVerifyFull))
+	default:
+		return nil, ErrInvalidSSLMode
+	}
+
+	if ds.Version != 0 {
+		opts = append(opts, postgres.PostgresVersion(ds.Version))
+	}
+	if ds.TimescaleDB {
+		opts = append(opts, postgres.TimescaleDB())
+	}
+
+	return postgres.New(ds.Name, ds.URL, opts...)
+}
+
+type MySQLDatasource struct {
+	DatasourceSQLSettings `yaml:",inline"`
+
+	Timezone      string  `yaml:",omitempty"`
+	SkipTLSVerify bool    `yaml:"skip_tls_verify,omitempty"`
+	CACert        *Secret `yaml:"ca_cert,omitempty"`
+}
+
+func (ds *MySQLDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	var opts []mysql.Option
+
+	if ds.Default {
+		opts = append(opts, mysql.Default())
+	}
+	if ds.Database != "" {
+		opts = append(opts, mysql.Database(ds.Database))
+	}
+	if ds.User != "" {
+		opts = append(opts, mysql.User(ds.User))
+	}
+	if ds.Password != nil {
+		password, err := secrets(*ds.Password)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mysql.Password(password))
+	}
+	if ds.MaxOpenConns != nil {
+		opts = append(opts, mysql.MaxOpenConns(*ds.MaxOpenConns))
+	}
+	if ds.MaxIdleConns != nil {
+		opts = append(opts, mysql.MaxIdleConns(*ds.MaxIdleConns))
+	}
+	if ds.ConnMaxLifetime != "" {
+		lifetime, err := parseDuration(ds.ConnMaxLifetime)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mysql.ConnMaxLifetime(lifetime))
+	}
+	if ds.MinTimeInterval != "" {
+		interval, err := parseDuration(ds.MinTimeInterval)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mysql.MinTimeInterval(interval))
+	}
+	if ds.Timezone != "" {
+		opts = append(opts, mysql.Timezone(ds.Timezone))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, mysql.SkipTLSVerify())
+	}
+	if ds.CACert != nil {
+		cert, err := secrets(*ds.CACert)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, mysql.WithCACert(cert))
+	}
+
+	return mysql.New(ds.Name, ds.URL, opts...)
+}
+
+type GraphiteDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	Version    string `yaml:",omitempty"`
+	Metrictank bool   `yaml:",omitempty"`
+}
+
+func (ds *GraphiteDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []graphite.Option
+	if ds.Default {
+		opts = append(opts, graphite.Default())
+	}
+	if settings.basicAuth {
+		opts = append(opts, graphite.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, graphite.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, graphite.WithCACert(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, graphite.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, graphite.ForwardOauthIdentity())
+	}
+	if ds.Version != "" {
+		opts = append(opts, graphite.Version(ds.Version))
+	}
+	if ds.Metrictank {
+		opts = append(opts, graphite.Metrictank())
+	}
+
+	return graphite.New(ds.Name, ds.URL, opts...)
+}
+
+type AlertmanagerDatasource struct {
+	DatasourceHTTPSettings `yaml:",inline"`
+
+	// Valid values are: prometheus, cortex, mimir
+	Implementation             string `yaml:",omitempty"`
+	HandleGrafanaManagedAlerts bool   `yaml:"handle_grafana_managed_alerts,omitempty"`
+}
+
+func (ds *AlertmanagerDatasource) toDatasource(secrets secretResolver) (datasource.Datasource, error) {
+	if ds.Default {
+		return nil, fmt.Errorf("alertmanager datasources can not be the default one: %w", ErrInvalidDatasource)
+	}
+
+	settings, err := ds.resolve(secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []alertmanager.Option
+	if settings.basicAuth {
+		opts = append(opts, alertmanager.BasicAuth(settings.basicAuthUser, settings.basicAuthPassword))
+	}
+	if ds.SkipTLSVerify {
+		opts = append(opts, alertmanager.SkipTLSVerify())
+	}
+	if settings.caCert != "" {
+		opts = append(opts, alertmanager.WithCACert(settings.caCert))
+	}
+	if ds.WithCredentials {
+		opts = append(opts, alertmanager.WithCredentials())
+	}
+	if ds.ForwardOauthIdentity {
+		opts = append(opts, alertmanager.ForwardOauthIdentity())
+	}
+
+	switch ds.Implementation {
+	case "":
+		// Nothing to do
+		break
+	case "prometheus":
+		opts = append(opts, alertmanager.WithImplementation(alertmanager.Prometheus))
+	case "cortex":
+		opts = append(opts, alertmanager.WithImplementation(alertmanager.Cortex))
+	case "mimir":
+		opts = append(opts, alertmanager.WithImplementation(alertmanager.Mimir))
+	default:
+		return nil, ErrInvalidAlertmanagerImplementation
+	}
+
+	if ds.HandleGrafanaManagedAlerts {
+		opts = append(opts, alertmanager.HandleGrafanaManagedAlerts())
+	}
+
+	return alertmanager.New(ds.Name, ds.URL, opts...)
+}
+
+func parseDuration(input string) (time.Duration, error) {
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': %w", input, err)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,250 @@
+package decoder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func datasourceJSON(t *testing.T, ds datasource.Datasource) map[string]interface{} {
+	t.Helper()
+
+	content, err := ds.MarshalJSON()
+	require.NoError(t, err)
+
+	parsed := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(content, &parsed))
+
+	return parsed
+}
+
+func TestEveryDatasourceCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	input := `
+datasources:
+  - prometheus: {name: prometheus, url: http://prometheus:9090}
+  - loki: {name: loki, url: http://loki:3100}
+  - tempo: {name: tempo, url: http://tempo:3200}
+  - jaeger: {name: jaeger, url: http://jaeger:16686}
+  - influxdb: {name: influxdb, url: http://influxdb:8086}
+  - cloudwatch: {name: cloudwatch}
+  - stackdriver: {name: stackdriver}
+  - elasticsearch: {name: elasticsearch, url: http://elasticsearch:9200}
+  - postgres: {name: postgres, url: postgres:5432}
+  - mysql: {name: mysql, url: mysql:3306}
+  - graphite: {name: graphite, url: http://graphite}
+  - alertmanager: {name: alertmanager, url: http://alertmanager:9093}
+`
+
+	datasources, err := UnmarshalDatasourcesYAML(strings.NewReader(input))
+	req.NoError(err)
+
+	expectedTypes := []string{
+		"prometheus", "loki", "tempo", "jaeger", "influxdb", "cloudwatch",
+		"stackdriver", "elasticsearch", "postgres", "mysql", "graphite", "alertmanager",
+	}
+
+	req.Len(datasources, len(expectedTypes))
+	for i, expectedType := range expectedTypes {
+		req.Equal(expectedType, datasources[i].Name())
+		req.Equal(expectedType, datasourceJSON(t, datasources[i])["type"])
+	}
+}
+
+func TestDatasourcesMustBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	_, err := UnmarshalDatasourcesYAML(strings.NewReader("datasources: [{}]"))
+
+	req.ErrorIs(err, ErrDatasourceNotConfigured)
+}
+
+func TestSecretsAreReadFromTheEnvironment(t *testing.T) {
+	req := require.New(t)
+	t.Setenv("GRABANA_TEST_PASSWORD", "s3cr3t")
+
+	input := `
+datasources:
+  - prometheus:
+      name: prometheus
+      url: http://prometheus:9090
+      basic_auth:
+        username: admin
+        password: {env: GRABANA_TEST_PASSWORD}
+`
+
+	datasources, err := UnmarshalDatasourcesYAML(strings.NewReader(input))
+	req.NoError(err)
+
+	parsed := datasourceJSON(t, datasources[0])
+	req.Equal("admin", parsed["basicAuthUser"])
+	req.Equal("s3cr3t", parsed["basicAuthPassword"])
+}
+
+func TestSecretsAreReadFromFiles(t *testing.T) {
+	req := require.New(t)
+
+	secretFile := filepath.Join(t.TempDir(), "password")
+	req.NoError(os.WriteFile(secretFile, []byte("s3cr3t\n"), 0600))
+
+	ds := PostgresDatasource{
+		DatasourceSQLSettings: DatasourceSQLSettings{
+			Name:     "postgres",
+			URL:      "postgres:5432",
+			Password: &Secret{File: secretFile},
+		},
+	}
+
+	decoded, err := ds.toDatasource(resolveSecret)
+	req.NoError(err)
+
+	parsed := datasourceJSON(t, decoded)
+	req.Equal("s3cr3t", parsed["secureJsonData"].(map[string]interface{})["password"])
+}
+
+func TestMissingSecretsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	ds := InfluxDBDatasource{
+		DatasourceHTTPSettings: DatasourceHTTPSettings{Name: "influxdb"},
+		Password:               &Secret{Env: "GRABANA_TEST_UNDEFINED_VARIABLE"},
+	}
+
+	_, err := ds.toDatasource(resolveSecret)
+
+	req.ErrorIs(err, ErrSecretNotFound)
+}
+
+func TestSecretsMustHaveASingleSource(t *testing.T) {
+	testCases := map[string]Secret{
+		"no source":   {},
+		"two sources": {Env: "PASSWORD", File: "/run/secrets/password"},
+	}
+
+	for name, testCase := range testCases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := require.New(t)
+
+			_, err := resolveSecret(tc)
+			req.ErrorIs(err, ErrInvalidSecret)
+
+			_, err = referenceSecret(tc)
+			req.ErrorIs(err, ErrInvalidSecret)
+		})
+	}
+}
+
+func TestSecretsCanBeReferencedForProvisioning(t *testing.T) {
+	req := require.New(t)
+
+	input := `
+datasources:
+  - mysql:
+      name: mysql
+      url: mysql:3306
+      password: {file: /run/secrets/mysql}
+  - cloudwatch:
+      name: cloudwatch
+      access_key: {env: AWS_ACCESS_KEY_ID}
+      secret_key: {env: AWS_SECRET_ACCESS_KEY}
+`
+
+	datasources, err := UnmarshalDatasourcesYAMLForProvisioning(strings.NewReader(input))
+	req.NoError(err)
+
+	mysql := datasourceJSON(t, datasources[0])
+	req.Equal("$__file{/run/secrets/mysql}", mysql["secureJsonData"].(map[string]interface{})["password"])
+
+	cloudwatch := datasourceJSON(t, datasources[1])
+	req.Equal("$__env{AWS_ACCESS_KEY_ID}", cloudwatch["secureJsonData"].(map[string]interface{})["accessKey"])
+	req.Equal("$__env{AWS_SECRET_ACCESS_KEY}", cloudwatch["secureJsonData"].(map[string]interface{})["secretKey"])
+}
+
+func TestStackdriverKeysAreNeverWrittenInProvisioningFiles(t *testing.T) {
+	req := require.New(t)
+
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	req.NoError(os.WriteFile(keyFile, []byte(`{"client_email": "grafana@project.iam.gserviceaccount.com", "private_key": "SOMETHING_REALLY_SECRET"}`), 0600))
+
+	input := fmt.Sprintf(`
+datasources:
+  - stackdriver:
+      name: stackdriver
+      jwt: {file: %s}
+`, keyFile)
+
+	datasources, err := UnmarshalDatasourcesYAMLForProvisioning(strings.NewReader(input))
+
+	req.ErrorIs(err, ErrSecretNotReferenceable)
+	req.Empty(datasources)
+	req.NotContains(err.Error(), "SOMETHING_REALLY_SECRET")
+
+	datasources, err = UnmarshalDatasourcesYAML(strings.NewReader(input))
+	req.NoError(err)
+	req.Equal("SOMETHING_REALLY_SECRET", datasourceJSON(t, datasources[0])["secureJsonData"].(map[string]interface{})["privateKey"])
+}
+
+func TestCloudWatchKeysMustBeSetTogether(t *testing.T) {
+	req := require.New(t)
+
+	ds := CloudWatchDatasource{Name: "cloudwatch", AccessKey: &Secret{Env: "AWS_ACCESS_KEY_ID"}}
+
+	_, err := ds.toDatasource(referenceSecret)
+
+	req.ErrorIs(err, ErrInvalidSecret)
+}
+
+func TestInvalidDatasourceValuesAreRejected(t *testing.T) {
+	testCases := map[string]struct {
+		model    DatasourceModel
+		expected error
+	}{
+		"ssl mode": {
+			model:    DatasourceModel{Postgres: &PostgresDatasource{SSLMode: "maybe"}},
+			expected: ErrInvalidSSLMode,
+		},
+		"index interval": {
+			model:    DatasourceModel{Elasticsearch: &ElasticsearchDatasource{Index: "logs", IndexInterval: "often"}},
+			expected: ErrInvalidIndexInterval,
+		},
+		"alertmanager implementation": {
+			model:    DatasourceModel{Alertmanager: &AlertmanagerDatasource{Implementation: "other"}},
+			expected: ErrInvalidAlertmanagerImplementation,
+		},
+		"default alertmanager": {
+			model:    DatasourceModel{Alertmanager: &AlertmanagerDatasource{DatasourceHTTPSettings: DatasourceHTTPSettings{Default: true}}},
+			expected: ErrInvalidDatasource,
+		},
+	}
+
+	for name, testCase := range testCases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := require.New(t)
+
+			_, err := tc.model.toDatasource(referenceSecret)
+
+			req.ErrorIs(err, tc.expected)
+		})
+	}
+}
+
+func TestDatasourcesWithInvalidDurationsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	ds := PrometheusDatasource{ScrapeInterval: "often"}
+
+	_, err := ds.toDatasource(referenceSecret)
+
+	req.Error(err)
+}
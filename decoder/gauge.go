@@ -18,14 +18,17 @@ type GaugeThresholdStep struct {
 
 type DashboardGauge struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	ValueMappings   DashboardValueMappings   `yaml:"value_mappings,omitempty"`
+	DataLinks       DashboardDataLinks       `yaml:"data_links,omitempty"`
 	Targets         []Target
 
 	Unit     string `yaml:",omitempty"`
@@ -71,6 +74,30 @@ func (gaugePanel DashboardGauge) toOption() (row.Option, error) {
 	if len(gaugePanel.Links) != 0 {
 		opts = append(opts, gauge.Links(gaugePanel.Links.toModel()...))
 	}
+	if len(gaugePanel.Transformations) != 0 {
+		transformations, err := gaugePanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, gauge.Transformations(transformations...))
+	}
+	if len(gaugePanel.ValueMappings) != 0 {
+		mappings, err := gaugePanel.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, gauge.ValueMappings(mappings...))
+	}
+	if len(gaugePanel.DataLinks) != 0 {
+		dataLinks, err := gaugePanel.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, gauge.DataLinks(dataLinks...))
+	}
 	if gaugePanel.Unit != "" {
 		opts = append(opts, gauge.Unit(gaugePanel.Unit))
 	}
@@ -203,5 +230,25 @@ func (gaugePanel DashboardGauge) target(t Target) (gauge.Option, error) {
 		return gauge.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return gauge.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return gauge.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return gauge.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
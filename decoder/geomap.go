@@ -0,0 +1,409 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/geomap"
+	"github.com/K-Phoen/grabana/geomap/layer"
+	"github.com/K-Phoen/grabana/row"
+)
+
+var ErrInvalidGeomapBaseLayer = fmt.Errorf("invalid geomap base layer")
+var ErrInvalidGeomapLayer = fmt.Errorf("invalid geomap layer")
+var ErrInvalidGeomapLocationMode = fmt.Errorf("invalid geomap location mode")
+var ErrInvalidGeomapArrowMode = fmt.Errorf("invalid geomap arrow mode")
+
+type DashboardGeomap struct {
+	Title           string
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	Targets         []Target                 `yaml:",omitempty"`
+
+	BaseLayer *GeomapBaseLayer `yaml:"base_layer,omitempty"`
+	Layers    []GeomapLayer    `yaml:",omitempty"`
+	View      *GeomapView      `yaml:",omitempty"`
+	Controls  *GeomapControls  `yaml:",omitempty"`
+}
+
+type GeomapBaseLayer struct {
+	// Type of base layer. Valid values are: default, osm, carto, arcgis, xyz
+	Type string
+	// Theme used by "carto" base layers. Valid values are: auto, light, dark
+	Theme string `yaml:",omitempty"`
+	// Server used by "arcgis" base layers. Valid values are: streets,
+	// world-imagery, world-physical, topo, usa-topo, ocean
+	Server string `yaml:",omitempty"`
+	// URL of the tile server used by "xyz" base layers.
+	URL         string `yaml:"url,omitempty"`
+	Attribution string `yaml:",omitempty"`
+}
+
+type GeomapLayer struct {
+	Markers *GeomapDataLayer `yaml:",omitempty"`
+	Heatmap *GeomapDataLayer `yaml:",omitempty"`
+	Route   *GeomapDataLayer `yaml:",omitempty"`
+}
+
+type GeomapDataLayer struct {
+	Name        string           `yaml:",omitempty"`
+	Location    *GeomapLocation  `yaml:",omitempty"`
+	Size        *GeomapDimension `yaml:",omitempty"`
+	Color       *GeomapColor     `yaml:",omitempty"`
+	Opacity     *float64         `yaml:",omitempty"`
+	ShowLegend  bool             `yaml:"show_legend,omitempty"`
+	HideTooltip bool             `yaml:"hide_tooltip,omitempty"`
+
+	// Radius of each point, for heatmap layers.
+	Radius *int `yaml:",omitempty"`
+	// Blur of each point, for heatmap layers.
+	Blur *int `yaml:",omitempty"`
+	// Arrows drawn along route layers. Valid values are: none, forward, backward
+	Arrows string `yaml:",omitempty"`
+}
+
+type GeomapLocation struct {
+	// Mode used to locate data points. Valid values are: auto, coords, geohash, lookup
+	Mode      string
+	Latitude  string `yaml:",omitempty"`
+	Longitude string `yaml:",omitempty"`
+	Geohash   string `yaml:",omitempty"`
+	Lookup    string `yaml:",omitempty"`
+	// Gazetteer used by the "lookup" mode. Either one of countries, usa_states,
+	// airports, spatial_codes or the path to a custom gazetteer.
+	Gazetteer string `yaml:",omitempty"`
+}
+
+type GeomapDimension struct {
+	Fixed *float64 `yaml:",omitempty"`
+	Field string   `yaml:",omitempty"`
+	Min   float64  `yaml:",omitempty"`
+	Max   float64  `yaml:",omitempty"`
+}
+
+type GeomapColor struct {
+	Fixed string `yaml:",omitempty"`
+	Field string `yaml:",omitempty"`
+}
+
+type GeomapView struct {
+	// Center of the initial view, as [latitude, longitude].
+	Center    *[2]float64 `yaml:",omitempty,flow"`
+	Zoom      float64     `yaml:",omitempty"`
+	FitToData bool        `yaml:"fit_to_data,omitempty"`
+}
+
+type GeomapControls struct {
+	HideZoom              bool `yaml:"hide_zoom,omitempty"`
+	DisableMouseWheelZoom bool `yaml:"disable_mouse_wheel_zoom,omitempty"`
+	ShowScale             bool `yaml:"show_scale,omitempty"`
+}
+
+func (panel DashboardGeomap) toOption() (row.Option, error) {
+	opts, err := panel.toOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return row.WithGeomap(panel.Title, opts...), nil
+}
+
+func (panel DashboardGeomap) toOptions() ([]geomap.Option, error) {
+	opts := []geomap.Option{}
+
+	if panel.Description != "" {
+		opts = append(opts, geomap.Description(panel.Description))
+	}
+	if panel.Span != 0 {
+		opts = append(opts, geomap.Span(panel.Span))
+	}
+	if panel.Height != "" {
+		opts = append(opts, geomap.Height(panel.Height))
+	}
+	if panel.Transparent {
+		opts = append(opts, geomap.Transparent())
+	}
+	if panel.Datasource != "" {
+		opts = append(opts, geomap.DataSource(panel.Datasource))
+	}
+	if panel.Repeat != "" {
+		opts = append(opts, geomap.Repeat(panel.Repeat))
+	}
+	if panel.RepeatDirection != "" {
+		direction, err := parsePanelRepeatDirection(panel.RepeatDirection)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, geomap.RepeatDirection(direction))
+	}
+	if len(panel.Links) != 0 {
+		opts = append(opts, geomap.Links(panel.Links.toModel()...))
+	}
+	if len(panel.Transformations) != 0 {
+		transformations, err := panel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, geomap.Transformations(transformations...))
+	}
+
+	for _, t := range panel.Targets {
+		opt, err := panel.target(t)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	if panel.BaseLayer != nil {
+		base, err := panel.BaseLayer.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, geomap.BaseLayer(base))
+	}
+
+	for _, l := range panel.Layers {
+		opt, err := l.toOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	if panel.View != nil {
+		opts = append(opts, panel.View.toOptions()...)
+	}
+	if panel.Controls != nil {
+		opts = append(opts, panel.Controls.toOptions()...)
+	}
+
+	return opts, nil
+}
+
+func (panel DashboardGeomap) target(t Target) (geomap.Option, error) {
+	if t.Prometheus != nil {
+		return geomap.WithPrometheusTarget(t.Prometheus.Query, t.Prometheus.toOptions()...), nil
+	}
+	if t.Graphite != nil {
+		return geomap.WithGraphiteTarget(t.Graphite.Query, t.Graphite.toOptions()...), nil
+	}
+	if t.InfluxDB != nil {
+		return geomap.WithInfluxDBTarget(t.InfluxDB.Query, t.InfluxDB.toOptions()...), nil
+	}
+	if t.Stackdriver != nil {
+		stackdriverTarget, err := t.Stackdriver.toTarget()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.WithStackdriverTarget(stackdriverTarget), nil
+	}
+	if t.Loki != nil {
+		return geomap.WithLokiTarget(t.Loki.Query, t.Loki.toOptions()...), nil
+	}
+
+	if t.CloudWatch != nil {
+		return geomap.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
+	return nil, ErrTargetNotConfigured
+}
+
+func (base GeomapBaseLayer) toModel() (*layer.Layer, error) {
+	switch base.Type {
+	case "default":
+		return layer.Default(), nil
+	case "osm":
+		return layer.OpenStreetMap(), nil
+	case "carto":
+		theme := layer.CartoAuto
+		if base.Theme != "" {
+			theme = layer.CartoTheme(base.Theme)
+		}
+
+		return layer.Carto(theme), nil
+	case "arcgis":
+		server := layer.WorldStreetMap
+		if base.Server != "" {
+			server = layer.ArcGISServer(base.Server)
+		}
+
+		return layer.ArcGIS(server), nil
+	case "xyz":
+		return layer.XYZ(base.URL, base.Attribution), nil
+	}
+
+	return nil, ErrInvalidGeomapBaseLayer
+}
+
+func (l GeomapLayer) toOption() (geomap.Option, error) {
+	if l.Markers != nil {
+		opts, err := l.Markers.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.Markers(l.Markers.Name, opts...), nil
+	}
+	if l.Heatmap != nil {
+		opts, err := l.Heatmap.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.Heatmap(l.Heatmap.Name, opts...), nil
+	}
+	if l.Route != nil {
+		opts, err := l.Route.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return geomap.Route(l.Route.Name, opts...), nil
+	}
+
+	return nil, ErrInvalidGeomapLayer
+}
+
+func (l GeomapDataLayer) toOptions() ([]layer.Option, error) {
+	opts := []layer.Option{}
+
+	if l.Location != nil {
+		opt, err := l.Location.toOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+	if l.Size != nil && l.Size.Field != "" {
+		opts = append(opts, layer.SizeField(l.Size.Field, l.Size.Min, l.Size.Max))
+	}
+	if l.Size != nil && l.Size.Fixed != nil {
+		opts = append(opts, layer.FixedSize(*l.Size.Fixed))
+	}
+	if l.Color != nil && l.Color.Field != "" {
+		opts = append(opts, layer.ColorField(l.Color.Field))
+	}
+	if l.Color != nil && l.Color.Fixed != "" {
+		opts = append(opts, layer.FixedColor(l.Color.Fixed))
+	}
+	if l.Opacity != nil {
+		opts = append(opts, layer.Opacity(*l.Opacity))
+	}
+	if l.ShowLegend {
+		opts = append(opts, layer.ShowLegend())
+	}
+	if l.HideTooltip {
+		opts = append(opts, layer.HideTooltip())
+	}
+	if l.Radius != nil {
+		opts = append(opts, layer.Radius(*l.Radius))
+	}
+	if l.Blur != nil {
+		opts = append(opts, layer.Blur(*l.Blur))
+	}
+
+	switch l.Arrows {
+	case "":
+	case "none":
+		opts = append(opts, layer.Arrows(layer.NoArrows))
+	case "forward":
+		opts = append(opts, layer.Arrows(layer.ForwardArrow))
+	case "backward":
+		opts = append(opts, layer.Arrows(layer.BackwardArrow))
+	default:
+		return nil, ErrInvalidGeomapArrowMode
+	}
+
+	return opts, nil
+}
+
+func (location GeomapLocation) toOption() (layer.Option, error) {
+	switch location.Mode {
+	case "auto":
+		return layer.AutoLocation(), nil
+	case "coords":
+		return layer.CoordsLocation(location.Latitude, location.Longitude), nil
+	case "geohash":
+		return layer.GeohashLocation(location.Geohash), nil
+	case "lookup":
+		return layer.LookupLocation(location.Lookup, location.gazetteer()), nil
+	}
+
+	return nil, ErrInvalidGeomapLocationMode
+}
+
+func (location GeomapLocation) gazetteer() layer.Gazetteer {
+	switch location.Gazetteer {
+	case "", "countries":
+		return layer.Countries
+	case "usa_states":
+		return layer.USStates
+	case "airports":
+		return layer.Airports
+	case "spatial_codes":
+		return layer.SpatialCodes
+	}
+
+	return layer.Gazetteer(location.Gazetteer)
+}
+
+func (view GeomapView) toOptions() []geomap.Option {
+	opts := []geomap.Option{}
+
+	if view.Center != nil {
+		opts = append(opts, geomap.Center(view.Center[0], view.Center[1]))
+	}
+	if view.FitToData {
+		opts = append(opts, geomap.FitToData())
+	}
+	if view.Zoom != 0 {
+		opts = append(opts, geomap.Zoom(view.Zoom))
+	}
+
+	return opts
+}
+
+func (controls GeomapControls) toOptions() []geomap.Option {
+	opts := []geomap.Option{}
+
+	if controls.HideZoom {
+		opts = append(opts, geomap.HideZoomControls())
+	}
+	if controls.DisableMouseWheelZoom {
+		opts = append(opts, geomap.DisableMouseWheelZoom())
+	}
+	if controls.ShowScale {
+		opts = append(opts, geomap.ShowScale())
+	}
+
+	return opts
+}
@@ -0,0 +1,150 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/geomap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeomapPanelsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	opacity := 0.8
+	radius := 10
+	panel := DashboardGeomap{
+		Title:       "Requests per country",
+		Description: "awesome description",
+		Span:        12,
+		Height:      "400px",
+		Transparent: true,
+		Datasource:  "prometheus",
+		Targets: []Target{
+			{Prometheus: &PrometheusTarget{Query: "sum(requests) by (country)"}},
+		},
+		BaseLayer: &GeomapBaseLayer{Type: "carto", Theme: "dark"},
+		Layers: []GeomapLayer{
+			{Markers: &GeomapDataLayer{
+				Name:     "Requests",
+				Location: &GeomapLocation{Mode: "lookup", Lookup: "country", Gazetteer: "countries"},
+				Size:     &GeomapDimension{Field: "Value", Min: 2, Max: 20},
+				Color:    &GeomapColor{Field: "Value"},
+				Opacity:  &opacity,
+			}},
+			{Heatmap: &GeomapDataLayer{Name: "Density", Radius: &radius}},
+			{Route: &GeomapDataLayer{
+				Name:     "Path",
+				Location: &GeomapLocation{Mode: "coords", Latitude: "lat", Longitude: "lon"},
+				Arrows:   "forward",
+			}},
+		},
+		View:     &GeomapView{Center: &[2]float64{48.8, 2.3}, Zoom: 5},
+		Controls: &GeomapControls{HideZoom: true, ShowScale: true},
+	}
+
+	opts, err := panel.toOptions()
+	req.NoError(err)
+
+	geomapPanel, err := geomap.New(panel.Title, opts...)
+	req.NoError(err)
+
+	sdkPanel := geomapPanel.Builder
+	req.Equal("geomap", sdkPanel.Type)
+	req.Equal(panel.Title, sdkPanel.Title)
+	req.Equal(panel.Description, *sdkPanel.Description)
+	req.Equal(panel.Span, sdkPanel.Span)
+	req.Equal("prometheus", sdkPanel.Datasource.LegacyName)
+	req.True(sdkPanel.Transparent)
+	req.Len((*sdkPanel.CustomPanel)["targets"], 1)
+
+	options, err := json.Marshal((*sdkPanel.CustomPanel)["options"])
+	req.NoError(err)
+
+	decodedOptions := struct {
+		View struct {
+			ID   string  `json:"id"`
+			Lat  float64 `json:"lat"`
+			Zoom float64 `json:"zoom"`
+		} `json:"view"`
+		Controls struct {
+			ShowZoom  bool `json:"showZoom"`
+			ShowScale bool `json:"showScale"`
+		} `json:"controls"`
+		Basemap struct {
+			Type   string `json:"type"`
+			Config struct {
+				Theme string `json:"theme"`
+			} `json:"config"`
+		} `json:"basemap"`
+		Layers []struct {
+			Type string `json:"type"`
+			Name string `json:"name"`
+		} `json:"layers"`
+	}{}
+	req.NoError(json.Unmarshal(options, &decodedOptions))
+
+	req.Equal("coords", decodedOptions.View.ID)
+	req.Equal(48.8, decodedOptions.View.Lat)
+	req.Equal(float64(5), decodedOptions.View.Zoom)
+	req.False(decodedOptions.Controls.ShowZoom)
+	req.True(decodedOptions.Controls.ShowScale)
+	req.Equal("carto", decodedOptions.Basemap.Type)
+	req.Equal("dark", decodedOptions.Basemap.Config.Theme)
+	req.Len(decodedOptions.Layers, 3)
+	req.Equal("markers", decodedOptions.Layers[0].Type)
+	req.Equal("heatmap", decodedOptions.Layers[1].Type)
+	req.Equal("route", decodedOptions.Layers[2].Type)
+}
+
+func TestGeomapPanelsWithInvalidBaseLayer(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardGeomap{
+		Title:     "Map",
+		BaseLayer: &GeomapBaseLayer{Type: "unknown"},
+	}
+
+	_, err := panel.toOption()
+	req.ErrorIs(err, ErrInvalidGeomapBaseLayer)
+}
+
+func TestGeomapPanelsWithEmptyLayer(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardGeomap{
+		Title:  "Map",
+		Layers: []GeomapLayer{{}},
+	}
+
+	_, err := panel.toOption()
+	req.ErrorIs(err, ErrInvalidGeomapLayer)
+}
+
+func TestGeomapPanelsWithInvalidLocationMode(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardGeomap{
+		Title: "Map",
+		Layers: []GeomapLayer{
+			{Markers: &GeomapDataLayer{Location: &GeomapLocation{Mode: "unknown"}}},
+		},
+	}
+
+	_, err := panel.toOption()
+	req.ErrorIs(err, ErrInvalidGeomapLocationMode)
+}
+
+func TestGeomapPanelsWithInvalidArrowMode(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardGeomap{
+		Title: "Map",
+		Layers: []GeomapLayer{
+			{Route: &GeomapDataLayer{Arrows: "sideways"}},
+		},
+	}
+
+	_, err := panel.toOption()
+	req.ErrorIs(err, ErrInvalidGeomapArrowMode)
+}
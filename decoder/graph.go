@@ -21,11 +21,12 @@ type DashboardGraph struct {
 	Repeat          string  `yaml:",omitempty"`
 	RepeatDirection string  `yaml:"repeat_direction,omitempty"`
 	Targets         []Target
-	Links           DashboardPanelLinks `yaml:",omitempty"`
-	Axes            *GraphAxes          `yaml:",omitempty"`
-	Legend          []string            `yaml:",omitempty,flow"`
-	Alert           *Alert              `yaml:",omitempty"`
-	Visualization   *GraphVisualization `yaml:",omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	Axes            *GraphAxes               `yaml:",omitempty"`
+	Legend          []string                 `yaml:",omitempty,flow"`
+	Alert           *Alert                   `yaml:",omitempty"`
+	Visualization   *GraphVisualization      `yaml:",omitempty"`
 }
 
 func (graphPanel DashboardGraph) toOption() (row.Option, error) {
@@ -59,6 +60,14 @@ func (graphPanel DashboardGraph) toOption() (row.Option, error) {
 	if len(graphPanel.Links) != 0 {
 		opts = append(opts, graph.Links(graphPanel.Links.toModel()...))
 	}
+	if len(graphPanel.Transformations) != 0 {
+		transformations, err := graphPanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, graph.Transformations(transformations...))
+	}
 	if graphPanel.Axes != nil && graphPanel.Axes.Right != nil {
 		opts = append(opts, graph.RightYAxis(graphPanel.Axes.Right.toOptions()...))
 	}
@@ -226,6 +235,26 @@ func (graphPanel *DashboardGraph) target(t Target) (graph.Option, error) {
 		return graph.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return graph.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return graph.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return graph.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
 
@@ -13,17 +13,18 @@ var ErrInvalidDataFormat = fmt.Errorf("invalid data format")
 // DashboardHeatmap represents a heatmap panel.
 type DashboardHeatmap struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	DataFormat      string              `yaml:"data_format,omitempty"`
-	HideZeroBuckets bool                `yaml:"hide_zero_buckets"`
-	HighlightCards  bool                `yaml:"highlight_cards"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	DataFormat      string                   `yaml:"data_format,omitempty"`
+	HideZeroBuckets bool                     `yaml:"hide_zero_buckets"`
+	HighlightCards  bool                     `yaml:"highlight_cards"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
 	Targets         []Target
 	ReverseYBuckets bool            `yaml:"reverse_y_buckets,omitempty"`
 	Tooltip         *HeatmapTooltip `yaml:",omitempty"`
@@ -120,6 +121,14 @@ func (heatmapPanel DashboardHeatmap) toOption() (row.Option, error) {
 	if len(heatmapPanel.Links) != 0 {
 		opts = append(opts, heatmap.Links(heatmapPanel.Links.toModel()...))
 	}
+	if len(heatmapPanel.Transformations) != 0 {
+		transformations, err := heatmapPanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, heatmap.Transformations(transformations...))
+	}
 	if heatmapPanel.DataFormat != "" {
 		switch heatmapPanel.DataFormat {
 		case "time_series_buckets":
@@ -179,5 +188,25 @@ func (heatmapPanel DashboardHeatmap) target(t Target) (heatmap.Option, error) {
 		return heatmap.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return heatmap.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return heatmap.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return heatmap.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
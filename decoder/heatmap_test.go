@@ -55,10 +55,10 @@ func TestHeatmapTooltipCanBeHidden(t *testing.T) {
 
 	req.NoError(err)
 
-	req.Len(builder.Rows, 1)
-	req.Len(builder.Rows[0].Panels, 1)
+	// the first panel is the row itself
+	req.Len(builder.Panels, 2)
 
-	sdkPanel := builder.Rows[0].Panels[0]
+	sdkPanel := builder.Panels[1]
 
 	req.False(sdkPanel.HeatmapPanel.Tooltip.Show)
 	req.False(sdkPanel.HeatmapPanel.Tooltip.ShowHistogram)
@@ -0,0 +1,63 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/sdk"
+)
+
+var ErrInvalidLibraryPanel = fmt.Errorf("invalid library panel")
+
+// LibraryPanelDefinition describes a library panel: a panel defined once and
+// referenced by dashboards.
+type LibraryPanelDefinition struct {
+	UID string `yaml:"uid"`
+
+	DashboardPanel `yaml:",inline"`
+}
+
+func (definition LibraryPanelDefinition) toModel() (*librarypanel.Definition, error) {
+	if definition.LibraryPanel != nil {
+		return nil, fmt.Errorf("library panel '%s' can not reference another library panel: %w", definition.UID, ErrInvalidLibraryPanel)
+	}
+
+	opt, err := definition.DashboardPanel.toOption()
+	if err != nil {
+		return nil, err
+	}
+
+	// panels are built within a row: a throwaway one is used to extract the
+	// panel.
+	board := sdk.NewBoard("")
+	if _, err := row.New(board, "", row.HideTitle(), opt); err != nil {
+		return nil, err
+	}
+
+	return librarypanel.Define(definition.UID, board.Panels[0])
+}
+
+// DashboardLibraryPanel represents a reference to a library panel.
+type DashboardLibraryPanel struct {
+	UID    string  `yaml:"uid"`
+	Name   string  `yaml:",omitempty"`
+	Span   float32 `yaml:",omitempty"`
+	Height string  `yaml:",omitempty"`
+}
+
+func (libraryPanel DashboardLibraryPanel) toOption() row.Option {
+	opts := []librarypanel.Option{}
+
+	if libraryPanel.Name != "" {
+		opts = append(opts, librarypanel.Name(libraryPanel.Name))
+	}
+	if libraryPanel.Span != 0 {
+		opts = append(opts, librarypanel.Span(libraryPanel.Span))
+	}
+	if libraryPanel.Height != "" {
+		opts = append(opts, librarypanel.Height(libraryPanel.Height))
+	}
+
+	return row.WithLibraryPanel(libraryPanel.UID, opts...)
+}
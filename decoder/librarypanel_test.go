@@ -0,0 +1,88 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryPanelReferencesCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardLibraryPanel{
+		UID:    "requests-by-status",
+		Name:   "Requests by status",
+		Span:   4,
+		Height: "300px",
+	}
+
+	testBoard, err := dashboard.New("", dashboard.Row("", panel.toOption()))
+	req.NoError(err)
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+
+	sdkPanel := testBoard.Internal().Panels[1]
+	req.Equal(panel.Name, sdkPanel.Title)
+	req.Equal(int(panel.Span*2), *sdkPanel.GridPos.W)
+	req.Equal(8, *sdkPanel.GridPos.H)
+	req.Equal(map[string]interface{}{
+		"uid":  "requests-by-status",
+		"name": "Requests by status",
+	}, (*sdkPanel.CustomPanel)["libraryPanel"])
+}
+
+func TestLibraryPanelsCanBeDefinedAndReferenced(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := UnmarshalYAML(strings.NewReader(`
+title: Awesome dashboard
+
+library_panels:
+  - uid: notes
+    text:
+      title: Notes
+      markdown: "# Notes"
+
+rows:
+  - name: Overview
+    panels:
+      - library_panel: {uid: notes, name: Notes}
+`))
+	req.NoError(err)
+
+	req.Len(builder.LibraryPanels(), 1)
+	definition := builder.LibraryPanels()[0]
+	req.Equal("notes", definition.UID)
+	req.Equal("Notes", definition.Name)
+	req.Equal("text", definition.Model.Type)
+	req.Equal("# Notes", definition.Model.TextPanel.Content)
+	req.Nil(definition.Model.GridPos.W)
+
+	req.Len(builder.Internal().Panels, 2)
+	req.Contains(*builder.Internal().Panels[1].CustomPanel, "libraryPanel")
+}
+
+func TestLibraryPanelsCanNotReferenceOtherLibraryPanels(t *testing.T) {
+	req := require.New(t)
+
+	definition := LibraryPanelDefinition{
+		UID: "nested",
+		DashboardPanel: DashboardPanel{
+			LibraryPanel: &DashboardLibraryPanel{UID: "other"},
+		},
+	}
+
+	_, err := definition.toModel()
+
+	req.ErrorIs(err, ErrInvalidLibraryPanel)
+}
+
+func TestLibraryPanelsMustBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	_, err := LibraryPanelDefinition{UID: "empty"}.toModel()
+
+	req.ErrorIs(err, ErrPanelNotConfigured)
+}
@@ -12,16 +12,17 @@ var ErrInvalidDeduplicationStrategy = fmt.Errorf("invalid deduplication strategy
 
 type DashboardLogs struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
-	Targets         []LogsTarget        `yaml:",omitempty"`
-	Visualization   *LogsVisualization  `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	Targets         []LogsTarget             `yaml:",omitempty"`
+	Visualization   *LogsVisualization       `yaml:",omitempty"`
 }
 
 type LogsTarget struct {
@@ -59,6 +60,14 @@ func (panel DashboardLogs) toOption() (row.Option, error) {
 	if len(panel.Links) != 0 {
 		opts = append(opts, logs.Links(panel.Links.toModel()...))
 	}
+	if len(panel.Transformations) != 0 {
+		transformations, err := panel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, logs.Transformations(transformations...))
+	}
 	for _, t := range panel.Targets {
 		opt, err := panel.target(t)
 		if err != nil {
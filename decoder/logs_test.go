@@ -45,8 +45,9 @@ func TestLogsPanelsCanBeDecoded(t *testing.T) {
 	testBoard, err := dashboard.New("", dashboard.Row("", rowOption))
 
 	req.NoError(err)
-	req.Len(testBoard.Internal().Rows, 1)
-	panels := testBoard.Internal().Rows[0].Panels
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+	panels := testBoard.Internal().Panels[1:]
 	req.Len(panels, 1)
 
 	sdkPanel := panels[0]
@@ -56,7 +57,7 @@ func TestLogsPanelsCanBeDecoded(t *testing.T) {
 	req.Len(logsPanel.Targets, 2)
 	req.Equal(panel.Title, sdkPanel.Title)
 	req.Equal(panel.Description, *sdkPanel.Description)
-	req.Equal(panel.Span, sdkPanel.Span)
+	req.Equal(int(panel.Span*2), *sdkPanel.GridPos.W)
 	req.True(sdkPanel.Transparent)
 	req.Equal(panel.Datasource, sdkPanel.Datasource.LegacyName)
 	req.Equal(panel.Repeat, *sdkPanel.Repeat)
@@ -96,8 +97,9 @@ func TestLogsPanelsWithValidSortOrder(t *testing.T) {
 
 			testBoard, err := dashboard.New("", dashboard.Row("", rowOption))
 			req.NoError(err)
-			req.Len(testBoard.Internal().Rows, 1)
-			panels := testBoard.Internal().Rows[0].Panels
+			// the first panel is the row itself
+			req.Len(testBoard.Internal().Panels, 2)
+			panels := testBoard.Internal().Panels[1:]
 			req.Len(panels, 1)
 
 			logsPanel := panels[0].LogsPanel
@@ -164,8 +166,9 @@ func TestLogsPanelsWithValidDeduplicationStrategy(t *testing.T) {
 
 			testBoard, err := dashboard.New("", dashboard.Row("", rowOption))
 			req.NoError(err)
-			req.Len(testBoard.Internal().Rows, 1)
-			panels := testBoard.Internal().Rows[0].Panels
+			// the first panel is the row itself
+			req.Len(testBoard.Internal().Panels, 2)
+			panels := testBoard.Internal().Panels[1:]
 			req.Len(panels, 1)
 
 			logsPanel := panels[0].LogsPanel
@@ -227,8 +230,9 @@ func TestLogsPanelsVisualizationOptionsCanBeSet(t *testing.T) {
 
 	testBoard, err := dashboard.New("", dashboard.Row("", rowOption))
 	req.NoError(err)
-	req.Len(testBoard.Internal().Rows, 1)
-	panels := testBoard.Internal().Rows[0].Panels
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+	panels := testBoard.Internal().Panels[1:]
 	req.Len(panels, 1)
 
 	logsPanel := panels[0].LogsPanel
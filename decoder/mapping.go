@@ -0,0 +1,92 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/mapping"
+)
+
+var ErrInvalidValueMapping = fmt.Errorf("invalid value mapping")
+var ErrInvalidSpecialValue = fmt.Errorf("invalid special value")
+
+type DashboardValueMappings []DashboardValueMapping
+
+type DashboardValueMapping struct {
+	// Exactly one of the following must be set.
+	Value *string            `yaml:",omitempty"`
+	Range *ValueMappingRange `yaml:",omitempty"`
+	Regex string             `yaml:",omitempty"`
+	// Valid values are: null, nan, null_nan, true, false, empty
+	Special string `yaml:",omitempty"`
+
+	Text  string `yaml:",omitempty"`
+	Color string `yaml:",omitempty"`
+}
+
+type ValueMappingRange struct {
+	From float64
+	To   float64
+}
+
+func (mappings DashboardValueMappings) toModel() ([]mapping.Mapping, error) {
+	models := make([]mapping.Mapping, 0, len(mappings))
+
+	for _, m := range mappings {
+		model, err := m.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (m DashboardValueMapping) toModel() (mapping.Mapping, error) {
+	opts := []mapping.Option{}
+
+	if m.Text != "" {
+		opts = append(opts, mapping.Text(m.Text))
+	}
+	if m.Color != "" {
+		opts = append(opts, mapping.Color(m.Color))
+	}
+
+	switch {
+	case m.Value != nil:
+		return mapping.Value(*m.Value, opts...), nil
+	case m.Range != nil:
+		return mapping.Range(m.Range.From, m.Range.To, opts...), nil
+	case m.Regex != "":
+		return mapping.Regex(m.Regex, opts...), nil
+	case m.Special != "":
+		special, err := m.specialValue()
+		if err != nil {
+			return mapping.Mapping{}, err
+		}
+
+		return mapping.Special(special, opts...), nil
+	}
+
+	return mapping.Mapping{}, ErrInvalidValueMapping
+}
+
+func (m DashboardValueMapping) specialValue() (mapping.SpecialValue, error) {
+	switch m.Special {
+	case "null":
+		return mapping.Null, nil
+	case "nan":
+		return mapping.NaN, nil
+	case "null_nan":
+		return mapping.NullOrNaN, nil
+	case "true":
+		return mapping.True, nil
+	case "false":
+		return mapping.False, nil
+	case "empty":
+		return mapping.Empty, nil
+	}
+
+	return "", ErrInvalidSpecialValue
+}
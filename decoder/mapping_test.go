@@ -0,0 +1,81 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValueMappingsCanBeDecoded(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		yaml     string
+		expected string
+	}{
+		{
+			desc:     "value",
+			yaml:     `{value: "1", text: Up, color: green}`,
+			expected: `{"type": "value", "options": {"1": {"text": "Up", "color": "green"}}}`,
+		},
+		{
+			desc:     "range",
+			yaml:     `{range: {from: 0, to: 10}, text: Low}`,
+			expected: `{"type": "range", "options": {"from": 0, "to": 10, "result": {"text": "Low"}}}`,
+		},
+		{
+			desc:     "regex",
+			yaml:     `{regex: "host-(.*)", text: "$1"}`,
+			expected: `{"type": "regex", "options": {"pattern": "host-(.*)", "result": {"text": "$1"}}}`,
+		},
+		{
+			desc:     "special",
+			yaml:     `{special: null_nan, text: N/A}`,
+			expected: `{"type": "special", "options": {"match": "null+nan", "result": {"text": "N/A"}}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			decoded := DashboardValueMapping{}
+			req.NoError(yaml.Unmarshal([]byte(tc.yaml), &decoded))
+
+			model, err := decoded.toModel()
+			req.NoError(err)
+
+			payload, err := json.Marshal(model)
+			req.NoError(err)
+
+			req.JSONEq(tc.expected, string(payload))
+		})
+	}
+}
+
+func TestInvalidValueMappingsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := DashboardValueMappings{{Text: "foo"}}.toModel()
+	req.ErrorIs(err, ErrInvalidValueMapping)
+
+	_, err = DashboardValueMappings{{Special: "invalid"}}.toModel()
+	req.ErrorIs(err, ErrInvalidSpecialValue)
+}
+
+func TestValueMappingsCanBeUsedInOverrides(t *testing.T) {
+	req := require.New(t)
+
+	value := "1"
+	properties := TimeSeriesOverrideProperties{
+		ValueMappings: DashboardValueMappings{{Value: &value, Text: "Up"}},
+		DataLinks:     DashboardDataLinks{{Title: "Details", URL: "https://example.org"}},
+	}
+
+	opts, err := properties.toOptions()
+	req.NoError(err)
+	req.Len(opts, 2)
+}
@@ -0,0 +1,49 @@
+package decoder
+
+import (
+	"github.com/K-Phoen/grabana/news"
+	"github.com/K-Phoen/grabana/row"
+)
+
+type DashboardNews struct {
+	Title       string
+	Description string              `yaml:",omitempty"`
+	Span        float32             `yaml:",omitempty"`
+	Height      string              `yaml:",omitempty"`
+	Transparent bool                `yaml:",omitempty"`
+	Links       DashboardPanelLinks `yaml:",omitempty"`
+
+	FeedURL    string `yaml:"feed_url"`
+	HideImages bool   `yaml:"hide_images,omitempty"`
+	UseProxy   bool   `yaml:"use_proxy,omitempty"`
+}
+
+func (panel DashboardNews) toOption() row.Option {
+	opts := []news.Option{
+		news.FeedURL(panel.FeedURL),
+	}
+
+	if panel.Description != "" {
+		opts = append(opts, news.Description(panel.Description))
+	}
+	if panel.Span != 0 {
+		opts = append(opts, news.Span(panel.Span))
+	}
+	if panel.Height != "" {
+		opts = append(opts, news.Height(panel.Height))
+	}
+	if panel.Transparent {
+		opts = append(opts, news.Transparent())
+	}
+	if len(panel.Links) != 0 {
+		opts = append(opts, news.Links(panel.Links.toModel()...))
+	}
+	if panel.HideImages {
+		opts = append(opts, news.HideImages())
+	}
+	if panel.UseProxy {
+		opts = append(opts, news.UseProxy())
+	}
+
+	return row.WithNews(panel.Title, opts...)
+}
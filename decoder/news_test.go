@@ -0,0 +1,47 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsPanelsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardNews{
+		Title:       "News",
+		Description: "awesome description",
+		Span:        4,
+		Height:      "300px",
+		Transparent: true,
+		FeedURL:     "https://grafana.com/blog/news.xml",
+		HideImages:  true,
+		UseProxy:    true,
+	}
+
+	testBoard, err := dashboard.New("", dashboard.Row("", panel.toOption()))
+	req.NoError(err)
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+
+	panels := testBoard.Internal().Panels[1:]
+	req.Len(panels, 1)
+
+	sdkPanel := panels[0]
+	req.Equal("news", sdkPanel.Type)
+	req.Equal(panel.Title, sdkPanel.Title)
+	req.Equal(panel.Description, *sdkPanel.Description)
+	req.Equal(int(panel.Span*2), *sdkPanel.GridPos.W)
+	req.True(sdkPanel.Transparent)
+
+	options, err := json.Marshal((*sdkPanel.CustomPanel)["options"])
+	req.NoError(err)
+	req.JSONEq(`{
+		"feedUrl": "https://grafana.com/blog/news.xml",
+		"showImage": false,
+		"useProxy": true
+	}`, string(options))
+}
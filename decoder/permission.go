@@ -0,0 +1,115 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/permission"
+)
+
+var ErrInvalidPermissionSubject = fmt.Errorf("a permission must be granted to exactly one of: role, team, user, service_account")
+var ErrInvalidPermissionRole = fmt.Errorf("invalid permission role. Valid values are: 'viewer', 'editor'")
+var ErrInvalidPermissionLevel = fmt.Errorf("invalid permission. Valid values are: 'view', 'edit', 'admin'")
+
+// DashboardPermissions describes who can access a dashboard and its folder.
+type DashboardPermissions struct {
+	Dashboard []Permission `yaml:",omitempty"`
+	Folder    []Permission `yaml:",omitempty"`
+}
+
+func (permissions *DashboardPermissions) toOptions() ([]dashboard.Option, error) {
+	var opts []dashboard.Option
+
+	if len(permissions.Dashboard) != 0 {
+		items, err := permissionItems(permissions.Dashboard)
+		if err != nil {
+			return nil, fmt.Errorf("dashboard permissions: %w", err)
+		}
+
+		opts = append(opts, dashboard.Permissions(items...))
+	}
+
+	if len(permissions.Folder) != 0 {
+		items, err := permissionItems(permissions.Folder)
+		if err != nil {
+			return nil, fmt.Errorf("folder permissions: %w", err)
+		}
+
+		opts = append(opts, dashboard.FolderPermissions(items...))
+	}
+
+	return opts, nil
+}
+
+func permissionItems(permissions []Permission) ([]permission.Item, error) {
+	items := make([]permission.Item, 0, len(permissions))
+
+	for _, perm := range permissions {
+		item, err := perm.toItem()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// Permission grants a level of access to a role, a team, a user (by login or
+// email) or a service account.
+type Permission struct {
+	Role           string `yaml:",omitempty"`
+	Team           string `yaml:",omitempty"`
+	User           string `yaml:",omitempty"`
+	ServiceAccount string `yaml:"service_account,omitempty"`
+	Permission     string
+}
+
+func (perm Permission) toItem() (permission.Item, error) {
+	level, err := perm.level()
+	if err != nil {
+		return permission.Item{}, err
+	}
+
+	subjects := 0
+	for _, subject := range []string{perm.Role, perm.Team, perm.User, perm.ServiceAccount} {
+		if subject != "" {
+			subjects++
+		}
+	}
+	if subjects != 1 {
+		return permission.Item{}, ErrInvalidPermissionSubject
+	}
+
+	switch {
+	case perm.Team != "":
+		return permission.ForTeam(perm.Team, level), nil
+	case perm.User != "":
+		return permission.ForUser(perm.User, level), nil
+	case perm.ServiceAccount != "":
+		return permission.ForServiceAccount(perm.ServiceAccount, level), nil
+	}
+
+	switch perm.Role {
+	case "viewer":
+		return permission.ForRole(permission.Viewer, level), nil
+	case "editor":
+		return permission.ForRole(permission.Editor, level), nil
+	}
+
+	return permission.Item{}, ErrInvalidPermissionRole
+}
+
+func (perm Permission) level() (permission.Level, error) {
+	switch perm.Permission {
+	case "view":
+		return permission.View, nil
+	case "edit":
+		return permission.Edit, nil
+	case "admin":
+		return permission.Admin, nil
+	}
+
+	return 0, ErrInvalidPermissionLevel
+}
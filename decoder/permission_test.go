@@ -0,0 +1,94 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/K-Phoen/grabana/permission"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermissionsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := UnmarshalYAML(strings.NewReader(`
+title: Awesome dashboard
+permissions:
+  dashboard:
+    - role: viewer
+      permission: view
+    - team: SRE
+      permission: admin
+    - user: jane@example.com
+      permission: edit
+    - service_account: ci
+      permission: edit
+  folder:
+    - role: editor
+      permission: edit
+`))
+	req.NoError(err)
+
+	req.Equal([]permission.Item{
+		permission.ForRole(permission.Viewer, permission.View),
+		permission.ForTeam("SRE", permission.Admin),
+		permission.ForUser("jane@example.com", permission.Edit),
+		permission.ForServiceAccount("ci", permission.Edit),
+	}, builder.Permissions())
+	req.Equal([]permission.Item{
+		permission.ForRole(permission.Editor, permission.Edit),
+	}, builder.FolderPermissions())
+}
+
+func TestPermissionsAreOptional(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := UnmarshalYAML(strings.NewReader(`title: Awesome dashboard`))
+	req.NoError(err)
+
+	req.Empty(builder.Permissions())
+	req.Empty(builder.FolderPermissions())
+}
+
+func TestInvalidPermissionsAreRejected(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		permission Permission
+		expected   error
+	}{
+		{
+			desc:       "no subject",
+			permission: Permission{Permission: "view"},
+			expected:   ErrInvalidPermissionSubject,
+		},
+		{
+			desc:       "several subjects",
+			permission: Permission{Team: "SRE", User: "jane", Permission: "view"},
+			expected:   ErrInvalidPermissionSubject,
+		},
+		{
+			desc:       "unknown role",
+			permission: Permission{Role: "admin", Permission: "view"},
+			expected:   ErrInvalidPermissionRole,
+		},
+		{
+			desc:       "unknown level",
+			permission: Permission{Role: "viewer", Permission: "read"},
+			expected:   ErrInvalidPermissionLevel,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			permissions := &DashboardPermissions{Dashboard: []Permission{tc.permission}}
+
+			_, err := permissions.toOptions()
+
+			req.ErrorIs(err, tc.expected)
+		})
+	}
+}
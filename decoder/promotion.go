@@ -0,0 +1,25 @@
+package decoder
+
+import (
+	"github.com/K-Phoen/grabana"
+)
+
+// PromotionRulesModel describes how dashboards are rewritten while being
+// promoted from an instance to another.
+type PromotionRulesModel struct {
+	Folders     map[string]string `yaml:",omitempty"`
+	Datasources map[string]string `yaml:",omitempty"`
+	Variables   map[string]string `yaml:",omitempty"`
+	Tags        map[string]string `yaml:",omitempty"`
+	AddTags     []string          `yaml:"add_tags,omitempty"`
+}
+
+func (model *PromotionRulesModel) toRules() grabana.PromotionRules {
+	return grabana.PromotionRules{
+		Folders:     model.Folders,
+		Datasources: model.Datasources,
+		Variables:   model.Variables,
+		Tags:        model.Tags,
+		AddTags:     model.AddTags,
+	}
+}
@@ -0,0 +1,42 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionRulesCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	input := `
+folders:
+  Staging/Infra: Infra
+datasources:
+  Prometheus staging: Prometheus
+variables:
+  env: production
+tags:
+  staging: production
+  wip: ""
+add_tags: [promoted]
+`
+
+	rules, err := UnmarshalPromotionRulesYAML(strings.NewReader(input))
+
+	req.NoError(err)
+	req.Equal(map[string]string{"Staging/Infra": "Infra"}, rules.Folders)
+	req.Equal(map[string]string{"Prometheus staging": "Prometheus"}, rules.Datasources)
+	req.Equal(map[string]string{"env": "production"}, rules.Variables)
+	req.Equal(map[string]string{"staging": "production", "wip": ""}, rules.Tags)
+	req.Equal([]string{"promoted"}, rules.AddTags)
+}
+
+func TestUnknownPromotionRulesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := UnmarshalPromotionRulesYAML(strings.NewReader("panels: {}"))
+
+	req.Error(err)
+}
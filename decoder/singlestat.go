@@ -13,14 +13,15 @@ var ErrInvalidSingleStatValueType = fmt.Errorf("invalid single stat value type")
 
 type DashboardSingleStat struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
 	Unit            string
 	Decimals        *int   `yaml:",omitempty"`
 	ValueType       string `yaml:"value_type"`
@@ -66,6 +67,14 @@ func (singleStatPanel DashboardSingleStat) toOption() (row.Option, error) {
 	if len(singleStatPanel.Links) != 0 {
 		opts = append(opts, singlestat.Links(singleStatPanel.Links.toModel()...))
 	}
+	if len(singleStatPanel.Transformations) != 0 {
+		transformations, err := singleStatPanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, singlestat.Transformations(transformations...))
+	}
 	if singleStatPanel.Unit != "" {
 		opts = append(opts, singlestat.Unit(singleStatPanel.Unit))
 	}
@@ -179,5 +188,25 @@ func (singleStatPanel DashboardSingleStat) target(t Target) (singlestat.Option,
 		return singlestat.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return singlestat.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return singlestat.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return singlestat.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
@@ -20,14 +20,17 @@ type StatThresholdStep struct {
 
 type DashboardStat struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	ValueMappings   DashboardValueMappings   `yaml:"value_mappings,omitempty"`
+	DataLinks       DashboardDataLinks       `yaml:"data_links,omitempty"`
 	Targets         []Target
 
 	Unit     string `yaml:",omitempty"`
@@ -76,6 +79,30 @@ func (statPanel DashboardStat) toOption() (row.Option, error) {
 	if len(statPanel.Links) != 0 {
 		opts = append(opts, stat.Links(statPanel.Links.toModel()...))
 	}
+	if len(statPanel.Transformations) != 0 {
+		transformations, err := statPanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, stat.Transformations(transformations...))
+	}
+	if len(statPanel.ValueMappings) != 0 {
+		mappings, err := statPanel.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, stat.ValueMappings(mappings...))
+	}
+	if len(statPanel.DataLinks) != 0 {
+		dataLinks, err := statPanel.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, stat.DataLinks(dataLinks...))
+	}
 	if statPanel.Unit != "" {
 		opts = append(opts, stat.Unit(statPanel.Unit))
 	}
@@ -255,5 +282,25 @@ func (statPanel DashboardStat) target(t Target) (stat.Option, error) {
 		return stat.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return stat.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return stat.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return stat.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
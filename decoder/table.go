@@ -1,25 +1,65 @@
 package decoder
 
 import (
+	"fmt"
+
 	"github.com/K-Phoen/grabana/row"
 	"github.com/K-Phoen/grabana/table"
+	"github.com/K-Phoen/grabana/table/fields"
 )
 
+var ErrInvalidTableCellDisplay = fmt.Errorf("invalid table cell display mode")
+var ErrInvalidTableAlign = fmt.Errorf("invalid table alignment")
+
 // DashboardTable represents a table panel.
 type DashboardTable struct {
 	Title                  string
-	Description            string              `yaml:",omitempty"`
-	Span                   float32             `yaml:",omitempty"`
-	Height                 string              `yaml:",omitempty"`
-	Transparent            bool                `yaml:",omitempty"`
-	Datasource             string              `yaml:",omitempty"`
-	Links                  DashboardPanelLinks `yaml:",omitempty"`
+	Description            string                   `yaml:",omitempty"`
+	Span                   float32                  `yaml:",omitempty"`
+	Height                 string                   `yaml:",omitempty"`
+	Transparent            bool                     `yaml:",omitempty"`
+	Datasource             string                   `yaml:",omitempty"`
+	Links                  DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations        DashboardTransformations `yaml:",omitempty"`
+	ValueMappings          DashboardValueMappings   `yaml:"value_mappings,omitempty"`
+	DataLinks              DashboardDataLinks       `yaml:"data_links,omitempty"`
 	Targets                []Target
 	HiddenColumns          []string            `yaml:"hidden_columns,flow"`
 	TimeSeriesAggregations []table.Aggregation `yaml:"time_series_aggregations"`
+
+	Unit           string          `yaml:",omitempty"`
+	Decimals       *int            `yaml:",omitempty"`
+	ColumnWidth    int             `yaml:"column_width,omitempty"`
+	MinColumnWidth int             `yaml:"min_column_width,omitempty"`
+	Filterable     bool            `yaml:",omitempty"`
+	Align          string          `yaml:",omitempty"`
+	CellDisplay    string          `yaml:"cell_display,omitempty"`
+	HideHeader     bool            `yaml:"hide_header,omitempty"`
+	Footer         *TableFooter    `yaml:",omitempty"`
+	SortBy         []TableSortBy   `yaml:"sort_by,omitempty"`
+	Overrides      []TableOverride `yaml:",omitempty"`
+}
+
+type TableFooter struct {
+	Reducers   []string `yaml:",omitempty,flow"`
+	Pagination bool     `yaml:",omitempty"`
+}
+
+type TableSortBy struct {
+	Column     string
+	Descending bool `yaml:",omitempty"`
 }
 
 func (tablePanel DashboardTable) toOption() (row.Option, error) {
+	opts, err := tablePanel.toOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return row.WithTable(tablePanel.Title, opts...), nil
+}
+
+func (tablePanel DashboardTable) toOptions() ([]table.Option, error) {
 	opts := []table.Option{}
 
 	if tablePanel.Description != "" {
@@ -40,6 +80,30 @@ func (tablePanel DashboardTable) toOption() (row.Option, error) {
 	if len(tablePanel.Links) != 0 {
 		opts = append(opts, table.Links(tablePanel.Links.toModel()...))
 	}
+	if len(tablePanel.Transformations) != 0 {
+		transformations, err := tablePanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.Transformations(transformations...))
+	}
+	if len(tablePanel.ValueMappings) != 0 {
+		mappings, err := tablePanel.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.ValueMappings(mappings...))
+	}
+	if len(tablePanel.DataLinks) != 0 {
+		dataLinks, err := tablePanel.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.DataLinks(dataLinks...))
+	}
 
 	for _, t := range tablePanel.Targets {
 		opt, err := tablePanel.target(t)
@@ -58,7 +122,23 @@ func (tablePanel DashboardTable) toOption() (row.Option, error) {
 		opts = append(opts, table.AsTimeSeriesAggregations(tablePanel.TimeSeriesAggregations))
 	}
 
-	return row.WithTable(tablePanel.Title, opts...), nil
+	visualizationOpts, err := tablePanel.visualizationOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, visualizationOpts...)
+
+	for _, override := range tablePanel.Overrides {
+		opt, err := override.toOption()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, opt)
+	}
+
+	return opts, nil
 }
 
 func (tablePanel *DashboardTable) target(t Target) (table.Option, error) {
@@ -71,6 +151,226 @@ func (tablePanel *DashboardTable) target(t Target) (table.Option, error) {
 	if t.InfluxDB != nil {
 		return table.WithInfluxDBTarget(t.InfluxDB.Query, t.InfluxDB.toOptions()...), nil
 	}
+	if t.Stackdriver != nil {
+		stackdriverTarget, err := t.Stackdriver.toTarget()
+		if err != nil {
+			return nil, err
+		}
+
+		return table.WithStackdriverTarget(stackdriverTarget), nil
+	}
+	if t.Loki != nil {
+		return table.WithLokiTarget(t.Loki.Query, t.Loki.toOptions()...), nil
+	}
+
+	if t.CloudWatch != nil {
+		return table.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return table.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return table.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
 
 	return nil, ErrTargetNotConfigured
 }
+
+func (tablePanel *DashboardTable) visualizationOptions() ([]table.Option, error) {
+	var opts []table.Option
+
+	if tablePanel.Unit != "" {
+		opts = append(opts, table.Unit(tablePanel.Unit))
+	}
+	if tablePanel.Decimals != nil {
+		opts = append(opts, table.Decimals(*tablePanel.Decimals))
+	}
+	if tablePanel.ColumnWidth != 0 {
+		opts = append(opts, table.ColumnWidth(tablePanel.ColumnWidth))
+	}
+	if tablePanel.MinColumnWidth != 0 {
+		opts = append(opts, table.MinColumnWidth(tablePanel.MinColumnWidth))
+	}
+	if tablePanel.Filterable {
+		opts = append(opts, table.Filterable())
+	}
+	if tablePanel.Align != "" {
+		align, err := tableAlignFromString(tablePanel.Align)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.Align(align))
+	}
+	if tablePanel.CellDisplay != "" {
+		mode, err := tableCellDisplayFromString(tablePanel.CellDisplay)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.CellDisplay(mode))
+	}
+	if tablePanel.HideHeader {
+		opts = append(opts, table.HideHeader())
+	}
+	if tablePanel.Footer != nil {
+		reducers, err := parseReducers(tablePanel.Footer.Reducers)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, table.Footer(reducers...))
+
+		if tablePanel.Footer.Pagination {
+			opts = append(opts, table.Pagination())
+		}
+	}
+
+	for _, sortBy := range tablePanel.SortBy {
+		opts = append(opts, table.SortBy(sortBy.Column, sortBy.Descending))
+	}
+
+	return opts, nil
+}
+
+type TableOverride struct {
+	Matcher    TimeSeriesOverrideMatcher `yaml:"match,flow"`
+	Properties TableOverrideProperties
+}
+
+func (override TableOverride) toOption() (table.Option, error) {
+	matcher, err := override.Matcher.toOption()
+	if err != nil {
+		return nil, err
+	}
+
+	overrideOpts, err := override.Properties.toOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return table.FieldOverride(matcher, overrideOpts...), nil
+}
+
+type TableOverrideProperties struct {
+	Unit        *string `yaml:",omitempty"`
+	Decimals    *int    `yaml:",omitempty"`
+	Width       *int    `yaml:",omitempty"`
+	MinWidth    *int    `yaml:"min_width,omitempty"`
+	Align       *string `yaml:",omitempty"`
+	Filterable  *bool   `yaml:",omitempty"`
+	CellDisplay *string `yaml:"cell_display,omitempty"`
+	Hidden      *bool   `yaml:",omitempty"`
+
+	ValueMappings DashboardValueMappings `yaml:"value_mappings,omitempty"`
+	DataLinks     DashboardDataLinks     `yaml:"data_links,omitempty"`
+}
+
+func (properties TableOverrideProperties) toOptions() ([]fields.OverrideOption, error) {
+	var opts []fields.OverrideOption
+
+	if properties.Unit != nil {
+		opts = append(opts, fields.Unit(*properties.Unit))
+	}
+	if properties.Decimals != nil {
+		opts = append(opts, fields.Decimals(*properties.Decimals))
+	}
+	if properties.Width != nil {
+		opts = append(opts, fields.Width(*properties.Width))
+	}
+	if properties.MinWidth != nil {
+		opts = append(opts, fields.MinWidth(*properties.MinWidth))
+	}
+	if properties.Align != nil {
+		align, err := tableAlignFromString(*properties.Align)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.Align(align))
+	}
+	if properties.Filterable != nil && *properties.Filterable {
+		opts = append(opts, fields.Filterable())
+	}
+	if properties.CellDisplay != nil {
+		mode, err := tableCellDisplayFromString(*properties.CellDisplay)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.CellDisplay(mode))
+	}
+	if properties.Hidden != nil && *properties.Hidden {
+		opts = append(opts, fields.Hidden())
+	}
+	if len(properties.ValueMappings) != 0 {
+		mappings, err := properties.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.ValueMappings(mappings...))
+	}
+	if len(properties.DataLinks) != 0 {
+		dataLinks, err := properties.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.DataLinks(dataLinks...))
+	}
+
+	return opts, nil
+}
+
+func tableAlignFromString(input string) (fields.AlignMode, error) {
+	switch input {
+	case "auto":
+		return fields.AlignAuto, nil
+	case "left":
+		return fields.AlignLeft, nil
+	case "center":
+		return fields.AlignCenter, nil
+	case "right":
+		return fields.AlignRight, nil
+	default:
+		return fields.AlignAuto, ErrInvalidTableAlign
+	}
+}
+
+func tableCellDisplayFromString(input string) (fields.CellDisplayMode, error) {
+	switch input {
+	case "auto":
+		return fields.Auto, nil
+	case "colored_text":
+		return fields.ColoredText, nil
+	case "colored_background":
+		return fields.ColoredBackground, nil
+	case "colored_background_solid":
+		return fields.ColoredBackgroundSolid, nil
+	case "gradient_gauge":
+		return fields.GradientGauge, nil
+	case "lcd_gauge":
+		return fields.LCDGauge, nil
+	case "basic_gauge":
+		return fields.BasicGauge, nil
+	case "json_view":
+		return fields.JSONView, nil
+	case "image":
+		return fields.Image, nil
+	case "sparkline":
+		return fields.Sparkline, nil
+	default:
+		return fields.Auto, ErrInvalidTableCellDisplay
+	}
+}
@@ -0,0 +1,180 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/table"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTablePanelsCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	payload := `
+title: Pods
+description: Every pod
+span: 12
+datasource: prometheus
+targets:
+  - prometheus:
+      query: "kube_pod_info"
+  - loki:
+      query: "{app=\"api\"}"
+unit: bytes
+decimals: 2
+column_width: 120
+min_column_width: 50
+filterable: true
+align: center
+cell_display: colored_background
+hide_header: true
+footer:
+  reducers: [avg, max]
+  pagination: true
+sort_by:
+  - column: Value
+    descending: true
+overrides:
+  - match: {field_name: Status}
+    properties:
+      cell_display: lcd_gauge
+      width: 80
+      hidden: true
+`
+
+	panel := DashboardTable{}
+	req.NoError(yaml.Unmarshal([]byte(payload), &panel))
+
+	opts, err := panel.toOptions()
+	req.NoError(err)
+
+	tablePanel, err := table.New(panel.Title, opts...)
+	req.NoError(err)
+
+	encoded, err := json.Marshal(tablePanel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type        string
+		Description string
+		Targets     []struct{ Expr string }
+		Options     struct {
+			ShowHeader bool
+			Footer     struct {
+				Show             bool
+				Reducer          []string
+				EnablePagination bool
+			}
+			SortBy []struct {
+				DisplayName string
+				Desc        bool
+			}
+		}
+		FieldConfig struct {
+			Defaults struct {
+				Unit     string
+				Decimals int
+				Custom   struct {
+					Align       string
+					CellOptions map[string]string
+					Filterable  bool
+					Width       int
+					MinWidth    int
+				}
+			}
+			Overrides []struct {
+				Matcher struct {
+					ID      string
+					Options string
+				}
+				Properties []struct {
+					ID string
+				}
+			}
+		}
+	}{}
+	req.NoError(json.Unmarshal(encoded, &decoded))
+
+	req.Equal("table", decoded.Type)
+	req.Equal("Every pod", decoded.Description)
+	req.Len(decoded.Targets, 2)
+
+	req.False(decoded.Options.ShowHeader)
+	req.True(decoded.Options.Footer.Show)
+	req.True(decoded.Options.Footer.EnablePagination)
+	req.Equal([]string{"mean", "max"}, decoded.Options.Footer.Reducer)
+	req.Len(decoded.Options.SortBy, 1)
+	req.Equal("Value", decoded.Options.SortBy[0].DisplayName)
+	req.True(decoded.Options.SortBy[0].Desc)
+
+	defaults := decoded.FieldConfig.Defaults
+	req.Equal("bytes", defaults.Unit)
+	req.Equal(2, defaults.Decimals)
+	req.Equal("center", defaults.Custom.Align)
+	req.Equal(map[string]string{"type": "color-background", "mode": "gradient"}, defaults.Custom.CellOptions)
+	req.True(defaults.Custom.Filterable)
+	req.Equal(120, defaults.Custom.Width)
+	req.Equal(50, defaults.Custom.MinWidth)
+
+	req.Len(decoded.FieldConfig.Overrides, 1)
+	req.Equal("byName", decoded.FieldConfig.Overrides[0].Matcher.ID)
+	req.Equal("Status", decoded.FieldConfig.Overrides[0].Matcher.Options)
+	req.Len(decoded.FieldConfig.Overrides[0].Properties, 3)
+}
+
+func TestDecodingTablePanelWithInvalidValuesFails(t *testing.T) {
+	anyField := ".*"
+	invalidAlign := "justify"
+	hidden := true
+
+	testCases := []struct {
+		desc     string
+		panel    DashboardTable
+		expected error
+	}{
+		{
+			desc:     "invalid cell display",
+			panel:    DashboardTable{CellDisplay: "sparkles"},
+			expected: ErrInvalidTableCellDisplay,
+		},
+		{
+			desc:     "invalid alignment",
+			panel:    DashboardTable{Align: "justify"},
+			expected: ErrInvalidTableAlign,
+		},
+		{
+			desc:     "invalid footer reducer",
+			panel:    DashboardTable{Footer: &TableFooter{Reducers: []string{"median"}}},
+			expected: ErrInvalidReducer,
+		},
+		{
+			desc: "invalid override property",
+			panel: DashboardTable{Overrides: []TableOverride{
+				{Matcher: TimeSeriesOverrideMatcher{Regex: &anyField}, Properties: TableOverrideProperties{Align: &invalidAlign}},
+			}},
+			expected: ErrInvalidTableAlign,
+		},
+		{
+			desc: "invalid override matcher",
+			panel: DashboardTable{Overrides: []TableOverride{
+				{Properties: TableOverrideProperties{Hidden: &hidden}},
+			}},
+			expected: ErrInvalidOverrideMatcher,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			_, err := tc.panel.toOptions()
+
+			req.Error(err)
+			req.ErrorIs(err, tc.expected)
+		})
+	}
+}
@@ -3,10 +3,13 @@ package decoder
 import (
 	"fmt"
 
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
 	"github.com/K-Phoen/grabana/target/graphite"
 	"github.com/K-Phoen/grabana/target/influxdb"
 	"github.com/K-Phoen/grabana/target/loki"
 	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/target/sql"
 	"github.com/K-Phoen/grabana/target/stackdriver"
 )
 
@@ -15,6 +18,9 @@ var ErrInvalidStackdriverType = fmt.Errorf("invalid stackdriver target type")
 var ErrInvalidStackdriverAggregation = fmt.Errorf("invalid stackdriver aggregation type")
 var ErrInvalidStackdriverPreprocessor = fmt.Errorf("invalid stackdriver preprocessor")
 var ErrInvalidStackdriverAlignment = fmt.Errorf("invalid stackdriver alignment method")
+var ErrInvalidElasticsearchMetric = fmt.Errorf("invalid elasticsearch metric type. Valid values are: 'count', 'avg', 'sum', 'min', 'max', 'unique_count'")
+var ErrInvalidElasticsearchBucket = fmt.Errorf("invalid elasticsearch bucket aggregation type. Valid values are: 'date_histogram', 'histogram', 'terms'")
+var ErrInvalidSQLFormat = fmt.Errorf("invalid SQL target format. Valid values are: 'table', 'time_series'")
 
 type Target struct {
 	Prometheus  *PrometheusTarget  `yaml:",omitempty"`
@@ -22,6 +28,10 @@ type Target struct {
 	InfluxDB    *InfluxDBTarget    `yaml:"influxdb,omitempty"`
 	Stackdriver *StackdriverTarget `yaml:",omitempty"`
 	Loki        *LokiTarget        `yaml:",omitempty"`
+
+	CloudWatch    *CloudWatchTarget    `yaml:"cloudwatch,omitempty"`
+	Elasticsearch *ElasticsearchTarget `yaml:",omitempty"`
+	SQL           *SQLTarget           `yaml:"sql,omitempty"`
 }
 
 type PrometheusTarget struct {
@@ -325,3 +335,205 @@ func (t StackdriverAlignment) toOption() (stackdriver.Option, error) {
 		return nil, ErrInvalidStackdriverAlignment
 	}
 }
+
+type CloudWatchTarget struct {
+	Namespace  string
+	Metric     string
+	Region     string            `yaml:",omitempty"`
+	Dimensions map[string]string `yaml:",omitempty"`
+	// Average, Sum, Minimum, Maximum, SampleCount or percentiles like p99
+	Statistics []string `yaml:",omitempty,flow"`
+	Period     string   `yaml:",omitempty"`
+	Legend     string   `yaml:",omitempty"`
+	Ref        string   `yaml:",omitempty"`
+	Hidden     bool     `yaml:",omitempty"`
+}
+
+func (t CloudWatchTarget) toOptions() []cloudwatch.Option {
+	opts := []cloudwatch.Option{
+		cloudwatch.Legend(t.Legend),
+		cloudwatch.Ref(t.Ref),
+	}
+
+	if t.Hidden {
+		opts = append(opts, cloudwatch.Hide())
+	}
+	if t.Region != "" {
+		opts = append(opts, cloudwatch.Region(t.Region))
+	}
+	if len(t.Dimensions) != 0 {
+		opts = append(opts, cloudwatch.Dimensions(t.Dimensions))
+	}
+	if t.Period != "" {
+		opts = append(opts, cloudwatch.Period(t.Period))
+	}
+	if len(t.Statistics) != 0 {
+		statistics := make([]cloudwatch.Statistic, 0, len(t.Statistics))
+		for _, statistic := range t.Statistics {
+			statistics = append(statistics, cloudwatch.Statistic(statistic))
+		}
+
+		opts = append(opts, cloudwatch.Statistics(statistics...))
+	}
+
+	return opts
+}
+
+type ElasticsearchTarget struct {
+	Query     string
+	TimeField string                `yaml:"time_field,omitempty"`
+	Metrics   []ElasticsearchMetric `yaml:",omitempty"`
+	GroupBy   []ElasticsearchBucket `yaml:"group_by,omitempty"`
+	Legend    string                `yaml:",omitempty"`
+	Ref       string                `yaml:",omitempty"`
+	Hidden    bool                  `yaml:",omitempty"`
+}
+
+type ElasticsearchMetric struct {
+	// Valid values are: count, avg, sum, min, max, unique_count
+	Type  string
+	Field string `yaml:",omitempty"`
+}
+
+type ElasticsearchBucket struct {
+	// Valid values are: date_histogram, histogram, terms
+	Type     string
+	Field    string
+	Interval string `yaml:",omitempty"`
+	Size     int    `yaml:",omitempty"`
+	Order    string `yaml:",omitempty"`
+	OrderBy  string `yaml:"order_by,omitempty"`
+}
+
+func (t ElasticsearchTarget) toOptions() ([]elasticsearch.Option, error) {
+	opts := []elasticsearch.Option{
+		elasticsearch.Legend(t.Legend),
+		elasticsearch.Ref(t.Ref),
+	}
+
+	if t.Hidden {
+		opts = append(opts, elasticsearch.Hide())
+	}
+	if t.TimeField != "" {
+		opts = append(opts, elasticsearch.TimeField(t.TimeField))
+	}
+
+	if len(t.Metrics) != 0 {
+		metrics := make([]elasticsearch.Metric, 0, len(t.Metrics))
+		for _, metric := range t.Metrics {
+			m, err := metric.toMetric()
+			if err != nil {
+				return nil, err
+			}
+
+			metrics = append(metrics, m)
+		}
+
+		opts = append(opts, elasticsearch.Metrics(metrics...))
+	}
+
+	if len(t.GroupBy) != 0 {
+		buckets := make([]elasticsearch.BucketAggregation, 0, len(t.GroupBy))
+		for _, bucket := range t.GroupBy {
+			b, err := bucket.toBucketAggregation()
+			if err != nil {
+				return nil, err
+			}
+
+			buckets = append(buckets, b)
+		}
+
+		opts = append(opts, elasticsearch.GroupBy(buckets...))
+	}
+
+	return opts, nil
+}
+
+func (metric ElasticsearchMetric) toMetric() (elasticsearch.Metric, error) {
+	switch metric.Type {
+	case "count":
+		return elasticsearch.Count(), nil
+	case "avg":
+		return elasticsearch.Average(metric.Field), nil
+	case "sum":
+		return elasticsearch.Sum(metric.Field), nil
+	case "min":
+		return elasticsearch.Min(metric.Field), nil
+	case "max":
+		return elasticsearch.Max(metric.Field), nil
+	case "unique_count":
+		return elasticsearch.UniqueCount(metric.Field), nil
+	default:
+		return elasticsearch.Metric{}, ErrInvalidElasticsearchMetric
+	}
+}
+
+func (bucket ElasticsearchBucket) toBucketAggregation() (elasticsearch.BucketAggregation, error) {
+	var aggregation elasticsearch.BucketAggregation
+
+	switch bucket.Type {
+	case "date_histogram":
+		interval := bucket.Interval
+		if interval == "" {
+			interval = "auto"
+		}
+
+		aggregation = elasticsearch.DateHistogram(bucket.Field, interval)
+	case "histogram":
+		aggregation = elasticsearch.Histogram(bucket.Field, bucket.Interval)
+	case "terms":
+		size := bucket.Size
+		if size == 0 {
+			size = 10
+		}
+
+		aggregation = elasticsearch.Terms(bucket.Field, size)
+	default:
+		return aggregation, ErrInvalidElasticsearchBucket
+	}
+
+	if bucket.Order != "" {
+		aggregation.Order = bucket.Order
+	}
+	if bucket.OrderBy != "" {
+		aggregation.OrderBy = bucket.OrderBy
+	}
+
+	return aggregation, nil
+}
+
+type SQLTarget struct {
+	Query string
+	// Valid values are: table, time_series
+	Format     string `yaml:",omitempty"`
+	TimeColumn string `yaml:"time_column,omitempty"`
+	Ref        string `yaml:",omitempty"`
+	Hidden     bool   `yaml:",omitempty"`
+}
+
+func (t SQLTarget) toOptions() ([]sql.Option, error) {
+	opts := []sql.Option{
+		sql.Ref(t.Ref),
+	}
+
+	if t.Hidden {
+		opts = append(opts, sql.Hide())
+	}
+	if t.TimeColumn != "" {
+		opts = append(opts, sql.TimeColumn(t.TimeColumn))
+	}
+
+	switch t.Format {
+	case "":
+		// Nothing to do
+		break
+	case "table":
+		opts = append(opts, sql.Format(sql.FormatTable))
+	case "time_series":
+		opts = append(opts, sql.Format(sql.FormatTimeSeries))
+	default:
+		return nil, ErrInvalidSQLFormat
+	}
+
+	return opts, nil
+}
@@ -3,9 +3,12 @@ package decoder
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
 	"github.com/K-Phoen/grabana/target/graphite"
 	"github.com/K-Phoen/grabana/target/influxdb"
 	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/target/sql"
 	"github.com/K-Phoen/grabana/target/stackdriver"
 	"github.com/stretchr/testify/require"
 )
@@ -340,3 +343,87 @@ func TestGraphiteHiddenTarget(t *testing.T) {
 
 	req.True(target.Builder.Hide)
 }
+
+func TestCloudWatchTarget(t *testing.T) {
+	req := require.New(t)
+
+	opts := CloudWatchTarget{
+		Region:     "eu-west-1",
+		Dimensions: map[string]string{"InstanceId": "*"},
+		Statistics: []string{"Maximum", "p99"},
+		Period:     "300",
+		Legend:     "{{InstanceId}}",
+		Hidden:     true,
+	}.toOptions()
+	target := cloudwatch.New("AWS/EC2", "CPUUtilization", opts...)
+
+	req.Equal("eu-west-1", target.Builder.Region)
+	req.Equal(map[string]string{"InstanceId": "*"}, target.Builder.Dimensions)
+	req.Equal([]string{"Maximum", "p99"}, target.Builder.Statistics)
+	req.Equal("300", target.Builder.Period)
+	req.Equal("{{InstanceId}}", target.Builder.Alias)
+	req.True(target.Builder.Hide)
+}
+
+func TestElasticsearchTarget(t *testing.T) {
+	req := require.New(t)
+
+	opts, err := ElasticsearchTarget{
+		TimeField: "time",
+		Metrics: []ElasticsearchMetric{
+			{Type: "count"},
+			{Type: "avg", Field: "duration"},
+		},
+		GroupBy: []ElasticsearchBucket{
+			{Type: "terms", Field: "host", Size: 5},
+			{Type: "date_histogram", Field: "time"},
+		},
+	}.toOptions()
+	req.NoError(err)
+
+	target := elasticsearch.New("level:error", opts...)
+
+	req.Equal("time", target.Builder.TimeField)
+	req.Len(target.Builder.Metrics, 2)
+	req.Equal("avg", target.Builder.Metrics[1].Type)
+	req.Len(target.Builder.BucketAggs, 2)
+	req.Equal("5", target.Builder.BucketAggs[0].Settings.Size)
+	req.Equal("auto", target.Builder.BucketAggs[1].Settings.Interval)
+}
+
+func TestElasticsearchTargetWithInvalidMetric(t *testing.T) {
+	req := require.New(t)
+
+	_, err := ElasticsearchTarget{Metrics: []ElasticsearchMetric{{Type: "median"}}}.toOptions()
+
+	req.ErrorIs(err, ErrInvalidElasticsearchMetric)
+}
+
+func TestElasticsearchTargetWithInvalidBucketAggregation(t *testing.T) {
+	req := require.New(t)
+
+	_, err := ElasticsearchTarget{GroupBy: []ElasticsearchBucket{{Type: "geohash_grid"}}}.toOptions()
+
+	req.ErrorIs(err, ErrInvalidElasticsearchBucket)
+}
+
+func TestSQLTarget(t *testing.T) {
+	req := require.New(t)
+
+	opts, err := SQLTarget{Format: "table", TimeColumn: "created_at", Hidden: true}.toOptions()
+	req.NoError(err)
+
+	target := sql.New("SELECT 1", opts...)
+
+	req.Equal("table", target.Builder.Format)
+	req.Equal("created_at", target.Builder.TimeColumn)
+	req.True(target.Builder.Hide)
+}
+
+func TestSQLTargetWithInvalidFormat(t *testing.T) {
+	req := require.New(t)
+
+	_, err := SQLTarget{Format: "heatmap"}.toOptions()
+
+	req.ErrorIs(err, ErrInvalidSQLFormat)
+}
@@ -19,14 +19,17 @@ var ErrInvalidOverrideMatcher = fmt.Errorf("invalid override matcher")
 
 type DashboardTimeSeries struct {
 	Title           string
-	Description     string              `yaml:",omitempty"`
-	Span            float32             `yaml:",omitempty"`
-	Height          string              `yaml:",omitempty"`
-	Transparent     bool                `yaml:",omitempty"`
-	Datasource      string              `yaml:",omitempty"`
-	Repeat          string              `yaml:",omitempty"`
-	RepeatDirection string              `yaml:"repeat_direction,omitempty"`
-	Links           DashboardPanelLinks `yaml:",omitempty"`
+	Description     string                   `yaml:",omitempty"`
+	Span            float32                  `yaml:",omitempty"`
+	Height          string                   `yaml:",omitempty"`
+	Transparent     bool                     `yaml:",omitempty"`
+	Datasource      string                   `yaml:",omitempty"`
+	Repeat          string                   `yaml:",omitempty"`
+	RepeatDirection string                   `yaml:"repeat_direction,omitempty"`
+	Links           DashboardPanelLinks      `yaml:",omitempty"`
+	Transformations DashboardTransformations `yaml:",omitempty"`
+	ValueMappings   DashboardValueMappings   `yaml:"value_mappings,omitempty"`
+	DataLinks       DashboardDataLinks       `yaml:"data_links,omitempty"`
 	Targets         []Target
 	Legend          []string                 `yaml:",omitempty,flow"`
 	Alert           *Alert                   `yaml:",omitempty"`
@@ -66,6 +69,30 @@ func (timeseriesPanel DashboardTimeSeries) toOption() (row.Option, error) {
 	if len(timeseriesPanel.Links) != 0 {
 		opts = append(opts, timeseries.Links(timeseriesPanel.Links.toModel()...))
 	}
+	if len(timeseriesPanel.Transformations) != 0 {
+		transformations, err := timeseriesPanel.Transformations.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, timeseries.Transformations(transformations...))
+	}
+	if len(timeseriesPanel.ValueMappings) != 0 {
+		mappings, err := timeseriesPanel.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, timeseries.ValueMappings(mappings...))
+	}
+	if len(timeseriesPanel.DataLinks) != 0 {
+		dataLinks, err := timeseriesPanel.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, timeseries.DataLinks(dataLinks...))
+	}
 	if len(timeseriesPanel.Legend) != 0 {
 		legendOpts, err := timeseriesPanel.legend()
 		if err != nil {
@@ -192,6 +219,26 @@ func (timeseriesPanel DashboardTimeSeries) target(t Target) (timeseries.Option,
 		return timeseries.WithStackdriverTarget(stackdriverTarget), nil
 	}
 
+	if t.CloudWatch != nil {
+		return timeseries.WithCloudWatchTarget(t.CloudWatch.Namespace, t.CloudWatch.Metric, t.CloudWatch.toOptions()...), nil
+	}
+	if t.Elasticsearch != nil {
+		opts, err := t.Elasticsearch.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return timeseries.WithElasticsearchTarget(t.Elasticsearch.Query, opts...), nil
+	}
+	if t.SQL != nil {
+		opts, err := t.SQL.toOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		return timeseries.WithSQLTarget(t.SQL.Query, opts...), nil
+	}
+
 	return nil, ErrTargetNotConfigured
 }
 
@@ -479,6 +526,9 @@ type TimeSeriesOverrideProperties struct {
 	NegativeY   *bool   `yaml:"negative_Y,omitempty"`
 	AxisDisplay *string `yaml:"axis_display,omitempty"`
 	Stack       *string `yaml:",omitempty"`
+
+	ValueMappings DashboardValueMappings `yaml:"value_mappings,omitempty"`
+	DataLinks     DashboardDataLinks     `yaml:"data_links,omitempty"`
 }
 
 func (properties TimeSeriesOverrideProperties) toOptions() ([]fields.OverrideOption, error) {
@@ -507,6 +557,22 @@ func (properties TimeSeriesOverrideProperties) toOptions() ([]fields.OverrideOpt
 	if properties.Stack != nil {
 		opts = append(opts, fields.Stack(fields.StackMode(*properties.Stack)))
 	}
+	if len(properties.ValueMappings) != 0 {
+		mappings, err := properties.ValueMappings.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.ValueMappings(mappings...))
+	}
+	if len(properties.DataLinks) != 0 {
+		dataLinks, err := properties.DataLinks.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, fields.DataLinks(dataLinks...))
+	}
 
 	return opts, nil
 }
@@ -47,8 +47,9 @@ func TestTimeSeriesCanBeDecoded(t *testing.T) {
 
 	testBoard, err := dashboard.New("test-board", dashboard.Row("test row", rowOption))
 	req.NoError(err)
-	req.Len(testBoard.Internal().Rows, 1)
-	panels := testBoard.Internal().Rows[0].Panels
+	// the first panel is the row itself
+	req.Len(testBoard.Internal().Panels, 2)
+	panels := testBoard.Internal().Panels[1:]
 	req.Len(panels, 1)
 
 	sdkPanel := panels[0]
@@ -60,7 +61,7 @@ func TestTimeSeriesCanBeDecoded(t *testing.T) {
 	req.Equal(panel.Datasource, sdkPanel.Datasource.LegacyName)
 	req.Equal(panel.Repeat, *sdkPanel.Repeat)
 	req.Equal(sdk.RepeatDirectionVertical, *sdkPanel.RepeatDirection)
-	req.Equal(panel.Span, sdkPanel.Span)
+	req.Equal(int(panel.Span*2), *sdkPanel.GridPos.W)
 	req.True(sdkPanel.Transparent)
 	req.Equal("hidden", tsPanel.Options.Legend.DisplayMode)
 
@@ -0,0 +1,403 @@
+package decoder
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/transformation"
+)
+
+var ErrInvalidTransformation = fmt.Errorf("invalid transformation")
+var ErrInvalidReducer = fmt.Errorf("invalid reducer")
+var ErrInvalidJoinMode = fmt.Errorf("invalid join mode")
+var ErrInvalidReduceMode = fmt.Errorf("invalid reduce mode")
+var ErrInvalidFilterType = fmt.Errorf("invalid filter type")
+var ErrInvalidFilterMatch = fmt.Errorf("invalid filter match")
+var ErrInvalidFilterCondition = fmt.Errorf("invalid filter condition")
+var ErrInvalidBinaryOperator = fmt.Errorf("invalid binary operator")
+
+type DashboardTransformations []DashboardTransformation
+
+type DashboardTransformation struct {
+	Organize       *OrganizeTransformation       `yaml:",omitempty"`
+	Merge          *MergeTransformation          `yaml:",omitempty"`
+	JoinByField    *JoinByFieldTransformation    `yaml:"join_by_field,omitempty"`
+	Reduce         *ReduceTransformation         `yaml:",omitempty"`
+	FilterByValue  *FilterByValueTransformation  `yaml:"filter_by_value,omitempty"`
+	GroupBy        *GroupByTransformation        `yaml:"group_by,omitempty"`
+	CalculateField *CalculateFieldTransformation `yaml:"calculate_field,omitempty"`
+	RenameByRegex  *RenameByRegexTransformation  `yaml:"rename_by_regex,omitempty"`
+	LabelsToFields *LabelsToFieldsTransformation `yaml:"labels_to_fields,omitempty"`
+	SortBy         *SortByTransformation         `yaml:"sort_by,omitempty"`
+	Limit          *int                          `yaml:",omitempty"`
+}
+
+type OrganizeTransformation struct {
+	Exclude []string          `yaml:",omitempty,flow"`
+	Rename  map[string]string `yaml:",omitempty"`
+	Order   []string          `yaml:",omitempty,flow"`
+}
+
+type MergeTransformation struct{}
+
+type JoinByFieldTransformation struct {
+	Field string
+	// Valid values are: outer, inner
+	Mode string `yaml:",omitempty"`
+}
+
+type ReduceTransformation struct {
+	// Valid values are: series_to_rows, reduce_fields
+	Mode     string   `yaml:",omitempty"`
+	Reducers []string `yaml:",flow"`
+}
+
+type FilterByValueTransformation struct {
+	// Valid values are: include, exclude
+	Type string `yaml:",omitempty"`
+	// Valid values are: any, all
+	Match      string
+	Conditions []FilterCondition
+}
+
+type FilterCondition struct {
+	Field string
+	// Valid values are: greater, greater_or_equal, lower, lower_or_equal,
+	// equal, not_equal, range, regex, is_null, is_not_null
+	Matcher string
+	Value   interface{} `yaml:",omitempty"`
+	From    float64     `yaml:",omitempty"`
+	To      float64     `yaml:",omitempty"`
+}
+
+type GroupByTransformation struct {
+	By        []string           `yaml:",flow"`
+	Aggregate []GroupByAggregate `yaml:",omitempty"`
+}
+
+type GroupByAggregate struct {
+	Field    string
+	Reducers []string `yaml:",flow"`
+}
+
+type CalculateFieldTransformation struct {
+	Alias         string
+	Binary        *BinaryCalculation    `yaml:",omitempty"`
+	ReduceRow     *ReduceRowCalculation `yaml:"reduce_row,omitempty"`
+	ReplaceFields bool                  `yaml:"replace_fields,omitempty"`
+}
+
+type BinaryCalculation struct {
+	Left string
+	// Valid values are: +, -, *, /
+	Operator string
+	Right    string
+}
+
+type ReduceRowCalculation struct {
+	Reducer string
+	Fields  []string `yaml:",omitempty,flow"`
+}
+
+type RenameByRegexTransformation struct {
+	Regex       string
+	Replacement string
+}
+
+type LabelsToFieldsTransformation struct {
+	AsRows     bool     `yaml:"as_rows,omitempty"`
+	ValueLabel string   `yaml:"value_label,omitempty"`
+	KeepLabels []string `yaml:"keep_labels,omitempty,flow"`
+}
+
+type SortByTransformation struct {
+	Field      string
+	Descending bool `yaml:",omitempty"`
+}
+
+func (transformations DashboardTransformations) toModel() ([]transformation.Transformation, error) {
+	models := make([]transformation.Transformation, 0, len(transformations))
+
+	for _, t := range transformations {
+		model, err := t.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+func (t DashboardTransformation) toModel() (transformation.Transformation, error) {
+	switch {
+	case t.Organize != nil:
+		return t.Organize.toModel(), nil
+	case t.Merge != nil:
+		return transformation.Merge(), nil
+	case t.JoinByField != nil:
+		return t.JoinByField.toModel()
+	case t.Reduce != nil:
+		return t.Reduce.toModel()
+	case t.FilterByValue != nil:
+		return t.FilterByValue.toModel()
+	case t.GroupBy != nil:
+		return t.GroupBy.toModel()
+	case t.CalculateField != nil:
+		return t.CalculateField.toModel()
+	case t.RenameByRegex != nil:
+		return transformation.RenameByRegex(t.RenameByRegex.Regex, t.RenameByRegex.Replacement), nil
+	case t.LabelsToFields != nil:
+		return t.LabelsToFields.toModel(), nil
+	case t.SortBy != nil:
+		order := transformation.Ascending
+		if t.SortBy.Descending {
+			order = transformation.Descending
+		}
+
+		return transformation.SortBy(t.SortBy.Field, order), nil
+	case t.Limit != nil:
+		return transformation.Limit(*t.Limit), nil
+	}
+
+	return transformation.Transformation{}, ErrInvalidTransformation
+}
+
+func (t OrganizeTransformation) toModel() transformation.Transformation {
+	opts := []transformation.OrganizeOption{}
+
+	if len(t.Exclude) != 0 {
+		opts = append(opts, transformation.ExcludeFields(t.Exclude...))
+	}
+	for field, name := range t.Rename {
+		opts = append(opts, transformation.RenameField(field, name))
+	}
+	if len(t.Order) != 0 {
+		opts = append(opts, transformation.OrderFields(t.Order...))
+	}
+
+	return transformation.OrganizeFields(opts...)
+}
+
+func (t JoinByFieldTransformation) toModel() (transformation.Transformation, error) {
+	switch t.Mode {
+	case "", "outer":
+		return transformation.JoinByField(t.Field, transformation.OuterJoin), nil
+	case "inner":
+		return transformation.JoinByField(t.Field, transformation.InnerJoin), nil
+	}
+
+	return transformation.Transformation{}, ErrInvalidJoinMode
+}
+
+func (t ReduceTransformation) toModel() (transformation.Transformation, error) {
+	var mode transformation.ReduceMode
+
+	switch t.Mode {
+	case "", "series_to_rows":
+		mode = transformation.SeriesToRows
+	case "reduce_fields":
+		mode = transformation.ReduceFields
+	default:
+		return transformation.Transformation{}, ErrInvalidReduceMode
+	}
+
+	reducers, err := parseReducers(t.Reducers)
+	if err != nil {
+		return transformation.Transformation{}, err
+	}
+
+	return transformation.Reduce(mode, reducers...), nil
+}
+
+func (t FilterByValueTransformation) toModel() (transformation.Transformation, error) {
+	var filterType transformation.FilterType
+	var match transformation.MatchType
+
+	switch t.Type {
+	case "", "include":
+		filterType = transformation.Include
+	case "exclude":
+		filterType = transformation.Exclude
+	default:
+		return transformation.Transformation{}, ErrInvalidFilterType
+	}
+
+	switch t.Match {
+	case "", "any":
+		match = transformation.MatchAny
+	case "all":
+		match = transformation.MatchAll
+	default:
+		return transformation.Transformation{}, ErrInvalidFilterMatch
+	}
+
+	conditions := make([]transformation.Condition, 0, len(t.Conditions))
+	for _, condition := range t.Conditions {
+		model, err := condition.toModel()
+		if err != nil {
+			return transformation.Transformation{}, err
+		}
+
+		conditions = append(conditions, model)
+	}
+
+	return transformation.FilterByValue(filterType, match, conditions...), nil
+}
+
+func (condition FilterCondition) toModel() (transformation.Condition, error) {
+	switch condition.Matcher {
+	case "equal":
+		return transformation.Equal(condition.Field, condition.Value), nil
+	case "not_equal":
+		return transformation.NotEqual(condition.Field, condition.Value), nil
+	case "range":
+		return transformation.InRange(condition.Field, condition.From, condition.To), nil
+	case "regex":
+		return transformation.Regex(condition.Field, fmt.Sprintf("%v", condition.Value)), nil
+	case "is_null":
+		return transformation.IsNull(condition.Field), nil
+	case "is_not_null":
+		return transformation.IsNotNull(condition.Field), nil
+	}
+
+	value, ok := toFloat(condition.Value)
+	if !ok {
+		return transformation.Condition{}, ErrInvalidFilterCondition
+	}
+
+	switch condition.Matcher {
+	case "greater":
+		return transformation.Greater(condition.Field, value), nil
+	case "greater_or_equal":
+		return transformation.GreaterOrEqual(condition.Field, value), nil
+	case "lower":
+		return transformation.Lower(condition.Field, value), nil
+	case "lower_or_equal":
+		return transformation.LowerOrEqual(condition.Field, value), nil
+	}
+
+	return transformation.Condition{}, ErrInvalidFilterCondition
+}
+
+func (t GroupByTransformation) toModel() (transformation.Transformation, error) {
+	opts := []transformation.GroupByOption{
+		transformation.By(t.By...),
+	}
+
+	for _, aggregate := range t.Aggregate {
+		reducers, err := parseReducers(aggregate.Reducers)
+		if err != nil {
+			return transformation.Transformation{}, err
+		}
+
+		opts = append(opts, transformation.Aggregate(aggregate.Field, reducers...))
+	}
+
+	return transformation.GroupBy(opts...), nil
+}
+
+func (t CalculateFieldTransformation) toModel() (transformation.Transformation, error) {
+	var calculation transformation.Calculation
+
+	switch {
+	case t.Binary != nil:
+		operator := transformation.BinaryOperator(t.Binary.Operator)
+		switch operator {
+		case transformation.Add, transformation.Subtract, transformation.Multiply, transformation.Divide:
+		default:
+			return transformation.Transformation{}, ErrInvalidBinaryOperator
+		}
+
+		calculation = transformation.Binary(t.Binary.Left, operator, t.Binary.Right)
+	case t.ReduceRow != nil:
+		reducer, err := parseReducer(t.ReduceRow.Reducer)
+		if err != nil {
+			return transformation.Transformation{}, err
+		}
+
+		calculation = transformation.ReduceRow(reducer, t.ReduceRow.Fields...)
+	default:
+		return transformation.Transformation{}, ErrInvalidTransformation
+	}
+
+	opts := []transformation.CalculateFieldOption{}
+	if t.ReplaceFields {
+		opts = append(opts, transformation.ReplaceFields())
+	}
+
+	return transformation.CalculateField(t.Alias, calculation, opts...), nil
+}
+
+func (t LabelsToFieldsTransformation) toModel() transformation.Transformation {
+	opts := []transformation.LabelsToFieldsOption{}
+
+	if t.AsRows {
+		opts = append(opts, transformation.LabelsAsRows())
+	}
+	if t.ValueLabel != "" {
+		opts = append(opts, transformation.ValueLabel(t.ValueLabel))
+	}
+	if len(t.KeepLabels) != 0 {
+		opts = append(opts, transformation.KeepLabels(t.KeepLabels...))
+	}
+
+	return transformation.LabelsToFields(opts...)
+}
+
+func parseReducers(input []string) ([]transformation.Reducer, error) {
+	reducers := make([]transformation.Reducer, 0, len(input))
+
+	for _, item := range input {
+		reducer, err := parseReducer(item)
+		if err != nil {
+			return nil, err
+		}
+
+		reducers = append(reducers, reducer)
+	}
+
+	return reducers, nil
+}
+
+func parseReducer(input string) (transformation.Reducer, error) {
+	switch input {
+	case "min":
+		return transformation.Min, nil
+	case "max":
+		return transformation.Max, nil
+	case "avg":
+		return transformation.Mean, nil
+	case "first":
+		return transformation.First, nil
+	case "first_non_null":
+		return transformation.FirstNotNull, nil
+	case "last":
+		return transformation.Last, nil
+	case "last_non_null":
+		return transformation.LastNotNull, nil
+	case "total":
+		return transformation.Sum, nil
+	case "count":
+		return transformation.Count, nil
+	case "range":
+		return transformation.Range, nil
+	case "delta":
+		return transformation.Delta, nil
+	case "distinct_count":
+		return transformation.Distinct, nil
+	}
+
+	return "", ErrInvalidReducer
+}
+
+func toFloat(input interface{}) (float64, bool) {
+	switch value := input.(type) {
+	case int:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,170 @@
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/geomap"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTransformationsCanBeDecoded(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		yaml     string
+		expected string
+	}{
+		{
+			desc:     "merge",
+			yaml:     `merge: {}`,
+			expected: `{"id": "merge", "options": {}}`,
+		},
+		{
+			desc:     "organize",
+			yaml:     `organize: {exclude: [Time], rename: {Value: Requests}, order: [host, Value]}`,
+			expected: `{"id": "organize", "options": {"excludeByName": {"Time": true}, "indexByName": {"host": 0, "Value": 1}, "renameByName": {"Value": "Requests"}}}`,
+		},
+		{
+			desc:     "join by field",
+			yaml:     `join_by_field: {field: time, mode: inner}`,
+			expected: `{"id": "joinByField", "options": {"byField": "time", "mode": "inner"}}`,
+		},
+		{
+			desc:     "reduce",
+			yaml:     `reduce: {mode: reduce_fields, reducers: [avg, last_non_null]}`,
+			expected: `{"id": "reduce", "options": {"mode": "reduceFields", "reducers": ["mean", "lastNotNull"]}}`,
+		},
+		{
+			desc: "filter by value",
+			yaml: `filter_by_value:
+  type: exclude
+  match: all
+  conditions:
+    - {field: Value, matcher: greater, value: 10}
+    - {field: host, matcher: regex, value: "web-.*"}
+    - {field: Other, matcher: range, from: 1, to: 2}`,
+			expected: `{"id": "filterByValue", "options": {"type": "exclude", "match": "all", "filters": [
+				{"fieldName": "Value", "config": {"id": "greater", "options": {"value": 10}}},
+				{"fieldName": "host", "config": {"id": "regex", "options": {"value": "web-.*"}}},
+				{"fieldName": "Other", "config": {"id": "range", "options": {"from": 1, "to": 2}}}
+			]}}`,
+		},
+		{
+			desc: "group by",
+			yaml: `group_by: {by: [host], aggregate: [{field: Value, reducers: [total]}]}`,
+			expected: `{"id": "groupBy", "options": {"fields": {
+				"host": {"aggregations": [], "operation": "groupby"},
+				"Value": {"aggregations": ["sum"], "operation": "aggregate"}
+			}}}`,
+		},
+		{
+			desc:     "calculate field",
+			yaml:     `calculate_field: {alias: ratio, binary: {left: errors, operator: /, right: total}, replace_fields: true}`,
+			expected: `{"id": "calculateField", "options": {"alias": "ratio", "mode": "binary", "binary": {"left": "errors", "operator": "/", "right": "total"}, "replaceFields": true}}`,
+		},
+		{
+			desc:     "rename by regex",
+			yaml:     `rename_by_regex: {regex: "(.*)-total", replacement: "$1"}`,
+			expected: `{"id": "renameByRegex", "options": {"regex": "(.*)-total", "renamePattern": "$1"}}`,
+		},
+		{
+			desc:     "labels to fields",
+			yaml:     `labels_to_fields: {as_rows: true, value_label: name}`,
+			expected: `{"id": "labelsToFields", "options": {"mode": "rows", "valueLabel": "name"}}`,
+		},
+		{
+			desc:     "sort by",
+			yaml:     `sort_by: {field: Value, descending: true}`,
+			expected: `{"id": "sortBy", "options": {"sort": [{"field": "Value", "desc": true}]}}`,
+		},
+		{
+			desc:     "limit",
+			yaml:     `limit: 10`,
+			expected: `{"id": "limit", "options": {"limitField": 10}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			decoded := DashboardTransformation{}
+			req.NoError(yaml.Unmarshal([]byte(tc.yaml), &decoded))
+
+			model, err := decoded.toModel()
+			req.NoError(err)
+
+			payload, err := json.Marshal(model)
+			req.NoError(err)
+
+			req.JSONEq(tc.expected, string(payload))
+		})
+	}
+}
+
+func TestInvalidTransformationsAreRejected(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		yaml     string
+		expected error
+	}{
+		{desc: "empty", yaml: `{}`, expected: ErrInvalidTransformation},
+		{desc: "invalid join mode", yaml: `join_by_field: {field: time, mode: left}`, expected: ErrInvalidJoinMode},
+		{desc: "invalid reduce mode", yaml: `reduce: {mode: invalid, reducers: [max]}`, expected: ErrInvalidReduceMode},
+		{desc: "invalid reducer", yaml: `reduce: {reducers: [median]}`, expected: ErrInvalidReducer},
+		{desc: "invalid filter type", yaml: `filter_by_value: {type: invalid, conditions: []}`, expected: ErrInvalidFilterType},
+		{desc: "invalid filter match", yaml: `filter_by_value: {match: invalid, conditions: []}`, expected: ErrInvalidFilterMatch},
+		{desc: "invalid filter matcher", yaml: `filter_by_value: {conditions: [{field: Value, matcher: invalid, value: 2}]}`, expected: ErrInvalidFilterCondition},
+		{desc: "non-numeric filter value", yaml: `filter_by_value: {conditions: [{field: Value, matcher: greater, value: foo}]}`, expected: ErrInvalidFilterCondition},
+		{desc: "invalid binary operator", yaml: `calculate_field: {alias: foo, binary: {left: A, operator: "%", right: B}}`, expected: ErrInvalidBinaryOperator},
+		{desc: "calculation not configured", yaml: `calculate_field: {alias: foo}`, expected: ErrInvalidTransformation},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			decoded := DashboardTransformation{}
+			req.NoError(yaml.Unmarshal([]byte(tc.yaml), &decoded))
+
+			_, err := decoded.toModel()
+			req.ErrorIs(err, tc.expected)
+		})
+	}
+}
+
+func TestPanelsCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel := DashboardGeomap{
+		Title: "Map",
+		Transformations: DashboardTransformations{
+			{Merge: &MergeTransformation{}},
+		},
+	}
+
+	opts, err := panel.toOptions()
+	req.NoError(err)
+
+	geomapPanel, err := geomap.New(panel.Title, opts...)
+	req.NoError(err)
+
+	payload, err := json.Marshal(geomapPanel.Builder)
+	req.NoError(err)
+	req.Contains(string(payload), `"transformations":[{"id":"merge","options":{}}]`)
+
+	invalidPanel := DashboardStat{
+		Title: "Stat",
+		Transformations: DashboardTransformations{
+			{},
+		},
+	}
+
+	_, err = invalidPanel.toOption()
+	req.ErrorIs(err, ErrInvalidTransformation)
+}
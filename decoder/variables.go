@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/variable/adhoc"
 	"github.com/K-Phoen/grabana/variable/constant"
 	"github.com/K-Phoen/grabana/variable/custom"
 	"github.com/K-Phoen/grabana/variable/datasource"
@@ -14,6 +15,9 @@ import (
 
 var ErrVariableNotConfigured = fmt.Errorf("variable not configured")
 var ErrInvalidHideValue = fmt.Errorf("invalid hide value. Valid values are: 'label', 'variable', empty")
+var ErrInvalidAdHocOperator = fmt.Errorf("invalid ad hoc filter operator. Valid values are: '=', '!=', '=~', '!~'")
+var ErrInvalidRefreshValue = fmt.Errorf("invalid refresh value. Valid values are: 'never', 'dashboard_load', 'time_change', empty")
+var ErrInvalidQueryVariable = fmt.Errorf("a query variable must define exactly one query: request, prometheus or loki")
 
 type DashboardVariable struct {
 	Interval   *VariableInterval   `yaml:",omitempty"`
@@ -22,6 +26,7 @@ type DashboardVariable struct {
 	Const      *VariableConst      `yaml:",omitempty"`
 	Datasource *VariableDatasource `yaml:",omitempty"`
 	Text       *VariableText       `yaml:",omitempty"`
+	AdHoc      *VariableAdHoc      `yaml:"adhoc,omitempty"`
 }
 
 func (variable *DashboardVariable) toOption() (dashboard.Option, error) {
@@ -43,6 +48,9 @@ func (variable *DashboardVariable) toOption() (dashboard.Option, error) {
 	if variable.Text != nil {
 		return variable.Text.toOption()
 	}
+	if variable.AdHoc != nil {
+		return variable.AdHoc.toOption()
+	}
 
 	return nil, ErrVariableNotConfigured
 }
@@ -168,22 +176,31 @@ type VariableQuery struct {
 	Name  string
 	Label string `yaml:",omitempty"`
 
-	Datasource string `yaml:",omitempty"`
-	Request    string
-
-	Regex      string `yaml:",omitempty"`
-	IncludeAll bool   `yaml:"include_all"`
-	DefaultAll bool   `yaml:"default_all"`
-	AllValue   string `yaml:"all_value,omitempty"`
-	Hide       string `yaml:",omitempty"`
-	Multiple   bool   `yaml:",omitempty"`
+	Datasource string                   `yaml:",omitempty"`
+	Request    string                   `yaml:",omitempty"`
+	Prometheus *VariableQueryPrometheus `yaml:",omitempty"`
+	Loki       *VariableQueryLoki       `yaml:",omitempty"`
+
+	Regex      string   `yaml:",omitempty"`
+	IncludeAll bool     `yaml:"include_all"`
+	DefaultAll bool     `yaml:"default_all"`
+	AllValue   string   `yaml:"all_value,omitempty"`
+	Hide       string   `yaml:",omitempty"`
+	Multiple   bool     `yaml:",omitempty"`
+	Current    []string `yaml:",omitempty,flow"`
+	Options    []string `yaml:",omitempty,flow"`
+	// Valid values are: 'never', 'dashboard_load', 'time_change'
+	Refresh string `yaml:",omitempty"`
 }
 
 func (variable *VariableQuery) toOption() (dashboard.Option, error) {
-	opts := []query.Option{
-		query.Request(variable.Request),
+	request, err := variable.request()
+	if err != nil {
+		return dashboard.VariableAsQuery(variable.Name), err
 	}
 
+	opts := []query.Option{request}
+
 	if variable.Datasource != "" {
 		opts = append(opts, query.DataSource(variable.Datasource))
 	}
@@ -205,6 +222,12 @@ func (variable *VariableQuery) toOption() (dashboard.Option, error) {
 	if variable.Multiple {
 		opts = append(opts, query.Multiple())
 	}
+	if len(variable.Options) != 0 {
+		opts = append(opts, query.StaticOptions(variable.Options...))
+	}
+	if len(variable.Current) != 0 {
+		opts = append(opts, query.Current(variable.Current...))
+	}
 
 	switch variable.Hide {
 	case "":
@@ -218,9 +241,104 @@ func (variable *VariableQuery) toOption() (dashboard.Option, error) {
 		return dashboard.VariableAsQuery(variable.Name), ErrInvalidHideValue
 	}
 
+	switch variable.Refresh {
+	case "":
+		// Nothing to do
+		break
+	case "never":
+		opts = append(opts, query.Refresh(query.Never))
+	case "dashboard_load":
+		opts = append(opts, query.Refresh(query.DashboardLoad))
+	case "time_change":
+		opts = append(opts, query.Refresh(query.TimeChange))
+	default:
+		return dashboard.VariableAsQuery(variable.Name), ErrInvalidRefreshValue
+	}
+
 	return dashboard.VariableAsQuery(variable.Name, opts...), nil
 }
 
+func (variable *VariableQuery) request() (query.Option, error) {
+	defined := 0
+	if variable.Request != "" {
+		defined++
+	}
+	if variable.Prometheus != nil {
+		defined++
+	}
+	if variable.Loki != nil {
+		defined++
+	}
+
+	if defined > 1 {
+		return nil, ErrInvalidQueryVariable
+	}
+
+	if variable.Prometheus != nil {
+		return variable.Prometheus.toOption()
+	}
+	if variable.Loki != nil {
+		return variable.Loki.toOption()
+	}
+
+	return query.Request(variable.Request), nil
+}
+
+type VariableQueryPrometheus struct {
+	LabelNames  bool   `yaml:"label_names,omitempty"`
+	LabelValues string `yaml:"label_values,omitempty"`
+	// Restricts the series considered by label_values
+	Selector    string `yaml:",omitempty"`
+	Metrics     string `yaml:",omitempty"`
+	QueryResult string `yaml:"query_result,omitempty"`
+	Series      string `yaml:",omitempty"`
+}
+
+func (prometheus *VariableQueryPrometheus) toOption() (query.Option, error) {
+	var opts []query.Option
+
+	if prometheus.LabelNames {
+		opts = append(opts, query.PrometheusLabelNames())
+	}
+	if prometheus.LabelValues != "" {
+		opts = append(opts, query.PrometheusLabelValues(prometheus.LabelValues, prometheus.Selector))
+	}
+	if prometheus.Metrics != "" {
+		opts = append(opts, query.PrometheusMetrics(prometheus.Metrics))
+	}
+	if prometheus.QueryResult != "" {
+		opts = append(opts, query.PrometheusQueryResult(prometheus.QueryResult))
+	}
+	if prometheus.Series != "" {
+		opts = append(opts, query.PrometheusSeries(prometheus.Series))
+	}
+
+	if len(opts) != 1 {
+		return nil, ErrInvalidQueryVariable
+	}
+
+	return opts[0], nil
+}
+
+type VariableQueryLoki struct {
+	LabelNames  bool   `yaml:"label_names,omitempty"`
+	LabelValues string `yaml:"label_values,omitempty"`
+	// Restricts the streams considered by label_values
+	Stream string `yaml:",omitempty"`
+}
+
+func (loki *VariableQueryLoki) toOption() (query.Option, error) {
+	if loki.LabelNames == (loki.LabelValues != "") {
+		return nil, ErrInvalidQueryVariable
+	}
+
+	if loki.LabelNames {
+		return query.LokiLabelNames(), nil
+	}
+
+	return query.LokiLabelValues(loki.LabelValues, loki.Stream), nil
+}
+
 type VariableDatasource struct {
 	Name  string
 	Label string `yaml:",omitempty"`
@@ -293,3 +411,92 @@ func (variable *VariableText) toOption() (dashboard.Option, error) {
 
 	return dashboard.VariableAsText(variable.Name, opts...), nil
 }
+
+type VariableAdHoc struct {
+	Name       string
+	Label      string `yaml:",omitempty"`
+	Datasource string `yaml:",omitempty"`
+	Hide       string `yaml:",omitempty"`
+
+	// Filters applied by default, until modified from the dashboard.
+	Filters []AdHocFilter `yaml:",omitempty"`
+	// Filters always applied, that can not be modified from the dashboard.
+	BaseFilters []AdHocFilter `yaml:"base_filters,omitempty"`
+}
+
+type AdHocFilter struct {
+	Key string
+	// Valid values are: =, !=, =~, !~. Defaults to =.
+	Operator string `yaml:",omitempty"`
+	Value    string
+}
+
+func (filter AdHocFilter) toModel() (adhoc.Filter, error) {
+	operator := adhoc.Operator(filter.Operator)
+
+	switch operator {
+	case "":
+		operator = adhoc.Equal
+	case adhoc.Equal, adhoc.NotEqual, adhoc.MatchesRegex, adhoc.NotMatchesRegex:
+	default:
+		return adhoc.Filter{}, ErrInvalidAdHocOperator
+	}
+
+	return adhoc.Filter{Key: filter.Key, Operator: operator, Value: filter.Value}, nil
+}
+
+func (variable *VariableAdHoc) toOption() (dashboard.Option, error) {
+	var opts []adhoc.Option
+
+	if variable.Label != "" {
+		opts = append(opts, adhoc.Label(variable.Label))
+	}
+	if variable.Datasource != "" {
+		opts = append(opts, adhoc.DataSource(variable.Datasource))
+	}
+
+	filters, err := adHocFilters(variable.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if len(filters) != 0 {
+		opts = append(opts, adhoc.Filters(filters...))
+	}
+
+	baseFilters, err := adHocFilters(variable.BaseFilters)
+	if err != nil {
+		return nil, err
+	}
+	if len(baseFilters) != 0 {
+		opts = append(opts, adhoc.BaseFilters(baseFilters...))
+	}
+
+	switch variable.Hide {
+	case "":
+		// Nothing to do
+		break
+	case "label":
+		opts = append(opts, adhoc.HideLabel())
+	case "variable":
+		opts = append(opts, adhoc.Hide())
+	default:
+		return dashboard.VariableAsAdHoc(variable.Name), ErrInvalidHideValue
+	}
+
+	return dashboard.VariableAsAdHoc(variable.Name, opts...), nil
+}
+
+func adHocFilters(filters []AdHocFilter) ([]adhoc.Filter, error) {
+	models := make([]adhoc.Filter, 0, len(filters))
+
+	for _, filter := range filters {
+		model, err := filter.toModel()
+		if err != nil {
+			return nil, err
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
@@ -3,6 +3,8 @@ package decoder
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,6 +36,10 @@ func TestValidHideValues(t *testing.T) {
 			datasource := VariableDatasource{Hide: tc}
 			_, err = datasource.toOption()
 			req.NoError(err)
+
+			adhoc := VariableAdHoc{Hide: tc}
+			_, err = adhoc.toOption()
+			req.NoError(err)
 		})
 	}
 }
@@ -61,4 +67,159 @@ func TestInvalidHideValue(t *testing.T) {
 	datasource := VariableDatasource{Hide: invalidValue}
 	_, err = datasource.toOption()
 	req.Equal(ErrInvalidHideValue, err)
+
+	adhoc := VariableAdHoc{Hide: invalidValue}
+	_, err = adhoc.toOption()
+	req.Equal(ErrInvalidHideValue, err)
+}
+
+func TestAdHocVariablesCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	variable := VariableAdHoc{
+		Name:       "filters",
+		Label:      "Filters",
+		Datasource: "prometheus",
+		Filters: []AdHocFilter{
+			{Key: "job", Value: "api"},
+		},
+		BaseFilters: []AdHocFilter{
+			{Key: "env", Operator: "!~", Value: "dev.*"},
+		},
+	}
+
+	opt, err := variable.toOption()
+	req.NoError(err)
+
+	builder, err := dashboard.New("", opt)
+	req.NoError(err)
+
+	templatedVar := builder.Internal().Templating.List[0]
+	req.Equal("adhoc", templatedVar.Type)
+	req.Equal("Filters", templatedVar.Label)
+	req.Equal("prometheus", templatedVar.Datasource.LegacyName)
+
+	content, err := builder.MarshalJSON()
+	req.NoError(err)
+	req.Contains(string(content), `"filters":[{"key":"job","operator":"=","value":"api"}]`)
+	req.Contains(string(content), `"baseFilters":[{"key":"env","operator":"!~","value":"dev.*"}]`)
+}
+
+func TestAdHocVariablesWithInvalidOperatorsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	variable := VariableAdHoc{
+		Name:    "filters",
+		Filters: []AdHocFilter{{Key: "job", Operator: "~=", Value: "api"}},
+	}
+
+	_, err := variable.toOption()
+
+	req.ErrorIs(err, ErrInvalidAdHocOperator)
+}
+
+func TestPrometheusQueryVariablesCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	variable := VariableQuery{
+		Name:       "instance",
+		Datasource: "prometheus",
+		Prometheus: &VariableQueryPrometheus{LabelValues: "instance", Selector: `up{job="api"}`},
+		Options:    []string{"api-1", "api-2"},
+		Current:    []string{"api-2"},
+		Refresh:    "never",
+	}
+
+	opt, err := variable.toOption()
+	req.NoError(err)
+
+	builder, err := dashboard.New("", opt)
+	req.NoError(err)
+
+	templatedVar := builder.Internal().Templating.List[0]
+	req.Equal("api-2", templatedVar.Current.Value)
+	req.Len(templatedVar.Options, 2)
+	req.True(templatedVar.Options[1].Selected)
+	req.Equal(int64(0), *templatedVar.Refresh.Value)
+
+	content, err := builder.MarshalJSON()
+	req.NoError(err)
+	req.Contains(string(content), `"definition":"label_values(up{job=\"api\"},instance)"`)
+}
+
+func TestLokiQueryVariablesCanBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	variable := VariableQuery{
+		Name: "app",
+		Loki: &VariableQueryLoki{LabelValues: "app", Stream: `{namespace="prod"}`},
+	}
+
+	opt, err := variable.toOption()
+	req.NoError(err)
+
+	builder, err := dashboard.New("", opt)
+	req.NoError(err)
+
+	request := builder.Internal().Templating.List[0].Query.(map[string]interface{})
+	req.Equal("app", request["label"])
+	req.Equal(`{namespace="prod"}`, request["stream"])
+}
+
+func TestQueryVariablesMustDefineASingleQuery(t *testing.T) {
+	testCases := map[string]VariableQuery{
+		"request and prometheus": {
+			Request:    "label_values(job)",
+			Prometheus: &VariableQueryPrometheus{LabelNames: true},
+		},
+		"several prometheus queries": {
+			Prometheus: &VariableQueryPrometheus{LabelNames: true, Metrics: ".*"},
+		},
+		"empty prometheus query": {
+			Prometheus: &VariableQueryPrometheus{},
+		},
+		"several loki queries": {
+			Loki: &VariableQueryLoki{LabelNames: true, LabelValues: "app"},
+		},
+		"empty loki query": {
+			Loki: &VariableQueryLoki{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		tc := testCase
+
+		t.Run(name, func(t *testing.T) {
+			req := require.New(t)
+
+			_, err := tc.toOption()
+
+			req.ErrorIs(err, ErrInvalidQueryVariable)
+		})
+	}
+}
+
+func TestQueryVariablesWithInvalidRefreshAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	variable := VariableQuery{Name: "job", Request: "label_values(job)", Refresh: "sometimes"}
+
+	_, err := variable.toOption()
+
+	req.ErrorIs(err, ErrInvalidRefreshValue)
+}
+
+func TestVariablesUsedBeforeBeingDeclaredCanNotBeDecoded(t *testing.T) {
+	req := require.New(t)
+
+	model := DashboardModel{
+		Variables: []DashboardVariable{
+			{Query: &VariableQuery{Name: "instance", Request: `label_values(up{job="$job"}, instance)`}},
+			{Query: &VariableQuery{Name: "job", Request: "label_values(up, job)"}},
+		},
+	}
+
+	_, err := model.ToBuilder()
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
 }
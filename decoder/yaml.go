@@ -3,7 +3,9 @@ package decoder
 import (
 	"io"
 
+	"github.com/K-Phoen/grabana"
 	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/datasource"
 	"gopkg.in/yaml.v3"
 )
 
@@ -18,3 +20,42 @@ func UnmarshalYAML(input io.Reader) (dashboard.Builder, error) {
 
 	return parsed.ToBuilder()
 }
+
+// UnmarshalDatasourcesYAML decodes a list of datasources. Secrets are read
+// from the environment or from files.
+func UnmarshalDatasourcesYAML(input io.Reader) ([]datasource.Datasource, error) {
+	return unmarshalDatasourcesYAML(input, resolveSecret)
+}
+
+// UnmarshalDatasourcesYAMLForProvisioning decodes a list of datasources
+// meant to be written in a Grafana provisioning file. Secrets are not read:
+// they are referenced using Grafana's $__env{} and $__file{} syntax instead.
+func UnmarshalDatasourcesYAMLForProvisioning(input io.Reader) ([]datasource.Datasource, error) {
+	return unmarshalDatasourcesYAML(input, referenceSecret)
+}
+
+// UnmarshalPromotionRulesYAML decodes the rules applied to dashboards
+// promoted from an instance to another.
+func UnmarshalPromotionRulesYAML(input io.Reader) (grabana.PromotionRules, error) {
+	decoder := yaml.NewDecoder(input)
+	decoder.KnownFields(true)
+
+	parsed := &PromotionRulesModel{}
+	if err := decoder.Decode(parsed); err != nil {
+		return grabana.PromotionRules{}, err
+	}
+
+	return parsed.toRules(), nil
+}
+
+func unmarshalDatasourcesYAML(input io.Reader, secrets secretResolver) ([]datasource.Datasource, error) {
+	decoder := yaml.NewDecoder(input)
+	decoder.KnownFields(true)
+
+	parsed := &DatasourcesModel{}
+	if err := decoder.Decode(parsed); err != nil {
+		return nil, err
+	}
+
+	return parsed.toDatasources(secrets)
+}
@@ -0,0 +1,86 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeAlertList(panel sdk.Panel) jen.Code {
+	settings := encoder.encodeBasePanelProperties(panel, "alertlist")
+	options := customPanelOptions(panel)
+
+	settings = append(settings, encoder.encodeAlertListStates(options))
+
+	if folder, ok := options["folder"].(map[string]interface{}); ok && mapString(folder, "title") != "" {
+		settings = append(settings, alertListQual("Folder").Call(lit(mapInt(folder, "id")), lit(mapString(folder, "title"))))
+	}
+	if filter := mapString(options, "alertInstanceLabelFilter"); filter != "" {
+		settings = append(settings, alertListQual("LabelFilter").Call(lit(filter)))
+	}
+	if name := mapString(options, "alertName"); name != "" {
+		settings = append(settings, alertListQual("AlertName").Call(lit(name)))
+	}
+	if mapBool(options, "dashboardAlerts") {
+		settings = append(settings, alertListQual("OnlyDashboardAlerts").Call())
+	}
+	if groupBy := mapStrings(options, "groupBy"); mapString(options, "groupMode") == "custom" && len(groupBy) != 0 {
+		settings = append(settings, alertListQual("GroupBy").Call(Map(groupBy, func(label string) jen.Code {
+			return lit(label)
+		})...))
+	}
+	if maxItems := mapInt(options, "maxItems"); maxItems > 0 {
+		settings = append(settings, alertListQual("MaxItems").Call(lit(maxItems)))
+	}
+	if sortOrder := mapInt(options, "sortOrder"); sortOrder != 0 {
+		settings = append(settings, encoder.encodeAlertListSortOrder(sortOrder))
+	}
+	if mapString(options, "viewMode") == "stat" {
+		settings = append(settings, alertListQual("View").Call(alertListQual("Stat")))
+	}
+
+	return rowQual("WithAlertList").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeAlertListStates(options map[string]interface{}) jen.Code {
+	filter, _ := options["stateFilter"].(map[string]interface{})
+
+	states := map[string]string{
+		"firing":  "Firing",
+		"pending": "Pending",
+		"noData":  "NoData",
+		"normal":  "Normal",
+		"error":   "Error",
+	}
+
+	var statesConsts []jen.Code
+	for _, state := range []string{"firing", "pending", "noData", "normal", "error"} {
+		if mapBool(filter, state) {
+			statesConsts = append(statesConsts, alertListQual(states[state]))
+		}
+	}
+
+	return alertListQual("States").Call(statesConsts...)
+}
+
+func (encoder *Encoder) encodeAlertListSortOrder(sortOrder int) jen.Code {
+	orders := map[int]string{
+		1: "AlphaAsc",
+		2: "AlphaDesc",
+		3: "Importance",
+		4: "TimeAsc",
+		5: "TimeDesc",
+	}
+
+	constName, ok := orders[sortOrder]
+	if !ok {
+		encoder.logger.Warn("unhandled alert list sort order: using alphabetical order as default", zap.Int("order", sortOrder))
+		constName = "AlphaAsc"
+	}
+
+	return alertListQual("Sort").Call(alertListQual(constName))
+}
+
+func alertListQual(name string) *jen.Statement {
+	return qual("alertlist", name)
+}
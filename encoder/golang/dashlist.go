@@ -0,0 +1,45 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+)
+
+// encodeDashList encodes dashboard list panels. The sdk only knows about
+// the legacy representation of these panels, which Grafana still migrates
+// on load.
+func (encoder *Encoder) encodeDashList(panel sdk.Panel) jen.Code {
+	settings := encoder.encodeBasePanelProperties(panel, "dashlist")
+	dashList := panel.DashlistPanel
+
+	if dashList.Starred {
+		settings = append(settings, dashListQual("Starred").Call())
+	}
+	if dashList.Recent {
+		settings = append(settings, dashListQual("Recent").Call())
+	}
+	if dashList.Search {
+		settings = append(settings, dashListQual("SearchQuery").Call(lit(dashList.Query)))
+
+		if len(dashList.Tags) != 0 {
+			settings = append(settings, dashListQual("SearchTags").Call(Map(dashList.Tags, func(tag string) jen.Code {
+				return lit(tag)
+			})...))
+		}
+		if dashList.FolderID > 0 {
+			settings = append(settings, dashListQual("SearchFolder").Call(lit(dashList.FolderID)))
+		}
+	}
+	if !dashList.Headings {
+		settings = append(settings, dashListQual("HideHeadings").Call())
+	}
+	if dashList.Limit > 0 {
+		settings = append(settings, dashListQual("MaxItems").Call(lit(dashList.Limit)))
+	}
+
+	return rowQual("WithDashboardList").MultiLineCall(settings...)
+}
+
+func dashListQual(name string) *jen.Statement {
+	return qual("dashlist", name)
+}
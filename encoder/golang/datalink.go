@@ -0,0 +1,76 @@
+package golang
+
+import (
+	"sort"
+
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeDataLinks(panel sdk.Panel, grabanaPackage string) []jen.Code {
+	links, _ := fieldConfigDefaults(panel)["links"].([]interface{})
+
+	var encodedLinks []jen.Code
+	for _, item := range links {
+		link, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		encodedLinks = append(encodedLinks, encoder.encodeDataLink(link))
+	}
+
+	if len(encodedLinks) == 0 {
+		return nil
+	}
+
+	return []jen.Code{
+		qual(grabanaPackage, "DataLinks").MultiLineCall(encodedLinks...),
+	}
+}
+
+func (encoder *Encoder) encodeDataLink(link map[string]interface{}) jen.Code {
+	var options []jen.Code
+
+	if mapBool(link, "targetBlank") {
+		options = append(options, datalinkQual("OpenInNewTab").Call())
+	}
+
+	internal, ok := link["internal"].(map[string]interface{})
+	if !ok {
+		args := append([]jen.Code{lit(mapString(link, "title")), lit(mapString(link, "url"))}, options...)
+
+		return datalinkQual("New").Call(args...)
+	}
+
+	query, _ := internal["query"].(map[string]interface{})
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	queryDict := jen.Dict{}
+	for _, key := range keys {
+		switch value := query[key].(type) {
+		case string, float64, bool:
+			queryDict[lit(key)] = lit(value)
+		default:
+			encoder.logger.Warn("unhandled data link query parameter: skipped", zap.String("key", key))
+		}
+	}
+
+	args := append([]jen.Code{
+		lit(mapString(link, "title")),
+		lit(mapString(internal, "datasourceUid")),
+		lit(mapString(internal, "datasourceName")),
+		jen.Map(jen.String()).Interface().Values(queryDict),
+	}, options...)
+
+	return datalinkQual("Internal").Call(args...)
+}
+
+func datalinkQual(name string) *jen.Statement {
+	return qual("datalink", name)
+}
@@ -106,6 +106,13 @@ func (encoder *Encoder) encodePanels(dashboard sdk.Board) []jen.Code {
 
 			if panel.RowPanel != nil && panel.RowPanel.Collapsed {
 				currentRow.Collapsed = true
+
+				// collapsed rows hold their panels
+				for _, rowPanel := range panel.RowPanel.Panels {
+					if convertedPanel, ok := encoder.encodeDataPanel(rowPanel); ok {
+						currentRow.Panels = append(currentRow.Panels, convertedPanel)
+					}
+				}
 			}
 			continue
 		}
@@ -130,6 +137,11 @@ func (encoder *Encoder) encodePanels(dashboard sdk.Board) []jen.Code {
 }
 
 func (encoder *Encoder) encodeDataPanel(panel sdk.Panel) (jen.Code, bool) {
+	// library panels are referenced by dashboards, whatever their type
+	if isLibraryPanelReference(panel) {
+		return encoder.encodeLibraryPanelReference(panel), true
+	}
+
 	switch panel.Type {
 	case "logs":
 		return encoder.convertLogs(panel), true
@@ -145,11 +157,19 @@ func (encoder *Encoder) encodeDataPanel(panel sdk.Panel) (jen.Code, bool) {
 		return encoder.encodeText(panel), true
 	case "heatmap":
 		return encoder.encodeHeatmap(panel), true
+	case "alertlist":
+		return encoder.encodeAlertList(panel), true
+	case "dashlist":
+		return encoder.encodeDashList(panel), true
+	case "news":
+		return encoder.encodeNews(panel), true
+	case "geomap":
+		return encoder.encodeGeomap(panel), true
+	case "table":
+		return encoder.encodeTable(panel), true
 	/*
 		case "singlestat":
 			return encoder.encodeSingleStat(panel), true
-		case "table":
-			return encoder.encodeTable(panel), true
 	*/
 	default:
 		encoder.logger.Warn("unhandled panel type: skipped", zap.String("type", panel.Type), zap.String("title", panel.Title))
@@ -8,6 +8,9 @@ import (
 
 func (encoder *Encoder) encodeGauge(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "gauge")
+	settings = append(settings, encoder.encodeTransformations(panel, "gauge")...)
+	settings = append(settings, encoder.encodeValueMappings(panel, "gauge")...)
+	settings = append(settings, encoder.encodeDataLinks(panel, "gauge")...)
 
 	settings = append(
 		settings,
@@ -0,0 +1,243 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeGeomap(panel sdk.Panel) jen.Code {
+	settings := encoder.encodeCommonPanelProperties(panel, "geomap")
+	settings = append(settings, encoder.encodeTransformations(panel, "geomap")...)
+	options := customPanelOptions(panel)
+
+	settings = append(
+		settings,
+		encoder.encodeTargets(customPanelTargets(panel), "geomap")...,
+	)
+
+	if basemap, ok := options["basemap"].(map[string]interface{}); ok {
+		if baseLayer := encoder.encodeGeomapBaseLayer(basemap); baseLayer != nil {
+			settings = append(settings, geomapQual("BaseLayer").Call(baseLayer))
+		}
+	}
+
+	layers, _ := options["layers"].([]interface{})
+	for _, item := range layers {
+		dataLayer, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if encodedLayer := encoder.encodeGeomapLayer(dataLayer); encodedLayer != nil {
+			settings = append(settings, encodedLayer)
+		}
+	}
+
+	settings = append(settings, encoder.encodeGeomapView(options)...)
+	settings = append(settings, encoder.encodeGeomapControls(options)...)
+
+	return rowQual("WithGeomap").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeGeomapBaseLayer(basemap map[string]interface{}) jen.Code {
+	config, _ := basemap["config"].(map[string]interface{})
+
+	switch mapString(basemap, "type") {
+	case "default":
+		return nil
+	case "osm-standard":
+		return geomapLayerQual("OpenStreetMap").Call()
+	case "carto":
+		theme := map[string]string{
+			"auto":  "CartoAuto",
+			"light": "CartoLight",
+			"dark":  "CartoDark",
+		}[mapString(config, "theme")]
+		if theme == "" {
+			theme = "CartoAuto"
+		}
+
+		return geomapLayerQual("Carto").Call(geomapLayerQual(theme))
+	case "esri-xyz":
+		server := map[string]string{
+			"streets":        "WorldStreetMap",
+			"world-imagery":  "WorldImagery",
+			"world-physical": "WorldPhysical",
+			"topo":           "Topographic",
+			"usa-topo":       "USATopographic",
+			"ocean":          "WorldOcean",
+		}[mapString(config, "server")]
+		if server == "" {
+			server = "WorldStreetMap"
+		}
+
+		return geomapLayerQual("ArcGIS").Call(geomapLayerQual(server))
+	case "xyz":
+		return geomapLayerQual("XYZ").Call(lit(mapString(config, "url")), lit(mapString(config, "attribution")))
+	}
+
+	encoder.logger.Warn("unhandled geomap base layer type: skipped", zap.String("type", mapString(basemap, "type")))
+
+	return nil
+}
+
+func (encoder *Encoder) encodeGeomapLayer(dataLayer map[string]interface{}) jen.Code {
+	constructors := map[string]string{
+		"markers": "Markers",
+		"heatmap": "Heatmap",
+		"route":   "Route",
+	}
+
+	layerType := mapString(dataLayer, "type")
+	constructor, ok := constructors[layerType]
+	if !ok {
+		encoder.logger.Warn("unhandled geomap layer type: skipped", zap.String("type", layerType))
+		return nil
+	}
+
+	config, _ := dataLayer["config"].(map[string]interface{})
+	style, _ := config["style"].(map[string]interface{})
+
+	settings := []jen.Code{
+		lit(mapString(dataLayer, "name")),
+	}
+
+	if location, ok := dataLayer["location"].(map[string]interface{}); ok {
+		if encodedLocation := encoder.encodeGeomapLocation(location); encodedLocation != nil {
+			settings = append(settings, encodedLocation)
+		}
+	}
+
+	size, _ := style["size"].(map[string]interface{})
+	if layerType == "heatmap" {
+		size, _ = config["weight"].(map[string]interface{})
+	}
+	if field := mapString(size, "field"); field != "" {
+		settings = append(settings, geomapLayerQual("SizeField").Call(lit(field), lit(mapFloat(size, "min")), lit(mapFloat(size, "max"))))
+	}
+	if fixed, ok := size["fixed"].(float64); ok {
+		settings = append(settings, geomapLayerQual("FixedSize").Call(lit(fixed)))
+	}
+
+	color, _ := style["color"].(map[string]interface{})
+	if field := mapString(color, "field"); field != "" {
+		settings = append(settings, geomapLayerQual("ColorField").Call(lit(field)))
+	}
+	if fixed := mapString(color, "fixed"); fixed != "" {
+		settings = append(settings, geomapLayerQual("FixedColor").Call(lit(fixed)))
+	}
+	if opacity, ok := style["opacity"].(float64); ok {
+		settings = append(settings, geomapLayerQual("Opacity").Call(lit(opacity)))
+	}
+
+	if mapBool(config, "showLegend") {
+		settings = append(settings, geomapLayerQual("ShowLegend").Call())
+	}
+	if tooltip, ok := dataLayer["tooltip"].(bool); ok && !tooltip {
+		settings = append(settings, geomapLayerQual("HideTooltip").Call())
+	}
+
+	if radius, ok := config["radius"].(float64); ok && layerType == "heatmap" {
+		settings = append(settings, geomapLayerQual("Radius").Call(lit(int(radius))))
+	}
+	if blur, ok := config["blur"].(float64); ok && layerType == "heatmap" {
+		settings = append(settings, geomapLayerQual("Blur").Call(lit(int(blur))))
+	}
+	if arrow, ok := config["arrow"].(float64); ok && layerType == "route" {
+		modes := map[int]string{
+			0:  "NoArrows",
+			1:  "ForwardArrow",
+			-1: "BackwardArrow",
+		}
+
+		if mode, ok := modes[int(arrow)]; ok {
+			settings = append(settings, geomapLayerQual("Arrows").Call(geomapLayerQual(mode)))
+		}
+	}
+
+	return geomapQual(constructor).MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeGeomapLocation(location map[string]interface{}) jen.Code {
+	switch mapString(location, "mode") {
+	case "auto":
+		return nil
+	case "coords":
+		return geomapLayerQual("CoordsLocation").Call(lit(mapString(location, "latitude")), lit(mapString(location, "longitude")))
+	case "geohash":
+		return geomapLayerQual("GeohashLocation").Call(lit(mapString(location, "geohash")))
+	case "lookup":
+		gazetteers := map[string]string{
+			"public/gazetteer/countries.json":    "Countries",
+			"public/gazetteer/usa-states.json":   "USStates",
+			"public/gazetteer/airports.geojson":  "Airports",
+			"public/gazetteer/countries.geojson": "SpatialCodes",
+		}
+
+		gazetteer := mapString(location, "gazetteer")
+		var gazetteerStmt jen.Code = geomapLayerQual("Countries")
+		if constName, ok := gazetteers[gazetteer]; ok {
+			gazetteerStmt = geomapLayerQual(constName)
+		} else if gazetteer != "" {
+			gazetteerStmt = geomapLayerQual("Gazetteer").Call(lit(gazetteer))
+		}
+
+		return geomapLayerQual("LookupLocation").Call(lit(mapString(location, "lookup")), gazetteerStmt)
+	}
+
+	encoder.logger.Warn("unhandled geomap location mode: skipped", zap.String("mode", mapString(location, "mode")))
+
+	return nil
+}
+
+func (encoder *Encoder) encodeGeomapView(options map[string]interface{}) []jen.Code {
+	var settings []jen.Code
+
+	view, ok := options["view"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	switch mapString(view, "id") {
+	case "fit":
+		settings = append(settings, geomapQual("FitToData").Call())
+	case "coords":
+		settings = append(settings, geomapQual("Center").Call(lit(mapFloat(view, "lat")), lit(mapFloat(view, "lon"))))
+	}
+
+	if zoom := mapFloat(view, "zoom"); zoom > 1 {
+		settings = append(settings, geomapQual("Zoom").Call(lit(zoom)))
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeGeomapControls(options map[string]interface{}) []jen.Code {
+	var settings []jen.Code
+
+	controls, ok := options["controls"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if showZoom, ok := controls["showZoom"].(bool); ok && !showZoom {
+		settings = append(settings, geomapQual("HideZoomControls").Call())
+	}
+	if mouseWheelZoom, ok := controls["mouseWheelZoom"].(bool); ok && !mouseWheelZoom {
+		settings = append(settings, geomapQual("DisableMouseWheelZoom").Call())
+	}
+	if mapBool(controls, "showScale") {
+		settings = append(settings, geomapQual("ShowScale").Call())
+	}
+
+	return settings
+}
+
+func geomapQual(name string) *jen.Statement {
+	return qual("geomap", name)
+}
+
+func geomapLayerQual(name string) *jen.Statement {
+	return qual("geomap/layer", name)
+}
@@ -8,6 +8,7 @@ import (
 
 func (encoder *Encoder) encodeGraph(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "graph")
+	settings = append(settings, encoder.encodeTransformations(panel, "graph")...)
 
 	settings = append(
 		settings,
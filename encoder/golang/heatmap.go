@@ -10,6 +10,7 @@ import (
 
 func (encoder *Encoder) encodeHeatmap(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "heatmap")
+	settings = append(settings, encoder.encodeTransformations(panel, "heatmap")...)
 
 	settings = append(
 		settings,
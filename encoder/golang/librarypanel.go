@@ -0,0 +1,44 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+)
+
+func isLibraryPanelReference(panel sdk.Panel) bool {
+	if panel.CustomPanel == nil {
+		return false
+	}
+
+	_, ok := (*panel.CustomPanel)["libraryPanel"].(map[string]interface{})
+
+	return ok
+}
+
+func (encoder *Encoder) encodeLibraryPanelReference(panel sdk.Panel) jen.Code {
+	reference, _ := (*panel.CustomPanel)["libraryPanel"].(map[string]interface{})
+	uid := mapString(reference, "uid")
+
+	settings := []jen.Code{
+		lit(uid),
+	}
+
+	if name := mapString(reference, "name"); name != "" && name != uid {
+		settings = append(settings, libraryPanelQual("Name").Call(lit(name)))
+	}
+
+	if panel.GridPos.W != nil && panel.GridPos.H != nil {
+		settings = append(
+			settings,
+			libraryPanelQual("Size").Call(lit(*panel.GridPos.W), lit(*panel.GridPos.H)),
+		)
+	} else if panel.Span != 0 {
+		settings = append(settings, libraryPanelQual("Span").Call(lit(panel.Span)))
+	}
+
+	return rowQual("WithLibraryPanel").MultiLineCall(settings...)
+}
+
+func libraryPanelQual(name string) *jen.Statement {
+	return qual("librarypanel", name)
+}
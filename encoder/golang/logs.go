@@ -9,6 +9,7 @@ import (
 
 func (encoder *Encoder) convertLogs(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "logs")
+	settings = append(settings, encoder.encodeTransformations(panel, "logs")...)
 
 	for _, target := range panel.LogsPanel.Targets {
 		settings = append(
@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"sort"
+
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeValueMappings(panel sdk.Panel, grabanaPackage string) []jen.Code {
+	mappings, _ := fieldConfigDefaults(panel)["mappings"].([]interface{})
+
+	var encodedMappings []jen.Code
+	for _, item := range mappings {
+		valueMapping, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		encodedMappings = append(encodedMappings, encoder.encodeValueMapping(valueMapping)...)
+	}
+
+	if len(encodedMappings) == 0 {
+		return nil
+	}
+
+	return []jen.Code{
+		qual(grabanaPackage, "ValueMappings").MultiLineCall(encodedMappings...),
+	}
+}
+
+func (encoder *Encoder) encodeValueMapping(valueMapping map[string]interface{}) []jen.Code {
+	options, _ := valueMapping["options"].(map[string]interface{})
+
+	switch mapString(valueMapping, "type") {
+	case "value":
+		values := make([]string, 0, len(options))
+		for value := range options {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		return Map(values, func(value string) jen.Code {
+			result, _ := options[value].(map[string]interface{})
+			args := append([]jen.Code{lit(value)}, encoder.encodeValueMappingResult(result)...)
+
+			return mappingQual("Value").Call(args...)
+		})
+	case "range":
+		result, _ := options["result"].(map[string]interface{})
+		args := append(
+			[]jen.Code{lit(mapFloat(options, "from")), lit(mapFloat(options, "to"))},
+			encoder.encodeValueMappingResult(result)...,
+		)
+
+		return []jen.Code{mappingQual("Range").Call(args...)}
+	case "regex":
+		result, _ := options["result"].(map[string]interface{})
+		args := append([]jen.Code{lit(mapString(options, "pattern"))}, encoder.encodeValueMappingResult(result)...)
+
+		return []jen.Code{mappingQual("Regex").Call(args...)}
+	case "special":
+		specialValues := map[string]string{
+			"null":     "Null",
+			"nan":      "NaN",
+			"null+nan": "NullOrNaN",
+			"true":     "True",
+			"false":    "False",
+			"empty":    "Empty",
+		}
+
+		constName, ok := specialValues[mapString(options, "match")]
+		if !ok {
+			encoder.logger.Warn("unhandled special value mapping: skipped", zap.String("match", mapString(options, "match")))
+			return nil
+		}
+
+		result, _ := options["result"].(map[string]interface{})
+		args := append([]jen.Code{mappingQual(constName)}, encoder.encodeValueMappingResult(result)...)
+
+		return []jen.Code{mappingQual("Special").Call(args...)}
+	}
+
+	encoder.logger.Warn("unhandled value mapping type: skipped", zap.String("type", mapString(valueMapping, "type")))
+
+	return nil
+}
+
+func (encoder *Encoder) encodeValueMappingResult(result map[string]interface{}) []jen.Code {
+	var settings []jen.Code
+
+	if text := mapString(result, "text"); text != "" {
+		settings = append(settings, mappingQual("Text").Call(lit(text)))
+	}
+	if color := mapString(result, "color"); color != "" {
+		settings = append(settings, mappingQual("Color").Call(lit(color)))
+	}
+
+	return settings
+}
+
+// fieldConfigDefaults returns the default field configuration of a panel,
+// as found in its raw definition.
+func fieldConfigDefaults(panel sdk.Panel) map[string]interface{} {
+	if panel.CustomPanel == nil {
+		return nil
+	}
+
+	fieldConfig, _ := (*panel.CustomPanel)["fieldConfig"].(map[string]interface{})
+	defaults, _ := fieldConfig["defaults"].(map[string]interface{})
+
+	return defaults
+}
+
+func mappingQual(name string) *jen.Statement {
+	return qual("mapping", name)
+}
@@ -0,0 +1,26 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+)
+
+func (encoder *Encoder) encodeNews(panel sdk.Panel) jen.Code {
+	settings := encoder.encodeBasePanelProperties(panel, "news")
+	options := customPanelOptions(panel)
+
+	settings = append(settings, newsQual("FeedURL").Call(lit(mapString(options, "feedUrl"))))
+
+	if showImage, ok := options["showImage"].(bool); ok && !showImage {
+		settings = append(settings, newsQual("HideImages").Call())
+	}
+	if mapBool(options, "useProxy") {
+		settings = append(settings, newsQual("UseProxy").Call())
+	}
+
+	return rowQual("WithNews").MultiLineCall(settings...)
+}
+
+func newsQual(name string) *jen.Statement {
+	return qual("news", name)
+}
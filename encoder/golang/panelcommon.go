@@ -7,6 +7,65 @@ import (
 )
 
 func (encoder *Encoder) encodeCommonPanelProperties(panel sdk.Panel, grabanaPackage string) []jen.Code {
+	settings := encoder.encodeBasePanelProperties(panel, grabanaPackage)
+
+	if panel.Repeat != nil {
+		settings = append(
+			settings,
+			qual(grabanaPackage, "Repeat").Call(lit(*panel.Repeat)),
+		)
+	}
+	if panel.RepeatDirection != nil {
+		directions := map[string]string{
+			"v": "RepeatDirectionVertical",
+			"h": "RepeatDirectionHorizontal",
+		}
+
+		constName, ok := directions[string(*panel.RepeatDirection)]
+		if !ok {
+			encoder.logger.Warn("unknown panel repeat direction", zap.String("direction", string(*panel.RepeatDirection)))
+		} else {
+			settings = append(settings,
+				qual(grabanaPackage, "RepeatDirection").Call(jen.Qual(sdkImportPath, constName)),
+			)
+		}
+
+		settings = append(
+			settings,
+			qual(grabanaPackage, "Repeat").Call(lit(*panel.Repeat)),
+		)
+	}
+	if panel.Datasource != nil && panel.Datasource.LegacyName != "" {
+		settings = append(
+			settings,
+			qual(grabanaPackage, "DataSource").Call(lit(panel.Datasource.LegacyName)),
+		)
+	}
+	if ref := encodeDatasourceRef(panel.Datasource); ref != nil {
+		settings = append(settings, qual(grabanaPackage, "DataSourceRef").Call(ref))
+	}
+
+	return settings
+}
+
+// encodeDatasourceRef encodes references to datasources by UID. References
+// by name are encoded by the DataSource() options instead.
+func encodeDatasourceRef(ref *sdk.DatasourceRef) jen.Code {
+	if ref == nil || ref.LegacyName != "" || ref.UID == "" {
+		return nil
+	}
+
+	encoded := qual("datasource", "ByUID").Call(lit(ref.UID))
+	if ref.Type != "" {
+		encoded = encoded.Dot("OfType").Call(lit(ref.Type))
+	}
+
+	return encoded
+}
+
+// encodeBasePanelProperties encodes the properties supported by every panel,
+// including the ones not relying on a datasource.
+func (encoder *Encoder) encodeBasePanelProperties(panel sdk.Panel, grabanaPackage string) []jen.Code {
 	settings := []jen.Code{
 		lit(panel.Title),
 	}
@@ -20,11 +79,15 @@ func (encoder *Encoder) encodeCommonPanelProperties(panel sdk.Panel, grabanaPack
 		)
 	}
 
-	span := panelSpan(panel)
-	if span != 0 {
+	if panel.GridPos.W != nil && panel.GridPos.H != nil {
+		settings = append(
+			settings,
+			qual(grabanaPackage, "Size").Call(lit(*panel.GridPos.W), lit(*panel.GridPos.H)),
+		)
+	} else if panel.Span != 0 {
 		settings = append(
 			settings,
-			qual(grabanaPackage, "Span").Call(lit(span)),
+			qual(grabanaPackage, "Span").Call(lit(panel.Span)),
 		)
 	}
 
@@ -34,7 +97,7 @@ func (encoder *Encoder) encodeCommonPanelProperties(panel sdk.Panel, grabanaPack
 			qual(grabanaPackage, "Description").Call(lit(*panel.Description)),
 		)
 	}
-	if panel.Height != nil {
+	if panel.Height != nil && panel.GridPos.H == nil {
 		settings = append(
 			settings,
 			qual(grabanaPackage, "Height").Call(lit(*(panel.Height).(*string))),
@@ -46,38 +109,6 @@ func (encoder *Encoder) encodeCommonPanelProperties(panel sdk.Panel, grabanaPack
 			qual(grabanaPackage, "Transparent").Call(lit(panel.Transparent)),
 		)
 	}
-	if panel.Repeat != nil {
-		settings = append(
-			settings,
-			qual(grabanaPackage, "Repeat").Call(lit(*panel.Repeat)),
-		)
-	}
-	if panel.RepeatDirection != nil {
-		directions := map[string]string{
-			"v": "RepeatDirectionVertical",
-			"h": "RepeatDirectionHorizontal",
-		}
-
-		constName, ok := directions[string(*panel.RepeatDirection)]
-		if !ok {
-			encoder.logger.Warn("unknown panel repeat direction", zap.String("direction", string(*panel.RepeatDirection)))
-		} else {
-			settings = append(settings,
-				qual(grabanaPackage, "RepeatDirection").Call(jen.Qual(sdkImportPath, constName)),
-			)
-		}
-
-		settings = append(
-			settings,
-			qual(grabanaPackage, "Repeat").Call(lit(*panel.Repeat)),
-		)
-	}
-	if panel.Datasource != nil && panel.Datasource.LegacyName != "" {
-		settings = append(
-			settings,
-			qual(grabanaPackage, "DataSource").Call(lit(panel.Datasource.LegacyName)),
-		)
-	}
 
 	return settings
 }
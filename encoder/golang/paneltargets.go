@@ -1,6 +1,8 @@
 package golang
 
 import (
+	"strconv"
+
 	"github.com/K-Phoen/jennifer/jen"
 	"github.com/K-Phoen/sdk"
 	"go.uber.org/zap"
@@ -27,6 +29,21 @@ func (encoder *Encoder) encodeTarget(target sdk.Target, grabanaPackage string) j
 		return encoder.encodePrometheusTarget(target, grabanaPackage)
 	}
 
+	// looks like SQL
+	if target.RawSql != "" {
+		return encoder.encodeSQLTarget(target, grabanaPackage)
+	}
+
+	// looks like cloudwatch
+	if target.Namespace != "" && target.MetricName != "" {
+		return encoder.encodeCloudWatchTarget(target, grabanaPackage)
+	}
+
+	// looks like elasticsearch
+	if len(target.Metrics) != 0 || len(target.BucketAggs) != 0 {
+		return encoder.encodeElasticsearchTarget(target, grabanaPackage)
+	}
+
 	/*
 		// looks like graphite
 		if target.Target != "" {
@@ -110,3 +127,153 @@ func (encoder *Encoder) encodePrometheusTarget(target sdk.Target, grabanaPackage
 
 	return qual(grabanaPackage, "WithPrometheusTarget").MultiLineCall(settings...)
 }
+
+func (encoder *Encoder) encodeSQLTarget(target sdk.Target, grabanaPackage string) jen.Code {
+	settings := []jen.Code{
+		lit(target.RawSql),
+	}
+
+	if target.RefID != "" {
+		settings = append(settings, qual("target/sql", "Ref").Call(lit(target.RefID)))
+	}
+	if target.Hide {
+		settings = append(settings, qual("target/sql", "Hide").Call())
+	}
+	if target.TimeColumn != "" {
+		settings = append(settings, qual("target/sql", "TimeColumn").Call(lit(target.TimeColumn)))
+	}
+
+	switch target.Format {
+	case "table":
+		settings = append(settings, qual("target/sql", "Format").Call(qual("target/sql", "FormatTable")))
+	case "time_series", "":
+	default:
+		encoder.logger.Warn("unhandled SQL target format: using 'time_series' instead", zap.String("format", target.Format))
+	}
+
+	return qual(grabanaPackage, "WithSQLTarget").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeCloudWatchTarget(target sdk.Target, grabanaPackage string) jen.Code {
+	settings := []jen.Code{
+		lit(target.Namespace),
+		lit(target.MetricName),
+	}
+
+	if target.Region != "" && target.Region != "default" {
+		settings = append(settings, qual("target/cloudwatch", "Region").Call(lit(target.Region)))
+	}
+	if len(target.Dimensions) != 0 {
+		dimensions := jen.Dict{}
+		for key, value := range target.Dimensions {
+			dimensions[lit(key)] = lit(value)
+		}
+
+		settings = append(
+			settings,
+			qual("target/cloudwatch", "Dimensions").Call(jen.Map(jen.String()).String().Values(dimensions)),
+		)
+	}
+	if len(target.Statistics) != 0 && !(len(target.Statistics) == 1 && target.Statistics[0] == "Average") {
+		statistics := make([]jen.Code, 0, len(target.Statistics))
+		for _, statistic := range target.Statistics {
+			statistics = append(statistics, cloudWatchStatistic(statistic))
+		}
+
+		settings = append(settings, qual("target/cloudwatch", "Statistics").Call(statistics...))
+	}
+	if target.Period != "" {
+		settings = append(settings, qual("target/cloudwatch", "Period").Call(lit(target.Period)))
+	}
+	if target.Alias != "" {
+		settings = append(settings, qual("target/cloudwatch", "Legend").Call(lit(target.Alias)))
+	}
+	if target.RefID != "" {
+		settings = append(settings, qual("target/cloudwatch", "Ref").Call(lit(target.RefID)))
+	}
+	if target.Hide {
+		settings = append(settings, qual("target/cloudwatch", "Hide").Call())
+	}
+
+	return qual(grabanaPackage, "WithCloudWatchTarget").MultiLineCall(settings...)
+}
+
+func cloudWatchStatistic(statistic string) jen.Code {
+	switch statistic {
+	case "Average", "Sum", "Minimum", "Maximum", "SampleCount":
+		return qual("target/cloudwatch", statistic)
+	}
+
+	if len(statistic) > 1 && statistic[0] == 'p' {
+		return qual("target/cloudwatch", "Percentile").Call(lit(statistic[1:]))
+	}
+
+	return qual("target/cloudwatch", "Statistic").Call(lit(statistic))
+}
+
+func (encoder *Encoder) encodeElasticsearchTarget(target sdk.Target, grabanaPackage string) jen.Code {
+	settings := []jen.Code{
+		lit(target.Query),
+	}
+
+	if target.TimeField != "" && target.TimeField != "@timestamp" {
+		settings = append(settings, qual("target/elasticsearch", "TimeField").Call(lit(target.TimeField)))
+	}
+
+	var metrics []jen.Code
+	for _, metric := range target.Metrics {
+		switch metric.Type {
+		case "count":
+			metrics = append(metrics, qual("target/elasticsearch", "Count").Call())
+		case "avg":
+			metrics = append(metrics, qual("target/elasticsearch", "Average").Call(lit(metric.Field)))
+		case "sum":
+			metrics = append(metrics, qual("target/elasticsearch", "Sum").Call(lit(metric.Field)))
+		case "min":
+			metrics = append(metrics, qual("target/elasticsearch", "Min").Call(lit(metric.Field)))
+		case "max":
+			metrics = append(metrics, qual("target/elasticsearch", "Max").Call(lit(metric.Field)))
+		case "cardinality":
+			metrics = append(metrics, qual("target/elasticsearch", "UniqueCount").Call(lit(metric.Field)))
+		default:
+			encoder.logger.Warn("unhandled elasticsearch metric: skipped", zap.String("type", metric.Type))
+		}
+	}
+	if len(metrics) != 0 {
+		settings = append(settings, qual("target/elasticsearch", "Metrics").MultiLineCall(metrics...))
+	}
+
+	var buckets []jen.Code
+	for _, bucket := range target.BucketAggs {
+		switch bucket.Type {
+		case "date_histogram":
+			buckets = append(buckets, qual("target/elasticsearch", "DateHistogram").Call(lit(bucket.Field), lit(bucket.Settings.Interval)))
+		case "histogram":
+			buckets = append(buckets, qual("target/elasticsearch", "Histogram").Call(lit(bucket.Field), lit(bucket.Settings.Interval)))
+		case "terms":
+			size, err := strconv.Atoi(bucket.Settings.Size)
+			if err != nil {
+				size = 10
+			}
+
+			buckets = append(buckets, qual("target/elasticsearch", "Terms").Call(lit(bucket.Field), lit(size)))
+		default:
+			encoder.logger.Warn("unhandled elasticsearch bucket aggregation: skipped", zap.String("type", bucket.Type))
+		}
+	}
+	if len(buckets) != 0 {
+		settings = append(settings, qual("target/elasticsearch", "GroupBy").MultiLineCall(buckets...))
+	}
+
+	if target.Alias != "" {
+		settings = append(settings, qual("target/elasticsearch", "Legend").Call(lit(target.Alias)))
+	}
+	if target.RefID != "" {
+		settings = append(settings, qual("target/elasticsearch", "Ref").Call(lit(target.RefID)))
+	}
+	if target.Hide {
+		settings = append(settings, qual("target/elasticsearch", "Hide").Call())
+	}
+
+	return qual(grabanaPackage, "WithElasticsearchTarget").MultiLineCall(settings...)
+}
@@ -8,6 +8,9 @@ import (
 
 func (encoder *Encoder) encodeStat(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "stat")
+	settings = append(settings, encoder.encodeTransformations(panel, "stat")...)
+	settings = append(settings, encoder.encodeValueMappings(panel, "stat")...)
+	settings = append(settings, encoder.encodeDataLinks(panel, "stat")...)
 
 	// TODO: ColorScheme
 
@@ -0,0 +1,388 @@
+package golang
+
+import (
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeTable(panel sdk.Panel) jen.Code {
+	settings := encoder.encodeCommonPanelProperties(panel, "table")
+
+	if isLegacyTable(panel) {
+		settings = append(settings, encoder.encodeLegacyTable(panel)...)
+	} else {
+		settings = append(settings, encoder.encodeModernTable(panel)...)
+	}
+
+	return rowQual("WithTable").MultiLineCall(settings...)
+}
+
+// isLegacyTable tells if the panel was defined using the "table-old" format,
+// predating Grafana 7.
+func isLegacyTable(panel sdk.Panel) bool {
+	if panel.CustomPanel == nil {
+		return true
+	}
+
+	_, hasStyles := (*panel.CustomPanel)["styles"]
+	_, hasFieldConfig := (*panel.CustomPanel)["fieldConfig"]
+
+	return hasStyles || !hasFieldConfig
+}
+
+func (encoder *Encoder) encodeLegacyTable(panel sdk.Panel) []jen.Code {
+	var settings []jen.Code
+
+	if panel.TablePanel == nil {
+		return nil
+	}
+
+	settings = append(settings, encoder.encodeTargets(panel.TablePanel.Targets, "table")...)
+
+	for _, style := range panel.TablePanel.Styles {
+		if style.Type != "hidden" {
+			continue
+		}
+
+		settings = append(settings, tableQual("HideColumn").Call(lit(style.Pattern)))
+	}
+
+	switch panel.TablePanel.Transform {
+	case "timeseries_to_columns":
+		settings = append(settings, tableQual("TimeSeriesToColumns").Call())
+	case "json":
+		settings = append(settings, tableQual("AsJSON").Call())
+	case "table":
+		settings = append(settings, tableQual("AsTable").Call())
+	case "timeseries_aggregations":
+		aggregationTypes := map[string]string{
+			"avg":     "AVG",
+			"count":   "Count",
+			"current": "Current",
+			"min":     "Min",
+			"max":     "Max",
+		}
+
+		aggregations := make([]jen.Code, 0, len(panel.TablePanel.Columns))
+		for _, column := range panel.TablePanel.Columns {
+			aggregationType, ok := aggregationTypes[column.Value]
+			if !ok {
+				encoder.logger.Warn("unhandled table aggregation: skipped", zap.String("aggregation", column.Value))
+				continue
+			}
+
+			aggregations = append(aggregations, jen.Values(jen.Dict{
+				jen.Id("Label"): lit(column.TextType),
+				jen.Id("Type"):  tableQual(aggregationType),
+			}))
+		}
+
+		settings = append(settings, tableQual("AsTimeSeriesAggregations").Call(
+			jen.Index().Add(tableQual("Aggregation")).Values(aggregations...),
+		))
+	case "timeseries_to_rows", "":
+		settings = append(settings, tableQual("TimeSeriesToRows").Call())
+	default:
+		encoder.logger.Warn("unhandled table transform: skipped", zap.String("transform", panel.TablePanel.Transform), zap.String("title", panel.Title))
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeModernTable(panel sdk.Panel) []jen.Code {
+	settings := encoder.encodeTableTransformations(panel)
+
+	settings = append(settings, encoder.encodeTargets(customPanelTargets(panel), "table")...)
+	settings = append(settings, encoder.encodeValueMappings(panel, "table")...)
+	settings = append(settings, encoder.encodeDataLinks(panel, "table")...)
+	settings = append(settings, encoder.encodeTableFieldDefaults(fieldConfigDefaults(panel))...)
+	settings = append(settings, encoder.encodeTableOptions(customPanelOptions(panel))...)
+	settings = append(settings, encoder.encodeTableOverrides(panel)...)
+
+	return settings
+}
+
+func (encoder *Encoder) encodeTableTransformations(panel sdk.Panel) []jen.Code {
+	transformations, _ := (*panel.CustomPanel)["transformations"].([]interface{})
+
+	var settings []jen.Code
+	var encodedTransformations []jen.Code
+
+	for i, item := range transformations {
+		transformation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// these transformations are only reachable through the options
+		// inherited from the legacy table panel, which always come first.
+		legacyOptions := map[string]string{
+			"seriesToRows":    "TimeSeriesToRows",
+			"seriesToColumns": "TimeSeriesToColumns",
+		}
+		if legacyOption, ok := legacyOptions[mapString(transformation, "id")]; ok && i == 0 {
+			settings = append(settings, tableQual(legacyOption).Call())
+			continue
+		}
+
+		if encoded := encoder.encodeTransformation(transformation); encoded != nil {
+			encodedTransformations = append(encodedTransformations, encoded)
+		}
+	}
+
+	if len(encodedTransformations) != 0 {
+		settings = append(settings, tableQual("Transformations").MultiLineCall(encodedTransformations...))
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeTableFieldDefaults(defaults map[string]interface{}) []jen.Code {
+	var settings []jen.Code
+
+	if unit := mapString(defaults, "unit"); unit != "" {
+		settings = append(settings, tableQual("Unit").Call(lit(unit)))
+	}
+	if _, ok := defaults["decimals"].(float64); ok {
+		settings = append(settings, tableQual("Decimals").Call(lit(mapInt(defaults, "decimals"))))
+	}
+
+	custom, _ := defaults["custom"].(map[string]interface{})
+
+	if width := mapInt(custom, "width"); width != 0 {
+		settings = append(settings, tableQual("ColumnWidth").Call(lit(width)))
+	}
+	if minWidth := mapInt(custom, "minWidth"); minWidth != 0 {
+		settings = append(settings, tableQual("MinColumnWidth").Call(lit(minWidth)))
+	}
+	if mapBool(custom, "filterable") {
+		settings = append(settings, tableQual("Filterable").Call())
+	}
+	if align := encoder.encodeTableAlign(mapString(custom, "align")); align != nil {
+		settings = append(settings, tableQual("Align").Call(align))
+	}
+
+	cellOptions, _ := custom["cellOptions"].(map[string]interface{})
+	if cellDisplay := encoder.encodeTableCellDisplay(cellOptions); cellDisplay != nil {
+		settings = append(settings, tableQual("CellDisplay").Call(cellDisplay))
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeTableOptions(options map[string]interface{}) []jen.Code {
+	var settings []jen.Code
+
+	if showHeader, ok := options["showHeader"].(bool); ok && !showHeader {
+		settings = append(settings, tableQual("HideHeader").Call())
+	}
+
+	footer, _ := options["footer"].(map[string]interface{})
+	if mapBool(footer, "show") {
+		settings = append(settings, tableQual("Footer").Call(encoder.encodeReducers(mapStrings(footer, "reducer"))...))
+	}
+	if mapBool(footer, "enablePagination") {
+		settings = append(settings, tableQual("Pagination").Call())
+	}
+
+	sortBy, _ := options["sortBy"].([]interface{})
+	for _, item := range sortBy {
+		sort, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		settings = append(settings, tableQual("SortBy").Call(lit(mapString(sort, "displayName")), lit(mapBool(sort, "desc"))))
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeTableOverrides(panel sdk.Panel) []jen.Code {
+	fieldConfig, _ := (*panel.CustomPanel)["fieldConfig"].(map[string]interface{})
+	overrides, _ := fieldConfig["overrides"].([]interface{})
+
+	var settings []jen.Code
+	for _, item := range overrides {
+		override, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if encoded := encoder.encodeTableOverride(override); encoded != nil {
+			settings = append(settings, encoded)
+		}
+	}
+
+	return settings
+}
+
+func (encoder *Encoder) encodeTableOverride(override map[string]interface{}) jen.Code {
+	matcher, _ := override["matcher"].(map[string]interface{})
+	properties, _ := override["properties"].([]interface{})
+	matcherOptions := mapString(matcher, "options")
+
+	// HideColumn() is how hidden columns used to be defined: keep using it
+	if mapString(matcher, "id") == "byRegexp" && len(properties) == 1 {
+		property, _ := properties[0].(map[string]interface{})
+
+		if mapString(property, "id") == "custom.hidden" && mapBool(property, "value") {
+			return tableQual("HideColumn").Call(lit(matcherOptions))
+		}
+	}
+
+	matchers := map[string]string{
+		"byName":       "ByName",
+		"byFrameRefID": "ByQuery",
+		"byRegexp":     "ByRegex",
+	}
+
+	var encodedMatcher jen.Code
+	if matcherFunc, ok := matchers[mapString(matcher, "id")]; ok {
+		encodedMatcher = tableFieldsQual(matcherFunc).Call(lit(matcherOptions))
+	} else if mapString(matcher, "id") == "byType" && matcherOptions == "time" {
+		encodedMatcher = tableFieldsQual("ByType").Call(qual("timeseries/fields", "FieldTypeTime"))
+	} else {
+		encoder.logger.Warn("unhandled override matcher: skipped", zap.String("matcher", mapString(matcher, "id")))
+		return nil
+	}
+
+	settings := []jen.Code{encodedMatcher}
+	for _, item := range properties {
+		property, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		settings = append(settings, encoder.encodeTableOverrideProperty(property)...)
+	}
+
+	return tableQual("FieldOverride").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeTableOverrideProperty(property map[string]interface{}) []jen.Code {
+	switch mapString(property, "id") {
+	case "unit":
+		return []jen.Code{tableFieldsQual("Unit").Call(lit(mapString(property, "value")))}
+	case "decimals":
+		return []jen.Code{tableFieldsQual("Decimals").Call(lit(mapInt(property, "value")))}
+	case "custom.width":
+		return []jen.Code{tableFieldsQual("Width").Call(lit(mapInt(property, "value")))}
+	case "custom.minWidth":
+		return []jen.Code{tableFieldsQual("MinWidth").Call(lit(mapInt(property, "value")))}
+	case "custom.filterable":
+		if mapBool(property, "value") {
+			return []jen.Code{tableFieldsQual("Filterable").Call()}
+		}
+
+		return nil
+	case "custom.hidden":
+		if mapBool(property, "value") {
+			return []jen.Code{tableFieldsQual("Hidden").Call()}
+		}
+
+		return nil
+	case "custom.align":
+		align := encoder.encodeTableAlign(mapString(property, "value"))
+		if align == nil {
+			align = tableFieldsQual("AlignAuto")
+		}
+
+		return []jen.Code{tableFieldsQual("Align").Call(align)}
+	case "custom.cellOptions":
+		cellOptions, _ := property["value"].(map[string]interface{})
+		cellDisplay := encoder.encodeTableCellDisplay(cellOptions)
+		if cellDisplay == nil {
+			cellDisplay = tableFieldsQual("Auto")
+		}
+
+		return []jen.Code{tableFieldsQual("CellDisplay").Call(cellDisplay)}
+	case "mappings":
+		mappings, _ := property["value"].([]interface{})
+
+		var encodedMappings []jen.Code
+		for _, item := range mappings {
+			if valueMapping, ok := item.(map[string]interface{}); ok {
+				encodedMappings = append(encodedMappings, encoder.encodeValueMapping(valueMapping)...)
+			}
+		}
+
+		return []jen.Code{tableFieldsQual("ValueMappings").Call(encodedMappings...)}
+	case "links":
+		links, _ := property["value"].([]interface{})
+
+		var encodedLinks []jen.Code
+		for _, item := range links {
+			if link, ok := item.(map[string]interface{}); ok {
+				encodedLinks = append(encodedLinks, encoder.encodeDataLink(link))
+			}
+		}
+
+		return []jen.Code{tableFieldsQual("DataLinks").Call(encodedLinks...)}
+	}
+
+	encoder.logger.Warn("unhandled table override property: skipped", zap.String("property", mapString(property, "id")))
+
+	return nil
+}
+
+// encodeTableAlign returns nil for the default alignment.
+func (encoder *Encoder) encodeTableAlign(align string) jen.Code {
+	constNames := map[string]string{
+		"left":   "AlignLeft",
+		"center": "AlignCenter",
+		"right":  "AlignRight",
+	}
+
+	if constName, ok := constNames[align]; ok {
+		return tableFieldsQual(constName)
+	}
+
+	return nil
+}
+
+// encodeTableCellDisplay returns nil for the default display mode.
+func (encoder *Encoder) encodeTableCellDisplay(cellOptions map[string]interface{}) jen.Code {
+	var constName string
+
+	switch mapString(cellOptions, "type") {
+	case "", "auto":
+		return nil
+	case "color-text":
+		constName = "ColoredText"
+	case "color-background":
+		constName = "ColoredBackground"
+		if mapString(cellOptions, "mode") == "basic" {
+			constName = "ColoredBackgroundSolid"
+		}
+	case "gauge":
+		constName = map[string]string{
+			"gradient": "GradientGauge",
+			"lcd":      "LCDGauge",
+			"basic":    "BasicGauge",
+		}[mapString(cellOptions, "mode")]
+		if constName == "" {
+			constName = "GradientGauge"
+		}
+	case "json-view":
+		constName = "JSONView"
+	case "image":
+		constName = "Image"
+	case "sparkline":
+		constName = "Sparkline"
+	default:
+		encoder.logger.Warn("unhandled table cell display mode: skipped", zap.String("type", mapString(cellOptions, "type")))
+		return nil
+	}
+
+	return tableFieldsQual(constName)
+}
+
+func tableQual(name string) *jen.Statement {
+	return qual("table", name)
+}
+
+func tableFieldsQual(name string) *jen.Statement {
+	return qual("table/fields", name)
+}
@@ -8,6 +8,9 @@ import (
 
 func (encoder *Encoder) encodeTimeseries(panel sdk.Panel) jen.Code {
 	settings := encoder.encodeCommonPanelProperties(panel, "timeseries")
+	settings = append(settings, encoder.encodeTransformations(panel, "timeseries")...)
+	settings = append(settings, encoder.encodeValueMappings(panel, "timeseries")...)
+	settings = append(settings, encoder.encodeDataLinks(panel, "timeseries")...)
 
 	settings = append(
 		settings,
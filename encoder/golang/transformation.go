@@ -0,0 +1,329 @@
+package golang
+
+import (
+	"sort"
+
+	"github.com/K-Phoen/jennifer/jen"
+	"github.com/K-Phoen/sdk"
+	"go.uber.org/zap"
+)
+
+func (encoder *Encoder) encodeTransformations(panel sdk.Panel, grabanaPackage string) []jen.Code {
+	if panel.CustomPanel == nil {
+		return nil
+	}
+
+	transformations, _ := (*panel.CustomPanel)["transformations"].([]interface{})
+
+	var encodedTransformations []jen.Code
+	for _, item := range transformations {
+		transformation, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if encoded := encoder.encodeTransformation(transformation); encoded != nil {
+			encodedTransformations = append(encodedTransformations, encoded)
+		}
+	}
+
+	if len(encodedTransformations) == 0 {
+		return nil
+	}
+
+	return []jen.Code{
+		qual(grabanaPackage, "Transformations").MultiLineCall(encodedTransformations...),
+	}
+}
+
+func (encoder *Encoder) encodeTransformation(transformation map[string]interface{}) jen.Code {
+	options, _ := transformation["options"].(map[string]interface{})
+
+	switch mapString(transformation, "id") {
+	case "merge":
+		return transformationQual("Merge").Call()
+	case "joinByField":
+		mode := "OuterJoin"
+		if mapString(options, "mode") == "inner" {
+			mode = "InnerJoin"
+		}
+
+		return transformationQual("JoinByField").Call(lit(mapString(options, "byField")), transformationQual(mode))
+	case "reduce":
+		mode := "SeriesToRows"
+		if mapString(options, "mode") == "reduceFields" {
+			mode = "ReduceFields"
+		}
+
+		args := append([]jen.Code{transformationQual(mode)}, encoder.encodeReducers(mapStrings(options, "reducers"))...)
+
+		return transformationQual("Reduce").Call(args...)
+	case "renameByRegex":
+		return transformationQual("RenameByRegex").Call(lit(mapString(options, "regex")), lit(mapString(options, "renamePattern")))
+	case "sortBy":
+		return encoder.encodeSortByTransformation(options)
+	case "limit":
+		return transformationQual("Limit").Call(lit(mapInt(options, "limitField")))
+	case "organize":
+		return encoder.encodeOrganizeTransformation(options)
+	case "filterByValue":
+		return encoder.encodeFilterByValueTransformation(options)
+	case "groupBy":
+		return encoder.encodeGroupByTransformation(options)
+	case "calculateField":
+		return encoder.encodeCalculateFieldTransformation(options)
+	case "labelsToFields":
+		return encoder.encodeLabelsToFieldsTransformation(options)
+	}
+
+	encoder.logger.Warn("unhandled transformation: skipped", zap.String("id", mapString(transformation, "id")))
+
+	return nil
+}
+
+func (encoder *Encoder) encodeSortByTransformation(options map[string]interface{}) jen.Code {
+	sorts, _ := options["sort"].([]interface{})
+	if len(sorts) == 0 {
+		return nil
+	}
+
+	sortBy, _ := sorts[0].(map[string]interface{})
+	order := "Ascending"
+	if mapBool(sortBy, "desc") {
+		order = "Descending"
+	}
+
+	return transformationQual("SortBy").Call(lit(mapString(sortBy, "field")), transformationQual(order))
+}
+
+func (encoder *Encoder) encodeOrganizeTransformation(options map[string]interface{}) jen.Code {
+	var settings []jen.Code
+
+	excludeByName, _ := options["excludeByName"].(map[string]interface{})
+	var excluded []string
+	for field := range excludeByName {
+		if mapBool(excludeByName, field) {
+			excluded = append(excluded, field)
+		}
+	}
+	sort.Strings(excluded)
+	if len(excluded) != 0 {
+		settings = append(settings, transformationQual("ExcludeFields").Call(Map(excluded, func(field string) jen.Code {
+			return lit(field)
+		})...))
+	}
+
+	renameByName, _ := options["renameByName"].(map[string]interface{})
+	renamed := make([]string, 0, len(renameByName))
+	for field := range renameByName {
+		renamed = append(renamed, field)
+	}
+	sort.Strings(renamed)
+	for _, field := range renamed {
+		settings = append(settings, transformationQual("RenameField").Call(lit(field), lit(mapString(renameByName, field))))
+	}
+
+	indexByName, _ := options["indexByName"].(map[string]interface{})
+	ordered := make([]string, 0, len(indexByName))
+	for field := range indexByName {
+		ordered = append(ordered, field)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return mapInt(indexByName, ordered[i]) < mapInt(indexByName, ordered[j])
+	})
+	if len(ordered) != 0 {
+		settings = append(settings, transformationQual("OrderFields").Call(Map(ordered, func(field string) jen.Code {
+			return lit(field)
+		})...))
+	}
+
+	return transformationQual("OrganizeFields").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeFilterByValueTransformation(options map[string]interface{}) jen.Code {
+	filterType := "Include"
+	if mapString(options, "type") == "exclude" {
+		filterType = "Exclude"
+	}
+
+	match := "MatchAny"
+	if mapString(options, "match") == "all" {
+		match = "MatchAll"
+	}
+
+	settings := []jen.Code{
+		transformationQual(filterType),
+		transformationQual(match),
+	}
+
+	matchers := map[string]string{
+		"greater":        "Greater",
+		"greaterOrEqual": "GreaterOrEqual",
+		"lower":          "Lower",
+		"lowerOrEqual":   "LowerOrEqual",
+		"equal":          "Equal",
+		"notEqual":       "NotEqual",
+		"regex":          "Regex",
+	}
+
+	filters, _ := options["filters"].([]interface{})
+	for _, item := range filters {
+		filter, _ := item.(map[string]interface{})
+		config, _ := filter["config"].(map[string]interface{})
+		configOptions, _ := config["options"].(map[string]interface{})
+		field := lit(mapString(filter, "fieldName"))
+
+		switch id := mapString(config, "id"); id {
+		case "range":
+			settings = append(settings, transformationQual("InRange").Call(field, lit(mapFloat(configOptions, "from")), lit(mapFloat(configOptions, "to"))))
+		case "isNull":
+			settings = append(settings, transformationQual("IsNull").Call(field))
+		case "isNotNull":
+			settings = append(settings, transformationQual("IsNotNull").Call(field))
+		default:
+			constructor, ok := matchers[id]
+			if !ok {
+				encoder.logger.Warn("unhandled filter by value condition: skipped", zap.String("id", id))
+				continue
+			}
+
+			settings = append(settings, transformationQual(constructor).Call(field, lit(configOptions["value"])))
+		}
+	}
+
+	return transformationQual("FilterByValue").MultiLineCall(settings...)
+}
+
+func (encoder *Encoder) encodeGroupByTransformation(options map[string]interface{}) jen.Code {
+	fields, _ := options["fields"].(map[string]interface{})
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var groupBy []string
+	var aggregations []jen.Code
+	for _, name := range names {
+		field, _ := fields[name].(map[string]interface{})
+
+		switch mapString(field, "operation") {
+		case "groupby":
+			groupBy = append(groupBy, name)
+		case "aggregate":
+			args := append([]jen.Code{lit(name)}, encoder.encodeReducers(mapStrings(field, "aggregations"))...)
+			aggregations = append(aggregations, transformationQual("Aggregate").Call(args...))
+		}
+	}
+
+	settings := []jen.Code{
+		transformationQual("By").Call(Map(groupBy, func(field string) jen.Code {
+			return lit(field)
+		})...),
+	}
+
+	return transformationQual("GroupBy").MultiLineCall(append(settings, aggregations...)...)
+}
+
+func (encoder *Encoder) encodeCalculateFieldTransformation(options map[string]interface{}) jen.Code {
+	var calculation jen.Code
+
+	switch mapString(options, "mode") {
+	case "binary":
+		binary, _ := options["binary"].(map[string]interface{})
+		operators := map[string]string{
+			"+": "Add",
+			"-": "Subtract",
+			"*": "Multiply",
+			"/": "Divide",
+		}
+
+		operator, ok := operators[mapString(binary, "operator")]
+		if !ok {
+			encoder.logger.Warn("unhandled binary operator: skipped", zap.String("operator", mapString(binary, "operator")))
+			return nil
+		}
+
+		calculation = transformationQual("Binary").Call(lit(mapString(binary, "left")), transformationQual(operator), lit(mapString(binary, "right")))
+	case "reduceRow":
+		reduce, _ := options["reduce"].(map[string]interface{})
+		args := append(
+			encoder.encodeReducers([]string{mapString(reduce, "reducer")}),
+			Map(mapStrings(reduce, "include"), func(field string) jen.Code {
+				return lit(field)
+			})...,
+		)
+		if len(args) == 0 {
+			return nil
+		}
+
+		calculation = transformationQual("ReduceRow").Call(args...)
+	default:
+		encoder.logger.Warn("unhandled calculate field mode: skipped", zap.String("mode", mapString(options, "mode")))
+		return nil
+	}
+
+	settings := []jen.Code{
+		lit(mapString(options, "alias")),
+		calculation,
+	}
+
+	if mapBool(options, "replaceFields") {
+		settings = append(settings, transformationQual("ReplaceFields").Call())
+	}
+
+	return transformationQual("CalculateField").Call(settings...)
+}
+
+func (encoder *Encoder) encodeLabelsToFieldsTransformation(options map[string]interface{}) jen.Code {
+	var settings []jen.Code
+
+	if mapString(options, "mode") == "rows" {
+		settings = append(settings, transformationQual("LabelsAsRows").Call())
+	}
+	if valueLabel := mapString(options, "valueLabel"); valueLabel != "" {
+		settings = append(settings, transformationQual("ValueLabel").Call(lit(valueLabel)))
+	}
+	if keepLabels := mapStrings(options, "keepLabels"); len(keepLabels) != 0 {
+		settings = append(settings, transformationQual("KeepLabels").Call(Map(keepLabels, func(label string) jen.Code {
+			return lit(label)
+		})...))
+	}
+
+	return transformationQual("LabelsToFields").Call(settings...)
+}
+
+func (encoder *Encoder) encodeReducers(reducers []string) []jen.Code {
+	constNames := map[string]string{
+		"min":           "Min",
+		"max":           "Max",
+		"mean":          "Mean",
+		"first":         "First",
+		"firstNotNull":  "FirstNotNull",
+		"last":          "Last",
+		"lastNotNull":   "LastNotNull",
+		"sum":           "Sum",
+		"count":         "Count",
+		"range":         "Range",
+		"delta":         "Delta",
+		"distinctCount": "Distinct",
+	}
+
+	var encoded []jen.Code
+	for _, reducer := range reducers {
+		constName, ok := constNames[reducer]
+		if !ok {
+			encoder.logger.Warn("unhandled reducer: skipped", zap.String("reducer", reducer))
+			continue
+		}
+
+		encoded = append(encoded, transformationQual(constName))
+	}
+
+	return encoded
+}
+
+func transformationQual(name string) *jen.Statement {
+	return qual("transformation", name)
+}
@@ -1,19 +1,12 @@
 package golang
 
 import (
+	"encoding/json"
+
 	"github.com/K-Phoen/jennifer/jen"
 	"github.com/K-Phoen/sdk"
 )
 
-func panelSpan(panel sdk.Panel) float32 {
-	span := panel.Span
-	if span == 0 && panel.GridPos.H != nil {
-		span = float32(*panel.GridPos.W / 2) // 24 units per row to 12
-	}
-
-	return span
-}
-
 func qual(pkg string, name string) *jen.Statement {
 	return jen.Qual(packageImportPath+"/"+pkg, name)
 }
@@ -31,3 +24,70 @@ func Map[I any, O any](input []I, mapFunc func(item I) O) []O {
 
 	return results
 }
+
+// customPanelOptions returns the "options" object of panels that the sdk
+// doesn't know about, and thus decodes as a generic map.
+func customPanelOptions(panel sdk.Panel) map[string]interface{} {
+	if panel.CustomPanel == nil {
+		return nil
+	}
+
+	options, _ := (*panel.CustomPanel)["options"].(map[string]interface{})
+
+	return options
+}
+
+// customPanelTargets returns the targets of panels that the sdk doesn't know
+// about, and thus decodes as a generic map.
+func customPanelTargets(panel sdk.Panel) []sdk.Target {
+	if panel.CustomPanel == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal((*panel.CustomPanel)["targets"])
+	if err != nil {
+		return nil
+	}
+
+	var targets []sdk.Target
+	_ = json.Unmarshal(raw, &targets)
+
+	return targets
+}
+
+func mapString(input map[string]interface{}, key string) string {
+	value, _ := input[key].(string)
+
+	return value
+}
+
+func mapBool(input map[string]interface{}, key string) bool {
+	value, _ := input[key].(bool)
+
+	return value
+}
+
+func mapInt(input map[string]interface{}, key string) int {
+	value, _ := input[key].(float64)
+
+	return int(value)
+}
+
+func mapFloat(input map[string]interface{}, key string) float64 {
+	value, _ := input[key].(float64)
+
+	return value
+}
+
+func mapStrings(input map[string]interface{}, key string) []string {
+	values, _ := input[key].([]interface{})
+	results := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			results = append(results, str)
+		}
+	}
+
+	return results
+}
@@ -1,6 +1,8 @@
 package golang
 
 import (
+	"strings"
+
 	"github.com/K-Phoen/jennifer/jen"
 	"github.com/K-Phoen/sdk"
 	"go.uber.org/zap"
@@ -72,9 +74,12 @@ func (encoder *Encoder) encodeQueryVar(variable sdk.TemplateVar) jen.Code {
 	if variable.Datasource != nil && variable.Datasource.LegacyName != "" {
 		settings = append(
 			settings,
-			qual("variable/query", "Datasource").Call(lit(variable.Datasource.LegacyName)),
+			qual("variable/query", "DataSource").Call(lit(variable.Datasource.LegacyName)),
 		)
 	}
+	if ref := encodeDatasourceRef(variable.Datasource); ref != nil {
+		settings = append(settings, qual("variable/query", "DataSourceRef").Call(ref))
+	}
 	if variable.Current.Value == "$__all" {
 		settings = append(settings, qual("variable/query", "DefaultAll").Call())
 	}
@@ -87,6 +92,8 @@ func (encoder *Encoder) encodeQueryVar(variable sdk.TemplateVar) jen.Code {
 	if variable.Refresh.Value != nil {
 		refreshConstName := "DashboardLoad"
 		switch *variable.Refresh.Value {
+		case 0:
+			refreshConstName = "Never"
 		case 1:
 			refreshConstName = "DashboardLoad"
 		case 2:
@@ -103,17 +110,8 @@ func (encoder *Encoder) encodeQueryVar(variable sdk.TemplateVar) jen.Code {
 		)
 	}
 	if variable.Query != nil {
-		if request, ok := variable.Query.(string); ok {
-			settings = append(
-				settings,
-				qual("variable/query", "Request").Call(lit(request)),
-			)
-		}
-		if request, ok := variable.Query.(map[string]interface{}); ok {
-			settings = append(
-				settings,
-				qual("variable/query", "Request").Call(lit(request["query"].(string))),
-			)
+		if request := encoder.encodeQueryVarRequest(variable); request != nil {
+			settings = append(settings, request)
 		}
 	}
 	if variable.Sort != 0 {
@@ -143,9 +141,143 @@ func (encoder *Encoder) encodeQueryVar(variable sdk.TemplateVar) jen.Code {
 		)
 	}
 
+	if staticOptions := queryVarStaticOptions(variable); len(staticOptions) != 0 {
+		settings = append(settings, qual("variable/query", "StaticOptions").Call(staticOptions...))
+	}
+	if current := queryVarCurrent(variable); len(current) != 0 {
+		settings = append(settings, qual("variable/query", "Current").Call(current...))
+	}
+
 	return dashboardQual("VariableAsQuery").MultiLineCall(settings...)
 }
 
+func (encoder *Encoder) encodeQueryVarRequest(variable sdk.TemplateVar) jen.Code {
+	if request, ok := variable.Query.(string); ok {
+		return qual("variable/query", "Request").Call(lit(request))
+	}
+
+	request, ok := variable.Query.(map[string]interface{})
+	if !ok {
+		encoder.logger.Warn("unhandled query for variable: skipped", zap.String("name", variable.Name))
+		return nil
+	}
+
+	if _, ok := request["qryType"]; ok {
+		return encoder.encodePrometheusQueryVarRequest(variable, request)
+	}
+	if _, ok := request["type"]; ok {
+		return encoder.encodeLokiQueryVarRequest(variable, request)
+	}
+
+	if query, ok := request["query"].(string); ok {
+		return qual("variable/query", "Request").Call(lit(query))
+	}
+
+	encoder.logger.Warn("unhandled query for variable: skipped", zap.String("name", variable.Name))
+
+	return nil
+}
+
+func (encoder *Encoder) encodePrometheusQueryVarRequest(variable sdk.TemplateVar, request map[string]interface{}) jen.Code {
+	query, _ := request["query"].(string)
+	queryType, _ := request["qryType"].(float64)
+
+	switch int(queryType) {
+	case 0:
+		return qual("variable/query", "PrometheusLabelNames").Call()
+	case 1:
+		if args, ok := functionArgs("label_values", query); ok {
+			selector, label := splitLabelValuesArgs(args)
+			return qual("variable/query", "PrometheusLabelValues").Call(lit(label), lit(selector))
+		}
+	case 2:
+		if regex, ok := functionArgs("metrics", query); ok {
+			return qual("variable/query", "PrometheusMetrics").Call(lit(regex))
+		}
+	case 3:
+		if expr, ok := functionArgs("query_result", query); ok {
+			return qual("variable/query", "PrometheusQueryResult").Call(lit(expr))
+		}
+	case 4:
+		return qual("variable/query", "PrometheusSeries").Call(lit(query))
+	}
+
+	encoder.logger.Warn("unhandled prometheus query for variable: using a raw request", zap.String("name", variable.Name), zap.Float64("type", queryType))
+
+	return qual("variable/query", "Request").Call(lit(query))
+}
+
+func (encoder *Encoder) encodeLokiQueryVarRequest(variable sdk.TemplateVar, request map[string]interface{}) jen.Code {
+	queryType, _ := request["type"].(float64)
+	label, _ := request["label"].(string)
+	stream, _ := request["stream"].(string)
+
+	switch int(queryType) {
+	case 0:
+		return qual("variable/query", "LokiLabelNames").Call()
+	case 1:
+		return qual("variable/query", "LokiLabelValues").Call(lit(label), lit(stream))
+	}
+
+	encoder.logger.Warn("unhandled loki query for variable: skipped", zap.String("name", variable.Name), zap.Float64("type", queryType))
+
+	return nil
+}
+
+// functionArgs extracts the arguments of a call like `name(args)`.
+func functionArgs(name string, call string) (string, bool) {
+	call = strings.TrimSpace(call)
+	if !strings.HasPrefix(call, name+"(") || !strings.HasSuffix(call, ")") {
+		return "", false
+	}
+
+	return strings.TrimSpace(call[len(name)+1 : len(call)-1]), true
+}
+
+// splitLabelValuesArgs splits the arguments of `label_values(selector, label)`.
+// The selector is optional.
+func splitLabelValuesArgs(args string) (string, string) {
+	separator := strings.LastIndex(args, ",")
+	if separator == -1 {
+		return "", args
+	}
+
+	return strings.TrimSpace(args[:separator]), strings.TrimSpace(args[separator+1:])
+}
+
+func queryVarStaticOptions(variable sdk.TemplateVar) []jen.Code {
+	var values []jen.Code
+
+	for _, option := range variable.Options {
+		if option.Value == "$__all" {
+			continue
+		}
+
+		values = append(values, lit(option.Value))
+	}
+
+	return values
+}
+
+func queryVarCurrent(variable sdk.TemplateVar) []jen.Code {
+	var values []jen.Code
+
+	switch current := variable.Current.Value.(type) {
+	case string:
+		if current != "" && current != "$__all" {
+			values = append(values, lit(current))
+		}
+	case []interface{}:
+		for _, value := range current {
+			if value, ok := value.(string); ok && value != "$__all" {
+				values = append(values, lit(value))
+			}
+		}
+	}
+
+	return values
+}
+
 func (encoder *Encoder) encodeDatasourceVar(variable sdk.TemplateVar) jen.Code {
 	settings := []jen.Code{
 		lit(variable.Name),
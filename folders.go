@@ -13,6 +13,13 @@ import (
 // ErrFolderNotFound is returned when the given folder can not be found.
 var ErrFolderNotFound = errors.New("folder not found")
 
+// ErrNestedFoldersUnsupported is returned when folders are looked for or
+// created within another folder, on a Grafana instance without nested
+// folders.
+var ErrNestedFoldersUnsupported = errors.New("nested folders are not supported by this Grafana instance")
+
+const foldersPerPage = 1000
+
 // Folder represents a dashboard folder.
 // See https://grafana.com/docs/grafana/latest/reference/dashboard_folders/
 type Folder struct {
@@ -38,13 +45,84 @@ func (client *Client) FindOrCreateFolder(ctx context.Context, name string) (*Fol
 	return folder, nil
 }
 
+// FindOrCreateFolderPath returns the folder at the end of the given path,
+// creating the missing folders along the way.
+// Paths are made of folder titles separated by slashes, like
+// "Infra/Kubernetes/Nodes". Slashes within titles are escaped as "\/".
+// Note: Grafana supports nested folders from v10. Older instances only accept
+// paths made of a single folder, ErrNestedFoldersUnsupported is returned
+// otherwise.
+func (client *Client) FindOrCreateFolderPath(ctx context.Context, path string) (*Folder, error) {
+	var folder *Folder
+
+	for _, title := range splitFolderPath(path) {
+		parentUID := ""
+		if folder != nil {
+			parentUID = folder.UID
+		}
+
+		child, err := client.findSubfolder(ctx, parentUID, title)
+		if err != nil && !errors.Is(err, ErrFolderNotFound) {
+			return nil, fmt.Errorf("could not find or create folder '%s': %w", title, err)
+		}
+		if child == nil {
+			child, err = client.CreateSubfolder(ctx, parentUID, title)
+			if err != nil {
+				return nil, fmt.Errorf("could not create folder '%s': %w", title, err)
+			}
+		}
+
+		folder = child
+	}
+
+	if folder == nil {
+		return nil, fmt.Errorf("invalid folder path '%s': %w", path, ErrFolderNotFound)
+	}
+
+	return folder, nil
+}
+
+// GetFolderByPath finds a folder, given its path. See FindOrCreateFolderPath
+// for the format of paths.
+func (client *Client) GetFolderByPath(ctx context.Context, path string) (*Folder, error) {
+	var folder *Folder
+
+	for _, title := range splitFolderPath(path) {
+		parentUID := ""
+		if folder != nil {
+			parentUID = folder.UID
+		}
+
+		child, err := client.findSubfolder(ctx, parentUID, title)
+		if err != nil {
+			return nil, err
+		}
+
+		folder = child
+	}
+
+	if folder == nil {
+		return nil, ErrFolderNotFound
+	}
+
+	return folder, nil
+}
+
 // CreateFolder creates a dashboard folder.
 // See https://grafana.com/docs/grafana/latest/reference/dashboard_folders/
 func (client *Client) CreateFolder(ctx context.Context, name string) (*Folder, error) {
+	return client.CreateSubfolder(ctx, "", name)
+}
+
+// CreateSubfolder creates a dashboard folder within the folder identified by
+// parentUID. An empty parentUID creates a top-level folder.
+func (client *Client) CreateSubfolder(ctx context.Context, parentUID string, name string) (*Folder, error) {
 	buf, err := json.Marshal(struct {
-		Title string `json:"title"`
+		Title     string `json:"title"`
+		ParentUID string `json:"parentUid,omitempty"`
 	}{
-		Title: name,
+		Title:     name,
+		ParentUID: parentUID,
 	})
 	if err != nil {
 		return nil, err
@@ -66,10 +144,22 @@ func (client *Client) CreateFolder(ctx context.Context, name string) (*Folder, e
 		return nil, err
 	}
 
+	// instances without nested folders ignore the parent and create the
+	// folder at the top level
+	if folder.ParentUID != parentUID {
+		if err := client.DeleteFolder(ctx, folder.UID); err != nil {
+			return nil, fmt.Errorf("could not delete folder '%s' created at the top level (%s): %w", name, err, ErrNestedFoldersUnsupported)
+		}
+
+		return nil, ErrNestedFoldersUnsupported
+	}
+
 	return &folder, nil
 }
 
 // GetFolderByTitle finds a folder, given its title.
+// Folders are looked for at every level: when several nested folders share a
+// title, GetFolderByPath should be used instead.
 func (client *Client) GetFolderByTitle(ctx context.Context, title string) (*Folder, error) {
 	resp, err := client.get(ctx, fmt.Sprintf("/api/search?type=dash-folder&query=%s", url.QueryEscape(title)))
 	if err != nil {
@@ -95,3 +185,164 @@ func (client *Client) GetFolderByTitle(ctx context.Context, title string) (*Fold
 
 	return nil, ErrFolderNotFound
 }
+
+// Subfolders lists the folders directly within the folder identified by
+// parentUID. An empty parentUID lists top-level folders.
+func (client *Client) Subfolders(ctx context.Context, parentUID string) ([]Folder, error) {
+	var folders []Folder
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("limit", fmt.Sprint(foldersPerPage))
+		params.Set("page", fmt.Sprint(page))
+		if parentUID != "" {
+			params.Set("parentUid", parentUID)
+		}
+
+		resp, err := client.get(ctx, "/api/folders?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			_ = resp.Body.Close()
+			return nil, ErrFolderNotFound
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := client.httpError(resp)
+			_ = resp.Body.Close()
+
+			return nil, err
+		}
+
+		var pageFolders []Folder
+		err = decodeJSON(resp.Body, &pageFolders)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range pageFolders {
+			// instances without nested folders ignore the parent and list
+			// the top level folders, the parent being one of them
+			if parentUID != "" && (pageFolders[i].UID == parentUID || (pageFolders[i].ParentUID != "" && pageFolders[i].ParentUID != parentUID)) {
+				return nil, ErrNestedFoldersUnsupported
+			}
+
+			pageFolders[i].ParentUID = parentUID
+		}
+
+		folders = append(folders, pageFolders...)
+
+		if len(pageFolders) < foldersPerPage {
+			return folders, nil
+		}
+	}
+}
+
+// MoveFolder moves a folder, along with its content, within the folder
+// identified by parentUID. An empty parentUID moves it to the top level.
+func (client *Client) MoveFolder(ctx context.Context, folderUID string, parentUID string) (*Folder, error) {
+	buf, err := json.Marshal(struct {
+		ParentUID string `json:"parentUid"`
+	}{
+		ParentUID: parentUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/folders/%s/move", url.PathEscape(folderUID)), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrFolderNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var folder Folder
+	if err := decodeJSON(resp.Body, &folder); err != nil {
+		return nil, err
+	}
+
+	return &folder, nil
+}
+
+// DeleteFolder deletes a folder, along with its content.
+func (client *Client) DeleteFolder(ctx context.Context, folderUID string) error {
+	resp, err := client.delete(ctx, "/api/folders/"+url.PathEscape(folderUID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrFolderNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// findSubfolder finds a folder by title, directly within the folder
+// identified by parentUID. Exact matches are preferred.
+func (client *Client) findSubfolder(ctx context.Context, parentUID string, title string) (*Folder, error) {
+	folders, err := client.Subfolders(ctx, parentUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *Folder
+	for i := range folders {
+		if folders[i].Title == title {
+			return &folders[i], nil
+		}
+		if match == nil && strings.EqualFold(folders[i].Title, title) {
+			match = &folders[i]
+		}
+	}
+
+	if match == nil {
+		return nil, ErrFolderNotFound
+	}
+
+	return match, nil
+}
+
+// splitFolderPath splits a path into folder titles. Empty segments are
+// ignored and "\/" is kept as a slash within a title.
+func splitFolderPath(path string) []string {
+	var titles []string
+	var current strings.Builder
+
+	flush := func() {
+		if title := strings.TrimSpace(current.String()); title != "" {
+			titles = append(titles, title)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path) && path[i+1] == '/':
+			current.WriteByte('/')
+			i++
+		case path[i] == '/':
+			flush()
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	flush()
+
+	return titles
+}
@@ -2,6 +2,7 @@ package grabana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -192,3 +193,252 @@ func TestGetFolderByTitleCanFail(t *testing.T) {
 	req.Error(err)
 	req.Nil(folder)
 }
+
+// folderTree fakes Grafana's nested folders API. Flat trees fake instances
+// without nested folders, ignoring parent UIDs.
+type folderTree struct {
+	flat    bool
+	folders []Folder
+	created []Folder
+	deleted []string
+}
+
+func (tree *folderTree) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+		parentUID := r.URL.Query().Get("parentUid")
+		children := []Folder{}
+
+		for _, folder := range tree.folders {
+			if tree.flat || folder.ParentUID == parentUID {
+				children = append(children, Folder{ID: folder.ID, UID: folder.UID, Title: folder.Title})
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(children)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/folders":
+		folder := Folder{}
+		_ = json.NewDecoder(r.Body).Decode(&folder)
+		folder.ID = uint(len(tree.folders) + 1)
+		folder.UID = fmt.Sprintf("uid-%d", folder.ID)
+		if tree.flat {
+			folder.ParentUID = ""
+		}
+
+		tree.folders = append(tree.folders, folder)
+		tree.created = append(tree.created, folder)
+
+		_ = json.NewEncoder(w).Encode(folder)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/folders/"):
+		tree.deleted = append(tree.deleted, strings.TrimPrefix(r.URL.Path, "/api/folders/"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestFindOrCreateFolderPathCreatesMissingFolders(t *testing.T) {
+	req := require.New(t)
+	tree := &folderTree{
+		folders: []Folder{
+			{ID: 1, UID: "infra", Title: "Infra"},
+			{ID: 2, UID: "platform", Title: "Platform"},
+			{ID: 3, UID: "platform-k8s", Title: "Kubernetes", ParentUID: "platform"},
+		},
+	}
+	ts := httptest.NewServer(tree)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.FindOrCreateFolderPath(context.TODO(), "Infra/Kubernetes/Nodes")
+
+	req.NoError(err)
+	req.Equal("Nodes", folder.Title)
+	req.Len(tree.created, 2)
+	req.Equal("Kubernetes", tree.created[0].Title)
+	req.Equal("infra", tree.created[0].ParentUID)
+	req.Equal("Nodes", tree.created[1].Title)
+	req.Equal(tree.created[0].UID, tree.created[1].ParentUID)
+}
+
+func TestFindOrCreateFolderPathReusesExistingFolders(t *testing.T) {
+	req := require.New(t)
+	tree := &folderTree{
+		folders: []Folder{
+			{ID: 1, UID: "infra", Title: "Infra"},
+			{ID: 2, UID: "infra-k8s", Title: "Kubernetes", ParentUID: "infra"},
+			{ID: 3, UID: "platform", Title: "Platform"},
+			{ID: 4, UID: "platform-k8s", Title: "Kubernetes", ParentUID: "platform"},
+		},
+	}
+	ts := httptest.NewServer(tree)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.FindOrCreateFolderPath(context.TODO(), "/Platform/Kubernetes/")
+
+	req.NoError(err)
+	req.Empty(tree.created)
+	req.Equal("platform-k8s", folder.UID)
+	req.Equal("platform", folder.ParentUID)
+}
+
+func TestFindOrCreateFolderPathFailsWithoutNestedFolders(t *testing.T) {
+	req := require.New(t)
+	tree := &folderTree{
+		flat: true,
+		folders: []Folder{
+			{ID: 1, UID: "infra", Title: "Infra"},
+			{ID: 2, UID: "nodes", Title: "Nodes"},
+		},
+	}
+	ts := httptest.NewServer(tree)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.FindOrCreateFolderPath(context.TODO(), "Infra/Nodes")
+
+	req.ErrorIs(err, ErrNestedFoldersUnsupported)
+	req.Empty(tree.created)
+
+	folder, err := client.FindOrCreateFolderPath(context.TODO(), "Nodes")
+
+	req.NoError(err)
+	req.Equal("nodes", folder.UID)
+}
+
+func TestSubfoldersCreatedAtTheTopLevelAreDeleted(t *testing.T) {
+	req := require.New(t)
+	tree := &folderTree{flat: true}
+	ts := httptest.NewServer(tree)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CreateSubfolder(context.TODO(), "infra", "Nodes")
+
+	req.ErrorIs(err, ErrNestedFoldersUnsupported)
+	req.Len(tree.created, 1)
+	req.Equal([]string{tree.created[0].UID}, tree.deleted)
+}
+
+func TestFindOrCreateFolderPathRejectsEmptyPaths(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(&folderTree{})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.FindOrCreateFolderPath(context.TODO(), " / ")
+
+	req.Error(err)
+}
+
+func TestGetFolderByPath(t *testing.T) {
+	req := require.New(t)
+	tree := &folderTree{
+		folders: []Folder{
+			{ID: 1, UID: "infra", Title: "Infra"},
+			{ID: 2, UID: "infra-k8s", Title: "Kubernetes", ParentUID: "infra"},
+		},
+	}
+	ts := httptest.NewServer(tree)
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.GetFolderByPath(context.TODO(), "Infra/Kubernetes")
+	req.NoError(err)
+	req.Equal("infra-k8s", folder.UID)
+
+	_, err = client.GetFolderByPath(context.TODO(), "Infra/Nodes")
+	req.ErrorIs(err, ErrFolderNotFound)
+}
+
+func TestSplitFolderPath(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal([]string{"Infra", "Kubernetes", "Nodes"}, splitFolderPath("Infra/Kubernetes/Nodes"))
+	req.Equal([]string{"Infra", "Kubernetes"}, splitFolderPath("/ Infra //Kubernetes/"))
+	req.Equal([]string{"CI/CD", "Jobs"}, splitFolderPath(`CI\/CD/Jobs`))
+	req.Empty(splitFolderPath(""))
+}
+
+func TestSubfoldersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("Nodes", payload["title"])
+		req.Equal("parent-uid", payload["parentUid"])
+
+		_, _ = fmt.Fprintln(w, `{"uid": "nodes", "id": 3, "title": "Nodes", "parentUid": "parent-uid"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.CreateSubfolder(context.TODO(), "parent-uid", "Nodes")
+
+	req.NoError(err)
+	req.Equal("parent-uid", folder.ParentUID)
+}
+
+func TestFoldersCanBeMoved(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/folders/nodes/move", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("new-parent", payload["parentUid"])
+
+		_, _ = fmt.Fprintln(w, `{"uid": "nodes", "id": 3, "title": "Nodes", "parentUid": "new-parent"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	folder, err := client.MoveFolder(context.TODO(), "nodes", "new-parent")
+
+	req.NoError(err)
+	req.Equal("new-parent", folder.ParentUID)
+}
+
+func TestFoldersCanBeDeleted(t *testing.T) {
+	req := require.New(t)
+	deleted := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/folders/nodes", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"message": "Folder deleted"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteFolder(context.TODO(), "nodes")
+
+	req.NoError(err)
+	req.True(deleted)
+}
+
+func TestDeletingAnUnknownFolderReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "folder not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteFolder(context.TODO(), "nodes")
+
+	req.ErrorIs(err, ErrFolderNotFound)
+}
@@ -3,13 +3,22 @@ package gauge
 import (
 	"fmt"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/scheme"
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
 	"github.com/K-Phoen/grabana/target/graphite"
 	"github.com/K-Phoen/grabana/target/influxdb"
 	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/target/sql"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -89,6 +98,10 @@ func New(title string, options ...Option) (*Gauge, error) {
 		}
 	}
 
+	if err := custompanel.Flatten(panel.Builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
@@ -124,12 +137,22 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the panel, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(gauge *Gauge) error {
+		gauge.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
 // WithPrometheusTarget adds a prometheus query to the graph.
 func WithPrometheusTarget(query string, options ...prometheus.Option) Option {
 	target := prometheus.New(query, options...)
 
 	return func(gauge *Gauge) error {
 		gauge.Builder.AddTarget(&sdk.Target{
+			Datasource:     target.Datasource.Internal(),
 			RefID:          target.Ref,
 			Hide:           target.Hidden,
 			Expr:           target.Expr,
@@ -167,6 +190,39 @@ func WithInfluxDBTarget(query string, options ...influxdb.Option) Option {
 	}
 }
 
+// WithCloudWatchTarget adds a CloudWatch metric query to the graph.
+func WithCloudWatchTarget(namespace string, metricName string, options ...cloudwatch.Option) Option {
+	target := cloudwatch.New(namespace, metricName, options...)
+
+	return func(gauge *Gauge) error {
+		gauge.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithElasticsearchTarget adds an Elasticsearch query to the graph.
+func WithElasticsearchTarget(query string, options ...elasticsearch.Option) Option {
+	target := elasticsearch.New(query, options...)
+
+	return func(gauge *Gauge) error {
+		gauge.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithSQLTarget adds a SQL query to the graph.
+func WithSQLTarget(query string, options ...sql.Option) Option {
+	target := sql.New(query, options...)
+
+	return func(gauge *Gauge) error {
+		gauge.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
 // WithStackdriverTarget adds a stackdriver query to the graph.
 func WithStackdriverTarget(target *stackdriver.Stackdriver) Option {
 	return func(gauge *Gauge) error {
@@ -199,6 +255,22 @@ func Height(height string) Option {
 	}
 }
 
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(gauge *Gauge) error {
+		return layout.At(gauge.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(gauge *Gauge) error {
+		return layout.Size(gauge.Builder, width, height)
+	}
+}
+
 // Description annotates the current visualization with a human-readable description.
 func Description(content string) Option {
 	return func(gauge *Gauge) error {
@@ -217,6 +289,35 @@ func Transparent() Option {
 	}
 }
 
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(gauge *Gauge) error {
+		custompanel.Set(gauge.Builder, "transformations", transformations)
+
+		return nil
+	}
+}
+
+// ValueMappings maps values to text and/or colors. Example: 0 → "Down",
+// 1 → "Up".
+func ValueMappings(mappings ...mapping.Mapping) Option {
+	return func(gauge *Gauge) error {
+		custompanel.SetIn(gauge.Builder, []string{"fieldConfig", "defaults", "mappings"}, mappings)
+
+		return nil
+	}
+}
+
+// DataLinks adds links to the values displayed by the panel.
+func DataLinks(links ...datalink.Link) Option {
+	return func(gauge *Gauge) error {
+		custompanel.SetIn(gauge.Builder, []string{"fieldConfig", "defaults", "links"}, links)
+
+		return nil
+	}
+}
+
 // Unit sets the unit of the data displayed on this axis.
 func Unit(unit string) Option {
 	return func(gauge *Gauge) error {
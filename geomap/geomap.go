@@ -0,0 +1,317 @@
+package geomap
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/geomap/layer"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/transformation"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a geomap panel.
+type Option func(geomap *Geomap) error
+
+type view struct {
+	ID        string   `json:"id"`
+	Lat       *float64 `json:"lat,omitempty"`
+	Lon       *float64 `json:"lon,omitempty"`
+	Zoom      float64  `json:"zoom"`
+	AllLayers bool     `json:"allLayers"`
+}
+
+type controls struct {
+	ShowZoom        bool `json:"showZoom"`
+	MouseWheelZoom  bool `json:"mouseWheelZoom"`
+	ShowAttribution bool `json:"showAttribution"`
+	ShowScale       bool `json:"showScale"`
+}
+
+type options struct {
+	View     view           `json:"view"`
+	Controls controls       `json:"controls"`
+	Basemap  *layer.Layer   `json:"basemap"`
+	Layers   []*layer.Layer `json:"layers"`
+}
+
+// Geomap represents a geomap panel.
+type Geomap struct {
+	Builder *sdk.Panel
+
+	options *options
+}
+
+// New creates a new geomap panel.
+func New(title string, options ...Option) (*Geomap, error) {
+	panel := &Geomap{
+		Builder: sdk.NewCustom(title),
+		options: defaultOptions(),
+	}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Type = "geomap"
+	panel.Builder.Renderer = nil
+	(*panel.Builder.CustomPanel)["options"] = panel.options
+	(*panel.Builder.CustomPanel)["targets"] = []sdk.Target{}
+
+	for _, opt := range append(defaults(), options...) {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+func defaultOptions() *options {
+	return &options{
+		View: view{ID: "zero", Zoom: 1, AllLayers: true},
+		Controls: controls{
+			ShowZoom:        true,
+			MouseWheelZoom:  true,
+			ShowAttribution: true,
+		},
+		Basemap: layer.Default(),
+		Layers:  []*layer.Layer{},
+	}
+}
+
+func defaults() []Option {
+	return []Option{
+		Span(6),
+	}
+}
+
+// Links adds links to be displayed on this panel.
+func Links(panelLinks ...links.Link) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+
+		for _, link := range panelLinks {
+			geomap.Builder.Links = append(geomap.Builder.Links, link.Builder)
+		}
+
+		return nil
+	}
+}
+
+// DataSource sets the data source to be used by the panel.
+func DataSource(source string) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Datasource = &sdk.DatasourceRef{LegacyName: source}
+
+		return nil
+	}
+}
+
+// DataSourceRef sets the data source to be used by the panel, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(geomap *Geomap) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		geomap.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Height = &height
+
+		return nil
+	}
+}
+
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(geomap *Geomap) error {
+		return layout.At(geomap.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(geomap *Geomap) error {
+		return layout.Size(geomap.Builder, width, height)
+	}
+}
+
+// Description annotates the current visualization with a human-readable description.
+func Description(content string) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Description = &content
+
+		return nil
+	}
+}
+
+// Transparent makes the background transparent.
+func Transparent() Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Transparent = true
+
+		return nil
+	}
+}
+
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(geomap *Geomap) error {
+		custompanel.Set(geomap.Builder, "transformations", transformations)
+
+		return nil
+	}
+}
+
+// Repeat configures repeating a panel for a variable
+func Repeat(repeat string) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.Repeat = &repeat
+
+		return nil
+	}
+}
+
+// RepeatDirection configures repeating vertical or horizontal
+func RepeatDirection(direction sdk.RepeatDirection) Option {
+	return func(geomap *Geomap) error {
+		geomap.Builder.RepeatDirection = &direction
+
+		return nil
+	}
+}
+
+// BaseLayer sets the layer used to render the map itself.
+// See layer.Default(), layer.OpenStreetMap(), layer.Carto(), layer.ArcGIS()
+// and layer.XYZ().
+func BaseLayer(base *layer.Layer) Option {
+	return func(geomap *Geomap) error {
+		geomap.options.Basemap = base
+
+		return nil
+	}
+}
+
+// Markers adds a layer rendering data points as markers.
+func Markers(name string, options ...layer.Option) Option {
+	return withLayer(layer.Markers, name, options...)
+}
+
+// Heatmap adds a layer rendering data points as a heatmap.
+func Heatmap(name string, options ...layer.Option) Option {
+	return withLayer(layer.Heatmap, name, options...)
+}
+
+// Route adds a layer rendering data points as a route.
+func Route(name string, options ...layer.Option) Option {
+	return withLayer(layer.Route, name, options...)
+}
+
+func withLayer(layerType layer.Type, name string, options ...layer.Option) Option {
+	return func(geomap *Geomap) error {
+		dataLayer, err := layer.New(layerType, name, options...)
+		if err != nil {
+			return err
+		}
+
+		geomap.options.Layers = append(geomap.options.Layers, dataLayer)
+
+		return nil
+	}
+}
+
+// Center centers the initial view on the given coordinates.
+func Center(latitude float64, longitude float64) Option {
+	return func(geomap *Geomap) error {
+		if latitude < -90 || latitude > 90 {
+			return fmt.Errorf("latitude must be between -90 and 90: %w", errors.ErrInvalidArgument)
+		}
+		if longitude < -180 || longitude > 180 {
+			return fmt.Errorf("longitude must be between -180 and 180: %w", errors.ErrInvalidArgument)
+		}
+
+		geomap.options.View.ID = "coords"
+		geomap.options.View.Lat = &latitude
+		geomap.options.View.Lon = &longitude
+
+		return nil
+	}
+}
+
+// FitToData makes the initial view fit the data displayed by the layers.
+func FitToData() Option {
+	return func(geomap *Geomap) error {
+		geomap.options.View.ID = "fit"
+		geomap.options.View.Lat = nil
+		geomap.options.View.Lon = nil
+
+		return nil
+	}
+}
+
+// Zoom sets the initial zoom level. Should be a number between 1 and 18.
+func Zoom(level float64) Option {
+	return func(geomap *Geomap) error {
+		if level < 1 || level > 18 {
+			return fmt.Errorf("zoom level must be between 1 and 18: %w", errors.ErrInvalidArgument)
+		}
+
+		geomap.options.View.Zoom = level
+
+		return nil
+	}
+}
+
+// HideZoomControls hides the zoom buttons.
+func HideZoomControls() Option {
+	return func(geomap *Geomap) error {
+		geomap.options.Controls.ShowZoom = false
+
+		return nil
+	}
+}
+
+// DisableMouseWheelZoom prevents the mouse wheel from zooming the map.
+func DisableMouseWheelZoom() Option {
+	return func(geomap *Geomap) error {
+		geomap.options.Controls.MouseWheelZoom = false
+
+		return nil
+	}
+}
+
+// ShowScale displays the map scale.
+func ShowScale() Option {
+	return func(geomap *Geomap) error {
+		geomap.options.Controls.ShowScale = true
+
+		return nil
+	}
+}
+
+func (geomap *Geomap) addTarget(target *sdk.Target) {
+	targets := (*geomap.Builder.CustomPanel)["targets"].([]sdk.Target)
+
+	(*geomap.Builder.CustomPanel)["targets"] = append(targets, *target)
+}
@@ -0,0 +1,341 @@
+package geomap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/geomap/layer"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGeomapPanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("Latency by region")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("Latency by region", panel.Builder.Title)
+	req.Equal("geomap", panel.Builder.Type)
+	req.Equal(float32(6), panel.Builder.Span)
+	req.Equal("zero", panel.options.View.ID)
+	req.Equal(layer.DefaultBase, panel.options.Basemap.Type)
+	req.Empty(panel.options.Layers)
+	req.Same(panel.options, (*panel.Builder.CustomPanel)["options"])
+}
+
+func TestGeomapPanelCanHaveLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Links(links.New("", "")))
+
+	req.NoError(err)
+	req.Len(panel.Builder.Links, 1)
+}
+
+func TestGeomapPanelCanHavePrometheusTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithPrometheusTarget(
+		"sum(rate(requests_total[5m])) by (region)",
+	))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveGraphiteTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithGraphiteTarget("stats_counts.statsd.packets_received"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveInfluxDBTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithInfluxDBTarget("buckets()"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveStackdriverTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithStackdriverTarget(stackdriver.Gauge("pubsub.googleapis.com/subscription/ack_message_count")))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelCanHaveLokiTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithLokiTarget("{app=\"loki\"}"))
+
+	req.NoError(err)
+	req.Len(panelTargets(panel), 1)
+}
+
+func TestGeomapPanelWidthCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Span(8))
+
+	req.NoError(err)
+	req.Equal(float32(8), panel.Builder.Span)
+}
+
+func TestGeomapPanelRejectIncorrectWidth(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Span(-8))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestGeomapPanelHeightCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
+
+func TestGeomapPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidGeomapPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestGeomapPanelBackgroundCanBeTransparent(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transparent())
+
+	req.NoError(err)
+	req.True(panel.Builder.Transparent)
+}
+
+func TestGeomapPanelDescriptionCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Description("lala"))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.Description)
+	req.Equal("lala", *panel.Builder.Description)
+}
+
+func TestGeomapPanelDataSourceCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSource("prometheus-default"))
+
+	req.NoError(err)
+	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
+}
+
+func TestGeomapPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
+func TestRepeatCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Repeat("ds"))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.Repeat)
+	req.Equal("ds", *panel.Builder.Repeat)
+}
+
+func TestRepeatDirectionCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", RepeatDirection(sdk.RepeatDirectionHorizontal))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.RepeatDirection)
+	req.Equal(sdk.RepeatDirectionHorizontal, *panel.Builder.RepeatDirection)
+}
+
+func TestBaseLayerCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", BaseLayer(layer.Carto(layer.CartoDark)))
+
+	req.NoError(err)
+	req.Equal(layer.CartoBase, panel.options.Basemap.Type)
+	req.Equal(layer.CartoDark, panel.options.Basemap.Config.Theme)
+}
+
+func TestDataLayersCanBeAdded(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New(
+		"",
+		Markers("Edges", layer.CoordsLocation("lat", "lon")),
+		Heatmap("Density"),
+		Route("Path"),
+	)
+
+	req.NoError(err)
+	req.Len(panel.options.Layers, 3)
+	req.Equal(layer.Markers, panel.options.Layers[0].Type)
+	req.Equal("Edges", panel.options.Layers[0].Name)
+	req.Equal(layer.Heatmap, panel.options.Layers[1].Type)
+	req.Equal(layer.Route, panel.options.Layers[2].Type)
+}
+
+func TestInvalidDataLayersAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Markers("Edges", layer.Radius(3)))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestViewCanBeCentered(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Center(46.5, 6.6), Zoom(4))
+
+	req.NoError(err)
+	req.Equal("coords", panel.options.View.ID)
+	req.Equal(46.5, *panel.options.View.Lat)
+	req.Equal(6.6, *panel.options.View.Lon)
+	req.Equal(float64(4), panel.options.View.Zoom)
+}
+
+func TestInvalidCoordinatesAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Center(91, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Center(0, -181))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestInvalidZoomLevelsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Zoom(0))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestViewCanFitData(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Center(10, 10), FitToData())
+
+	req.NoError(err)
+	req.Equal("fit", panel.options.View.ID)
+	req.Nil(panel.options.View.Lat)
+	req.Nil(panel.options.View.Lon)
+}
+
+func TestControlsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", HideZoomControls(), DisableMouseWheelZoom(), ShowScale())
+
+	req.NoError(err)
+	req.False(panel.options.Controls.ShowZoom)
+	req.False(panel.options.Controls.MouseWheelZoom)
+	req.True(panel.options.Controls.ShowScale)
+}
+
+func panelTargets(panel *Geomap) []sdk.Target {
+	return (*panel.Builder.CustomPanel)["targets"].([]sdk.Target)
+}
+
+func TestGeomapPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("geomap", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
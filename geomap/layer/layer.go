@@ -0,0 +1,419 @@
+package layer
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+)
+
+// Option represents an option that can be used to configure a data layer.
+type Option func(layer *Layer) error
+
+// Type represents the type of a layer.
+type Type string
+
+const (
+	Markers Type = "markers"
+	Heatmap Type = "heatmap"
+	Route   Type = "route"
+
+	DefaultBase       Type = "default"
+	OpenStreetMapBase Type = "osm-standard"
+	CartoBase         Type = "carto"
+	ArcGISBase        Type = "esri-xyz"
+	XYZBase           Type = "xyz"
+)
+
+// LocationMode defines how the location of each data point is resolved.
+type LocationMode string
+
+const (
+	Auto    LocationMode = "auto"
+	Coords  LocationMode = "coords"
+	Geohash LocationMode = "geohash"
+	Lookup  LocationMode = "lookup"
+)
+
+// Gazetteer references a list of known locations, used to resolve lookup
+// fields into coordinates.
+type Gazetteer string
+
+const (
+	Countries    Gazetteer = "public/gazetteer/countries.json"
+	USStates     Gazetteer = "public/gazetteer/usa-states.json"
+	Airports     Gazetteer = "public/gazetteer/airports.geojson"
+	SpatialCodes Gazetteer = "public/gazetteer/countries.geojson"
+)
+
+// CartoTheme represents the theme used by a CARTO base layer.
+type CartoTheme string
+
+const (
+	CartoAuto  CartoTheme = "auto"
+	CartoLight CartoTheme = "light"
+	CartoDark  CartoTheme = "dark"
+)
+
+// ArcGISServer represents the map served by an ArcGIS base layer.
+type ArcGISServer string
+
+const (
+	WorldStreetMap ArcGISServer = "streets"
+	WorldImagery   ArcGISServer = "world-imagery"
+	WorldPhysical  ArcGISServer = "world-physical"
+	Topographic    ArcGISServer = "topo"
+	USATopographic ArcGISServer = "usa-topo"
+	WorldOcean     ArcGISServer = "ocean"
+)
+
+// ArrowMode defines how arrows are drawn along a route.
+type ArrowMode int
+
+const (
+	NoArrows      ArrowMode = 0
+	ForwardArrow  ArrowMode = 1
+	BackwardArrow ArrowMode = -1
+)
+
+// Location describes how data points are placed on the map.
+type Location struct {
+	Mode      LocationMode `json:"mode"`
+	Latitude  string       `json:"latitude,omitempty"`
+	Longitude string       `json:"longitude,omitempty"`
+	Geohash   string       `json:"geohash,omitempty"`
+	Lookup    string       `json:"lookup,omitempty"`
+	Gazetteer Gazetteer    `json:"gazetteer,omitempty"`
+}
+
+// Dimension maps a field of the data to a visual property, or uses a fixed
+// value.
+type Dimension struct {
+	Fixed interface{} `json:"fixed,omitempty"`
+	Field string      `json:"field,omitempty"`
+	Min   *float64    `json:"min,omitempty"`
+	Max   *float64    `json:"max,omitempty"`
+}
+
+// Style describes how markers and routes are drawn.
+type Style struct {
+	Size    *Dimension `json:"size,omitempty"`
+	Color   *Dimension `json:"color,omitempty"`
+	Opacity *float64   `json:"opacity,omitempty"`
+}
+
+// Config holds the configuration of a layer. Only the fields relevant to the
+// layer's type are set.
+type Config struct {
+	Style      *Style `json:"style,omitempty"`
+	ShowLegend *bool  `json:"showLegend,omitempty"`
+
+	// heatmap layers
+	Weight *Dimension `json:"weight,omitempty"`
+	Radius *int       `json:"radius,omitempty"`
+	Blur   *int       `json:"blur,omitempty"`
+
+	// route layers
+	Arrow *ArrowMode `json:"arrow,omitempty"`
+
+	// base layers
+	Theme       CartoTheme   `json:"theme,omitempty"`
+	ShowLabels  *bool        `json:"showLabels,omitempty"`
+	Server      ArcGISServer `json:"server,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Attribution string       `json:"attribution,omitempty"`
+}
+
+// Layer represents a geomap layer.
+type Layer struct {
+	Type     Type      `json:"type"`
+	Name     string    `json:"name,omitempty"`
+	Config   Config    `json:"config"`
+	Location *Location `json:"location,omitempty"`
+	Tooltip  *bool     `json:"tooltip,omitempty"`
+}
+
+// New creates a new data layer of the given type.
+func New(layerType Type, name string, options ...Option) (*Layer, error) {
+	yep := true
+	layer := &Layer{
+		Type:     layerType,
+		Name:     name,
+		Location: &Location{Mode: Auto},
+		Tooltip:  &yep,
+	}
+
+	switch layerType {
+	case Markers, Route:
+		layer.Config.Style = &Style{}
+	case Heatmap:
+	default:
+		return nil, fmt.Errorf("'%s' is not a data layer type: %w", layerType, errors.ErrInvalidArgument)
+	}
+
+	for _, opt := range append(defaults(layerType), options...) {
+		if err := opt(layer); err != nil {
+			return nil, err
+		}
+	}
+
+	return layer, nil
+}
+
+func defaults(layerType Type) []Option {
+	if layerType == Heatmap {
+		return []Option{
+			FixedSize(1),
+			Radius(5),
+			Blur(15),
+		}
+	}
+
+	return []Option{
+		SizeField("", 2, 15),
+		FixedSize(5),
+		FixedColor("dark-green"),
+		Opacity(0.4),
+	}
+}
+
+// Default creates a base layer using the default map configured in Grafana.
+func Default() *Layer {
+	return &Layer{Type: DefaultBase}
+}
+
+// OpenStreetMap creates a base layer using OpenStreetMap tiles.
+func OpenStreetMap() *Layer {
+	return &Layer{Type: OpenStreetMapBase}
+}
+
+// Carto creates a base layer using CARTO tiles.
+func Carto(theme CartoTheme) *Layer {
+	yep := true
+
+	return &Layer{
+		Type:   CartoBase,
+		Config: Config{Theme: theme, ShowLabels: &yep},
+	}
+}
+
+// ArcGIS creates a base layer using ArcGIS tiles.
+func ArcGIS(server ArcGISServer) *Layer {
+	return &Layer{
+		Type:   ArcGISBase,
+		Config: Config{Server: server},
+	}
+}
+
+// XYZ creates a base layer using tiles served by the given XYZ tile server.
+// Example: "https://tile.openstreetmap.org/{z}/{x}/{y}.png".
+func XYZ(url string, attribution string) *Layer {
+	return &Layer{
+		Type:   XYZBase,
+		Config: Config{URL: url, Attribution: attribution},
+	}
+}
+
+// AutoLocation lets Grafana guess the location of each data point based on
+// field names.
+func AutoLocation() Option {
+	return func(layer *Layer) error {
+		layer.Location = &Location{Mode: Auto}
+
+		return nil
+	}
+}
+
+// CoordsLocation reads the location of each data point from a latitude and
+// a longitude field.
+func CoordsLocation(latitudeField string, longitudeField string) Option {
+	return func(layer *Layer) error {
+		layer.Location = &Location{
+			Mode:      Coords,
+			Latitude:  latitudeField,
+			Longitude: longitudeField,
+		}
+
+		return nil
+	}
+}
+
+// GeohashLocation reads the location of each data point from a geohash field.
+func GeohashLocation(field string) Option {
+	return func(layer *Layer) error {
+		layer.Location = &Location{
+			Mode:    Geohash,
+			Geohash: field,
+		}
+
+		return nil
+	}
+}
+
+// LookupLocation resolves the location of each data point by looking up the
+// value of the given field in a gazetteer.
+func LookupLocation(field string, gazetteer Gazetteer) Option {
+	return func(layer *Layer) error {
+		layer.Location = &Location{
+			Mode:      Lookup,
+			Lookup:    field,
+			Gazetteer: gazetteer,
+		}
+
+		return nil
+	}
+}
+
+// FixedSize sets a fixed size for markers and routes, or a fixed weight for
+// heatmaps.
+func FixedSize(size float64) Option {
+	return func(layer *Layer) error {
+		dimension := layer.sizeDimension()
+		dimension.Fixed = size
+
+		return nil
+	}
+}
+
+// SizeField derives the size of markers and routes, or the weight of
+// heatmaps, from the given field. Values are scaled between min and max.
+func SizeField(field string, min float64, max float64) Option {
+	return func(layer *Layer) error {
+		if min > max {
+			return fmt.Errorf("min size must be lower than max size: %w", errors.ErrInvalidArgument)
+		}
+
+		dimension := layer.sizeDimension()
+		dimension.Field = field
+		dimension.Min = &min
+		dimension.Max = &max
+
+		return nil
+	}
+}
+
+// FixedColor sets a fixed color for markers and routes.
+func FixedColor(color string) Option {
+	return func(layer *Layer) error {
+		if layer.Config.Style == nil {
+			return fmt.Errorf("color is not supported by %s layers: %w", layer.Type, errors.ErrInvalidArgument)
+		}
+
+		if layer.Config.Style.Color == nil {
+			layer.Config.Style.Color = &Dimension{}
+		}
+
+		layer.Config.Style.Color.Fixed = color
+
+		return nil
+	}
+}
+
+// ColorField derives the color of markers and routes from the given field,
+// using the panel's color scheme.
+func ColorField(field string) Option {
+	return func(layer *Layer) error {
+		if layer.Config.Style == nil {
+			return fmt.Errorf("color is not supported by %s layers: %w", layer.Type, errors.ErrInvalidArgument)
+		}
+
+		if layer.Config.Style.Color == nil {
+			layer.Config.Style.Color = &Dimension{}
+		}
+
+		layer.Config.Style.Color.Field = field
+
+		return nil
+	}
+}
+
+// Opacity sets the opacity of markers and routes. Should be a number between
+// 0 and 1.
+func Opacity(opacity float64) Option {
+	return func(layer *Layer) error {
+		if layer.Config.Style == nil {
+			return fmt.Errorf("opacity is not supported by %s layers: %w", layer.Type, errors.ErrInvalidArgument)
+		}
+		if opacity < 0 || opacity > 1 {
+			return fmt.Errorf("opacity must be between 0 and 1: %w", errors.ErrInvalidArgument)
+		}
+
+		layer.Config.Style.Opacity = &opacity
+
+		return nil
+	}
+}
+
+// ShowLegend displays a legend for the layer.
+func ShowLegend() Option {
+	return func(layer *Layer) error {
+		yep := true
+		layer.Config.ShowLegend = &yep
+
+		return nil
+	}
+}
+
+// HideTooltip disables the tooltip for the layer's data points.
+func HideTooltip() Option {
+	return func(layer *Layer) error {
+		nope := false
+		layer.Tooltip = &nope
+
+		return nil
+	}
+}
+
+// Radius sets the radius of each data point of a heatmap layer, in pixels.
+func Radius(radius int) Option {
+	return func(layer *Layer) error {
+		if layer.Type != Heatmap {
+			return fmt.Errorf("radius is only supported by heatmap layers: %w", errors.ErrInvalidArgument)
+		}
+
+		layer.Config.Radius = &radius
+
+		return nil
+	}
+}
+
+// Blur sets the blur of each data point of a heatmap layer, in pixels.
+func Blur(blur int) Option {
+	return func(layer *Layer) error {
+		if layer.Type != Heatmap {
+			return fmt.Errorf("blur is only supported by heatmap layers: %w", errors.ErrInvalidArgument)
+		}
+
+		layer.Config.Blur = &blur
+
+		return nil
+	}
+}
+
+// Arrows configures how arrows are drawn along a route layer.
+func Arrows(mode ArrowMode) Option {
+	return func(layer *Layer) error {
+		if layer.Type != Route {
+			return fmt.Errorf("arrows are only supported by route layers: %w", errors.ErrInvalidArgument)
+		}
+
+		layer.Config.Arrow = &mode
+
+		return nil
+	}
+}
+
+func (layer *Layer) sizeDimension() *Dimension {
+	if layer.Type == Heatmap {
+		if layer.Config.Weight == nil {
+			layer.Config.Weight = &Dimension{}
+		}
+
+		return layer.Config.Weight
+	}
+
+	if layer.Config.Style.Size == nil {
+		layer.Config.Style.Size = &Dimension{}
+	}
+
+	return layer.Config.Style.Size
+}
@@ -0,0 +1,215 @@
+package layer
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkersLayersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "Edges")
+
+	req.NoError(err)
+	req.Equal(Markers, layer.Type)
+	req.Equal("Edges", layer.Name)
+	req.Equal(Auto, layer.Location.Mode)
+	req.True(*layer.Tooltip)
+	req.Equal(float64(5), layer.Config.Style.Size.Fixed)
+	req.Equal(float64(2), *layer.Config.Style.Size.Min)
+	req.Equal(float64(15), *layer.Config.Style.Size.Max)
+	req.Equal("dark-green", layer.Config.Style.Color.Fixed)
+	req.Equal(0.4, *layer.Config.Style.Opacity)
+}
+
+func TestHeatmapLayersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Heatmap, "Density", Radius(10), Blur(20))
+
+	req.NoError(err)
+	req.Equal(Heatmap, layer.Type)
+	req.Nil(layer.Config.Style)
+	req.Equal(float64(1), layer.Config.Weight.Fixed)
+	req.Equal(10, *layer.Config.Radius)
+	req.Equal(20, *layer.Config.Blur)
+}
+
+func TestRouteLayersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Route, "Path", Arrows(ForwardArrow))
+
+	req.NoError(err)
+	req.Equal(Route, layer.Type)
+	req.Equal(ForwardArrow, *layer.Config.Arrow)
+}
+
+func TestBaseLayerTypesCanNotBeUsedAsDataLayers(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(CartoBase, "")
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLocationCanBeReadFromCoordinates(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", CoordsLocation("lat", "lon"))
+
+	req.NoError(err)
+	req.Equal(Location{Mode: Coords, Latitude: "lat", Longitude: "lon"}, *layer.Location)
+}
+
+func TestLocationCanBeReadFromGeohash(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", GeohashLocation("hash"))
+
+	req.NoError(err)
+	req.Equal(Location{Mode: Geohash, Geohash: "hash"}, *layer.Location)
+}
+
+func TestLocationCanBeLookedUp(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", LookupLocation("country", Countries))
+
+	req.NoError(err)
+	req.Equal(Location{Mode: Lookup, Lookup: "country", Gazetteer: Countries}, *layer.Location)
+}
+
+func TestLocationCanBeGuessed(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", GeohashLocation("hash"), AutoLocation())
+
+	req.NoError(err)
+	req.Equal(Location{Mode: Auto}, *layer.Location)
+}
+
+func TestSizeCanBeMappedToAField(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", SizeField("latency", 1, 20))
+
+	req.NoError(err)
+	req.Equal("latency", layer.Config.Style.Size.Field)
+	req.Equal(float64(1), *layer.Config.Style.Size.Min)
+	req.Equal(float64(20), *layer.Config.Style.Size.Max)
+}
+
+func TestSizeBoundariesAreValidated(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(Markers, "", SizeField("latency", 20, 1))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestHeatmapWeightCanBeMappedToAField(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Heatmap, "", SizeField("requests", 0, 1))
+
+	req.NoError(err)
+	req.Equal("requests", layer.Config.Weight.Field)
+}
+
+func TestColorCanBeMappedToAField(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", ColorField("latency"))
+
+	req.NoError(err)
+	req.Equal("latency", layer.Config.Style.Color.Field)
+}
+
+func TestFixedColorCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Route, "", FixedColor("red"))
+
+	req.NoError(err)
+	req.Equal("red", layer.Config.Style.Color.Fixed)
+}
+
+func TestColorIsNotSupportedByHeatmaps(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(Heatmap, "", FixedColor("red"))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestOpacityIsValidated(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(Markers, "", Opacity(1.2))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLegendCanBeShown(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", ShowLegend())
+
+	req.NoError(err)
+	req.True(*layer.Config.ShowLegend)
+}
+
+func TestTooltipCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	layer, err := New(Markers, "", HideTooltip())
+
+	req.NoError(err)
+	req.False(*layer.Tooltip)
+}
+
+func TestHeatmapSpecificOptionsAreRejectedOnOtherLayers(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(Route, "", Blur(3))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestArrowsAreRejectedOnNonRouteLayers(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New(Markers, "", Arrows(BackwardArrow))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestBaseLayersCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal(DefaultBase, Default().Type)
+	req.Equal(OpenStreetMapBase, OpenStreetMap().Type)
+
+	carto := Carto(CartoLight)
+	req.Equal(CartoBase, carto.Type)
+	req.Equal(CartoLight, carto.Config.Theme)
+	req.True(*carto.Config.ShowLabels)
+
+	arcgis := ArcGIS(WorldImagery)
+	req.Equal(ArcGISBase, arcgis.Type)
+	req.Equal(WorldImagery, arcgis.Config.Server)
+
+	xyz := XYZ("https://tile.example.org/{z}/{x}/{y}.png", "Example")
+	req.Equal(XYZBase, xyz.Type)
+	req.Equal("https://tile.example.org/{z}/{x}/{y}.png", xyz.Config.URL)
+	req.Equal("Example", xyz.Config.Attribution)
+}
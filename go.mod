@@ -6,6 +6,7 @@ require (
 	github.com/K-Phoen/jennifer v0.0.0-20230811102814-e6c78cf40086
 	github.com/K-Phoen/sdk v0.12.4
 	github.com/blang/semver v3.5.1+incompatible
+	github.com/invopop/jsonschema v0.12.0
 	github.com/prometheus/common v0.45.0
 	github.com/rhysd/go-github-selfupdate v1.2.3
 	github.com/spf13/cobra v1.8.0
@@ -26,7 +27,6 @@ require (
 	github.com/gosimple/unidecode v1.0.1 // indirect
 	github.com/inconshreveable/go-update v0.0.0-20160112193335-8152e7eb6ccf // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/invopop/jsonschema v0.12.0 // indirect
 	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
@@ -1,13 +1,16 @@
 package graph
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/K-Phoen/grabana/axis"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/graph/series"
 	"github.com/K-Phoen/grabana/links"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -61,6 +64,33 @@ func TestGraphPanelCanHaveInfluxDBTargets(t *testing.T) {
 	req.Len(panel.Builder.GraphPanel.Targets, 1)
 }
 
+func TestGraphPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.GraphPanel.Targets, 1)
+}
+
+func TestGraphPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.GraphPanel.Targets, 1)
+}
+
+func TestGraphPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.GraphPanel.Targets, 1)
+}
+
 func TestGraphPanelCanHaveStackdriverTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -97,6 +127,28 @@ func TestGraphPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestGraphPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidGraphPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestGraphPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -125,6 +177,16 @@ func TestGraphPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestGraphPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestLeftYAxisCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -377,3 +439,29 @@ func TestInvalidSeriesOverridesAreRejected(t *testing.T) {
 	req.Error(err)
 	req.ErrorIs(err, errors.ErrInvalidArgument)
 }
+
+func TestGraphPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("graph", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
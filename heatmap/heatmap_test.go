@@ -1,12 +1,15 @@
 package heatmap
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/heatmap/axis"
 	"github.com/K-Phoen/grabana/links"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +63,33 @@ func TestHeatmapPanelCanHaveInfluxDBTargets(t *testing.T) {
 	req.Len(panel.Builder.HeatmapPanel.Targets, 1)
 }
 
+func TestHeatmapPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.HeatmapPanel.Targets, 1)
+}
+
+func TestHeatmapPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.HeatmapPanel.Targets, 1)
+}
+
+func TestHeatmapPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.HeatmapPanel.Targets, 1)
+}
+
 func TestHeatmapPanelCanHaveStackdriverTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -96,6 +126,28 @@ func TestHeatmapPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestHeatmapPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidHeatmapPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestHeatmapPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -124,6 +176,16 @@ func TestHeatmapPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestHeatmapPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestDataFormatCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -251,3 +313,29 @@ func TestYAxisCanBeSet(t *testing.T) {
 	req.NoError(err)
 	req.Equal("none", panel.Builder.HeatmapPanel.YAxis.Format)
 }
+
+func TestHeatmapPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("heatmap", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
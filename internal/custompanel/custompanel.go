@@ -0,0 +1,123 @@
+// Package custompanel allows panels to carry settings that the sdk doesn't
+// model, like transformations.
+//
+// These settings are stored in the panel's (otherwise unused) CustomPanel map
+// and merged into the panel's JSON representation by Flatten().
+package custompanel
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/sdk"
+)
+
+// Set defines a setting that will be added to the JSON representation of the
+// given panel.
+func Set(panel *sdk.Panel, key string, value interface{}) {
+	if panel.CustomPanel == nil {
+		panel.CustomPanel = &sdk.CustomPanel{}
+	}
+
+	(*panel.CustomPanel)[key] = value
+}
+
+// SetIn defines a setting nested in objects, creating them when needed.
+// Example: SetIn(panel, []string{"fieldConfig", "defaults", "mappings"}, mappings).
+func SetIn(panel *sdk.Panel, path []string, value interface{}) {
+	if panel.CustomPanel == nil {
+		panel.CustomPanel = &sdk.CustomPanel{}
+	}
+
+	current := map[string]interface{}(*panel.CustomPanel)
+	for _, key := range path[:len(path)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+
+		current = next
+	}
+
+	current[path[len(path)-1]] = value
+}
+
+// Get returns a setting previously defined with Set().
+func Get(panel *sdk.Panel, key string) (interface{}, bool) {
+	if panel.CustomPanel == nil {
+		return nil, false
+	}
+
+	value, ok := (*panel.CustomPanel)[key]
+
+	return value, ok
+}
+
+// Flatten turns a typed panel carrying additional settings into a custom
+// panel, so that these settings are included in its JSON representation.
+// Settings holding objects are deep-merged with the ones already defined by
+// the typed panel.
+// The typed part of the panel is left untouched but won't be used anymore
+// when marshalling the panel.
+func Flatten(panel *sdk.Panel) error {
+	if panel.OfType == sdk.CustomType || panel.CustomPanel == nil || len(*panel.CustomPanel) == 0 {
+		return nil
+	}
+
+	extras, err := toMap(*panel.CustomPanel)
+	if err != nil {
+		return err
+	}
+
+	panel.CustomPanel = nil
+
+	full, err := toMap(panel)
+	if err != nil {
+		return err
+	}
+
+	common, err := toMap(panel.CommonPanel)
+	if err != nil {
+		return err
+	}
+
+	for key := range common {
+		delete(full, key)
+	}
+
+	merge(full, extras)
+
+	custom := sdk.CustomPanel(full)
+	panel.CustomPanel = &custom
+	panel.OfType = sdk.CustomType
+
+	return nil
+}
+
+func merge(target map[string]interface{}, source map[string]interface{}) {
+	for key, value := range source {
+		sourceMap, sourceIsMap := value.(map[string]interface{})
+		targetMap, targetIsMap := target[key].(map[string]interface{})
+
+		if sourceIsMap && targetIsMap {
+			merge(targetMap, sourceMap)
+			continue
+		}
+
+		target[key] = value
+	}
+}
+
+func toMap(input interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &output); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
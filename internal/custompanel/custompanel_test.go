@@ -0,0 +1,79 @@
+package custompanel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenIsANoopWithoutCustomSettings(t *testing.T) {
+	req := require.New(t)
+
+	panel := sdk.NewTimeseries("")
+
+	req.NoError(Flatten(panel))
+
+	req.Equal(sdk.TimeseriesType, panel.OfType)
+}
+
+func TestFlattenMergesCustomSettings(t *testing.T) {
+	req := require.New(t)
+
+	panel := sdk.NewTimeseries("Requests")
+	panel.TimeseriesPanel.FieldConfig.Defaults.Unit = "short"
+
+	Set(panel, "transformations", []string{"merge"})
+	Set(panel, "fieldConfig", map[string]interface{}{
+		"defaults": map[string]interface{}{"mappings": []string{}},
+	})
+
+	req.NoError(Flatten(panel))
+
+	req.Equal(sdk.CustomType, panel.OfType)
+
+	payload, err := json.Marshal(panel)
+	req.NoError(err)
+
+	decoded := map[string]interface{}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("timeseries", decoded["type"])
+	req.Equal("Requests", decoded["title"])
+	req.Equal([]interface{}{"merge"}, decoded["transformations"])
+
+	defaults := decoded["fieldConfig"].(map[string]interface{})["defaults"].(map[string]interface{})
+	req.Equal("short", defaults["unit"])
+	req.Equal([]interface{}{}, defaults["mappings"])
+}
+
+func TestSetOnCustomPanelsDefinesTheSettingDirectly(t *testing.T) {
+	req := require.New(t)
+
+	panel := sdk.NewCustom("")
+
+	Set(panel, "transformations", []string{"merge"})
+
+	value, ok := Get(panel, "transformations")
+	req.True(ok)
+	req.Equal([]string{"merge"}, value)
+}
+
+func TestSetInCreatesNestedSettings(t *testing.T) {
+	req := require.New(t)
+
+	panel := sdk.NewStat("")
+
+	SetIn(panel, []string{"fieldConfig", "defaults", "mappings"}, []string{"mapping"})
+	SetIn(panel, []string{"fieldConfig", "defaults", "links"}, []string{"link"})
+
+	value, ok := Get(panel, "fieldConfig")
+	req.True(ok)
+	req.Equal(map[string]interface{}{
+		"defaults": map[string]interface{}{
+			"mappings": []string{"mapping"},
+			"links":    []string{"link"},
+		},
+	}, value)
+}
@@ -0,0 +1,63 @@
+package layout
+
+// grid keeps track of the cells occupied by panels within a row.
+type grid struct {
+	lines [][GridWidth]bool
+
+	// position after the last packed panel: packing resumes from there.
+	cursorX int
+	cursorY int
+}
+
+func newGrid() *grid {
+	return &grid{}
+}
+
+func (g *grid) free(x int, y int, width int, height int) bool {
+	for line := y; line < y+height && line < len(g.lines); line++ {
+		for column := x; column < x+width; column++ {
+			if g.lines[line][column] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (g *grid) occupy(x int, y int, width int, height int) {
+	for len(g.lines) < y+height {
+		g.lines = append(g.lines, [GridWidth]bool{})
+	}
+
+	for line := y; line < y+height; line++ {
+		for column := x; column < x+width; column++ {
+			g.lines[line][column] = true
+		}
+	}
+}
+
+// pack finds the first free spot able to hold a panel of the given
+// dimensions, looking from left to right and top to bottom from where the
+// previous panel was placed.
+func (g *grid) pack(width int, height int) (int, int) {
+	x, y := g.cursorX, g.cursorY
+
+	for !g.fits(x, y, width, height) {
+		x++
+
+		if x+width > GridWidth {
+			x = 0
+			y++
+		}
+	}
+
+	g.occupy(x, y, width, height)
+	g.cursorX, g.cursorY = x+width, y
+
+	return x, y
+}
+
+func (g *grid) fits(x int, y int, width int, height int) bool {
+	return x+width <= GridWidth && g.free(x, y, width, height)
+}
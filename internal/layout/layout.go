@@ -0,0 +1,280 @@
+// Package layout places panels on the grid used by Grafana since its 5.0
+// release.
+//
+// The grid is 24 units wide. Rows are represented by "row" panels, spanning
+// the whole width of the grid and followed by their panels. Collapsed rows
+// hold their panels instead.
+package layout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// GridWidth is the number of columns of the grid.
+const GridWidth = 24
+
+const (
+	// cellHeight and cellMargin are the dimensions (in pixels) used by Grafana
+	// to convert legacy heights into grid units.
+	cellHeight = 30
+	cellMargin = 8
+
+	// minPanelHeight is the smallest height a panel can have, in pixels.
+	minPanelHeight = 3 * cellHeight
+
+	// defaultSpan is used for panels defining neither a span nor a width.
+	defaultSpan = 4
+
+	// defaultRowHeight is used for rows without a height, in pixels.
+	defaultRowHeight = 250
+)
+
+// At places the given panel at an explicit position, relative to the
+// top-left corner of its row.
+func At(panel *sdk.Panel, x int, y int) error {
+	if x < 0 || x >= GridWidth {
+		return fmt.Errorf("x must be between 0 and %d: %w", GridWidth-1, errors.ErrInvalidArgument)
+	}
+	if y < 0 {
+		return fmt.Errorf("y must be positive: %w", errors.ErrInvalidArgument)
+	}
+
+	panel.GridPos.X = &x
+	panel.GridPos.Y = &y
+
+	return nil
+}
+
+// Size sets the dimensions of the given panel, in grid units.
+func Size(panel *sdk.Panel, width int, height int) error {
+	if width < 1 || width > GridWidth {
+		return fmt.Errorf("width must be between 1 and %d: %w", GridWidth, errors.ErrInvalidArgument)
+	}
+	if height < 1 {
+		return fmt.Errorf("height must be positive: %w", errors.ErrInvalidArgument)
+	}
+
+	panel.GridPos.W = &width
+	panel.GridPos.H = &height
+
+	return nil
+}
+
+// AddRow lays out the given row and its panels below the panels already
+// present in the board.
+//
+// Panels explicitly positioned are placed first, relatively to the row. The
+// other ones are packed from left to right, in the order they were defined,
+// wrapping when the width of the grid is exceeded.
+// Every panel is also given an ID, unique within the board.
+func AddRow(board *sdk.Board, row *sdk.Row) error {
+	originY := bottom(board.Panels)
+	nextID := maxID(board.Panels) + 1
+
+	var rowPanel *sdk.Panel
+	if row.ShowTitle || row.Collapse || row.Repeat != nil {
+		rowPanel = newRowPanel(row, originY)
+		rowPanel.ID = nextID
+
+		nextID++
+		originY++
+	}
+
+	panels, err := place(row, originY)
+	if err != nil {
+		return err
+	}
+
+	for i := range panels {
+		panels[i].ID = nextID
+		nextID++
+	}
+
+	if rowPanel != nil && row.Collapse {
+		rowPanel.RowPanel.Panels = panels
+		board.Panels = append(board.Panels, rowPanel)
+
+		return nil
+	}
+
+	if rowPanel != nil {
+		board.Panels = append(board.Panels, rowPanel)
+	}
+
+	for i := range panels {
+		board.Panels = append(board.Panels, &panels[i])
+	}
+
+	return nil
+}
+
+func newRowPanel(row *sdk.Row, y int) *sdk.Panel {
+	x, w, h := 0, GridWidth, 1
+
+	panel := &sdk.Panel{
+		CommonPanel: sdk.CommonPanel{
+			OfType: sdk.RowType,
+			Type:   "row",
+			Title:  row.Title,
+			Repeat: row.Repeat,
+		},
+		RowPanel: &sdk.RowPanel{
+			Panels:    []sdk.Panel{},
+			Collapsed: row.Collapse,
+		},
+	}
+
+	panel.GridPos.X = &x
+	panel.GridPos.Y = &y
+	panel.GridPos.W = &w
+	panel.GridPos.H = &h
+
+	return panel
+}
+
+func place(row *sdk.Row, originY int) ([]sdk.Panel, error) {
+	rowHeight := gridHeight(string(row.Height))
+	panels := make([]sdk.Panel, len(row.Panels))
+	grid := newGrid()
+
+	// explicitly positioned panels first, so that the other ones can be
+	// packed around them.
+	for i, panel := range row.Panels {
+		panels[i] = panel
+		width, height := dimensions(panel, rowHeight)
+
+		if panel.GridPos.X == nil || panel.GridPos.Y == nil {
+			continue
+		}
+
+		x, y := *panel.GridPos.X, *panel.GridPos.Y
+		if x+width > GridWidth {
+			return nil, fmt.Errorf("panel '%s' does not fit in the grid: %w", panel.Title, errors.ErrInvalidArgument)
+		}
+		if !grid.free(x, y, width, height) {
+			return nil, fmt.Errorf("panel '%s' overlaps with another panel: %w", panel.Title, errors.ErrInvalidArgument)
+		}
+
+		grid.occupy(x, y, width, height)
+		setGridPos(&panels[i], x, originY+y, width, height)
+	}
+
+	for i, panel := range row.Panels {
+		if panel.GridPos.X != nil && panel.GridPos.Y != nil {
+			continue
+		}
+
+		width, height := dimensions(panel, rowHeight)
+		x, y := grid.pack(width, height)
+
+		setGridPos(&panels[i], x, originY+y, width, height)
+	}
+
+	return panels, nil
+}
+
+func setGridPos(panel *sdk.Panel, x int, y int, width int, height int) {
+	panel.GridPos.X = &x
+	panel.GridPos.Y = &y
+	panel.GridPos.W = &width
+	panel.GridPos.H = &height
+
+	// legacy sizing properties are superseded by the position in the grid
+	panel.Span = 0
+	panel.Height = nil
+}
+
+func dimensions(panel sdk.Panel, rowHeight int) (int, int) {
+	width := defaultSpan * 2
+	if panel.GridPos.W != nil {
+		width = *panel.GridPos.W
+	} else if panel.Span != 0 {
+		width = int(math.Floor(float64(panel.Span))) * GridWidth / 12
+	}
+
+	height := rowHeight
+	if panel.GridPos.H != nil {
+		height = *panel.GridPos.H
+	} else if legacyHeight := panelHeight(panel); legacyHeight != "" {
+		height = gridHeight(legacyHeight)
+	}
+
+	return width, height
+}
+
+func panelHeight(panel sdk.Panel) string {
+	switch height := panel.Height.(type) {
+	case string:
+		return height
+	case *string:
+		if height != nil {
+			return *height
+		}
+	}
+
+	return ""
+}
+
+// gridHeight converts a legacy height, in pixels, into grid units.
+// Example: "400px"
+func gridHeight(height string) int {
+	pixels, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(height), "px"))
+	if err != nil {
+		pixels = defaultRowHeight
+	}
+	if pixels < minPanelHeight {
+		pixels = minPanelHeight
+	}
+
+	return int(math.Ceil(float64(pixels) / float64(cellHeight+cellMargin)))
+}
+
+// bottom returns the first line of the grid free of any panel.
+func bottom(panels []*sdk.Panel) int {
+	lowest := 0
+
+	for _, panel := range panels {
+		if panel.GridPos.Y == nil {
+			continue
+		}
+
+		height := 1
+		if panel.GridPos.H != nil && panel.OfType != sdk.RowType {
+			height = *panel.GridPos.H
+		}
+
+		if *panel.GridPos.Y+height > lowest {
+			lowest = *panel.GridPos.Y + height
+		}
+	}
+
+	return lowest
+}
+
+func maxID(panels []*sdk.Panel) uint {
+	var highest uint
+
+	for _, panel := range panels {
+		if panel.ID > highest {
+			highest = panel.ID
+		}
+
+		if panel.RowPanel == nil {
+			continue
+		}
+
+		for _, child := range panel.RowPanel.Panels {
+			if child.ID > highest {
+				highest = child.ID
+			}
+		}
+	}
+
+	return highest
+}
@@ -0,0 +1,200 @@
+package layout
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanelsArePackedFromLeftToRight(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	err := AddRow(board, testRow("Row", panelWithSpan("a", 6), panelWithSpan("b", 6), panelWithSpan("c", 4)))
+
+	req.NoError(err)
+	req.Len(board.Panels, 4)
+
+	req.Equal("row", board.Panels[0].Type)
+	requireGridPos(t, board.Panels[0], 0, 0, 24, 1)
+	requireGridPos(t, board.Panels[1], 0, 1, 12, 7)
+	requireGridPos(t, board.Panels[2], 12, 1, 12, 7)
+	requireGridPos(t, board.Panels[3], 0, 8, 8, 7)
+}
+
+func TestPanelsArePackedBelowShorterOnes(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	err := AddRow(board, testRow("Row",
+		panelWithSize("tall", 12, 8),
+		panelWithSize("short", 12, 4),
+		panelWithSize("other", 12, 4),
+	))
+
+	req.NoError(err)
+	requireGridPos(t, board.Panels[1], 0, 1, 12, 8)
+	requireGridPos(t, board.Panels[2], 12, 1, 12, 4)
+	requireGridPos(t, board.Panels[3], 12, 5, 12, 4)
+}
+
+func TestLegacyHeightsAreConvertedToGridUnits(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+	height := "400px"
+
+	panel := panelWithSpan("a", 12)
+	panel.Height = &height
+
+	req.NoError(AddRow(board, testRow("Row", panel)))
+
+	requireGridPos(t, board.Panels[1], 0, 1, 24, 11)
+	req.Zero(board.Panels[1].Span)
+	req.Nil(board.Panels[1].Height)
+}
+
+func TestExplicitlyPlacedPanelsArePositionedRelativelyToTheirRow(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	placed := panelWithSize("placed", 12, 4)
+	req.NoError(At(&placed, 0, 0))
+
+	req.NoError(AddRow(board, testRow("First", panelWithSize("a", 24, 3))))
+	req.NoError(AddRow(board, testRow("Second", panelWithSize("auto", 12, 4), placed)))
+
+	req.Len(board.Panels, 5)
+	requireGridPos(t, board.Panels[2], 0, 4, 24, 1)
+	// the automatically placed panel goes around the explicitly placed one
+	requireGridPos(t, board.Panels[3], 12, 5, 12, 4)
+	requireGridPos(t, board.Panels[4], 0, 5, 12, 4)
+}
+
+func TestOverlappingPanelsAreRejected(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	first := panelWithSize("first", 12, 4)
+	second := panelWithSize("second", 12, 4)
+	req.NoError(At(&first, 0, 0))
+	req.NoError(At(&second, 6, 2))
+
+	err := AddRow(board, testRow("Row", first, second))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestPanelsOverflowingTheGridAreRejected(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel := panelWithSize("wide", 12, 4)
+	req.NoError(At(&panel, 18, 0))
+
+	err := AddRow(board, testRow("Row", panel))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestCollapsedRowsHoldTheirPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	collapsed := testRow("Collapsed", panelWithSize("a", 12, 4), panelWithSize("b", 12, 4))
+	collapsed.Collapse = true
+
+	req.NoError(AddRow(board, collapsed))
+	req.NoError(AddRow(board, testRow("Next", panelWithSize("c", 12, 4))))
+
+	req.Len(board.Panels, 3)
+	req.True(board.Panels[0].RowPanel.Collapsed)
+	req.Len(board.Panels[0].RowPanel.Panels, 2)
+	requireGridPos(t, &board.Panels[0].RowPanel.Panels[0], 0, 1, 12, 4)
+
+	// the next row starts right after the collapsed one
+	requireGridPos(t, board.Panels[1], 0, 1, 24, 1)
+	requireGridPos(t, board.Panels[2], 0, 2, 12, 4)
+}
+
+func TestRowsWithoutTitleAreNotRepresented(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	row := testRow("", panelWithSize("a", 12, 4))
+	row.ShowTitle = false
+
+	req.NoError(AddRow(board, row))
+
+	req.Len(board.Panels, 1)
+	requireGridPos(t, board.Panels[0], 0, 0, 12, 4)
+}
+
+func TestPanelsAreGivenUniqueIDs(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	collapsed := testRow("Collapsed", panelWithSize("a", 12, 4))
+	collapsed.Collapse = true
+
+	req.NoError(AddRow(board, collapsed))
+	req.NoError(AddRow(board, testRow("Next", panelWithSize("b", 12, 4))))
+
+	req.Equal(uint(1), board.Panels[0].ID)
+	req.Equal(uint(2), board.Panels[0].RowPanel.Panels[0].ID)
+	req.Equal(uint(3), board.Panels[1].ID)
+	req.Equal(uint(4), board.Panels[2].ID)
+}
+
+func TestInvalidPositionsAreRejected(t *testing.T) {
+	req := require.New(t)
+	panel := sdk.NewText("")
+
+	req.ErrorIs(At(panel, -1, 0), errors.ErrInvalidArgument)
+	req.ErrorIs(At(panel, 24, 0), errors.ErrInvalidArgument)
+	req.ErrorIs(At(panel, 0, -1), errors.ErrInvalidArgument)
+}
+
+func TestInvalidSizesAreRejected(t *testing.T) {
+	req := require.New(t)
+	panel := sdk.NewText("")
+
+	req.ErrorIs(Size(panel, 0, 4), errors.ErrInvalidArgument)
+	req.ErrorIs(Size(panel, 25, 4), errors.ErrInvalidArgument)
+	req.ErrorIs(Size(panel, 12, 0), errors.ErrInvalidArgument)
+}
+
+func testRow(title string, panels ...sdk.Panel) *sdk.Row {
+	return &sdk.Row{
+		Title:     title,
+		ShowTitle: true,
+		Height:    "250px",
+		Panels:    panels,
+	}
+}
+
+func panelWithSpan(title string, span float32) sdk.Panel {
+	panel := sdk.NewText(title)
+	panel.Span = span
+
+	return *panel
+}
+
+func panelWithSize(title string, width int, height int) sdk.Panel {
+	panel := sdk.NewText(title)
+	_ = Size(panel, width, height)
+
+	return *panel
+}
+
+func requireGridPos(t *testing.T, panel *sdk.Panel, x int, y int, width int, height int) {
+	t.Helper()
+
+	req := require.New(t)
+
+	req.Equal(x, *panel.GridPos.X, "x")
+	req.Equal(y, *panel.GridPos.Y, "y")
+	req.Equal(width, *panel.GridPos.W, "width")
+	req.Equal(height, *panel.GridPos.H, "height")
+}
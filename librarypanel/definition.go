@@ -0,0 +1,43 @@
+package librarypanel
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/sdk"
+)
+
+// Definition represents a library panel, as stored in Grafana.
+type Definition struct {
+	UID   string
+	Name  string
+	Model *sdk.Panel
+}
+
+// Define creates the definition of a library panel, based on a panel built
+// by any of the panel packages. The library panel is named after the title of
+// the panel.
+// Example: librarypanel.Define("requests-by-status", requestsPanel.Builder)
+func Define(uid string, panel *sdk.Panel) (*Definition, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("library panel UID can not be empty: %w", errors.ErrInvalidArgument)
+	}
+	if panel.Title == "" {
+		return nil, fmt.Errorf("library panel '%s' must have a title: %w", uid, errors.ErrInvalidArgument)
+	}
+
+	model := *panel
+
+	// the position of the panel is defined by the dashboards referencing it
+	model.ID = 0
+	model.GridPos.X = nil
+	model.GridPos.Y = nil
+	model.GridPos.W = nil
+	model.GridPos.H = nil
+
+	return &Definition{
+		UID:   uid,
+		Name:  panel.Title,
+		Model: &model,
+	}, nil
+}
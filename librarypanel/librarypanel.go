@@ -0,0 +1,104 @@
+// Package librarypanel allows panels to be defined once and shared between
+// dashboards.
+//
+// Library panels are stored in Grafana, independently of any dashboard.
+// Dashboards only hold references to them: updating a library panel updates
+// every dashboard using it.
+package librarypanel
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a reference to
+// a library panel.
+type Option func(reference *LibraryPanel) error
+
+// LibraryPanel represents a reference to a library panel, as included in a
+// dashboard.
+type LibraryPanel struct {
+	Builder *sdk.Panel
+}
+
+// New creates a new reference to the library panel identified by the given
+// UID.
+func New(uid string, options ...Option) (*LibraryPanel, error) {
+	if uid == "" {
+		return nil, fmt.Errorf("library panel UID can not be empty: %w", errors.ErrInvalidArgument)
+	}
+
+	panel := &LibraryPanel{Builder: sdk.NewCustom(uid)}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Renderer = nil
+	panel.Builder.Type = ""
+	panel.Builder.Span = 6
+
+	reference := map[string]interface{}{
+		"uid":  uid,
+		"name": uid,
+	}
+	(*panel.Builder.CustomPanel)["libraryPanel"] = reference
+
+	for _, opt := range options {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+// Name sets the name of the referenced library panel. It is displayed while
+// the library panel itself is being loaded.
+func Name(name string) Option {
+	return func(reference *LibraryPanel) error {
+		reference.Builder.Title = name
+		(*reference.Builder.CustomPanel)["libraryPanel"].(map[string]interface{})["name"] = name
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(reference *LibraryPanel) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		reference.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(reference *LibraryPanel) error {
+		reference.Builder.Height = &height
+
+		return nil
+	}
+}
+
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(reference *LibraryPanel) error {
+		return layout.At(reference.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(reference *LibraryPanel) error {
+		return layout.Size(reference.Builder, width, height)
+	}
+}
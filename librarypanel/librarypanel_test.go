@@ -0,0 +1,132 @@
+package librarypanel
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/text"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLibraryPanelReferencesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("requests-by-status")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("requests-by-status", panel.Builder.Title)
+	req.Equal(float32(6), panel.Builder.Span)
+	req.Equal(map[string]interface{}{
+		"uid":  "requests-by-status",
+		"name": "requests-by-status",
+	}, (*panel.Builder.CustomPanel)["libraryPanel"])
+}
+
+func TestLibraryPanelReferencesRequireAnUID(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("")
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLibraryPanelReferencesCanBeNamed(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("requests-by-status", Name("Requests by status"))
+
+	req.NoError(err)
+	req.Equal("Requests by status", panel.Builder.Title)
+	req.Equal("Requests by status", (*panel.Builder.CustomPanel)["libraryPanel"].(map[string]interface{})["name"])
+}
+
+func TestLibraryPanelReferencesAreMarshalledWithTheirUID(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("requests-by-status", Name("Requests"))
+	req.NoError(err)
+
+	content, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	raw := map[string]interface{}{}
+	req.NoError(json.Unmarshal(content, &raw))
+
+	req.Equal(map[string]interface{}{
+		"uid":  "requests-by-status",
+		"name": "Requests",
+	}, raw["libraryPanel"])
+}
+
+func TestLibraryPanelReferencesCanHaveASpan(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("uid", Span(12))
+
+	req.NoError(err)
+	req.Equal(float32(12), panel.Builder.Span)
+}
+
+func TestInvalidSpanIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("uid", Span(32))
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLibraryPanelReferencesCanHaveAHeight(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("uid", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
+
+func TestLibraryPanelReferencesCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("uid", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestLibraryPanelsCanBeDefinedFromAnyPanel(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := text.New("Notes", text.Markdown("# Notes"), text.Size(12, 4))
+	req.NoError(err)
+	panel.Builder.ID = 3
+
+	definition, err := Define("notes", panel.Builder)
+
+	req.NoError(err)
+	req.Equal("notes", definition.UID)
+	req.Equal("Notes", definition.Name)
+	req.Equal("# Notes", definition.Model.TextPanel.Content)
+	req.Zero(definition.Model.ID)
+	req.Nil(definition.Model.GridPos.W)
+	// the original panel is left untouched
+	req.Equal(12, *panel.Builder.GridPos.W)
+}
+
+func TestLibraryPanelDefinitionsRequireAnUIDAndATitle(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := text.New("")
+	req.NoError(err)
+
+	_, err = Define("", panel.Builder)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	panel.Builder.Title = ""
+	_, err = Define("uid", panel.Builder)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
@@ -0,0 +1,148 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/K-Phoen/grabana/librarypanel"
+)
+
+// ErrLibraryPanelNotFound is returned when the given library panel can not be found.
+var ErrLibraryPanelNotFound = errors.New("library panel not found")
+
+// libraryPanelKind is the kind used by Grafana to identify library panels
+// among library elements.
+const libraryPanelKind = 1
+
+// LibraryPanel represents a Grafana library panel.
+// See https://grafana.com/docs/grafana/latest/dashboards/build-dashboards/manage-library-panels/
+type LibraryPanel struct {
+	ID        uint   `json:"id"`
+	UID       string `json:"uid"`
+	Name      string `json:"name"`
+	FolderID  uint   `json:"folderId"`
+	FolderUID string `json:"folderUid"`
+	Version   int    `json:"version"`
+}
+
+type libraryPanelRequest struct {
+	UID       string          `json:"uid"`
+	Name      string          `json:"name"`
+	FolderID  uint            `json:"folderId"`
+	FolderUID string          `json:"folderUid,omitempty"`
+	Kind      int             `json:"kind"`
+	Model     json.RawMessage `json:"model"`
+	Version   int             `json:"version,omitempty"`
+}
+
+// GetLibraryPanelByUID finds a library panel, given its UID.
+func (client *Client) GetLibraryPanelByUID(ctx context.Context, uid string) (*LibraryPanel, error) {
+	resp, err := client.get(ctx, "/api/library-elements/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrLibraryPanelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		Result LibraryPanel `json:"result"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Result, nil
+}
+
+// UpsertLibraryPanel creates or replaces a library panel, in the given folder.
+func (client *Client) UpsertLibraryPanel(ctx context.Context, folder *Folder, panel *librarypanel.Definition) (*LibraryPanel, error) {
+	model, err := json.Marshal(panel.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.upsertLibraryPanel(ctx, folder, panel, model)
+}
+
+// upsertLibraryPanel creates or updates a library panel, described by the
+// given JSON model.
+func (client *Client) upsertLibraryPanel(ctx context.Context, folder *Folder, panel *librarypanel.Definition, model json.RawMessage) (*LibraryPanel, error) {
+	existing, err := client.GetLibraryPanelByUID(ctx, panel.UID)
+	if err != nil && !errors.Is(err, ErrLibraryPanelNotFound) {
+		return nil, fmt.Errorf("could not find library panel '%s': %w", panel.UID, err)
+	}
+
+	request := libraryPanelRequest{
+		UID:       panel.UID,
+		Name:      panel.Name,
+		FolderID:  folder.ID,
+		FolderUID: folder.UID,
+		Kind:      libraryPanelKind,
+		Model:     model,
+	}
+
+	method := http.MethodPost
+	path := "/api/library-elements"
+	if existing != nil {
+		// updates are only accepted for the latest version of the panel
+		method = http.MethodPatch
+		path += "/" + url.PathEscape(panel.UID)
+		request.Version = existing.Version
+	}
+
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, method, path, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		Result LibraryPanel `json:"result"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Result, nil
+}
+
+// DeleteLibraryPanel deletes a library panel given its UID.
+// Library panels still used by dashboards can not be deleted.
+func (client *Client) DeleteLibraryPanel(ctx context.Context, uid string) error {
+	resp, err := client.delete(ctx, "/api/library-elements/"+url.PathEscape(uid))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrLibraryPanelNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
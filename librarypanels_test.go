@@ -0,0 +1,195 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/librarypanel"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/text"
+	"github.com/stretchr/testify/require"
+)
+
+func testLibraryPanel(t *testing.T) *librarypanel.Definition {
+	t.Helper()
+
+	panel, err := text.New("Requests by status", text.Markdown("Requests"))
+	require.NoError(t, err)
+
+	definition, err := librarypanel.Define("requests-by-status", panel.Builder)
+	require.NoError(t, err)
+
+	return definition
+}
+
+func TestALibraryPanelCanBeFoundByUID(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/library-elements/requests-by-status", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"result": {"id": 3, "uid": "requests-by-status", "name": "Requests by status", "version": 2}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	panel, err := client.GetLibraryPanelByUID(context.TODO(), "requests-by-status")
+
+	req.NoError(err)
+	req.Equal(uint(3), panel.ID)
+	req.Equal("Requests by status", panel.Name)
+	req.Equal(2, panel.Version)
+}
+
+func TestFetchingAnUnknownLibraryPanelFailsCleanly(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetLibraryPanelByUID(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrLibraryPanelNotFound)
+}
+
+func TestLibraryPanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+	var payload map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/library-elements", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"result": {"id": 3, "uid": "requests-by-status", "name": "Requests by status", "version": 1}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	panel, err := client.UpsertLibraryPanel(context.TODO(), &Folder{ID: 2, UID: "folder-uid"}, testLibraryPanel(t))
+
+	req.NoError(err)
+	req.Equal(uint(3), panel.ID)
+	req.Equal("requests-by-status", payload["uid"])
+	req.Equal("Requests by status", payload["name"])
+	req.Equal("folder-uid", payload["folderUid"])
+	req.Equal(float64(1), payload["kind"])
+	req.Equal("text", payload["model"].(map[string]interface{})["type"])
+	req.NotContains(payload, "version")
+}
+
+func TestExistingLibraryPanelsAreUpdated(t *testing.T) {
+	req := require.New(t)
+	var payload map[string]interface{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = fmt.Fprintln(w, `{"result": {"id": 3, "uid": "requests-by-status", "version": 4}}`)
+			return
+		}
+
+		req.Equal(http.MethodPatch, r.Method)
+		req.Equal("/api/library-elements/requests-by-status", r.URL.Path)
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+
+		_, _ = fmt.Fprintln(w, `{"result": {"id": 3, "uid": "requests-by-status", "version": 5}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	panel, err := client.UpsertLibraryPanel(context.TODO(), &Folder{ID: 2}, testLibraryPanel(t))
+
+	req.NoError(err)
+	req.Equal(5, panel.Version)
+	req.Equal(float64(4), payload["version"])
+}
+
+func TestUpsertLibraryPanelCanFail(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintln(w, `{"message": "invalid model"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.UpsertLibraryPanel(context.TODO(), &Folder{ID: 2}, testLibraryPanel(t))
+
+	req.Error(err)
+	req.Contains(err.Error(), "invalid model")
+}
+
+func TestDeletingAnUnknownLibraryPanelReturnsSpecificError(t *testing.T) {
+	req := require.New(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteLibraryPanel(context.TODO(), "unknown")
+
+	req.ErrorIs(err, ErrLibraryPanelNotFound)
+}
+
+func TestLibraryPanelsAreUpsertedBeforeTheDashboardsUsingThem(t *testing.T) {
+	req := require.New(t)
+	var calls []string
+
+	builder, err := dashboard.New(
+		"Dashboard",
+		dashboard.LibraryPanels(testLibraryPanel(t)),
+		dashboard.Row("Row", row.WithLibraryPanel("requests-by-status")),
+	)
+	req.NoError(err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.URL.Path == "/api/library-elements/requests-by-status":
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/api/library-elements":
+			_, _ = fmt.Fprintln(w, `{"result": {"id": 3, "uid": "requests-by-status", "version": 1}}`)
+		case r.URL.Path == "/api/dashboards/db":
+			_, _ = fmt.Fprintln(w, `{"id": 1, "uid": "cIBgcSjkk"}`)
+		case r.URL.Path == "/api/dashboards/uid/cIBgcSjkk":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"id": 1, "uid": "cIBgcSjkk"}}`)
+		default:
+			_, _ = fmt.Fprintln(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.UpsertDashboard(context.TODO(), &Folder{ID: 2}, builder)
+
+	req.NoError(err)
+	req.Equal("GET /api/library-elements/requests-by-status", calls[0])
+	req.Equal("POST /api/library-elements", calls[1])
+	req.Equal("POST /api/dashboards/db", calls[2])
+}
@@ -3,9 +3,13 @@ package logs
 import (
 	"fmt"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
 	"github.com/K-Phoen/grabana/links"
 	"github.com/K-Phoen/grabana/target/loki"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -47,6 +51,10 @@ func New(title string, options ...Option) (*Logs, error) {
 		}
 	}
 
+	if err := custompanel.Flatten(panel.Builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
@@ -79,12 +87,22 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the panel, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(logs *Logs) error {
+		logs.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
 // WithLokiTarget adds a loki query to the graph.
 func WithLokiTarget(query string, options ...loki.Option) Option {
 	target := loki.New(query, options...)
 
 	return func(logs *Logs) error {
 		logs.Builder.AddTarget(&sdk.Target{
+			Datasource:   target.Datasource.Internal(),
 			RefID:        target.Ref,
 			Expr:         target.Expr,
 			LegendFormat: target.LegendFormat,
@@ -117,6 +135,22 @@ func Height(height string) Option {
 	}
 }
 
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(logs *Logs) error {
+		return layout.At(logs.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(logs *Logs) error {
+		return layout.Size(logs.Builder, width, height)
+	}
+}
+
 // Description annotates the current visualization with a human-readable description.
 func Description(content string) Option {
 	return func(logs *Logs) error {
@@ -135,6 +169,16 @@ func Transparent() Option {
 	}
 }
 
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(logs *Logs) error {
+		custompanel.Set(logs.Builder, "transformations", transformations)
+
+		return nil
+	}
+}
+
 // Repeat configures repeating a panel for a variable
 func Repeat(repeat string) Option {
 	return func(logs *Logs) error {
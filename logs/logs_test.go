@@ -1,10 +1,13 @@
 package logs
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -67,6 +70,28 @@ func TestLogsPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestLogsPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidLogsPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestLogsPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -95,6 +120,16 @@ func TestLogsPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("loki-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestLogsPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestRepeatCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -216,3 +251,29 @@ func TestDedupStrategyCanBeSet(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("logs", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
@@ -0,0 +1,125 @@
+package mapping
+
+// Option represents an option that can be used to configure the result of a
+// value mapping.
+type Option func(result *Result)
+
+// Type represents the type of a value mapping.
+type Type string
+
+const (
+	ValueType   Type = "value"
+	RangeType   Type = "range"
+	RegexType   Type = "regex"
+	SpecialType Type = "special"
+)
+
+// SpecialValue represents a special value that can be matched by a mapping.
+type SpecialValue string
+
+const (
+	Null      SpecialValue = "null"
+	NaN       SpecialValue = "nan"
+	NullOrNaN SpecialValue = "null+nan"
+	True      SpecialValue = "true"
+	False     SpecialValue = "false"
+	Empty     SpecialValue = "empty"
+)
+
+// Result describes how a mapped value is displayed.
+type Result struct {
+	Text  string `json:"text,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
+// Mapping represents a value mapping, used to display values as text and/or
+// with a specific color.
+type Mapping struct {
+	Type    Type        `json:"type"`
+	Options interface{} `json:"options"`
+}
+
+type rangeOptions struct {
+	From   float64 `json:"from"`
+	To     float64 `json:"to"`
+	Result Result  `json:"result"`
+}
+
+type regexOptions struct {
+	Pattern string `json:"pattern"`
+	Result  Result `json:"result"`
+}
+
+type specialOptions struct {
+	Match  SpecialValue `json:"match"`
+	Result Result       `json:"result"`
+}
+
+// Value maps an exact value. Example: mapping.Value("1", mapping.Text("Up")).
+func Value(value string, options ...Option) Mapping {
+	return Mapping{
+		Type: ValueType,
+		Options: map[string]Result{
+			value: newResult(options...),
+		},
+	}
+}
+
+// Range maps values between from and to (inclusive).
+func Range(from float64, to float64, options ...Option) Mapping {
+	return Mapping{
+		Type: RangeType,
+		Options: rangeOptions{
+			From:   from,
+			To:     to,
+			Result: newResult(options...),
+		},
+	}
+}
+
+// Regex maps values matching the given regular expression. The result's text
+// can refer to capture groups. Example: "$1".
+func Regex(pattern string, options ...Option) Mapping {
+	return Mapping{
+		Type: RegexType,
+		Options: regexOptions{
+			Pattern: pattern,
+			Result:  newResult(options...),
+		},
+	}
+}
+
+// Special maps special values, like null or NaN.
+func Special(match SpecialValue, options ...Option) Mapping {
+	return Mapping{
+		Type: SpecialType,
+		Options: specialOptions{
+			Match:  match,
+			Result: newResult(options...),
+		},
+	}
+}
+
+func newResult(options ...Option) Result {
+	result := Result{}
+
+	for _, opt := range options {
+		opt(&result)
+	}
+
+	return result
+}
+
+// Text sets the text displayed instead of the mapped value.
+func Text(text string) Option {
+	return func(result *Result) {
+		result.Text = text
+	}
+}
+
+// Color sets the color used to display the mapped value.
+func Color(color string) Option {
+	return func(result *Result) {
+		result.Color = color
+	}
+}
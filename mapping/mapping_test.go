@@ -0,0 +1,50 @@
+package mapping
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMappingsCanBeMarshalled(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		mapping  Mapping
+		expected string
+	}{
+		{
+			desc:     "value",
+			mapping:  Value("1", Text("Up"), Color("green")),
+			expected: `{"type": "value", "options": {"1": {"text": "Up", "color": "green"}}}`,
+		},
+		{
+			desc:     "range",
+			mapping:  Range(0, 10, Text("Low")),
+			expected: `{"type": "range", "options": {"from": 0, "to": 10, "result": {"text": "Low"}}}`,
+		},
+		{
+			desc:     "regex",
+			mapping:  Regex("host-(.*)", Text("$1")),
+			expected: `{"type": "regex", "options": {"pattern": "host-(.*)", "result": {"text": "$1"}}}`,
+		},
+		{
+			desc:     "special",
+			mapping:  Special(NullOrNaN, Text("N/A"), Color("gray")),
+			expected: `{"type": "special", "options": {"match": "null+nan", "result": {"text": "N/A", "color": "gray"}}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			payload, err := json.Marshal(tc.mapping)
+			req.NoError(err)
+
+			req.JSONEq(tc.expected, string(payload))
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package news
+
+import (
+	"fmt"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a news panel.
+type Option func(news *News) error
+
+type options struct {
+	FeedURL   string `json:"feedUrl"`
+	ShowImage bool   `json:"showImage"`
+	UseProxy  bool   `json:"useProxy"`
+}
+
+// News represents a news panel.
+type News struct {
+	Builder *sdk.Panel
+
+	options *options
+}
+
+// New creates a new news panel.
+func New(title string, options ...Option) (*News, error) {
+	panel := &News{
+		Builder: sdk.NewCustom(title),
+		options: defaultOptions(),
+	}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Type = "news"
+	panel.Builder.Renderer = nil
+	(*panel.Builder.CustomPanel)["options"] = panel.options
+
+	for _, opt := range append(defaults(), options...) {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	return panel, nil
+}
+
+func defaultOptions() *options {
+	return &options{
+		ShowImage: true,
+	}
+}
+
+func defaults() []Option {
+	return []Option{
+		Span(6),
+	}
+}
+
+// Links adds links to be displayed on this panel.
+func Links(panelLinks ...links.Link) Option {
+	return func(news *News) error {
+		news.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+
+		for _, link := range panelLinks {
+			news.Builder.Links = append(news.Builder.Links, link.Builder)
+		}
+
+		return nil
+	}
+}
+
+// Span sets the width of the panel, in grid units. Should be a positive
+// number between 1 and 12. Example: 6.
+func Span(span float32) Option {
+	return func(news *News) error {
+		if span < 1 || span > 12 {
+			return fmt.Errorf("span must be between 1 and 12: %w", errors.ErrInvalidArgument)
+		}
+
+		news.Builder.Span = span
+
+		return nil
+	}
+}
+
+// Height sets the height of the panel, in pixels. Example: "400px".
+func Height(height string) Option {
+	return func(news *News) error {
+		news.Builder.Height = &height
+
+		return nil
+	}
+}
+
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(news *News) error {
+		return layout.At(news.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(news *News) error {
+		return layout.Size(news.Builder, width, height)
+	}
+}
+
+// Description annotates the current visualization with a human-readable description.
+func Description(content string) Option {
+	return func(news *News) error {
+		news.Builder.Description = &content
+
+		return nil
+	}
+}
+
+// Transparent makes the background transparent.
+func Transparent() Option {
+	return func(news *News) error {
+		news.Builder.Transparent = true
+
+		return nil
+	}
+}
+
+// FeedURL sets the URL of the RSS or Atom feed to display.
+func FeedURL(url string) Option {
+	return func(news *News) error {
+		news.options.FeedURL = url
+
+		return nil
+	}
+}
+
+// HideImages hides the images associated with each news.
+func HideImages() Option {
+	return func(news *News) error {
+		news.options.ShowImage = false
+
+		return nil
+	}
+}
+
+// UseProxy makes Grafana fetch the feed, which is useful when it can not be
+// accessed directly because of CORS restrictions.
+func UseProxy() Option {
+	return func(news *News) error {
+		news.options.UseProxy = true
+
+		return nil
+	}
+}
@@ -0,0 +1,127 @@
+package news
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/links"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNewsPanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("News")
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("News", panel.Builder.Title)
+	req.Equal("news", panel.Builder.Type)
+	req.Equal(float32(6), panel.Builder.Span)
+	req.True(panel.options.ShowImage)
+	req.Same(panel.options, (*panel.Builder.CustomPanel)["options"])
+}
+
+func TestNewsPanelCanHaveLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Links(links.New("", "")))
+
+	req.NoError(err)
+	req.Len(panel.Builder.Links, 1)
+}
+
+func TestNewsPanelWidthCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Span(8))
+
+	req.NoError(err)
+	req.Equal(float32(8), panel.Builder.Span)
+}
+
+func TestNewsPanelRejectIncorrectWidth(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Span(-8))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestNewsPanelHeightCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Height("400px"))
+
+	req.NoError(err)
+	req.Equal("400px", *(panel.Builder.Height).(*string))
+}
+
+func TestNewsPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidNewsPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestNewsPanelBackgroundCanBeTransparent(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transparent())
+
+	req.NoError(err)
+	req.True(panel.Builder.Transparent)
+}
+
+func TestNewsPanelDescriptionCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Description("lala"))
+
+	req.NoError(err)
+	req.NotNil(panel.Builder.Description)
+	req.Equal("lala", *panel.Builder.Description)
+}
+
+func TestFeedURLCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", FeedURL("https://grafana.com/blog/news.xml"))
+
+	req.NoError(err)
+	req.Equal("https://grafana.com/blog/news.xml", panel.options.FeedURL)
+}
+
+func TestImagesCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", HideImages())
+
+	req.NoError(err)
+	req.False(panel.options.ShowImage)
+}
+
+func TestFeedCanBeProxied(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", UseProxy())
+
+	req.NoError(err)
+	req.True(panel.options.UseProxy)
+}
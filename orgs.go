@@ -0,0 +1,143 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrOrgNotFound is returned when the given organization can not be found.
+var ErrOrgNotFound = errors.New("organization not found")
+
+const orgsPerPage = 100
+
+// Org represents an organization.
+type Org struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateOrg creates a new organization.
+// Note: this requires server admin permissions.
+func (client *Client) CreateOrg(ctx context.Context, name string) (*Org, error) {
+	buf, err := json.Marshal(struct {
+		Name string `json:"name"`
+	}{
+		Name: name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/orgs", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var response struct {
+		OrgID uint `json:"orgId"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Org{ID: response.OrgID, Name: name}, nil
+}
+
+// Orgs lists all the organizations.
+// Note: this requires server admin permissions.
+func (client *Client) Orgs(ctx context.Context) ([]Org, error) {
+	var orgs []Org
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("perpage", fmt.Sprint(orgsPerPage))
+		params.Set("page", fmt.Sprint(page))
+
+		resp, err := client.get(ctx, "/api/orgs?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := client.httpError(resp)
+			_ = resp.Body.Close()
+
+			return nil, err
+		}
+
+		var pageOrgs []Org
+		err = decodeJSON(resp.Body, &pageOrgs)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		orgs = append(orgs, pageOrgs...)
+
+		if len(pageOrgs) < orgsPerPage {
+			return orgs, nil
+		}
+	}
+}
+
+// GetOrgByName finds an organization, given its name.
+func (client *Client) GetOrgByName(ctx context.Context, name string) (*Org, error) {
+	resp, err := client.get(ctx, "/api/orgs/name/"+url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrOrgNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	org := &Org{}
+	if err := decodeJSON(resp.Body, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// AddOrgUser adds an existing user to an organization, with the given role.
+// The user is identified by its login or email.
+func (client *Client) AddOrgUser(ctx context.Context, orgID uint, loginOrEmail string, role APIKeyRole) error {
+	buf, err := json.Marshal(struct {
+		LoginOrEmail string     `json:"loginOrEmail"`
+		Role         APIKeyRole `json:"role"`
+	}{
+		LoginOrEmail: loginOrEmail,
+		Role:         role,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/orgs/%d/users", orgID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateOrg(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/orgs", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("Team A", payload["name"])
+
+		_, _ = fmt.Fprintln(w, `{"orgId": 4, "message": "Organization created"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	org, err := client.CreateOrg(context.TODO(), "Team A")
+
+	req.NoError(err)
+	req.Equal(uint(4), org.ID)
+	req.Equal("Team A", org.Name)
+}
+
+func TestCreateOrgReportsConflicts(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = fmt.Fprintln(w, `{"message": "Organization name taken"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CreateOrg(context.TODO(), "Team A")
+
+	req.ErrorIs(err, ErrConflict)
+}
+
+func TestOrgsAreListedAcrossPages(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/orgs", r.URL.Path)
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = fmt.Fprintln(w, `[{"id": 101, "name": "last"}]`)
+			return
+		}
+
+		orgs := make([]Org, 0, orgsPerPage)
+		for i := 0; i < orgsPerPage; i++ {
+			orgs = append(orgs, Org{ID: uint(i + 1), Name: fmt.Sprintf("org-%d", i+1)})
+		}
+
+		req.NoError(json.NewEncoder(w).Encode(orgs))
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	orgs, err := client.Orgs(context.TODO())
+
+	req.NoError(err)
+	req.Len(orgs, orgsPerPage+1)
+	req.Equal("last", orgs[orgsPerPage].Name)
+}
+
+func TestGetOrgByName(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/orgs/name/Team A", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"id": 4, "name": "Team A"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	org, err := client.GetOrgByName(context.TODO(), "Team A")
+
+	req.NoError(err)
+	req.Equal(uint(4), org.ID)
+}
+
+func TestGetOrgByNameReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Organization not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetOrgByName(context.TODO(), "Team A")
+
+	req.ErrorIs(err, ErrOrgNotFound)
+}
+
+func TestAddOrgUser(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/orgs/4/users", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("jane@example.com", payload["loginOrEmail"])
+		req.Equal("Viewer", payload["role"])
+
+		_, _ = fmt.Fprintln(w, `{"message": "User added to organization"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.AddOrgUser(context.TODO(), 4, "jane@example.com", ViewerRole)
+
+	req.NoError(err)
+}
+
+func TestClientsCanBeScopedToAnOrg(t *testing.T) {
+	req := require.New(t)
+	orgHeaders := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		orgHeaders = append(orgHeaders, r.Header.Get("X-Grafana-Org-Id"))
+
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+	scopedClient := NewClient(http.DefaultClient, ts.URL, WithOrgID(2))
+
+	_, err := client.APIKeys(context.TODO())
+	req.NoError(err)
+	_, err = scopedClient.APIKeys(context.TODO())
+	req.NoError(err)
+	_, err = scopedClient.ForOrg(3).APIKeys(context.TODO())
+	req.NoError(err)
+	_, err = scopedClient.APIKeys(context.TODO())
+	req.NoError(err)
+
+	req.Equal([]string{"", "2", "3", "2"}, orgHeaders)
+}
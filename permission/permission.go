@@ -0,0 +1,206 @@
+// Package permission describes who can access a folder or a dashboard.
+package permission
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Level represents a level of access.
+type Level uint8
+
+const (
+	View  Level = 1
+	Edit  Level = 2
+	Admin Level = 4
+)
+
+// String implements the fmt.Stringer interface.
+func (level Level) String() string {
+	switch level {
+	case View:
+		return "View"
+	case Edit:
+		return "Edit"
+	case Admin:
+		return "Admin"
+	}
+
+	return fmt.Sprintf("Level(%d)", level)
+}
+
+// Role represents a role given to the users of an organization.
+type Role string
+
+const (
+	Viewer Role = "Viewer"
+	Editor Role = "Editor"
+)
+
+// Item grants a level of access to a role, a team, a user or a service
+// account. Teams, users and service accounts can be referenced by name:
+// such references are resolved into IDs by the client.
+type Item struct {
+	Role Role
+
+	TeamID uint
+	Team   string
+
+	// UserID identifies users as well as service accounts.
+	UserID uint
+	// User is the login or the email of a user.
+	User           string
+	ServiceAccount string
+
+	Level Level
+
+	// Inherited tells if the item is granted by a parent folder. Inherited
+	// items are only returned when reading permissions.
+	Inherited bool
+}
+
+// ForRole grants a level of access to a role.
+func ForRole(role Role, level Level) Item {
+	return Item{Role: role, Level: level}
+}
+
+// ForTeam grants a level of access to a team, given its name.
+func ForTeam(name string, level Level) Item {
+	return Item{Team: name, Level: level}
+}
+
+// ForTeamID grants a level of access to a team, given its ID.
+func ForTeamID(id uint, level Level) Item {
+	return Item{TeamID: id, Level: level}
+}
+
+// ForUser grants a level of access to a user, given its login or email.
+func ForUser(loginOrEmail string, level Level) Item {
+	return Item{User: loginOrEmail, Level: level}
+}
+
+// ForUserID grants a level of access to a user, given its ID.
+func ForUserID(id uint, level Level) Item {
+	return Item{UserID: id, Level: level}
+}
+
+// ForServiceAccount grants a level of access to a service account, given its
+// name.
+func ForServiceAccount(name string, level Level) Item {
+	return Item{ServiceAccount: name, Level: level}
+}
+
+// Resolved tells if the subject of the item is identified by its ID, or is a
+// role.
+func (item Item) Resolved() bool {
+	return item.Role != "" || item.TeamID != 0 || item.UserID != 0
+}
+
+// Subject describes who is granted access.
+func (item Item) Subject() string {
+	switch {
+	case item.Role != "":
+		return fmt.Sprintf("role %s", item.Role)
+	case item.Team != "":
+		return fmt.Sprintf("team %s", item.Team)
+	case item.TeamID != 0:
+		return fmt.Sprintf("team #%d", item.TeamID)
+	case item.ServiceAccount != "":
+		return fmt.Sprintf("service account %s", item.ServiceAccount)
+	case item.User != "":
+		return fmt.Sprintf("user %s", item.User)
+	}
+
+	return fmt.Sprintf("user #%d", item.UserID)
+}
+
+// String implements the fmt.Stringer interface.
+func (item Item) String() string {
+	return fmt.Sprintf("%s: %s", item.Subject(), item.Level)
+}
+
+// key identifies the subject of a resolved item.
+func (item Item) key() string {
+	switch {
+	case item.Role != "":
+		return "role:" + string(item.Role)
+	case item.TeamID != 0:
+		return fmt.Sprintf("team:%d", item.TeamID)
+	}
+
+	return fmt.Sprintf("user:%d", item.UserID)
+}
+
+// Change describes an item whose level of access changes.
+type Change struct {
+	Before Item
+	After  Item
+}
+
+// Diff describes the changes needed to go from a set of permissions to
+// another.
+type Diff struct {
+	Added   []Item
+	Removed []Item
+	Changed []Change
+}
+
+// Compare returns the changes needed to go from the current permissions to
+// the desired ones. Both sets must be resolved. Inherited items are ignored.
+func Compare(current []Item, desired []Item) Diff {
+	diff := Diff{}
+	currentByKey := make(map[string]Item, len(current))
+
+	for _, item := range current {
+		if item.Inherited {
+			continue
+		}
+
+		currentByKey[item.key()] = item
+	}
+
+	for _, item := range desired {
+		before, found := currentByKey[item.key()]
+		delete(currentByKey, item.key())
+
+		switch {
+		case !found:
+			diff.Added = append(diff.Added, item)
+		case before.Level != item.Level:
+			diff.Changed = append(diff.Changed, Change{Before: before, After: item})
+		}
+	}
+
+	for _, item := range currentByKey {
+		diff.Removed = append(diff.Removed, item)
+	}
+
+	sort.Slice(diff.Removed, func(i, j int) bool {
+		return diff.Removed[i].key() < diff.Removed[j].key()
+	})
+
+	return diff
+}
+
+// Empty tells if there is no change.
+func (diff Diff) Empty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// String implements the fmt.Stringer interface.
+func (diff Diff) String() string {
+	lines := make([]string, 0, len(diff.Added)+len(diff.Removed)+len(diff.Changed))
+
+	for _, item := range diff.Added {
+		lines = append(lines, "+ "+item.String())
+	}
+	for _, change := range diff.Changed {
+		lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", change.After.Subject(), change.Before.Level, change.After.Level))
+	}
+	for _, item := range diff.Removed {
+		lines = append(lines, "- "+item.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
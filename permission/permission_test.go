@@ -0,0 +1,66 @@
+package permission
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemsDescribeTheirSubject(t *testing.T) {
+	req := require.New(t)
+
+	req.Equal("role Viewer: View", ForRole(Viewer, View).String())
+	req.Equal("team SRE: Admin", ForTeam("SRE", Admin).String())
+	req.Equal("team #3: Edit", ForTeamID(3, Edit).String())
+	req.Equal("user jane@example.com: Edit", ForUser("jane@example.com", Edit).String())
+	req.Equal("user #4: View", ForUserID(4, View).String())
+	req.Equal("service account ci: Edit", ForServiceAccount("ci", Edit).String())
+}
+
+func TestItemsReferencedByNameAreNotResolved(t *testing.T) {
+	req := require.New(t)
+
+	req.True(ForRole(Editor, Edit).Resolved())
+	req.True(ForTeamID(3, Edit).Resolved())
+	req.True(ForUserID(3, Edit).Resolved())
+	req.False(ForTeam("SRE", Edit).Resolved())
+	req.False(ForUser("jane", Edit).Resolved())
+	req.False(ForServiceAccount("ci", Edit).Resolved())
+}
+
+func TestIdenticalPermissionsHaveNoDiff(t *testing.T) {
+	req := require.New(t)
+
+	items := []Item{ForRole(Viewer, View), ForTeamID(3, Admin)}
+
+	diff := Compare(items, items)
+
+	req.True(diff.Empty())
+	req.Empty(diff.String())
+}
+
+func TestPermissionsCanBeCompared(t *testing.T) {
+	req := require.New(t)
+
+	current := []Item{
+		ForRole(Viewer, View),
+		ForRole(Editor, Edit),
+		{TeamID: 3, Team: "SRE", Level: Edit},
+		{UserID: 9, User: "admin", Level: Admin, Inherited: true},
+	}
+	desired := []Item{
+		ForRole(Viewer, View),
+		ForTeamID(3, Admin),
+		ForUserID(4, Edit),
+	}
+
+	diff := Compare(current, desired)
+
+	req.False(diff.Empty())
+	req.Equal([]Item{ForUserID(4, Edit)}, diff.Added)
+	req.Equal([]Item{ForRole(Editor, Edit)}, diff.Removed)
+	req.Len(diff.Changed, 1)
+	req.Equal(Edit, diff.Changed[0].Before.Level)
+	req.Equal(Admin, diff.Changed[0].After.Level)
+	req.Equal("+ user #4: Edit\n~ team #3: Edit -> Admin\n- role Editor: Edit", diff.String())
+}
@@ -0,0 +1,198 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/K-Phoen/grabana/permission"
+)
+
+type permissionItemJSON struct {
+	Role       permission.Role  `json:"role,omitempty"`
+	TeamID     uint             `json:"teamId,omitempty"`
+	Team       string           `json:"team,omitempty"`
+	UserID     uint             `json:"userId,omitempty"`
+	UserLogin  string           `json:"userLogin,omitempty"`
+	Permission permission.Level `json:"permission"`
+	Inherited  bool             `json:"inherited,omitempty"`
+}
+
+// SetFolderPermissions replaces the permissions of a folder. Teams, users and
+// service accounts referenced by name are resolved first.
+func (client *Client) SetFolderPermissions(ctx context.Context, folderUID string, items ...permission.Item) error {
+	return client.setPermissions(ctx, folderPermissionsPath(folderUID), ErrFolderNotFound, items)
+}
+
+// FolderPermissions returns the permissions of a folder.
+func (client *Client) FolderPermissions(ctx context.Context, folderUID string) ([]permission.Item, error) {
+	return client.permissions(ctx, folderPermissionsPath(folderUID), ErrFolderNotFound)
+}
+
+// DiffFolderPermissions returns the changes that SetFolderPermissions would
+// make to the permissions of a folder.
+func (client *Client) DiffFolderPermissions(ctx context.Context, folderUID string, items ...permission.Item) (permission.Diff, error) {
+	return client.diffPermissions(ctx, folderPermissionsPath(folderUID), ErrFolderNotFound, items)
+}
+
+// SetDashboardPermissions replaces the permissions of a dashboard, except for
+// the ones inherited from its folder. Teams, users and service accounts
+// referenced by name are resolved first.
+func (client *Client) SetDashboardPermissions(ctx context.Context, dashboardUID string, items ...permission.Item) error {
+	return client.setPermissions(ctx, dashboardPermissionsPath(dashboardUID), ErrDashboardNotFound, items)
+}
+
+// DashboardPermissions returns the permissions of a dashboard, including the
+// ones inherited from its folder.
+func (client *Client) DashboardPermissions(ctx context.Context, dashboardUID string) ([]permission.Item, error) {
+	return client.permissions(ctx, dashboardPermissionsPath(dashboardUID), ErrDashboardNotFound)
+}
+
+// DiffDashboardPermissions returns the changes that SetDashboardPermissions
+// would make to the permissions of a dashboard.
+func (client *Client) DiffDashboardPermissions(ctx context.Context, dashboardUID string, items ...permission.Item) (permission.Diff, error) {
+	return client.diffPermissions(ctx, dashboardPermissionsPath(dashboardUID), ErrDashboardNotFound, items)
+}
+
+func folderPermissionsPath(folderUID string) string {
+	return fmt.Sprintf("/api/folders/%s/permissions", url.PathEscape(folderUID))
+}
+
+func dashboardPermissionsPath(dashboardUID string) string {
+	return fmt.Sprintf("/api/dashboards/uid/%s/permissions", url.PathEscape(dashboardUID))
+}
+
+func (client *Client) setPermissions(ctx context.Context, path string, notFoundErr error, items []permission.Item) error {
+	resolved, err := client.resolvePermissions(ctx, items)
+	if err != nil {
+		return err
+	}
+
+	request := struct {
+		Items []permissionItemJSON `json:"items"`
+	}{
+		Items: make([]permissionItemJSON, 0, len(resolved)),
+	}
+	for _, item := range resolved {
+		request.Items = append(request.Items, permissionItemJSON{
+			Role:       item.Role,
+			TeamID:     item.TeamID,
+			UserID:     item.UserID,
+			Permission: item.Level,
+		})
+	}
+
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	// permissions are replaced as a whole: the request can be retried
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, path, buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return notFoundErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+func (client *Client) permissions(ctx context.Context, path string, notFoundErr error) ([]permission.Item, error) {
+	resp, err := client.get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, notFoundErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var rawItems []permissionItemJSON
+	if err := decodeJSON(resp.Body, &rawItems); err != nil {
+		return nil, err
+	}
+
+	items := make([]permission.Item, 0, len(rawItems))
+	for _, item := range rawItems {
+		items = append(items, permission.Item{
+			Role:      item.Role,
+			TeamID:    item.TeamID,
+			Team:      item.Team,
+			UserID:    item.UserID,
+			User:      item.UserLogin,
+			Level:     item.Permission,
+			Inherited: item.Inherited,
+		})
+	}
+
+	return items, nil
+}
+
+func (client *Client) diffPermissions(ctx context.Context, path string, notFoundErr error, items []permission.Item) (permission.Diff, error) {
+	resolved, err := client.resolvePermissions(ctx, items)
+	if err != nil {
+		return permission.Diff{}, err
+	}
+
+	current, err := client.permissions(ctx, path, notFoundErr)
+	if err != nil {
+		return permission.Diff{}, err
+	}
+
+	return permission.Compare(current, resolved), nil
+}
+
+// resolvePermissions replaces references to teams, users and service
+// accounts by name with their ID.
+func (client *Client) resolvePermissions(ctx context.Context, items []permission.Item) ([]permission.Item, error) {
+	resolved := make([]permission.Item, 0, len(items))
+
+	for _, item := range items {
+		switch {
+		case item.Resolved():
+		case item.Team != "":
+			team, err := client.GetTeamByName(ctx, item.Team)
+			if err != nil {
+				return nil, fmt.Errorf("could not resolve team '%s': %w", item.Team, err)
+			}
+
+			item.TeamID = team.ID
+		case item.ServiceAccount != "":
+			account, err := client.GetServiceAccountByName(ctx, item.ServiceAccount)
+			if err != nil {
+				return nil, fmt.Errorf("could not resolve service account '%s': %w", item.ServiceAccount, err)
+			}
+
+			// service accounts are users, as far as permissions are concerned
+			item.UserID = account.ID
+		case item.User != "":
+			user, err := client.GetUserByLoginOrEmail(ctx, item.User)
+			if err != nil {
+				return nil, fmt.Errorf("could not resolve user '%s': %w", item.User, err)
+			}
+
+			item.UserID = user.ID
+		default:
+			return nil, fmt.Errorf("permission %s grants access to nobody", item)
+		}
+
+		resolved = append(resolved, item)
+	}
+
+	return resolved, nil
+}
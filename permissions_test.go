@@ -0,0 +1,145 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/permission"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolderPermissionsCanBeSet(t *testing.T) {
+	req := require.New(t)
+	var payload struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/teams/search":
+			req.Equal("SRE", r.URL.Query().Get("name"))
+			_, _ = fmt.Fprintln(w, `{"teams": [{"id": 3, "name": "SRE"}]}`)
+		case "/api/users/lookup":
+			req.Equal("jane@example.com", r.URL.Query().Get("loginOrEmail"))
+			_, _ = fmt.Fprintln(w, `{"id": 11, "login": "jane", "email": "jane@example.com"}`)
+		case "/api/serviceaccounts/search":
+			_, _ = fmt.Fprintln(w, `{"totalCount": 1, "serviceAccounts": [{"id": 12, "name": "ci"}]}`)
+		case "/api/folders/folder-uid/permissions":
+			req.Equal(http.MethodPost, r.Method)
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			_, _ = fmt.Fprintln(w, `{"message": "Folder permissions updated"}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetFolderPermissions(
+		context.TODO(),
+		"folder-uid",
+		permission.ForRole(permission.Viewer, permission.View),
+		permission.ForTeam("SRE", permission.Admin),
+		permission.ForUser("jane@example.com", permission.Edit),
+		permission.ForServiceAccount("ci", permission.Edit),
+	)
+
+	req.NoError(err)
+	req.Equal([]map[string]interface{}{
+		{"role": "Viewer", "permission": float64(1)},
+		{"teamId": float64(3), "permission": float64(4)},
+		{"userId": float64(11), "permission": float64(2)},
+		{"userId": float64(12), "permission": float64(2)},
+	}, payload.Items)
+}
+
+func TestSettingPermissionsFailsIfASubjectCanNotBeResolved(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/search", r.URL.Path)
+		_, _ = fmt.Fprintln(w, `{"teams": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetFolderPermissions(context.TODO(), "folder-uid", permission.ForTeam("SRE", permission.Admin))
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
+
+func TestSettingPermissionsOfAnUnknownDashboardFails(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Dashboard not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetDashboardPermissions(context.TODO(), "dashboard-uid", permission.ForRole(permission.Viewer, permission.View))
+
+	req.ErrorIs(err, ErrDashboardNotFound)
+}
+
+func TestDashboardPermissionsCanBeRead(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+		req.Equal("/api/dashboards/uid/dashboard-uid/permissions", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[
+	{"role": "Viewer", "permission": 1, "permissionName": "View", "inherited": true},
+	{"teamId": 3, "team": "SRE", "permission": 4, "permissionName": "Admin"},
+	{"userId": 11, "userLogin": "jane", "permission": 2, "permissionName": "Edit"}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	items, err := client.DashboardPermissions(context.TODO(), "dashboard-uid")
+
+	req.NoError(err)
+	req.Equal([]permission.Item{
+		{Role: permission.Viewer, Level: permission.View, Inherited: true},
+		{TeamID: 3, Team: "SRE", Level: permission.Admin},
+		{UserID: 11, User: "jane", Level: permission.Edit},
+	}, items)
+}
+
+func TestDashboardPermissionsCanBeDiffed(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+
+		_, _ = fmt.Fprintln(w, `[
+	{"role": "Viewer", "permission": 1, "inherited": true},
+	{"teamId": 3, "team": "SRE", "permission": 2},
+	{"userId": 11, "userLogin": "jane", "permission": 2}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	diff, err := client.DiffDashboardPermissions(
+		context.TODO(),
+		"dashboard-uid",
+		permission.ForTeamID(3, permission.Admin),
+		permission.ForRole(permission.Editor, permission.Edit),
+	)
+
+	req.NoError(err)
+	req.Equal("+ role Editor: Edit\n~ team #3: Edit -> Admin\n- user jane: Edit", diff.String())
+}
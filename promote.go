@@ -0,0 +1,475 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/sdk"
+)
+
+// PromotionRules describes how dashboards and alerts are rewritten while
+// being promoted from an instance to another.
+type PromotionRules struct {
+	// Folders maps paths of source folders to paths of target folders.
+	// Unmapped folders keep their path.
+	Folders map[string]string
+
+	// Datasources maps names or UIDs of source datasources to names or UIDs
+	// of target datasources. Unmapped datasources are looked for by name.
+	Datasources map[string]string
+
+	// Variables sets the default value of dashboard variables, by name.
+	Variables map[string]string
+
+	// Tags renames the tags of dashboards. Tags renamed to an empty string
+	// are removed.
+	Tags map[string]string
+
+	// AddTags lists tags added to every promoted dashboard.
+	AddTags []string
+}
+
+// PromotionPlan describes the changes made by a promotion. Nothing is changed
+// on the target instance until the plan is applied.
+type PromotionPlan struct {
+	SourceFolder string
+	TargetFolder string
+	// CreateFolder tells if the target folder does not exist yet.
+	CreateFolder bool
+	Dashboards   []PromotedDashboard
+
+	target *Client
+}
+
+// PromotedDashboard is a dashboard about to be promoted, along with its
+// alerts.
+type PromotedDashboard struct {
+	UID   string
+	Title string
+	// Create tells if the dashboard does not exist yet on the target
+	// instance.
+	Create      bool
+	AlertGroups []string
+
+	board  *sdk.Board
+	alerts []ruleGroup
+}
+
+// Promote copies the dashboards of a folder, along with their alerts, from
+// the source instance to the target one. Dashboards keep their UID.
+func Promote(ctx context.Context, source *Client, target *Client, folderPath string, rules PromotionRules) (*PromotionPlan, error) {
+	plan, err := PlanPromotion(ctx, source, target, folderPath, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, plan.Apply(ctx)
+}
+
+// PlanPromotion fetches and rewrites the dashboards of a folder, along with
+// their alerts, without changing anything on the target instance.
+// Datasources used by the promoted dashboards must exist on the target
+// instance.
+func PlanPromotion(ctx context.Context, source *Client, target *Client, folderPath string, rules PromotionRules) (*PromotionPlan, error) {
+	sourceFolder, err := source.GetFolderByPath(ctx, folderPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find source folder '%s': %w", folderPath, err)
+	}
+
+	plan := &PromotionPlan{
+		SourceFolder: folderPath,
+		TargetFolder: folderPath,
+		target:       target,
+	}
+	if targetPath, ok := rules.Folders[folderPath]; ok {
+		plan.TargetFolder = targetPath
+	}
+
+	if _, err := target.GetFolderByPath(ctx, plan.TargetFolder); err != nil {
+		if !errors.Is(err, ErrFolderNotFound) {
+			return nil, fmt.Errorf("could not find target folder '%s': %w", plan.TargetFolder, err)
+		}
+
+		plan.CreateFolder = true
+	}
+
+	sourceDatasources, err := source.datasourceResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch source datasources: %w", err)
+	}
+	targetDatasources, err := target.datasourceResolver(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch target datasources: %w", err)
+	}
+
+	rewriter := &promotionRewriter{
+		rules:  rules,
+		source: sourceDatasources,
+		target: targetDatasources,
+	}
+
+	hits, err := source.SearchDashboards(ctx, SearchQuery{FolderUIDs: []string{sourceFolder.UID}})
+	if err != nil {
+		return nil, fmt.Errorf("could not list dashboards of folder '%s': %w", folderPath, err)
+	}
+
+	for _, hit := range hits {
+		promoted, err := planDashboardPromotion(ctx, source, target, rewriter, hit.UID)
+		if err != nil {
+			return nil, fmt.Errorf("could not promote dashboard '%s': %w", hit.Title, err)
+		}
+
+		plan.Dashboards = append(plan.Dashboards, *promoted)
+	}
+
+	sort.Slice(plan.Dashboards, func(i, j int) bool {
+		return plan.Dashboards[i].UID < plan.Dashboards[j].UID
+	})
+
+	return plan, nil
+}
+
+func planDashboardPromotion(ctx context.Context, source *Client, target *Client, rewriter *promotionRewriter, uid string) (*PromotedDashboard, error) {
+	board, err := source.rawDashboardByUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces, err := source.dashboardRuleGroups(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch alerts: %w", err)
+	}
+
+	// IDs are specific to the instance the dashboard comes from
+	board.ID = 0
+
+	if err := rewriter.rewriteBoard(board); err != nil {
+		return nil, err
+	}
+
+	promoted := &PromotedDashboard{
+		UID:   board.UID,
+		Title: board.Title,
+		board: board,
+	}
+
+	// every group ends up in the target folder: groups sharing their name
+	// are merged
+	groupIndex := map[string]int{}
+	for _, groups := range namespaces {
+		for _, group := range groups {
+			if err := rewriter.rewriteAlertGroup(&group); err != nil {
+				return nil, fmt.Errorf("could not promote alert '%s': %w", group.Name, err)
+			}
+
+			if i, ok := groupIndex[group.Name]; ok {
+				promoted.alerts[i].Rules = append(promoted.alerts[i].Rules, group.Rules...)
+				continue
+			}
+
+			groupIndex[group.Name] = len(promoted.alerts)
+			promoted.alerts = append(promoted.alerts, group)
+			promoted.AlertGroups = append(promoted.AlertGroups, group.Name)
+		}
+	}
+
+	sort.Slice(promoted.alerts, func(i, j int) bool {
+		return promoted.alerts[i].Name < promoted.alerts[j].Name
+	})
+	sort.Strings(promoted.AlertGroups)
+
+	if _, err := target.rawDashboardByUID(ctx, uid); err != nil {
+		if !errors.Is(err, ErrDashboardNotFound) {
+			return nil, err
+		}
+
+		promoted.Create = true
+	}
+
+	return promoted, nil
+}
+
+// Apply creates or updates the dashboards and alerts described by the plan on
+// the target instance. Alerts are merged into the alert rule groups of the
+// target folder: rules of other dashboards sharing these groups are kept.
+func (plan *PromotionPlan) Apply(ctx context.Context) error {
+	folder, err := plan.target.FindOrCreateFolderPath(ctx, plan.TargetFolder)
+	if err != nil {
+		return fmt.Errorf("could not find or create folder '%s': %w", plan.TargetFolder, err)
+	}
+
+	for _, promoted := range plan.Dashboards {
+		if _, _, err := plan.target.saveDashboard(ctx, folder, dashboard.FromBoard(promoted.board), promoted.alerts); err != nil {
+			return fmt.Errorf("could not promote dashboard '%s': %w", promoted.Title, err)
+		}
+
+		if err := plan.target.replaceDashboardRules(ctx, folder.UID, promoted.UID, promoted.alerts); err != nil {
+			return fmt.Errorf("could not promote alerts of dashboard '%s': %w", promoted.Title, err)
+		}
+	}
+
+	return nil
+}
+
+// String describes the plan, one line per promoted dashboard. Dashboards
+// about to be created are prefixed with "+", updated ones with "~".
+func (plan *PromotionPlan) String() string {
+	var lines []string
+
+	folderLine := fmt.Sprintf("folder '%s' -> '%s'", plan.SourceFolder, plan.TargetFolder)
+	if plan.CreateFolder {
+		folderLine += " (created)"
+	}
+	lines = append(lines, folderLine)
+
+	for _, promoted := range plan.Dashboards {
+		prefix := "~"
+		if promoted.Create {
+			prefix = "+"
+		}
+
+		line := fmt.Sprintf("%s dashboard '%s' (%s)", prefix, promoted.Title, promoted.UID)
+		if len(promoted.AlertGroups) != 0 {
+			line += fmt.Sprintf(", alerts: %s", strings.Join(promoted.AlertGroups, ", "))
+		}
+
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// promotionRewriter applies promotion rules on dashboards and alerts.
+type promotionRewriter struct {
+	rules  PromotionRules
+	source *datasourceResolver
+	target *datasourceResolver
+}
+
+func (rewriter *promotionRewriter) rewriteBoard(board *sdk.Board) error {
+	for _, ref := range boardDatasourceRefs(board) {
+		if err := rewriter.rewriteDatasourceRef(ref); err != nil {
+			return err
+		}
+	}
+
+	for i := range board.Templating.List {
+		variable := &board.Templating.List[i]
+
+		value, ok := rewriter.rules.Variables[variable.Name]
+		if !ok {
+			continue
+		}
+
+		variable.Current = sdk.Current{
+			Text:  &sdk.StringSliceString{Value: []string{value}, Valid: true},
+			Value: value,
+		}
+		for j := range variable.Options {
+			variable.Options[j].Selected = variable.Options[j].Value == value
+		}
+
+		// the value of these variables is their query
+		if variable.Type == "constant" || variable.Type == "textbox" {
+			variable.Query = value
+		}
+	}
+
+	board.Tags = rewriter.rewriteTags(board.Tags)
+
+	return nil
+}
+
+func (rewriter *promotionRewriter) rewriteTags(tags []string) []string {
+	seen := map[string]bool{}
+	rewritten := []string{}
+
+	add := func(tag string) {
+		if tag == "" || seen[tag] {
+			return
+		}
+
+		seen[tag] = true
+		rewritten = append(rewritten, tag)
+	}
+
+	for _, tag := range tags {
+		if renamed, ok := rewriter.rules.Tags[tag]; ok {
+			tag = renamed
+		}
+
+		add(tag)
+	}
+
+	for _, tag := range rewriter.rules.AddTags {
+		add(tag)
+	}
+
+	return rewritten
+}
+
+// rewriteAlertGroup points the queries of the rules of a group to target
+// datasources. UIDs of rules are specific to the source instance, and are
+// removed.
+func (rewriter *promotionRewriter) rewriteAlertGroup(group *ruleGroup) error {
+	for i := range group.Rules {
+		rule, err := editGrafanaRule(group.Rules[i], func(grafanaRule map[string]json.RawMessage) error {
+			for _, field := range ruleIdentifierFields {
+				delete(grafanaRule, field)
+			}
+
+			if len(grafanaRule["data"]) == 0 {
+				return nil
+			}
+
+			var queries []map[string]json.RawMessage
+			if err := json.Unmarshal(grafanaRule["data"], &queries); err != nil {
+				return err
+			}
+
+			for _, query := range queries {
+				if err := rewriter.rewriteAlertQuery(query); err != nil {
+					return err
+				}
+			}
+
+			buf, err := json.Marshal(queries)
+			grafanaRule["data"] = buf
+
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		group.Rules[i] = rule
+	}
+
+	return nil
+}
+
+func (rewriter *promotionRewriter) rewriteAlertQuery(query map[string]json.RawMessage) error {
+	sourceUID := rawString(query["datasourceUid"])
+	if alert.IsExpression(&sdk.AlertQuery{DatasourceUID: sourceUID}) {
+		return nil
+	}
+
+	targetDatasource, err := rewriter.targetDatasource(sourceUID)
+	if err != nil {
+		return err
+	}
+
+	targetUID, err := json.Marshal(targetDatasource.UID)
+	if err != nil {
+		return err
+	}
+
+	query["datasourceUid"] = targetUID
+
+	if len(query["model"]) == 0 {
+		return nil
+	}
+
+	model := map[string]json.RawMessage{}
+	if err := json.Unmarshal(query["model"], &model); err != nil {
+		return err
+	}
+
+	// references by UID are rewritten. Older rules reference their datasource
+	// by name within their model: these references are left untouched, the
+	// datasourceUid of the query prevails over them.
+	if datasource := model["datasource"]; len(datasource) != 0 && datasource[0] == '{' {
+		modelDatasource := map[string]json.RawMessage{}
+		if err := json.Unmarshal(model["datasource"], &modelDatasource); err != nil {
+			return err
+		}
+
+		targetType, err := json.Marshal(targetDatasource.Type)
+		if err != nil {
+			return err
+		}
+
+		modelDatasource["uid"] = targetUID
+		modelDatasource["type"] = targetType
+
+		if model["datasource"], err = json.Marshal(modelDatasource); err != nil {
+			return err
+		}
+	}
+
+	query["model"], err = json.Marshal(model)
+
+	return err
+}
+
+func (rewriter *promotionRewriter) rewriteDatasourceRef(ref *sdk.DatasourceRef) error {
+	if !needsResolution(ref) || (ref.UID == "" && ref.LegacyName == "") {
+		return nil
+	}
+
+	sourceRef := ref.UID
+	if sourceRef == "" {
+		sourceRef = ref.LegacyName
+	}
+
+	targetDatasource, err := rewriter.targetDatasource(sourceRef)
+	if err != nil {
+		return err
+	}
+
+	ref.UID = targetDatasource.UID
+	ref.Type = targetDatasource.Type
+	ref.LegacyName = ""
+
+	return nil
+}
+
+// targetDatasource finds the target datasource matching the source
+// datasource designated by the given name or UID.
+func (rewriter *promotionRewriter) targetDatasource(sourceRef string) (datasourceSummary, error) {
+	candidates := []string{sourceRef}
+	if source, ok := findDatasource(rewriter.source.datasources, sourceRef); ok {
+		candidates = []string{source.UID, source.Name}
+	}
+
+	targetRef := ""
+	for _, candidate := range candidates {
+		if mapped, ok := rewriter.rules.Datasources[candidate]; ok {
+			targetRef = mapped
+			break
+		}
+	}
+	if targetRef == "" {
+		targetRef = candidates[len(candidates)-1]
+	}
+
+	target, ok := findDatasource(rewriter.target.datasources, targetRef)
+	if !ok {
+		return datasourceSummary{}, fmt.Errorf("no datasource '%s' on the target instance for '%s': %w", targetRef, sourceRef, ErrDatasourceNotFound)
+	}
+
+	return target, nil
+}
+
+// findDatasource finds a datasource by UID, or by name.
+func findDatasource(datasources []datasourceSummary, ref string) (datasourceSummary, bool) {
+	for _, ds := range datasources {
+		if ds.UID == ref {
+			return ds, true
+		}
+	}
+
+	for _, ds := range datasources {
+		if ds.Name == ref {
+			return ds, true
+		}
+	}
+
+	return datasourceSummary{}, false
+}
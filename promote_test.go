@@ -0,0 +1,306 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func promotionSourceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	req := require.New(t)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/folders":
+			_, _ = fmt.Fprintln(w, `[{"id": 3, "uid": "staging-infra", "title": "Infra"}]`)
+		case "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-staging", "name": "Prometheus staging", "type": "prometheus"}]`)
+		case "/api/search":
+			req.Equal("staging-infra", r.URL.Query().Get("folderUIDs"))
+			_, _ = fmt.Fprintln(w, `[{"uid": "nodes", "title": "Nodes"}]`)
+		case "/api/dashboards/uid/nodes":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {
+	"id": 42,
+	"uid": "nodes",
+	"title": "Nodes",
+	"tags": ["staging", "k8s"],
+	"templating": {"list": [
+		{"name": "env", "type": "custom", "current": {"text": "staging", "value": "staging"}, "options": [
+			{"text": "staging", "value": "staging", "selected": true},
+			{"text": "production", "value": "production", "selected": false}
+		]}
+	]},
+	"panels": [
+		{"id": 1, "type": "timeseries", "title": "CPU", "datasource": {"uid": "prom-staging", "type": "prometheus"}, "targets": [
+			{"refId": "A", "datasource": {"uid": "prom-staging", "type": "prometheus"}}
+		]}
+	]
+}}`)
+		case "/api/ruler/grafana/api/v1/rules":
+			req.Equal("nodes", r.URL.Query().Get("dashboard_uid"))
+			_, _ = fmt.Fprintln(w, `{"Infra": [{"name": "Nodes down", "interval": "1m", "rules": [{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "staging-rule", "namespace_uid": "staging-infra", "title": "Nodes down", "is_paused": true, "data": [
+	{"refId": "A", "datasourceUid": "prom-staging", "model": {"datasource": {"uid": "prom-staging"}}},
+	{"refId": "B", "datasourceUid": "__expr__", "model": {"datasource": {"uid": "__expr__"}}}
+]}}]}]}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+}
+
+func promotionRules() PromotionRules {
+	return PromotionRules{
+		Folders:     map[string]string{"Infra": "Production"},
+		Datasources: map[string]string{"Prometheus staging": "Prometheus"},
+		Variables:   map[string]string{"env": "production"},
+		Tags:        map[string]string{"staging": "production"},
+		AddTags:     []string{"promoted"},
+	}
+}
+
+func TestPlanningAPromotionLeavesTheTargetUntouched(t *testing.T) {
+	req := require.New(t)
+
+	source := promotionSourceServer(t)
+	defer source.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/folders":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-prod", "name": "Prometheus", "type": "prometheus"}]`)
+		case "/api/dashboards/uid/nodes":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer target.Close()
+
+	plan, err := PlanPromotion(
+		context.TODO(),
+		NewClient(http.DefaultClient, source.URL),
+		NewClient(http.DefaultClient, target.URL),
+		"Infra",
+		promotionRules(),
+	)
+
+	req.NoError(err)
+	req.Equal("Production", plan.TargetFolder)
+	req.True(plan.CreateFolder)
+	req.Len(plan.Dashboards, 1)
+	req.True(plan.Dashboards[0].Create)
+	req.Equal([]string{"Nodes down"}, plan.Dashboards[0].AlertGroups)
+	req.Equal("folder 'Infra' -> 'Production' (created)\n+ dashboard 'Nodes' (nodes), alerts: Nodes down", plan.String())
+}
+
+func TestPromotionRewritesDashboardsAndAlerts(t *testing.T) {
+	req := require.New(t)
+	dashboardCreated := false
+	var promotedDashboard struct {
+		Dashboard sdk.Board `json:"dashboard"`
+		FolderUID string    `json:"folderUid"`
+	}
+	var promotedAlert sdk.Alert
+	var promotedAlertJSON []byte
+
+	source := promotionSourceServer(t)
+	defer source.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			_, _ = fmt.Fprintln(w, `[]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/folders":
+			_, _ = fmt.Fprintln(w, `{"id": 8, "uid": "prod-infra", "title": "Production"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-prod", "name": "Prometheus", "type": "prometheus"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/nodes":
+			if !dashboardCreated {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "nodes"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			dashboardCreated = true
+			body, err := io.ReadAll(r.Body)
+			req.NoError(err)
+			req.NoError(json.Unmarshal(body, &promotedDashboard))
+			// rolling back to the promoted version restores its alerts
+			req.Contains(string(body), alertsSnapshotKey)
+			_, _ = fmt.Fprintln(w, `{"uid": "nodes"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			_, _ = fmt.Fprintln(w, `{}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra/Nodes down":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra":
+			var err error
+			promotedAlertJSON, err = io.ReadAll(r.Body)
+			req.NoError(err)
+			req.NoError(json.Unmarshal(promotedAlertJSON, &promotedAlert))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer target.Close()
+
+	_, err := Promote(
+		context.TODO(),
+		NewClient(http.DefaultClient, source.URL),
+		NewClient(http.DefaultClient, target.URL),
+		"Infra",
+		promotionRules(),
+	)
+
+	req.NoError(err)
+
+	board := promotedDashboard.Dashboard
+	req.Equal("prod-infra", promotedDashboard.FolderUID)
+	req.Equal("nodes", board.UID)
+	req.Equal(uint(0), board.ID)
+	req.Equal([]string{"production", "k8s", "promoted"}, board.Tags)
+	req.Equal("production", board.Templating.List[0].Current.Value)
+	req.True(board.Templating.List[0].Options[1].Selected)
+	req.False(board.Templating.List[0].Options[0].Selected)
+	req.Equal("prom-prod", board.Panels[0].Datasource.UID)
+	req.Equal("prom-prod", (*board.Panels[0].GetTargets())[0].Datasource.UID)
+
+	req.Equal("Nodes down", promotedAlert.Name)
+	// UIDs of rules are specific to the source instance
+	req.NotContains(string(promotedAlertJSON), "staging-rule")
+	req.NotContains(string(promotedAlertJSON), "staging-infra")
+	req.Equal("prom-prod", promotedAlert.Rules[0].GrafanaAlert.Data[0].DatasourceUID)
+	req.Equal("prom-prod", promotedAlert.Rules[0].GrafanaAlert.Data[0].Model.Datasource.UID)
+	req.Equal("prometheus", promotedAlert.Rules[0].GrafanaAlert.Data[0].Model.Datasource.Type)
+	req.Equal("__expr__", promotedAlert.Rules[0].GrafanaAlert.Data[1].DatasourceUID)
+}
+
+func TestPromotionMergesAlertsIntoGroupsSharedWithOtherDashboards(t *testing.T) {
+	req := require.New(t)
+	var writes []string
+	savedGroups := map[string]ruleGroup{}
+
+	source := promotionSourceServer(t)
+	defer source.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			_, _ = fmt.Fprintln(w, `[{"id": 8, "uid": "prod-infra", "title": "Production"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-prod", "name": "Prometheus", "type": "prometheus"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/nodes":
+			_, _ = fmt.Fprintln(w, `{"dashboard": {"uid": "nodes"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/db":
+			_, _ = fmt.Fprintln(w, `{"uid": "nodes"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			req.Equal("nodes", r.URL.Query().Get("dashboard_uid"))
+			_, _ = fmt.Fprintln(w, `{"Production": [
+	{"name": "Nodes down", "rules": [{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "prod-rule", "title": "Nodes down", "namespace_uid": "prod-infra"}}]},
+	{"name": "Capacity", "rules": [{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "capacity-nodes", "title": "Capacity", "namespace_uid": "prod-infra"}}]},
+	{"name": "Legacy", "rules": [{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "legacy-nodes", "title": "Legacy", "namespace_uid": "prod-infra"}}]}
+]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra/Nodes down":
+			_, _ = fmt.Fprintln(w, `{"name": "Nodes down", "interval": "5m", "rules": [
+	{"annotations": {"__dashboardUid__": "api"}, "grafana_alert": {"uid": "api-rule", "title": "API down"}},
+	{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "prod-rule", "title": "Nodes down"}}
+]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra/Capacity":
+			_, _ = fmt.Fprintln(w, `{"name": "Capacity", "rules": [
+	{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "capacity-nodes", "title": "Capacity"}},
+	{"annotations": {"__dashboardUid__": "api"}, "grafana_alert": {"uid": "capacity-api", "title": "API capacity"}}
+]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra/Legacy":
+			_, _ = fmt.Fprintln(w, `{"name": "Legacy", "rules": [{"annotations": {"__dashboardUid__": "nodes"}, "grafana_alert": {"uid": "legacy-nodes", "title": "Legacy"}}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra/Legacy":
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/ruler/grafana/api/v1/rules/prod-infra":
+			group := ruleGroup{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&group))
+			savedGroups[group.Name] = group
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer target.Close()
+
+	_, err := Promote(
+		context.TODO(),
+		NewClient(http.DefaultClient, source.URL),
+		NewClient(http.DefaultClient, target.URL),
+		"Infra",
+		promotionRules(),
+	)
+
+	req.NoError(err)
+	req.ElementsMatch([]string{
+		"POST /api/dashboards/db",
+		"POST /api/ruler/grafana/api/v1/rules/prod-infra",
+		"DELETE /api/ruler/grafana/api/v1/rules/prod-infra/Legacy",
+		"POST /api/ruler/grafana/api/v1/rules/prod-infra",
+	}, writes)
+
+	// rules of other dashboards are kept in the groups the dashboard leaves
+	req.Len(savedGroups["Capacity"].Rules, 1)
+	req.Equal("capacity-api", summarizeRule(savedGroups["Capacity"].Rules[0]).GrafanaAlert.UID)
+
+	// and in the groups it shares
+	nodesDown := savedGroups["Nodes down"]
+	req.Equal("1m", nodesDown.Interval)
+	req.Len(nodesDown.Rules, 2)
+	req.Equal("api-rule", summarizeRule(nodesDown.Rules[0]).GrafanaAlert.UID)
+	// the promoted rule updates the one it replaces
+	req.Equal("prod-rule", summarizeRule(nodesDown.Rules[1]).GrafanaAlert.UID)
+	req.Contains(string(nodesDown.Rules[1]), `"is_paused":true`)
+	req.Contains(string(nodesDown.Rules[1]), `"datasourceUid":"prom-prod"`)
+}
+
+func TestPromotionFailsForDatasourcesMissingOnTheTarget(t *testing.T) {
+	req := require.New(t)
+
+	source := promotionSourceServer(t)
+	defer source.Close()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+
+		switch r.URL.Path {
+		case "/api/folders":
+			_, _ = fmt.Fprintln(w, `[{"uid": "infra", "title": "Infra"}]`)
+		case "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "loki", "name": "Loki", "type": "loki"}]`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer target.Close()
+
+	_, err := PlanPromotion(
+		context.TODO(),
+		NewClient(http.DefaultClient, source.URL),
+		NewClient(http.DefaultClient, target.URL),
+		"Infra",
+		PromotionRules{},
+	)
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+}
@@ -0,0 +1,228 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+// TimeRange represents the period of time covered by a query. Bounds can be
+// absolute (epoch in milliseconds) or relative ("now-1h", "now").
+type TimeRange struct {
+	From string
+	To   string
+}
+
+// QueryResult holds the result of a single query, identified by its ref ID.
+type QueryResult struct {
+	Status int
+	Error  string
+	Frames []DataFrame
+}
+
+// Failed tells if the query could not be executed.
+func (result QueryResult) Failed() bool {
+	return result.Error != ""
+}
+
+// Empty tells if the query returned no data.
+func (result QueryResult) Empty() bool {
+	for _, frame := range result.Frames {
+		if frame.Len() != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DataFrame is a table of data returned by a datasource, stored by column.
+// See https://grafana.com/docs/grafana/latest/developers/plugins/data-frames/
+type DataFrame struct {
+	Name   string
+	RefID  string
+	Fields []DataFrameField
+}
+
+// Len returns the number of rows in the frame.
+func (frame DataFrame) Len() int {
+	if len(frame.Fields) == 0 {
+		return 0
+	}
+
+	return len(frame.Fields[0].Values)
+}
+
+// DataFrameField is a column of a data frame.
+type DataFrameField struct {
+	Name   string
+	Type   string
+	Labels map[string]string
+	Values []interface{}
+}
+
+type dataFrameJSON struct {
+	Schema struct {
+		Name   string `json:"name"`
+		RefID  string `json:"refId"`
+		Fields []struct {
+			Name   string            `json:"name"`
+			Type   string            `json:"type"`
+			Labels map[string]string `json:"labels"`
+		} `json:"fields"`
+	} `json:"schema"`
+	Data struct {
+		Values [][]interface{} `json:"values"`
+	} `json:"data"`
+}
+
+type queryResponseJSON struct {
+	Results map[string]struct {
+		Status int             `json:"status"`
+		Error  string          `json:"error"`
+		Frames []dataFrameJSON `json:"frames"`
+	} `json:"results"`
+}
+
+// QueryDatasource runs the given targets against a datasource, through
+// Grafana. Results are indexed by the ref ID of their target: targets without
+// ref ID are given one ("A", "B", ...).
+func (client *Client) QueryDatasource(ctx context.Context, ref datasource.Ref, targets []*sdk.Target, timeRange TimeRange) (map[string]QueryResult, error) {
+	resolver, err := client.datasourceResolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	datasourceRef := ref.Internal()
+	if datasourceRef == nil {
+		datasourceRef, err = resolver.defaultDatasource()
+	} else {
+		err = resolver.resolve(datasourceRef)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]map[string]interface{}, 0, len(targets))
+	for i, target := range targets {
+		query, err := targetQuery(*target, datasourceRef)
+		if err != nil {
+			return nil, err
+		}
+
+		if query["refId"] == "" {
+			query["refId"] = defaultRefID(i)
+		}
+
+		queries = append(queries, query)
+	}
+
+	buf, err := json.Marshal(map[string]interface{}{
+		"from":    timeRange.From,
+		"to":      timeRange.To,
+		"queries": queries,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendIdempotentJSON(ctx, http.MethodPost, "/api/ds/query", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// failing queries are reported with an error status, along with the
+	// results of the other queries
+	response := queryResponseJSON{}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Results) == 0 {
+		if resp.StatusCode != http.StatusOK {
+			return nil, newAPIError(resp, body)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results := make(map[string]QueryResult, len(response.Results))
+	for refID, result := range response.Results {
+		frames := make([]DataFrame, 0, len(result.Frames))
+		for _, frame := range result.Frames {
+			frames = append(frames, frame.toDataFrame())
+		}
+
+		results[refID] = QueryResult{
+			Status: result.Status,
+			Error:  result.Error,
+			Frames: frames,
+		}
+	}
+
+	return results, nil
+}
+
+// targetQuery describes a target as expected by Grafana's query API.
+func targetQuery(target sdk.Target, datasourceRef *sdk.DatasourceRef) (map[string]interface{}, error) {
+	target.Datasource = nil
+
+	content, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+
+	query := map[string]interface{}{}
+	if err := json.Unmarshal(content, &query); err != nil {
+		return nil, err
+	}
+
+	query["datasource"] = map[string]string{
+		"uid":  datasourceRef.UID,
+		"type": datasourceRef.Type,
+	}
+
+	return query, nil
+}
+
+func defaultRefID(index int) string {
+	if index < 26 {
+		return string(rune('A' + index))
+	}
+
+	return fmt.Sprintf("Q%d", index)
+}
+
+func (frame dataFrameJSON) toDataFrame() DataFrame {
+	fields := make([]DataFrameField, 0, len(frame.Schema.Fields))
+
+	for i, field := range frame.Schema.Fields {
+		var values []interface{}
+		if i < len(frame.Data.Values) {
+			values = frame.Data.Values[i]
+		}
+
+		fields = append(fields, DataFrameField{
+			Name:   field.Name,
+			Type:   field.Type,
+			Labels: field.Labels,
+			Values: values,
+		})
+	}
+
+	return DataFrame{
+		Name:   frame.Schema.Name,
+		RefID:  frame.Schema.RefID,
+		Fields: fields,
+	}
+}
@@ -0,0 +1,136 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func queryTestServer(t *testing.T, queryHandler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/datasources" {
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus", "isDefault": true}]`)
+			return
+		}
+
+		queryHandler(w, r)
+	}))
+}
+
+func TestDatasourcesCanBeQueried(t *testing.T) {
+	req := require.New(t)
+
+	ts := queryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/ds/query", r.URL.Path)
+
+		query := struct {
+			From    string                   `json:"from"`
+			To      string                   `json:"to"`
+			Queries []map[string]interface{} `json:"queries"`
+		}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&query))
+
+		req.Equal("now-1h", query.From)
+		req.Equal("now", query.To)
+		req.Len(query.Queries, 1)
+		req.Equal("A", query.Queries[0]["refId"])
+		req.Equal("up", query.Queries[0]["expr"])
+		req.Equal(map[string]interface{}{"uid": "prom-uid", "type": "prometheus"}, query.Queries[0]["datasource"])
+
+		_, _ = fmt.Fprintln(w, `{"results": {"A": {"status": 200, "frames": [{
+  "schema": {"refId": "A", "fields": [{"name": "Time", "type": "time"}, {"name": "Value", "type": "number", "labels": {"job": "api"}}]},
+  "data": {"values": [[1700000000000, 1700000015000], [1, 1]]}
+}]}}}`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	results, err := client.QueryDatasource(
+		context.TODO(),
+		datasource.ByName("Prometheus"),
+		[]*sdk.Target{prometheus.New("up").Internal()},
+		TimeRange{From: "now-1h", To: "now"},
+	)
+
+	req.NoError(err)
+	req.Len(results, 1)
+
+	result := results["A"]
+	req.False(result.Failed())
+	req.False(result.Empty())
+	req.Len(result.Frames, 1)
+	req.Equal(2, result.Frames[0].Len())
+	req.Equal("Value", result.Frames[0].Fields[1].Name)
+	req.Equal(map[string]string{"job": "api"}, result.Frames[0].Fields[1].Labels)
+}
+
+func TestQueryErrorsAreReportedByQuery(t *testing.T) {
+	req := require.New(t)
+
+	ts := queryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintln(w, `{"results": {
+  "A": {"status": 400, "error": "parse error: unexpected end of input"},
+  "B": {"status": 200, "frames": []}
+}}`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	results, err := client.QueryDatasource(
+		context.TODO(),
+		datasource.Ref{},
+		[]*sdk.Target{prometheus.New("up{").Internal(), prometheus.New("absent_metric").Internal()},
+		TimeRange{From: "now-1h", To: "now"},
+	)
+
+	req.NoError(err)
+	req.True(results["A"].Failed())
+	req.Equal("parse error: unexpected end of input", results["A"].Error)
+	req.False(results["B"].Failed())
+	req.True(results["B"].Empty())
+}
+
+func TestQueryDatasourceForwardsErrors(t *testing.T) {
+	req := require.New(t)
+
+	ts := queryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "access denied"}`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.QueryDatasource(context.TODO(), datasource.Ref{}, []*sdk.Target{prometheus.New("up").Internal()}, TimeRange{From: "now-1h", To: "now"})
+
+	req.ErrorContains(err, "access denied")
+}
+
+func TestQueryingAnUnknownDatasourceFails(t *testing.T) {
+	req := require.New(t)
+
+	ts := queryTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no query should be sent")
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.QueryDatasource(context.TODO(), datasource.ByName("Loki"), []*sdk.Target{{Expr: "{}"}}, TimeRange{From: "now-1h", To: "now"})
+
+	req.ErrorIs(err, ErrDatasourceNotFound)
+}
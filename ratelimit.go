@@ -0,0 +1,66 @@
+package grabana
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// WithRateLimit limits the rate at which the client sends requests to
+// Grafana: no more than requestsPerSecond on average, with bursts of up to
+// burst requests. A rate of zero or less disables the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(client *Client) {
+		if requestsPerSecond <= 0 {
+			client.rateLimiter = nil
+			return
+		}
+
+		if burst < 1 {
+			burst = 1
+		}
+
+		client.rateLimiter = &rateLimiter{
+			rate:   requestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			now:    time.Now,
+		}
+	}
+}
+
+// rateLimiter is a token bucket, refilled at a constant rate.
+type rateLimiter struct {
+	lock   sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// wait blocks until a request can be sent.
+func (limiter *rateLimiter) wait(ctx context.Context) error {
+	limiter.lock.Lock()
+
+	now := limiter.now()
+	if !limiter.last.IsZero() {
+		limiter.tokens += now.Sub(limiter.last).Seconds() * limiter.rate
+		if limiter.tokens > limiter.burst {
+			limiter.tokens = limiter.burst
+		}
+	}
+	limiter.last = now
+
+	// the token is taken right away: concurrent callers queue behind it
+	limiter.tokens--
+	missing := -limiter.tokens
+
+	limiter.lock.Unlock()
+
+	if missing <= 0 {
+		return nil
+	}
+
+	return sleep(ctx, time.Duration(missing/limiter.rate*float64(time.Second)))
+}
@@ -0,0 +1,64 @@
+package grabana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterAllowsBursts(t *testing.T) {
+	req := require.New(t)
+
+	now := time.Now()
+	limiter := &rateLimiter{rate: 1, burst: 3, tokens: 3, now: func() time.Time { return now }}
+
+	for i := 0; i < 3; i++ {
+		req.NoError(limiter.wait(context.TODO()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req.ErrorIs(limiter.wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiterIsRefilledOverTime(t *testing.T) {
+	req := require.New(t)
+
+	now := time.Now()
+	limiter := &rateLimiter{rate: 10, burst: 1, tokens: 1, now: func() time.Time { return now }}
+
+	req.NoError(limiter.wait(context.TODO()))
+
+	now = now.Add(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req.NoError(limiter.wait(ctx))
+}
+
+func TestClientRequestsAreRateLimited(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRateLimit(50, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := client.APIKeys(context.TODO())
+		req.NoError(err)
+	}
+
+	// the first request is free, the two others wait 20ms each
+	req.GreaterOrEqual(time.Since(start), 35*time.Millisecond)
+}
@@ -0,0 +1,131 @@
+package grabana
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type retryPolicy struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// WithRetries sets up the client to retry idempotent requests failing with a
+// network error or a transient HTTP status (429, 502, 503 or 504).
+// The delay between two attempts doubles after each of them, starting at
+// initialBackoff and capped at maxBackoff. A "Retry-After" header sent by
+// Grafana takes precedence, within the same cap.
+func WithRetries(maxRetries int, initialBackoff time.Duration, maxBackoff time.Duration) Option {
+	return func(client *Client) {
+		client.retry = retryPolicy{
+			maxRetries:     maxRetries,
+			initialBackoff: initialBackoff,
+			maxBackoff:     maxBackoff,
+		}
+	}
+}
+
+func (policy retryPolicy) shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func (policy retryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	delay := policy.initialBackoff
+	for i := 0; i < attempt; i++ {
+		if policy.maxBackoff > 0 && delay >= policy.maxBackoff {
+			break
+		}
+
+		delay *= 2
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+	}
+
+	if policy.maxBackoff > 0 && delay > policy.maxBackoff {
+		delay = policy.maxBackoff
+	}
+
+	return delay
+}
+
+// do sends the request, retrying it if it is idempotent and the client is
+// configured to do so.
+func (client Client) do(request *http.Request, idempotent bool) (*http.Response, error) {
+	ctx := request.Context()
+	client.modifyRequest(request)
+
+	for attempt := 0; ; attempt++ {
+		if client.rateLimiter != nil {
+			if err := client.rateLimiter.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		attemptRequest := request.Clone(ctx)
+		if attempt != 0 && request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptRequest.Body = body
+		}
+
+		resp, err := client.http.Do(attemptRequest)
+		if !idempotent || attempt >= client.retry.maxRetries || !client.retry.shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay := client.retry.backoff(attempt, resp)
+
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
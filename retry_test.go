@@ -0,0 +1,194 @@
+package grabana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/K-Phoen/grabana/alertmanager"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyServer fails the given number of requests with the given status
+// before handling them.
+func flakyServer(failures int32, status int, handler http.HandlerFunc) (*httptest.Server, *int32) {
+	attempts := new(int32)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(attempts, 1) <= failures {
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintln(w, `{"message": "injected failure"}`)
+			return
+		}
+
+		handler(w, r)
+	}))
+
+	return ts, attempts
+}
+
+func TestIdempotentRequestsAreRetriedOnTransientErrors(t *testing.T) {
+	req := require.New(t)
+
+	ts, attempts := flakyServer(2, http.StatusBadGateway, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[{"id": 2, "name": "foo"}]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	keys, err := client.APIKeys(context.TODO())
+
+	req.NoError(err)
+	req.Len(keys, 1)
+	req.Equal(int32(3), atomic.LoadInt32(attempts))
+}
+
+func TestRetriesAreBounded(t *testing.T) {
+	req := require.New(t)
+
+	ts, attempts := flakyServer(10, http.StatusServiceUnavailable, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(2, time.Millisecond, 5*time.Millisecond))
+
+	_, err := client.APIKeys(context.TODO())
+
+	req.Error(err)
+	req.Equal(int32(3), atomic.LoadInt32(attempts))
+
+	apiErr := &APIError{}
+	req.True(errors.As(err, &apiErr))
+	req.Equal(http.StatusServiceUnavailable, apiErr.StatusCode)
+}
+
+func TestRequestsAreNotRetriedByDefault(t *testing.T) {
+	req := require.New(t)
+
+	ts, attempts := flakyServer(1, http.StatusBadGateway, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.APIKeys(context.TODO())
+
+	req.Error(err)
+	req.Equal(int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestNonTransientErrorsAreNotRetried(t *testing.T) {
+	req := require.New(t)
+
+	ts, attempts := flakyServer(1, http.StatusNotFound, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	_, err := client.APIKeys(context.TODO())
+
+	req.ErrorIs(err, ErrNotFound)
+	req.Equal(int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestNonIdempotentRequestsAreNotRetried(t *testing.T) {
+	req := require.New(t)
+
+	ts, attempts := flakyServer(1, http.StatusBadGateway, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `{"key": "secret"}`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	_, err := client.CreateServiceAccountToken(context.TODO(), 1, CreateServiceAccountTokenRequest{Name: "token"})
+
+	req.Error(err)
+	req.Equal(int32(1), atomic.LoadInt32(attempts))
+}
+
+func TestIdempotentPostsAreRetriedWithTheirBody(t *testing.T) {
+	req := require.New(t)
+	bodies := []string{}
+
+	ts, attempts := flakyServer(1, http.StatusGatewayTimeout, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		req.NoError(err)
+		bodies = append(bodies, string(body))
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprintln(w, `{}`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(3, time.Millisecond, 5*time.Millisecond))
+
+	err := client.ConfigureAlertManager(context.TODO(), alertmanager.New())
+
+	req.NoError(err)
+	req.Equal(int32(2), atomic.LoadInt32(attempts))
+	req.Len(bodies, 1)
+	req.Contains(bodies[0], `"alertmanager_config"`)
+}
+
+func TestRetriesStopWhenTheContextIsDone(t *testing.T) {
+	req := require.New(t)
+
+	ts, _ := flakyServer(10, http.StatusBadGateway, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `[]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithRetries(5, time.Hour, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.APIKeys(ctx)
+
+	req.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestBackoffIsExponentialAndCapped(t *testing.T) {
+	req := require.New(t)
+
+	policy := retryPolicy{maxRetries: 10, initialBackoff: 100 * time.Millisecond, maxBackoff: time.Second}
+
+	req.Equal(100*time.Millisecond, policy.backoff(0, nil))
+	req.Equal(200*time.Millisecond, policy.backoff(1, nil))
+	req.Equal(400*time.Millisecond, policy.backoff(2, nil))
+	req.Equal(time.Second, policy.backoff(5, nil))
+}
+
+func TestBackoffIsExponentialWhenUncapped(t *testing.T) {
+	req := require.New(t)
+
+	policy := retryPolicy{maxRetries: 10, initialBackoff: 100 * time.Millisecond}
+
+	req.Equal(100*time.Millisecond, policy.backoff(0, nil))
+	req.Equal(200*time.Millisecond, policy.backoff(1, nil))
+	req.Equal(3200*time.Millisecond, policy.backoff(5, nil))
+}
+
+func TestBackoffHonorsRetryAfterHeaders(t *testing.T) {
+	req := require.New(t)
+
+	policy := retryPolicy{maxRetries: 10, initialBackoff: 100 * time.Millisecond, maxBackoff: 5 * time.Second}
+	resp := &http.Response{Header: http.Header{}}
+
+	resp.Header.Set("Retry-After", "2")
+	req.Equal(2*time.Second, policy.backoff(0, resp))
+
+	resp.Header.Set("Retry-After", "60")
+	req.Equal(5*time.Second, policy.backoff(0, resp))
+}
@@ -2,10 +2,16 @@ package row
 
 import (
 	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/alertlist"
+	"github.com/K-Phoen/grabana/dashlist"
 	"github.com/K-Phoen/grabana/gauge"
+	"github.com/K-Phoen/grabana/geomap"
 	"github.com/K-Phoen/grabana/graph"
 	"github.com/K-Phoen/grabana/heatmap"
+	"github.com/K-Phoen/grabana/internal/layout"
+	"github.com/K-Phoen/grabana/librarypanel"
 	"github.com/K-Phoen/grabana/logs"
+	"github.com/K-Phoen/grabana/news"
 	"github.com/K-Phoen/grabana/singlestat"
 	"github.com/K-Phoen/grabana/stat"
 	"github.com/K-Phoen/grabana/table"
@@ -23,9 +29,18 @@ type Row struct {
 	alerts  []*alert.Alert
 }
 
-// New creates a new row.
+// New creates a new row and adds it to the given board.
+// The row and its panels are laid out on the board's grid: a "row" panel is
+// followed by the panels it contains, or holds them if it is collapsed.
 func New(board *sdk.Board, title string, options ...Option) (*Row, error) {
-	panel := &Row{builder: board.AddRow(title)}
+	panel := &Row{
+		builder: &sdk.Row{
+			Title:    title,
+			Editable: true,
+			Height:   "250px",
+			Panels:   []sdk.Panel{},
+		},
+	}
 
 	for _, opt := range append(defaults(), options...) {
 		if err := opt(panel); err != nil {
@@ -33,6 +48,10 @@ func New(board *sdk.Board, title string, options ...Option) (*Row, error) {
 		}
 	}
 
+	if err := layout.AddRow(board, panel.builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
@@ -62,10 +81,7 @@ func WithGraph(title string, options ...graph.Option) Option {
 			return nil
 		}
 
-		if panel.Builder.Datasource != nil {
-			panel.Alert.Datasource = panel.Builder.Datasource.LegacyName
-		}
-
+		panel.Alert.Datasource = panel.Builder.Datasource
 		row.alerts = append(row.alerts, panel.Alert)
 
 		return nil
@@ -86,10 +102,7 @@ func WithTimeSeries(title string, options ...timeseries.Option) Option {
 			return nil
 		}
 
-		if panel.Builder.Datasource != nil {
-			panel.Alert.Datasource = panel.Builder.Datasource.LegacyName
-		}
-
+		panel.Alert.Datasource = panel.Builder.Datasource
 		row.alerts = append(row.alerts, panel.Alert)
 
 		return nil
@@ -167,6 +180,22 @@ func WithTable(title string, options ...table.Option) Option {
 	}
 }
 
+// WithLegacyTable adds a "table" panel using the legacy table plugin in the row.
+//
+// Deprecated: use WithTable() instead.
+func WithLegacyTable(title string, options ...table.Option) Option {
+	return func(row *Row) error {
+		panel, err := table.NewLegacy(title, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
 // WithText adds a "text" panel in the row.
 func WithText(title string, options ...text.Option) Option {
 	return func(row *Row) error {
@@ -195,6 +224,77 @@ func WithHeatmap(title string, options ...heatmap.Option) Option {
 	}
 }
 
+// WithGeomap adds a "geomap" panel in the row.
+func WithGeomap(title string, options ...geomap.Option) Option {
+	return func(row *Row) error {
+		panel, err := geomap.New(title, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
+// WithAlertList adds an "alert list" panel in the row.
+func WithAlertList(title string, options ...alertlist.Option) Option {
+	return func(row *Row) error {
+		panel, err := alertlist.New(title, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
+// WithDashboardList adds a "dashboard list" panel in the row.
+func WithDashboardList(title string, options ...dashlist.Option) Option {
+	return func(row *Row) error {
+		panel, err := dashlist.New(title, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
+// WithNews adds a "news" panel in the row.
+func WithNews(title string, options ...news.Option) Option {
+	return func(row *Row) error {
+		panel, err := news.New(title, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
+// WithLibraryPanel adds a reference to the library panel identified by the
+// given UID in the row.
+func WithLibraryPanel(uid string, options ...librarypanel.Option) Option {
+	return func(row *Row) error {
+		panel, err := librarypanel.New(uid, options...)
+		if err != nil {
+			return err
+		}
+
+		row.builder.Add(panel.Builder)
+
+		return nil
+	}
+}
+
 // ShowTitle ensures that the title of the row will be displayed.
 func ShowTitle() Option {
 	return func(row *Row) error {
@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/graph"
+	"github.com/K-Phoen/grabana/text"
 	"github.com/K-Phoen/grabana/timeseries"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
@@ -76,7 +78,7 @@ func TestRowsCanHaveGraphsAndAlert(t *testing.T) {
 	req.Len(panel.builder.Panels, 1)
 	req.Len(panel.Alerts(), 1)
 
-	req.Equal("Prometheus", panel.Alerts()[0].Datasource)
+	req.Equal("Prometheus", panel.Alerts()[0].Datasource.LegacyName)
 }
 
 func TestRowsCanHaveTimeSeries(t *testing.T) {
@@ -114,7 +116,7 @@ func TestRowsCanHaveTimeSeriesAndAlert(t *testing.T) {
 	req.Len(panel.builder.Panels, 1)
 	req.Len(panel.Alerts(), 1)
 
-	req.Equal("Prometheus", panel.Alerts()[0].Datasource)
+	req.Equal("Prometheus", panel.Alerts()[0].Datasource.LegacyName)
 }
 
 func TestRowsCanHaveTextPanels(t *testing.T) {
@@ -137,6 +139,17 @@ func TestRowsCanHaveTablePanels(t *testing.T) {
 	req.Len(panel.builder.Panels, 1)
 }
 
+func TestRowsCanHaveLegacyTablePanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithLegacyTable("Some table"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+	req.NotNil(panel.builder.Panels[0].TablePanel)
+}
+
 func TestRowsCanHaveSingleStatPanels(t *testing.T) {
 	req := require.New(t)
 	board := sdk.NewBoard("")
@@ -187,6 +200,66 @@ func TestRowsCanHaveGaugePanels(t *testing.T) {
 	req.Len(panel.builder.Panels, 1)
 }
 
+func TestRowsCanHaveGeomapPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithGeomap("Some map"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+}
+
+func TestRowsCanHaveAlertListPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithAlertList("Some alerts"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+}
+
+func TestRowsCanHaveDashboardListPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithDashboardList("Some dashboards"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+}
+
+func TestRowsCanHaveNewsPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithNews("Some news"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+}
+
+func TestRowsCanHaveLibraryPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	panel, err := New(board, "", WithLibraryPanel("requests-by-status"))
+
+	req.NoError(err)
+	req.Len(panel.builder.Panels, 1)
+	req.NotNil(board.Panels[1].GridPos.W)
+}
+
+func TestInvalidLibraryPanelsAreRejected(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	_, err := New(board, "", WithLibraryPanel(""))
+
+	req.Error(err)
+}
+
 func TestRowsCanHaveRepeatedPanels(t *testing.T) {
 	req := require.New(t)
 	board := sdk.NewBoard("")
@@ -206,3 +279,41 @@ func TestRowsCanBeCollapsedByDefault(t *testing.T) {
 	req.NoError(err)
 	req.True(panel.builder.Collapse)
 }
+
+func TestRowsAreLaidOutOnTheBoardGrid(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	_, err := New(board, "Some row", WithText("Some text"), WithTimeSeries("Some series"))
+
+	req.NoError(err)
+	req.Len(board.Panels, 3)
+	req.Equal("row", board.Panels[0].Type)
+	req.Equal("Some text", board.Panels[1].Title)
+	req.NotNil(board.Panels[1].GridPos.Y)
+	req.Equal(1, *board.Panels[1].GridPos.Y)
+}
+
+func TestCollapsedRowsHoldTheirPanels(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	_, err := New(board, "Some row", WithText("Some text"), Collapse())
+
+	req.NoError(err)
+	req.Len(board.Panels, 1)
+	req.True(board.Panels[0].RowPanel.Collapsed)
+	req.Len(board.Panels[0].RowPanel.Panels, 1)
+}
+
+func TestOverlappingPanelsAreRejected(t *testing.T) {
+	req := require.New(t)
+	board := sdk.NewBoard("")
+
+	_, err := New(board, "Some row",
+		WithText("first", text.At(0, 0), text.Size(12, 4)),
+		WithText("second", text.At(6, 0), text.Size(12, 4)),
+	)
+
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
@@ -0,0 +1,186 @@
+package grabana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// defaultSearchLimit is the number of dashboards fetched per page when the
+// search query doesn't say otherwise.
+const defaultSearchLimit = 1000
+
+// maxSearchLimit is the maximum number of dashboards Grafana returns per page.
+const maxSearchLimit = 5000
+
+// SearchQuery describes the dashboards to look for. Empty criteria match
+// every dashboard.
+type SearchQuery struct {
+	// Query matches dashboards with a title containing it, ignoring case.
+	Query string
+	// Tags matches dashboards having all of these tags.
+	Tags []string
+	// FolderUIDs matches dashboards within one of these folders.
+	FolderUIDs []string
+	// Starred matches dashboards starred by the user making the search.
+	Starred bool
+	// Limit is the number of dashboards fetched per page. Defaults to 1000,
+	// and can not exceed 5000.
+	Limit int
+	// Page restricts the search to a single page of results, starting at 1.
+	// By default, every page is fetched.
+	Page int
+}
+
+func (query SearchQuery) limit() int {
+	if query.Limit <= 0 {
+		return defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		return maxSearchLimit
+	}
+
+	return query.Limit
+}
+
+func (query SearchQuery) params(page int) url.Values {
+	params := url.Values{}
+	params.Set("type", "dash-db")
+	params.Set("limit", fmt.Sprint(query.limit()))
+	params.Set("page", fmt.Sprint(page))
+
+	if query.Query != "" {
+		params.Set("query", query.Query)
+	}
+	for _, tag := range query.Tags {
+		params.Add("tag", tag)
+	}
+	for _, folderUID := range query.FolderUIDs {
+		params.Add("folderUIDs", folderUID)
+	}
+	if query.Starred {
+		params.Set("starred", "true")
+	}
+
+	return params
+}
+
+// SearchDashboards lists the dashboards matching the given query. Results are
+// fetched page by page until the last one, unless the query targets a
+// single page.
+func (client *Client) SearchDashboards(ctx context.Context, query SearchQuery) ([]Dashboard, error) {
+	var dashboards []Dashboard
+
+	iterator := client.IterateDashboards(ctx, query)
+	for iterator.Next() {
+		dashboards = append(dashboards, iterator.Dashboard())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return dashboards, nil
+}
+
+// IterateDashboards streams the dashboards matching the given query. Pages
+// of results are only fetched when the previous one has been consumed.
+//
+//	iterator := client.IterateDashboards(ctx, grabana.SearchQuery{Tags: []string{"kubernetes"}})
+//	for iterator.Next() {
+//		fmt.Println(iterator.Dashboard().Title)
+//	}
+//	if err := iterator.Err(); err != nil {
+//		return err
+//	}
+func (client *Client) IterateDashboards(ctx context.Context, query SearchQuery) *DashboardIterator {
+	page := query.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	return &DashboardIterator{
+		ctx:    ctx,
+		client: client,
+		query:  query,
+		page:   page,
+	}
+}
+
+// DashboardIterator iterates over the results of a search. See
+// Client.IterateDashboards().
+type DashboardIterator struct {
+	ctx    context.Context
+	client *Client
+	query  SearchQuery
+
+	page    int
+	buffer  []Dashboard
+	current Dashboard
+	done    bool
+	err     error
+}
+
+// Next advances the iterator to the next dashboard, fetching a new page of
+// results if needed. It returns false when there are no more dashboards or
+// when an error occurred.
+func (iterator *DashboardIterator) Next() bool {
+	for len(iterator.buffer) == 0 {
+		if iterator.done || iterator.err != nil {
+			return false
+		}
+
+		iterator.fetchPage()
+	}
+
+	iterator.current = iterator.buffer[0]
+	iterator.buffer = iterator.buffer[1:]
+
+	return true
+}
+
+// Dashboard returns the current dashboard.
+func (iterator *DashboardIterator) Dashboard() Dashboard {
+	return iterator.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (iterator *DashboardIterator) Err() error {
+	return iterator.err
+}
+
+func (iterator *DashboardIterator) fetchPage() {
+	dashboards, err := iterator.client.searchDashboardsPage(iterator.ctx, iterator.query, iterator.page)
+	if err != nil {
+		iterator.err = err
+		return
+	}
+
+	iterator.buffer = dashboards
+	iterator.page++
+
+	if iterator.query.Page > 0 || len(dashboards) < iterator.query.limit() {
+		iterator.done = true
+	}
+}
+
+func (client *Client) searchDashboardsPage(ctx context.Context, query SearchQuery, page int) ([]Dashboard, error) {
+	resp, err := client.get(ctx, "/api/search?"+query.params(page).Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var dashboards []Dashboard
+	if err := decodeJSON(resp.Body, &dashboards); err != nil {
+		return nil, err
+	}
+
+	return dashboards, nil
+}
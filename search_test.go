@@ -0,0 +1,160 @@
+package grabana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchDashboardsSendsTheQueryCriteria(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		req.Equal("/api/search", r.URL.Path)
+		req.Equal("dash-db", query.Get("type"))
+		req.Equal("nodes", query.Get("query"))
+		req.Equal([]string{"kubernetes", "prod"}, query["tag"])
+		req.Equal([]string{"infra", "platform"}, query["folderUIDs"])
+		req.Equal("true", query.Get("starred"))
+		req.Equal("1000", query.Get("limit"))
+		req.Equal("1", query.Get("page"))
+
+		_, _ = fmt.Fprintln(w, `[{"uid": "nodes", "title": "Nodes", "tags": ["kubernetes", "prod"], "isStarred": true, "folderUid": "infra"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.SearchDashboards(context.TODO(), SearchQuery{
+		Query:      "nodes",
+		Tags:       []string{"kubernetes", "prod"},
+		FolderUIDs: []string{"infra", "platform"},
+		Starred:    true,
+	})
+
+	req.NoError(err)
+	req.Len(dashboards, 1)
+	req.Equal("nodes", dashboards[0].UID)
+	req.True(dashboards[0].IsStarred)
+	req.Equal("infra", dashboards[0].FolderUID)
+}
+
+func TestSearchDashboardsFetchesEveryPage(t *testing.T) {
+	req := require.New(t)
+	requestedPages := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("2", r.URL.Query().Get("limit"))
+
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		switch page {
+		case "1":
+			_, _ = fmt.Fprintln(w, `[{"uid": "a"}, {"uid": "b"}]`)
+		case "2":
+			_, _ = fmt.Fprintln(w, `[{"uid": "c"}, {"uid": "d"}]`)
+		default:
+			_, _ = fmt.Fprintln(w, `[{"uid": "e"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.SearchDashboards(context.TODO(), SearchQuery{Limit: 2})
+
+	req.NoError(err)
+	req.Equal([]string{"1", "2", "3"}, requestedPages)
+	req.Len(dashboards, 5)
+	req.Equal("e", dashboards[4].UID)
+}
+
+func TestSearchDashboardsCanFetchASinglePage(t *testing.T) {
+	req := require.New(t)
+	requestedPages := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		_, _ = fmt.Fprintln(w, `[{"uid": "c"}, {"uid": "d"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboards, err := client.SearchDashboards(context.TODO(), SearchQuery{Limit: 2, Page: 2})
+
+	req.NoError(err)
+	req.Equal([]string{"2"}, requestedPages)
+	req.Len(dashboards, 2)
+}
+
+func TestSearchLimitIsCapped(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("5000", r.URL.Query().Get("limit"))
+
+		_, _ = fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.SearchDashboards(context.TODO(), SearchQuery{Limit: 10000})
+
+	req.NoError(err)
+}
+
+func TestDashboardIteratorFetchesPagesLazily(t *testing.T) {
+	req := require.New(t)
+	requestedPages := 0
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPages++
+
+		_, _ = fmt.Fprintln(w, `[{"uid": "a"}, {"uid": "b"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	iterator := client.IterateDashboards(context.TODO(), SearchQuery{Limit: 2})
+
+	req.True(iterator.Next())
+	req.Equal("a", iterator.Dashboard().UID)
+	req.True(iterator.Next())
+	req.Equal("b", iterator.Dashboard().UID)
+	req.Equal(1, requestedPages)
+
+	req.True(iterator.Next())
+	req.Equal(2, requestedPages)
+	req.NoError(iterator.Err())
+}
+
+func TestDashboardIteratorStopsOnErrors(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintln(w, `{"message": "Permission denied"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	iterator := client.IterateDashboards(context.TODO(), SearchQuery{})
+
+	req.False(iterator.Next())
+	req.ErrorIs(iterator.Err(), ErrForbidden)
+
+	_, err := client.SearchDashboards(context.TODO(), SearchQuery{})
+	req.ErrorIs(err, ErrForbidden)
+}
@@ -0,0 +1,291 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrServiceAccountNotFound is returned when the given service account can
+// not be found.
+var ErrServiceAccountNotFound = errors.New("service account not found")
+
+// ErrServiceAccountTokenNotFound is returned when the given service account
+// token can not be found.
+var ErrServiceAccountTokenNotFound = errors.New("service account token not found")
+
+const serviceAccountsPerPage = 100
+
+// ServiceAccount represents a service account.
+type ServiceAccount struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"name"`
+	Login      string `json:"login"`
+	Role       string `json:"role"`
+	IsDisabled bool   `json:"isDisabled"`
+}
+
+// CreateServiceAccountRequest represents a request made to the service
+// account creation endpoint.
+type CreateServiceAccountRequest struct {
+	Name       string     `json:"name"`
+	Role       APIKeyRole `json:"role"`
+	IsDisabled bool       `json:"isDisabled"`
+}
+
+// ServiceAccountToken represents a token belonging to a service account.
+type ServiceAccountToken struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Created    time.Time  `json:"created"`
+	Expiration *time.Time `json:"expiration"`
+	HasExpired bool       `json:"hasExpired"`
+}
+
+// CreateServiceAccountTokenRequest represents a request made to the service
+// account token creation endpoint. Tokens without time to live never expire.
+type CreateServiceAccountTokenRequest struct {
+	Name          string `json:"name"`
+	SecondsToLive int    `json:"secondsToLive,omitempty"`
+}
+
+// CreateServiceAccount creates a new service account.
+func (client *Client) CreateServiceAccount(ctx context.Context, request CreateServiceAccountRequest) (*ServiceAccount, error) {
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/serviceaccounts", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var account ServiceAccount
+	if err := decodeJSON(resp.Body, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// ServiceAccounts lists service accounts, indexed by name.
+func (client *Client) ServiceAccounts(ctx context.Context) (map[string]ServiceAccount, error) {
+	accounts, err := client.searchServiceAccounts(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	accountsMap := make(map[string]ServiceAccount, len(accounts))
+	for _, account := range accounts {
+		accountsMap[account.Name] = account
+	}
+
+	return accountsMap, nil
+}
+
+// GetServiceAccountByName finds a service account, given its name.
+func (client *Client) GetServiceAccountByName(ctx context.Context, name string) (*ServiceAccount, error) {
+	accounts, err := client.searchServiceAccounts(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// the search isn't exact
+	for i := range accounts {
+		if accounts[i].Name == name {
+			return &accounts[i], nil
+		}
+	}
+
+	return nil, ErrServiceAccountNotFound
+}
+
+func (client *Client) searchServiceAccounts(ctx context.Context, query string) ([]ServiceAccount, error) {
+	var accounts []ServiceAccount
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("query", query)
+		params.Set("perpage", fmt.Sprint(serviceAccountsPerPage))
+		params.Set("page", fmt.Sprint(page))
+
+		resp, err := client.get(ctx, "/api/serviceaccounts/search?"+params.Encode())
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			err := client.httpError(resp)
+			_ = resp.Body.Close()
+
+			return nil, err
+		}
+
+		var response struct {
+			TotalCount      int              `json:"totalCount"`
+			ServiceAccounts []ServiceAccount `json:"serviceAccounts"`
+		}
+		err = decodeJSON(resp.Body, &response)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, response.ServiceAccounts...)
+
+		if len(response.ServiceAccounts) == 0 || len(accounts) >= response.TotalCount {
+			return accounts, nil
+		}
+	}
+}
+
+// DeleteServiceAccount deletes a service account, along with its tokens.
+func (client *Client) DeleteServiceAccount(ctx context.Context, serviceAccountID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/serviceaccounts/%d", serviceAccountID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// CreateServiceAccountToken creates a new token for the given service
+// account, and returns its key.
+func (client *Client) CreateServiceAccountToken(ctx context.Context, serviceAccountID uint, request CreateServiceAccountTokenRequest) (string, error) {
+	buf, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID), buf)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", client.httpError(resp)
+	}
+
+	var response struct {
+		Key string `json:"key"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return "", err
+	}
+
+	return response.Key, nil
+}
+
+// ServiceAccountTokens lists the tokens of the given service account,
+// indexed by name.
+func (client *Client) ServiceAccountTokens(ctx context.Context, serviceAccountID uint) (map[string]ServiceAccountToken, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/serviceaccounts/%d/tokens", serviceAccountID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrServiceAccountNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var tokens []ServiceAccountToken
+	if err := decodeJSON(resp.Body, &tokens); err != nil {
+		return nil, err
+	}
+
+	tokensMap := make(map[string]ServiceAccountToken, len(tokens))
+	for _, token := range tokens {
+		tokensMap[token.Name] = token
+	}
+
+	return tokensMap, nil
+}
+
+// RevokeServiceAccountToken deletes a token of the given service account.
+func (client *Client) RevokeServiceAccountToken(ctx context.Context, serviceAccountID uint, tokenID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/serviceaccounts/%d/tokens/%d", serviceAccountID, tokenID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrServiceAccountTokenNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// MigrateAPIKey converts an API key into a service account holding a single
+// token. The key keeps working, as the token of the new service account.
+func (client *Client) MigrateAPIKey(ctx context.Context, name string) error {
+	apiKeys, err := client.APIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	key, ok := apiKeys[name]
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+
+	return client.migrateAPIKeys(ctx, fmt.Sprintf("/api/serviceaccounts/migrate/%d", key.ID))
+}
+
+// MigrateAPIKeys converts every API key into a service account holding a
+// single token. The keys keep working, as tokens of the new service accounts.
+func (client *Client) MigrateAPIKeys(ctx context.Context) error {
+	return client.migrateAPIKeys(ctx, "/api/serviceaccounts/migrate")
+}
+
+func (client *Client) migrateAPIKeys(ctx context.Context, path string) error {
+	resp, err := client.sendJSON(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrAPIKeyNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
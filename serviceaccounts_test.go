@@ -0,0 +1,327 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateServiceAccount(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/serviceaccounts", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("ci", payload["name"])
+		req.Equal("Editor", payload["role"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintln(w, `{"id": 3, "name": "ci", "login": "sa-ci", "role": "Editor", "isDisabled": false}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	account, err := client.CreateServiceAccount(context.TODO(), CreateServiceAccountRequest{
+		Name: "ci",
+		Role: EditorRole,
+	})
+
+	req.NoError(err)
+	req.Equal(uint(3), account.ID)
+	req.Equal("sa-ci", account.Login)
+	req.Equal("Editor", account.Role)
+}
+
+func TestServiceAccountsAreListedAcrossPages(t *testing.T) {
+	req := require.New(t)
+	requestedPages := []string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/serviceaccounts/search", r.URL.Path)
+
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		w.WriteHeader(http.StatusOK)
+		if page == "1" {
+			_, _ = fmt.Fprintln(w, `{"totalCount": 2, "serviceAccounts": [{"id": 1, "name": "ci"}]}`)
+			return
+		}
+
+		_, _ = fmt.Fprintln(w, `{"totalCount": 2, "serviceAccounts": [{"id": 2, "name": "backup"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	accounts, err := client.ServiceAccounts(context.TODO())
+
+	req.NoError(err)
+	req.Equal([]string{"1", "2"}, requestedPages)
+	req.Len(accounts, 2)
+	req.Equal(uint(1), accounts["ci"].ID)
+	req.Equal(uint(2), accounts["backup"].ID)
+}
+
+func TestGetServiceAccountByName(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("ci", r.URL.Query().Get("query"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"totalCount": 2, "serviceAccounts": [{"id": 1, "name": "ci-legacy"}, {"id": 2, "name": "ci"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	account, err := client.GetServiceAccountByName(context.TODO(), "ci")
+
+	req.NoError(err)
+	req.Equal(uint(2), account.ID)
+}
+
+func TestGetServiceAccountByNameReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"totalCount": 0, "serviceAccounts": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetServiceAccountByName(context.TODO(), "ci")
+
+	req.Error(err)
+	req.ErrorIs(err, ErrServiceAccountNotFound)
+}
+
+func TestDeleteServiceAccount(t *testing.T) {
+	req := require.New(t)
+	deleted := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/serviceaccounts/3", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteServiceAccount(context.TODO(), 3)
+
+	req.NoError(err)
+	req.True(deleted)
+}
+
+func TestDeleteServiceAccountReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteServiceAccount(context.TODO(), 3)
+
+	req.Error(err)
+	req.ErrorIs(err, ErrServiceAccountNotFound)
+}
+
+func TestCreateServiceAccountToken(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/serviceaccounts/3/tokens", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("deploy", payload["name"])
+		req.EqualValues(3600, payload["secondsToLive"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"id": 7, "name": "deploy", "key": "glsa_secret"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	token, err := client.CreateServiceAccountToken(context.TODO(), 3, CreateServiceAccountTokenRequest{
+		Name:          "deploy",
+		SecondsToLive: 3600,
+	})
+
+	req.NoError(err)
+	req.Equal("glsa_secret", token)
+}
+
+func TestServiceAccountTokens(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+		req.Equal("/api/serviceaccounts/3/tokens", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `[
+	{"id": 7, "name": "deploy", "created": "2022-10-01T10:00:00Z", "expiration": "2022-10-01T11:00:00Z", "hasExpired": true},
+	{"id": 8, "name": "forever", "created": "2022-10-01T10:00:00Z", "expiration": null, "hasExpired": false}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	tokens, err := client.ServiceAccountTokens(context.TODO(), 3)
+
+	req.NoError(err)
+	req.Len(tokens, 2)
+	req.Equal(uint(7), tokens["deploy"].ID)
+	req.True(tokens["deploy"].HasExpired)
+	req.NotNil(tokens["deploy"].Expiration)
+	req.Nil(tokens["forever"].Expiration)
+}
+
+func TestRevokeServiceAccountToken(t *testing.T) {
+	req := require.New(t)
+	deleted := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/serviceaccounts/3/tokens/7", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.RevokeServiceAccountToken(context.TODO(), 3, 7)
+
+	req.NoError(err)
+	req.True(deleted)
+}
+
+func TestRevokeServiceAccountTokenReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.RevokeServiceAccountToken(context.TODO(), 3, 7)
+
+	req.Error(err)
+	req.ErrorIs(err, ErrServiceAccountTokenNotFound)
+}
+
+func TestMigrateAPIKey(t *testing.T) {
+	req := require.New(t)
+	migrated := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/keys" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintln(w, `[{"id": 2, "name": "foo"}]`)
+			return
+		}
+
+		migrated = true
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/serviceaccounts/migrate/2", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.MigrateAPIKey(context.TODO(), "foo")
+
+	req.NoError(err)
+	req.True(migrated)
+}
+
+func TestMigrateAPIKeyReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `[{"id": 2, "name": "foo"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.MigrateAPIKey(context.TODO(), "bar")
+
+	req.Error(err)
+	req.ErrorIs(err, ErrAPIKeyNotFound)
+}
+
+func TestMigrateAPIKeys(t *testing.T) {
+	req := require.New(t)
+	migrated := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		migrated = true
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/serviceaccounts/migrate", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.MigrateAPIKeys(context.TODO())
+
+	req.NoError(err)
+	req.True(migrated)
+}
+
+func TestServiceAccountTokenCanBeUsedToAuthenticate(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("Bearer glsa_secret", r.Header.Get("Authorization"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintln(w, `{"totalCount": 0, "serviceAccounts": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL, WithServiceAccountToken("glsa_secret"))
+
+	_, err := client.ServiceAccounts(context.TODO())
+
+	req.NoError(err)
+}
@@ -4,12 +4,19 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
 	"github.com/K-Phoen/grabana/target/graphite"
 	"github.com/K-Phoen/grabana/target/influxdb"
 	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/target/sql"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -109,6 +116,10 @@ func New(title string, options ...Option) (*SingleStat, error) {
 		}
 	}
 
+	if err := custompanel.Flatten(panel.Builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
@@ -151,12 +162,22 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the panel, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(singleStat *SingleStat) error {
+		singleStat.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
 // WithPrometheusTarget adds a prometheus query to the graph.
 func WithPrometheusTarget(query string, options ...prometheus.Option) Option {
 	target := prometheus.New(query, options...)
 
 	return func(singleStat *SingleStat) error {
 		singleStat.Builder.AddTarget(&sdk.Target{
+			Datasource:     target.Datasource.Internal(),
 			RefID:          target.Ref,
 			Hide:           target.Hidden,
 			Expr:           target.Expr,
@@ -194,6 +215,39 @@ func WithInfluxDBTarget(query string, options ...influxdb.Option) Option {
 	}
 }
 
+// WithCloudWatchTarget adds a CloudWatch metric query to the graph.
+func WithCloudWatchTarget(namespace string, metricName string, options ...cloudwatch.Option) Option {
+	target := cloudwatch.New(namespace, metricName, options...)
+
+	return func(singleStat *SingleStat) error {
+		singleStat.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithElasticsearchTarget adds an Elasticsearch query to the graph.
+func WithElasticsearchTarget(query string, options ...elasticsearch.Option) Option {
+	target := elasticsearch.New(query, options...)
+
+	return func(singleStat *SingleStat) error {
+		singleStat.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithSQLTarget adds a SQL query to the graph.
+func WithSQLTarget(query string, options ...sql.Option) Option {
+	target := sql.New(query, options...)
+
+	return func(singleStat *SingleStat) error {
+		singleStat.Builder.AddTarget(target.Builder)
+
+		return nil
+	}
+}
+
 // WithStackdriverTarget adds a stackdriver query to the graph.
 func WithStackdriverTarget(target *stackdriver.Stackdriver) Option {
 	return func(singleStat *SingleStat) error {
@@ -226,6 +280,22 @@ func Height(height string) Option {
 	}
 }
 
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(singleStat *SingleStat) error {
+		return layout.At(singleStat.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(singleStat *SingleStat) error {
+		return layout.Size(singleStat.Builder, width, height)
+	}
+}
+
 // Description annotates the current visualization with a human-readable description.
 func Description(content string) Option {
 	return func(singleStat *SingleStat) error {
@@ -244,6 +314,16 @@ func Transparent() Option {
 	}
 }
 
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(singleStat *SingleStat) error {
+		custompanel.Set(singleStat.Builder, "transformations", transformations)
+
+		return nil
+	}
+}
+
 // Unit sets the unit of the data displayed on this axis.
 func Unit(unit string) Option {
 	return func(singleStat *SingleStat) error {
@@ -1,11 +1,14 @@
 package singlestat
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/links"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -59,6 +62,33 @@ func TestSingleStatPanelCanHaveInfluxDBTargets(t *testing.T) {
 	req.Len(panel.Builder.SinglestatPanel.Targets, 1)
 }
 
+func TestSingleStatPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.SinglestatPanel.Targets, 1)
+}
+
+func TestSingleStatPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.SinglestatPanel.Targets, 1)
+}
+
+func TestSingleStatPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.SinglestatPanel.Targets, 1)
+}
+
 func TestSingleStatPanelCanHaveStackdriverTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -95,6 +125,28 @@ func TestSingleStatPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestSingleStatPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidSingleStatPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestSingleStatPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -123,6 +175,16 @@ func TestSingleStatPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestSingleStatPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestRepeatCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -341,3 +403,29 @@ func TestRangeToTextMappingsCanBeConfigured(t *testing.T) {
 	req.NoError(err)
 	req.Len(panel.Builder.SinglestatPanel.RangeMaps, 3)
 }
+
+func TestSingleStatPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("singlestat", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
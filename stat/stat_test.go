@@ -1,11 +1,16 @@
 package stat
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -59,6 +64,33 @@ func TestStatPanelCanHaveInfluxDBTargets(t *testing.T) {
 	req.Len(panel.Builder.StatPanel.Targets, 1)
 }
 
+func TestStatPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.StatPanel.Targets, 1)
+}
+
+func TestStatPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.StatPanel.Targets, 1)
+}
+
+func TestStatPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.StatPanel.Targets, 1)
+}
+
 func TestStatPanelCanHaveStackdriverTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -95,6 +127,28 @@ func TestStatPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestStatPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidStatPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestStatPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -123,6 +177,16 @@ func TestStatPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestStatPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestRepeatCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -440,3 +504,62 @@ func TestRelativeThresholdsCanBeConfigured(t *testing.T) {
 func float64Ptr(input float64) *float64 {
 	return &input
 }
+
+func TestStatPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("stat", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
+
+func TestStatPanelCanHaveValueMappingsAndDataLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("",
+		ValueMappings(mapping.Value("0", mapping.Text("Down")), mapping.Value("1", mapping.Text("Up"))),
+		DataLinks(datalink.New("Details", "https://example.org/${__value.raw}")),
+	)
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		FieldConfig struct {
+			Defaults struct {
+				Mappings []struct {
+					Type string
+				}
+				Links []struct {
+					Title string
+					URL   string
+				}
+			}
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Len(decoded.FieldConfig.Defaults.Mappings, 2)
+	req.Equal("value", decoded.FieldConfig.Defaults.Mappings[0].Type)
+	req.Len(decoded.FieldConfig.Defaults.Links, 1)
+	req.Equal("https://example.org/${__value.raw}", decoded.FieldConfig.Defaults.Links[0].URL)
+}
@@ -0,0 +1,141 @@
+package fields
+
+import (
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/mapping"
+	"github.com/K-Phoen/grabana/timeseries/fields"
+	"github.com/K-Phoen/sdk"
+)
+
+// Matcher selects the fields an override applies to.
+type Matcher = fields.Matcher
+
+// OverrideOption represents an option that can be used to override the
+// configuration of the matched fields.
+// Options from the timeseries/fields package can also be used.
+type OverrideOption = fields.OverrideOption
+
+// CellDisplayMode defines how cells are displayed.
+type CellDisplayMode string
+
+const (
+	Auto                   CellDisplayMode = "auto"
+	ColoredText            CellDisplayMode = "color-text"
+	ColoredBackground      CellDisplayMode = "color-background"
+	ColoredBackgroundSolid CellDisplayMode = "color-background-solid"
+	GradientGauge          CellDisplayMode = "gradient-gauge"
+	LCDGauge               CellDisplayMode = "lcd-gauge"
+	BasicGauge             CellDisplayMode = "basic-gauge"
+	JSONView               CellDisplayMode = "json-view"
+	Image                  CellDisplayMode = "image"
+	Sparkline              CellDisplayMode = "sparkline"
+)
+
+// AlignMode defines how the content of cells is aligned.
+type AlignMode string
+
+const (
+	AlignAuto   AlignMode = "auto"
+	AlignLeft   AlignMode = "left"
+	AlignCenter AlignMode = "center"
+	AlignRight  AlignMode = "right"
+)
+
+// CellOptions returns the cell options understood by Grafana for this
+// display mode.
+func (mode CellDisplayMode) CellOptions() map[string]interface{} {
+	switch mode {
+	case ColoredBackground:
+		return map[string]interface{}{"type": "color-background", "mode": "gradient"}
+	case ColoredBackgroundSolid:
+		return map[string]interface{}{"type": "color-background", "mode": "basic"}
+	case GradientGauge:
+		return map[string]interface{}{"type": "gauge", "mode": "gradient"}
+	case LCDGauge:
+		return map[string]interface{}{"type": "gauge", "mode": "lcd"}
+	case BasicGauge:
+		return map[string]interface{}{"type": "gauge", "mode": "basic"}
+	}
+
+	return map[string]interface{}{"type": string(mode)}
+}
+
+// ByName matches a specific field name.
+func ByName(name string) Matcher {
+	return fields.ByName(name)
+}
+
+// ByQuery matches all fields returned by the given query.
+func ByQuery(ref string) Matcher {
+	return fields.ByQuery(ref)
+}
+
+// ByRegex matches fields names using a regex.
+func ByRegex(regex string) Matcher {
+	return fields.ByRegex(regex)
+}
+
+// ByType matches fields with a specific type.
+func ByType(fieldType fields.FieldType) Matcher {
+	return fields.ByType(fieldType)
+}
+
+// Unit overrides the unit.
+func Unit(unit string) OverrideOption {
+	return fields.Unit(unit)
+}
+
+// Decimals overrides the number of decimals displayed.
+func Decimals(decimals int) OverrideOption {
+	return property("decimals", decimals)
+}
+
+// Width overrides the width of the column, in pixels.
+func Width(width int) OverrideOption {
+	return property("custom.width", width)
+}
+
+// MinWidth overrides the minimum width of the column, in pixels.
+func MinWidth(width int) OverrideOption {
+	return property("custom.minWidth", width)
+}
+
+// Align overrides how the content of the cells is aligned.
+func Align(mode AlignMode) OverrideOption {
+	return property("custom.align", string(mode))
+}
+
+// Filterable enables filtering the rows using the values of the column.
+func Filterable() OverrideOption {
+	return property("custom.filterable", true)
+}
+
+// CellDisplay overrides how the cells are displayed.
+func CellDisplay(mode CellDisplayMode) OverrideOption {
+	return property("custom.cellOptions", mode.CellOptions())
+}
+
+// Hidden hides the column.
+func Hidden() OverrideOption {
+	return property("custom.hidden", true)
+}
+
+// ValueMappings overrides the value mappings.
+func ValueMappings(mappings ...mapping.Mapping) OverrideOption {
+	return fields.ValueMappings(mappings...)
+}
+
+// DataLinks overrides the links attached to the values.
+func DataLinks(links ...datalink.Link) OverrideOption {
+	return fields.DataLinks(links...)
+}
+
+func property(id string, value interface{}) OverrideOption {
+	return func(field *sdk.FieldConfigOverride) {
+		field.Properties = append(field.Properties,
+			sdk.FieldConfigOverrideProperty{
+				ID:    id,
+				Value: value,
+			})
+	}
+}
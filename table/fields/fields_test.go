@@ -0,0 +1,96 @@
+package fields
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/mapping"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCellDisplayModesCanBeConvertedToCellOptions(t *testing.T) {
+	testCases := []struct {
+		mode     CellDisplayMode
+		expected map[string]interface{}
+	}{
+		{mode: Auto, expected: map[string]interface{}{"type": "auto"}},
+		{mode: ColoredText, expected: map[string]interface{}{"type": "color-text"}},
+		{mode: ColoredBackground, expected: map[string]interface{}{"type": "color-background", "mode": "gradient"}},
+		{mode: ColoredBackgroundSolid, expected: map[string]interface{}{"type": "color-background", "mode": "basic"}},
+		{mode: GradientGauge, expected: map[string]interface{}{"type": "gauge", "mode": "gradient"}},
+		{mode: LCDGauge, expected: map[string]interface{}{"type": "gauge", "mode": "lcd"}},
+		{mode: BasicGauge, expected: map[string]interface{}{"type": "gauge", "mode": "basic"}},
+		{mode: JSONView, expected: map[string]interface{}{"type": "json-view"}},
+		{mode: Image, expected: map[string]interface{}{"type": "image"}},
+		{mode: Sparkline, expected: map[string]interface{}{"type": "sparkline"}},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(string(tc.mode), func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.mode.CellOptions())
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	req := require.New(t)
+
+	overrideCfg := &sdk.FieldConfigOverride{}
+	ByName("Status")(overrideCfg)
+
+	req.Equal("byName", overrideCfg.Matcher.ID)
+	req.Equal("Status", overrideCfg.Matcher.Options)
+}
+
+func TestOverrideProperties(t *testing.T) {
+	testCases := []struct {
+		name          string
+		option        OverrideOption
+		expectedID    string
+		expectedValue interface{}
+	}{
+		{name: "unit", option: Unit("bytes"), expectedID: "unit", expectedValue: "bytes"},
+		{name: "decimals", option: Decimals(2), expectedID: "decimals", expectedValue: 2},
+		{name: "width", option: Width(120), expectedID: "custom.width", expectedValue: 120},
+		{name: "min width", option: MinWidth(50), expectedID: "custom.minWidth", expectedValue: 50},
+		{name: "align", option: Align(AlignCenter), expectedID: "custom.align", expectedValue: "center"},
+		{name: "filterable", option: Filterable(), expectedID: "custom.filterable", expectedValue: true},
+		{name: "hidden", option: Hidden(), expectedID: "custom.hidden", expectedValue: true},
+		{
+			name:          "cell display",
+			option:        CellDisplay(Sparkline),
+			expectedID:    "custom.cellOptions",
+			expectedValue: map[string]interface{}{"type": "sparkline"},
+		},
+		{
+			name:          "value mappings",
+			option:        ValueMappings(mapping.Value("1", mapping.Text("Up"))),
+			expectedID:    "mappings",
+			expectedValue: []mapping.Mapping{mapping.Value("1", mapping.Text("Up"))},
+		},
+		{
+			name:          "data links",
+			option:        DataLinks(datalink.New("Details", "https://example.org")),
+			expectedID:    "links",
+			expectedValue: []datalink.Link{datalink.New("Details", "https://example.org")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.name, func(t *testing.T) {
+			req := require.New(t)
+
+			overrideCfg := &sdk.FieldConfigOverride{}
+			tc.option(overrideCfg)
+
+			req.Len(overrideCfg.Properties, 1)
+			req.Equal(tc.expectedID, overrideCfg.Properties[0].ID)
+			req.Equal(tc.expectedValue, overrideCfg.Properties[0].Value)
+		})
+	}
+}
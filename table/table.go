@@ -2,12 +2,17 @@ package table
 
 import (
 	"fmt"
+	"reflect"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
 	"github.com/K-Phoen/grabana/links"
-	"github.com/K-Phoen/grabana/target/graphite"
-	"github.com/K-Phoen/grabana/target/influxdb"
-	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/mapping"
+	"github.com/K-Phoen/grabana/table/fields"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -35,20 +40,131 @@ const (
 	Max AggregationType = "max"
 )
 
+// Transforms inherited from the legacy table panel.
+const (
+	transformTimeSeriesToRows    = "timeseries_to_rows"
+	transformTimeSeriesToColumns = "timeseries_to_columns"
+	transformJSON                = "json"
+	transformTable               = "table"
+	transformAnnotations         = "annotations"
+	transformAggregations        = "timeseries_aggregations"
+)
+
 // Aggregation configures how to display an aggregate in the table.
 type Aggregation struct {
 	Label string
 	Type  AggregationType
 }
 
+type footer struct {
+	Show             bool                     `json:"show"`
+	Reducer          []transformation.Reducer `json:"reducer"`
+	EnablePagination bool                     `json:"enablePagination"`
+}
+
+type sortBy struct {
+	DisplayName string `json:"displayName"`
+	Desc        bool   `json:"desc"`
+}
+
+type options struct {
+	ShowHeader bool     `json:"showHeader"`
+	Footer     footer   `json:"footer"`
+	SortBy     []sortBy `json:"sortBy,omitempty"`
+}
+
+type customDefaults struct {
+	Align       fields.AlignMode       `json:"align"`
+	CellOptions map[string]interface{} `json:"cellOptions"`
+	Filterable  bool                   `json:"filterable"`
+	Inspect     bool                   `json:"inspect"`
+	Width       int                    `json:"width,omitempty"`
+	MinWidth    int                    `json:"minWidth,omitempty"`
+}
+
+type fieldDefaults struct {
+	Unit     string            `json:"unit,omitempty"`
+	Decimals *int              `json:"decimals,omitempty"`
+	Custom   customDefaults    `json:"custom"`
+	Mappings []mapping.Mapping `json:"mappings"`
+	Links    []datalink.Link   `json:"links,omitempty"`
+}
+
+type fieldConfig struct {
+	Defaults  fieldDefaults             `json:"defaults"`
+	Overrides []sdk.FieldConfigOverride `json:"overrides"`
+}
+
 // Table represents a table panel.
 type Table struct {
 	Builder *sdk.Panel
+
+	// legacy tells if the panel is described with the settings of the legacy
+	// table plugin (styles, transform, …). Grafana migrates them to the
+	// modern table plugin when loading the dashboard.
+	legacy bool
+
+	options     *options
+	fieldConfig *fieldConfig
+
+	// transform and aggregations are set by the options inherited from the
+	// legacy table panel (TimeSeriesToRows(), AsTable(), …). As with the
+	// legacy panel, the last of these options wins.
+	transform    string
+	aggregations []Aggregation
+
+	// transformations translated from transform, for modern table panels
+	legacyTransformations []transformation.Transformation
+	transformations       []transformation.Transformation
 }
 
 // New creates a new table panel.
 func New(title string, options ...Option) (*Table, error) {
-	panel := &Table{Builder: sdk.NewTable(title)}
+	panel := &Table{
+		Builder:     sdk.NewCustom(title),
+		options:     defaultOptions(),
+		fieldConfig: defaultFieldConfig(),
+	}
+
+	panel.Builder.IsNew = false
+	panel.Builder.Type = "table"
+	panel.Builder.Renderer = nil
+	(*panel.Builder.CustomPanel)["options"] = panel.options
+	(*panel.Builder.CustomPanel)["fieldConfig"] = panel.fieldConfig
+	(*panel.Builder.CustomPanel)["targets"] = []sdk.Target{}
+
+	for _, opt := range append(defaults(), options...) {
+		if err := opt(panel); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := panel.applyTransform(); err != nil {
+		return nil, err
+	}
+
+	transformations := append(panel.legacyTransformations, panel.transformations...)
+	if len(transformations) != 0 {
+		(*panel.Builder.CustomPanel)["transformations"] = transformations
+	}
+
+	return panel, nil
+}
+
+// NewLegacy creates a new table panel using the legacy table plugin, as
+// Grafana did before v7. Options only supported by the modern table panel
+// are rejected.
+//
+// Deprecated: Grafana migrates legacy table panels on the fly, use New()
+// instead.
+func NewLegacy(title string, options ...Option) (*Table, error) {
+	panel := &Table{
+		Builder:     sdk.NewTable(title),
+		legacy:      true,
+		options:     defaultOptions(),
+		fieldConfig: defaultFieldConfig(),
+		transform:   transformTimeSeriesToRows,
+	}
 	empty := ""
 
 	panel.Builder.IsNew = false
@@ -66,66 +182,146 @@ func New(title string, options ...Option) (*Table, error) {
 		}
 	}
 
+	if err := panel.applyLegacySettings(); err != nil {
+		return nil, err
+	}
+
+	if err := custompanel.Flatten(panel.Builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
-func defaults() []Option {
-	return []Option{
-		Span(6),
-		TimeSeriesToRows(),
+// applyTransform translates the transform inherited from the legacy table
+// panel into transformations and cell display settings.
+func (table *Table) applyTransform() error {
+	table.legacyTransformations = nil
+
+	switch table.transform {
+	case transformTimeSeriesToRows:
+		table.legacyTransformations = []transformation.Transformation{
+			{ID: "seriesToRows", Options: map[string]interface{}{}},
+		}
+	case transformTimeSeriesToColumns:
+		table.legacyTransformations = []transformation.Transformation{
+			{ID: "seriesToColumns", Options: map[string]interface{}{}},
+		}
+	case transformTable:
+		table.legacyTransformations = []transformation.Transformation{transformation.Merge()}
+	case transformJSON:
+		table.fieldConfig.Defaults.Custom.CellOptions = fields.JSONView.CellOptions()
+	case transformAggregations:
+		return table.applyAggregations()
 	}
+
+	return nil
 }
 
-// Links adds links to be displayed on this panel.
-func Links(panelLinks ...links.Link) Option {
-	return func(table *Table) error {
-		table.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+func (table *Table) applyAggregations() error {
+	reducers := make([]transformation.Reducer, 0, len(table.aggregations))
+	renames := make([]transformation.OrganizeOption, 0, len(table.aggregations))
 
-		for _, link := range panelLinks {
-			table.Builder.Links = append(table.Builder.Links, link.Builder)
+	for _, aggregation := range table.aggregations {
+		reducer, displayName, err := aggregationReducer(aggregation.Type)
+		if err != nil {
+			return err
 		}
 
-		return nil
+		reducers = append(reducers, reducer)
+
+		if aggregation.Label != "" {
+			renames = append(renames, transformation.RenameField(displayName, aggregation.Label))
+		}
+	}
+
+	table.legacyTransformations = []transformation.Transformation{
+		transformation.Reduce(transformation.SeriesToRows, reducers...),
+	}
+
+	if len(renames) != 0 {
+		table.legacyTransformations = append(table.legacyTransformations, transformation.OrganizeFields(renames...))
 	}
+
+	return nil
 }
 
-// WithPrometheusTarget adds a prometheus target to the table.
-func WithPrometheusTarget(query string, options ...prometheus.Option) Option {
-	target := prometheus.New(query, options...)
+// applyLegacySettings copies the settings supported by the legacy table
+// panel to its builder.
+func (table *Table) applyLegacySettings() error {
+	mappings := table.fieldConfig.Defaults.Mappings
+	dataLinks := table.fieldConfig.Defaults.Links
 
-	return func(table *Table) error {
-		table.Builder.AddTarget(&sdk.Target{
-			RefID:          target.Ref,
-			Expr:           target.Expr,
-			IntervalFactor: target.IntervalFactor,
-			Interval:       target.Interval,
-			Step:           target.Step,
-			LegendFormat:   target.LegendFormat,
-			Instant:        target.Instant,
-			Format:         target.Format,
-		})
+	table.fieldConfig.Defaults.Mappings = []mapping.Mapping{}
+	table.fieldConfig.Defaults.Links = nil
 
-		return nil
+	if !reflect.DeepEqual(table.options, defaultOptions()) || !reflect.DeepEqual(table.fieldConfig, defaultFieldConfig()) {
+		return fmt.Errorf("legacy table panels only support hidden columns, transformations, value mappings and data links: %w", errors.ErrInvalidArgument)
 	}
-}
 
-// WithGraphiteTarget adds a Graphite target to the table.
-func WithGraphiteTarget(query string, options ...graphite.Option) Option {
-	target := graphite.New(query, options...)
+	table.Builder.TablePanel.Transform = table.transform
+	if table.transform == transformAggregations {
+		columns := make([]sdk.Column, 0, len(table.aggregations))
 
-	return func(table *Table) error {
-		table.Builder.AddTarget(target.Builder)
+		for _, aggregation := range table.aggregations {
+			columns = append(columns, sdk.Column{
+				TextType: aggregation.Label,
+				Value:    string(aggregation.Type),
+			})
+		}
 
-		return nil
+		table.Builder.TablePanel.Columns = columns
+	}
+
+	if len(table.transformations) != 0 {
+		custompanel.Set(table.Builder, "transformations", table.transformations)
+	}
+	if len(mappings) != 0 {
+		custompanel.SetIn(table.Builder, []string{"fieldConfig", "defaults", "mappings"}, mappings)
+	}
+	if len(dataLinks) != 0 {
+		custompanel.SetIn(table.Builder, []string{"fieldConfig", "defaults", "links"}, dataLinks)
+	}
+
+	return nil
+}
+
+func defaultOptions() *options {
+	return &options{
+		ShowHeader: true,
+		Footer: footer{
+			Reducer: []transformation.Reducer{transformation.Sum},
+		},
+	}
+}
+
+func defaultFieldConfig() *fieldConfig {
+	return &fieldConfig{
+		Defaults: fieldDefaults{
+			Custom: customDefaults{
+				Align:       fields.AlignAuto,
+				CellOptions: fields.Auto.CellOptions(),
+			},
+			Mappings: []mapping.Mapping{},
+		},
+		Overrides: []sdk.FieldConfigOverride{},
 	}
 }
 
-// WithInfluxDBTarget adds an InfluxDB target to the table.
-func WithInfluxDBTarget(query string, options ...influxdb.Option) Option {
-	target := influxdb.New(query, options...)
+func defaults() []Option {
+	return []Option{
+		Span(6),
+	}
+}
 
+// Links adds links to be displayed on this panel.
+func Links(panelLinks ...links.Link) Option {
 	return func(table *Table) error {
-		table.Builder.AddTarget(target.Builder)
+		table.Builder.Links = make([]sdk.Link, 0, len(panelLinks))
+
+		for _, link := range panelLinks {
+			table.Builder.Links = append(table.Builder.Links, link.Builder)
+		}
 
 		return nil
 	}
@@ -134,6 +330,10 @@ func WithInfluxDBTarget(query string, options ...influxdb.Option) Option {
 // HideColumn hides the column having a label matching the given pattern.
 func HideColumn(columnLabelPattern string) Option {
 	return func(table *Table) error {
+		if !table.legacy {
+			return FieldOverride(fields.ByRegex(columnLabelPattern), fields.Hidden())(table)
+		}
+
 		table.Builder.TablePanel.Styles = append([]sdk.ColumnStyle{
 			{
 				Pattern: columnLabelPattern,
@@ -147,44 +347,35 @@ func HideColumn(columnLabelPattern string) Option {
 
 // TimeSeriesToRows displays the data in rows.
 func TimeSeriesToRows() Option {
-	return func(table *Table) error {
-		table.Builder.TablePanel.Transform = "timeseries_to_rows"
-
-		return nil
-	}
+	return transform(transformTimeSeriesToRows)
 }
 
 // TimeSeriesToColumns displays the data in columns.
 func TimeSeriesToColumns() Option {
-	return func(table *Table) error {
-		table.Builder.TablePanel.Transform = "timeseries_to_columns"
-
-		return nil
-	}
+	return transform(transformTimeSeriesToColumns)
 }
 
-// AsJSON displays the data as JSON.
+// AsJSON displays the data as JSON. Takes precedence over CellDisplay().
 func AsJSON() Option {
-	return func(table *Table) error {
-		table.Builder.TablePanel.Transform = "json"
-
-		return nil
-	}
+	return transform(transformJSON)
 }
 
 // AsTable displays the data as a table.
 func AsTable() Option {
-	return func(table *Table) error {
-		table.Builder.TablePanel.Transform = "table"
-
-		return nil
-	}
+	return transform(transformTable)
 }
 
 // AsAnnotations displays the data as annotations.
+//
+// Deprecated: only supported by legacy table panels, see NewLegacy(). The
+// modern table panel has no equivalent: it displays the data as is.
 func AsAnnotations() Option {
 	return func(table *Table) error {
-		table.Builder.TablePanel.Transform = "annotations"
+		if !table.legacy {
+			return fmt.Errorf("annotations are only supported by legacy table panels: %w", errors.ErrInvalidArgument)
+		}
+
+		table.transform = transformAnnotations
 
 		return nil
 	}
@@ -193,17 +384,33 @@ func AsAnnotations() Option {
 // AsTimeSeriesAggregations displays the data according to the given aggregation methods.
 func AsTimeSeriesAggregations(aggregations []Aggregation) Option {
 	return func(table *Table) error {
-		columns := make([]sdk.Column, 0, len(aggregations))
+		table.transform = transformAggregations
+		table.aggregations = aggregations
 
-		for _, aggregation := range aggregations {
-			columns = append(columns, sdk.Column{
-				TextType: aggregation.Label,
-				Value:    string(aggregation.Type),
-			})
-		}
+		return nil
+	}
+}
 
-		table.Builder.TablePanel.Transform = "timeseries_aggregations"
-		table.Builder.TablePanel.Columns = columns
+func aggregationReducer(aggregationType AggregationType) (transformation.Reducer, string, error) {
+	switch aggregationType {
+	case AVG:
+		return transformation.Mean, "Mean", nil
+	case Count:
+		return transformation.Count, "Count", nil
+	case Current:
+		return transformation.LastNotNull, "Last *", nil
+	case Min:
+		return transformation.Min, "Min", nil
+	case Max:
+		return transformation.Max, "Max", nil
+	}
+
+	return "", "", fmt.Errorf("unknown aggregation type '%s': %w", aggregationType, errors.ErrInvalidArgument)
+}
+
+func transform(mode string) Option {
+	return func(table *Table) error {
+		table.transform = mode
 
 		return nil
 	}
@@ -218,6 +425,15 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the table, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(table *Table) error {
+		table.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
 // Span sets the width of the panel, in grid units. Should be a positive
 // number between 1 and 12. Example: 6.
 func Span(span float32) Option {
@@ -241,6 +457,22 @@ func Height(height string) Option {
 	}
 }
 
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(table *Table) error {
+		return layout.At(table.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(table *Table) error {
+		return layout.Size(table.Builder, width, height)
+	}
+}
+
 // Description annotates the current visualization with a human-readable description.
 func Description(content string) Option {
 	return func(table *Table) error {
@@ -258,3 +490,178 @@ func Transparent() Option {
 		return nil
 	}
 }
+
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(table *Table) error {
+		table.transformations = transformations
+
+		return nil
+	}
+}
+
+// ValueMappings maps values to text and/or colors. Example: 0 → "Down",
+// 1 → "Up".
+func ValueMappings(mappings ...mapping.Mapping) Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Mappings = mappings
+
+		return nil
+	}
+}
+
+// DataLinks adds links to the values displayed by the panel.
+func DataLinks(links ...datalink.Link) Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Links = links
+
+		return nil
+	}
+}
+
+// Unit sets the unit of the data.
+func Unit(unit string) Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Unit = unit
+
+		return nil
+	}
+}
+
+// Decimals sets the number of decimals that should be displayed.
+func Decimals(count int) Option {
+	return func(table *Table) error {
+		if count < 0 {
+			return fmt.Errorf("decimals must be greater than 0: %w", errors.ErrInvalidArgument)
+		}
+
+		table.fieldConfig.Defaults.Decimals = &count
+
+		return nil
+	}
+}
+
+// ColumnWidth sets the width of the columns, in pixels.
+func ColumnWidth(width int) Option {
+	return func(table *Table) error {
+		if width <= 0 {
+			return fmt.Errorf("column width must be greater than 0: %w", errors.ErrInvalidArgument)
+		}
+
+		table.fieldConfig.Defaults.Custom.Width = width
+
+		return nil
+	}
+}
+
+// MinColumnWidth sets the minimum width of the columns, in pixels.
+func MinColumnWidth(width int) Option {
+	return func(table *Table) error {
+		if width <= 0 {
+			return fmt.Errorf("minimum column width must be greater than 0: %w", errors.ErrInvalidArgument)
+		}
+
+		table.fieldConfig.Defaults.Custom.MinWidth = width
+
+		return nil
+	}
+}
+
+// Filterable enables filtering the rows using the values of the columns.
+func Filterable() Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Custom.Filterable = true
+
+		return nil
+	}
+}
+
+// Align defines how the content of the cells is aligned.
+func Align(mode fields.AlignMode) Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Custom.Align = mode
+
+		return nil
+	}
+}
+
+// CellDisplay defines how the cells are displayed.
+func CellDisplay(mode fields.CellDisplayMode) Option {
+	return func(table *Table) error {
+		table.fieldConfig.Defaults.Custom.CellOptions = mode.CellOptions()
+
+		return nil
+	}
+}
+
+// HideHeader hides the header of the table.
+func HideHeader() Option {
+	return func(table *Table) error {
+		table.options.ShowHeader = false
+
+		return nil
+	}
+}
+
+// Footer displays a footer, summarizing the columns with the given reducers.
+func Footer(reducers ...transformation.Reducer) Option {
+	return func(table *Table) error {
+		table.options.Footer.Show = true
+
+		if len(reducers) != 0 {
+			table.options.Footer.Reducer = reducers
+		}
+
+		return nil
+	}
+}
+
+// Pagination splits the rows in pages, instead of scrolling them.
+func Pagination() Option {
+	return func(table *Table) error {
+		table.options.Footer.EnablePagination = true
+
+		return nil
+	}
+}
+
+// SortBy sorts the rows using the values of the given column.
+func SortBy(column string, descending bool) Option {
+	return func(table *Table) error {
+		table.options.SortBy = append(table.options.SortBy, sortBy{
+			DisplayName: column,
+			Desc:        descending,
+		})
+
+		return nil
+	}
+}
+
+// FieldOverride allows overriding visualization options.
+func FieldOverride(m fields.Matcher, opts ...fields.OverrideOption) Option {
+	return func(table *Table) error {
+		override := sdk.FieldConfigOverride{}
+
+		m(&override)
+
+		for _, opt := range opts {
+			opt(&override)
+		}
+
+		table.fieldConfig.Overrides = append(table.fieldConfig.Overrides, override)
+
+		return nil
+	}
+}
+
+func (table *Table) addTarget(target *sdk.Target) {
+	if table.legacy {
+		table.Builder.AddTarget(target)
+		return
+	}
+
+	targets := (*table.Builder.CustomPanel)["targets"].([]sdk.Target)
+
+	(*table.Builder.CustomPanel)["targets"] = append(targets, *target)
+}
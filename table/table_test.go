@@ -1,10 +1,18 @@
 package table
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/mapping"
+	"github.com/K-Phoen/grabana/table/fields"
+	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/grabana/transformation"
+	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -55,6 +63,28 @@ func TestTablePanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestTablePanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidTablePanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestTablePanelDataSourceCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -64,13 +94,24 @@ func TestTablePanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestTablePanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestTablePanelCanHavePrometheusTargets(t *testing.T) {
 	req := require.New(t)
 
 	panel, err := New("", WithPrometheusTarget("go_threads"))
 
 	req.NoError(err)
-	req.Len(panel.Builder.TablePanel.Targets, 1)
+	req.Len(targets(panel), 1)
+	req.Equal("go_threads", targets(panel)[0].Expr)
 }
 
 func TestTablePanelCanHaveGraphiteTargets(t *testing.T) {
@@ -79,7 +120,7 @@ func TestTablePanelCanHaveGraphiteTargets(t *testing.T) {
 	panel, err := New("", WithGraphiteTarget("stats_counts.statsd.packets_received"))
 
 	req.NoError(err)
-	req.Len(panel.Builder.TablePanel.Targets, 1)
+	req.Len(targets(panel), 1)
 }
 
 func TestTablePanelCanHaveInfluxDBTargets(t *testing.T) {
@@ -88,7 +129,53 @@ func TestTablePanelCanHaveInfluxDBTargets(t *testing.T) {
 	panel, err := New("", WithInfluxDBTarget("buckets()"))
 
 	req.NoError(err)
-	req.Len(panel.Builder.TablePanel.Targets, 1)
+	req.Len(targets(panel), 1)
+}
+
+func TestTablePanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(targets(panel), 1)
+}
+
+func TestTablePanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(targets(panel), 1)
+}
+
+func TestTablePanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(targets(panel), 1)
+}
+
+func TestTablePanelCanHaveStackdriverTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithStackdriverTarget(stackdriver.Gauge("pubsub.googleapis.com/subscription/ack_message_count")))
+
+	req.NoError(err)
+	req.Len(targets(panel), 1)
+}
+
+func TestTablePanelCanHaveLokiTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithLokiTarget("rate({app=\"loki\"}[$__interval])"))
+
+	req.NoError(err)
+	req.Len(targets(panel), 1)
+	req.Equal("rate({app=\"loki\"}[$__interval])", targets(panel)[0].Expr)
 }
 
 func TestColumnsCanBeHidden(t *testing.T) {
@@ -97,13 +184,12 @@ func TestColumnsCanBeHidden(t *testing.T) {
 	panel, err := New("", HideColumn("Time.*"), HideColumn("Duration.*"))
 
 	req.NoError(err)
-	req.Len(panel.Builder.TablePanel.Styles, 3)
-	req.Equal("Duration.*", panel.Builder.TablePanel.Styles[0].Pattern)
-	req.Equal("hidden", panel.Builder.TablePanel.Styles[0].Type)
-	req.Equal("Time.*", panel.Builder.TablePanel.Styles[1].Pattern)
-	req.Equal("hidden", panel.Builder.TablePanel.Styles[1].Type)
-	req.Equal("/.*/", panel.Builder.TablePanel.Styles[2].Pattern)
-	req.Equal("string", panel.Builder.TablePanel.Styles[2].Type)
+	req.Len(panel.fieldConfig.Overrides, 2)
+	req.Equal("byRegexp", panel.fieldConfig.Overrides[0].Matcher.ID)
+	req.Equal("Time.*", panel.fieldConfig.Overrides[0].Matcher.Options)
+	req.Equal("custom.hidden", panel.fieldConfig.Overrides[0].Properties[0].ID)
+	req.Equal(true, panel.fieldConfig.Overrides[0].Properties[0].Value)
+	req.Equal("Duration.*", panel.fieldConfig.Overrides[1].Matcher.Options)
 }
 
 func TestDataCanBeTransformedInTimeSeriesToRows(t *testing.T) {
@@ -112,7 +198,7 @@ func TestDataCanBeTransformedInTimeSeriesToRows(t *testing.T) {
 	panel, err := New("", TimeSeriesToRows())
 
 	req.NoError(err)
-	req.Equal("timeseries_to_rows", panel.Builder.TablePanel.Transform)
+	req.Equal([]string{"seriesToRows"}, transformationIDs(t, panel))
 }
 
 func TestDataCanBeTransformedInTimeSeriesToColumns(t *testing.T) {
@@ -121,7 +207,7 @@ func TestDataCanBeTransformedInTimeSeriesToColumns(t *testing.T) {
 	panel, err := New("", TimeSeriesToColumns())
 
 	req.NoError(err)
-	req.Equal("timeseries_to_columns", panel.Builder.TablePanel.Transform)
+	req.Equal([]string{"seriesToColumns"}, transformationIDs(t, panel))
 }
 
 func TestDataCanBeTransformedAsJSON(t *testing.T) {
@@ -130,7 +216,8 @@ func TestDataCanBeTransformedAsJSON(t *testing.T) {
 	panel, err := New("", AsJSON())
 
 	req.NoError(err)
-	req.Equal("json", panel.Builder.TablePanel.Transform)
+	req.Empty(transformationIDs(t, panel))
+	req.Equal("json-view", panel.fieldConfig.Defaults.Custom.CellOptions["type"])
 }
 
 func TestDataCanBeTransformedAsTable(t *testing.T) {
@@ -139,16 +226,15 @@ func TestDataCanBeTransformedAsTable(t *testing.T) {
 	panel, err := New("", AsTable())
 
 	req.NoError(err)
-	req.Equal("table", panel.Builder.TablePanel.Transform)
+	req.Equal([]string{"merge"}, transformationIDs(t, panel))
 }
 
-func TestDataCanBeTransformedAsAnnotations(t *testing.T) {
+func TestAnnotationsAreOnlySupportedByLegacyTables(t *testing.T) {
 	req := require.New(t)
 
-	panel, err := New("", AsAnnotations())
+	_, err := New("", TimeSeriesToRows(), AsAnnotations())
 
-	req.NoError(err)
-	req.Equal("annotations", panel.Builder.TablePanel.Transform)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
 }
 
 func TestDataCanBeTransformedAsTimeSeriesAggregations(t *testing.T) {
@@ -159,13 +245,56 @@ func TestDataCanBeTransformedAsTimeSeriesAggregations(t *testing.T) {
 			Label: "Average",
 			Type:  AVG,
 		},
+		{
+			Label: "Current",
+			Type:  Current,
+		},
 	}))
 
 	req.NoError(err)
-	req.Equal("timeseries_aggregations", panel.Builder.TablePanel.Transform)
-	req.Len(panel.Builder.TablePanel.Columns, 1)
-	req.Equal("Average", panel.Builder.TablePanel.Columns[0].TextType)
-	req.Equal(string(AVG), panel.Builder.TablePanel.Columns[0].Value)
+	req.Equal([]string{"reduce", "organize"}, transformationIDs(t, panel))
+
+	reduce := panel.legacyTransformations[0].Options.(map[string]interface{})
+	req.Equal(transformation.SeriesToRows, reduce["mode"])
+	req.Equal([]transformation.Reducer{transformation.Mean, transformation.LastNotNull}, reduce["reducers"])
+}
+
+func TestInvalidTimeSeriesAggregationsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", AsTimeSeriesAggregations([]Aggregation{
+		{Label: "Median", Type: "median"},
+	}))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestLegacyTransformationsAreAppliedBeforeTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("",
+		Transformations(transformation.Limit(10)),
+		TimeSeriesToRows(),
+	)
+
+	req.NoError(err)
+	req.Equal([]string{"seriesToRows", "limit"}, transformationIDs(t, panel))
+}
+
+func TestTheLastLegacyTransformWins(t *testing.T) {
+	req := require.New(t)
+
+	jsonFirst, err := New("", AsJSON(), TimeSeriesToRows())
+	req.NoError(err)
+
+	jsonLast, err := New("", TimeSeriesToRows(), AsJSON())
+	req.NoError(err)
+
+	req.Equal([]string{"seriesToRows"}, transformationIDs(t, jsonFirst))
+	req.Equal("auto", jsonFirst.fieldConfig.Defaults.Custom.CellOptions["type"])
+	req.Empty(transformationIDs(t, jsonLast))
+	req.Equal("json-view", jsonLast.fieldConfig.Defaults.Custom.CellOptions["type"])
 }
 
 func TestTablePanelBackgroundCanBeTransparent(t *testing.T) {
@@ -186,3 +315,320 @@ func TestTablePanelDescriptionCanBeSet(t *testing.T) {
 	req.NotNil(panel.Builder.Description)
 	req.Equal("lala", *panel.Builder.Description)
 }
+
+func TestTablePanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("table", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
+
+func TestTablePanelCanHaveValueMappingsAndDataLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("",
+		ValueMappings(mapping.Value("0", mapping.Text("Down")), mapping.Value("1", mapping.Text("Up"))),
+		DataLinks(datalink.New("Details", "https://example.org/${__value.raw}")),
+	)
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		FieldConfig struct {
+			Defaults struct {
+				Mappings []struct {
+					Type string
+				}
+				Links []struct {
+					Title string
+					URL   string
+				}
+			}
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Len(decoded.FieldConfig.Defaults.Mappings, 2)
+	req.Equal("value", decoded.FieldConfig.Defaults.Mappings[0].Type)
+	req.Len(decoded.FieldConfig.Defaults.Links, 1)
+	req.Equal("https://example.org/${__value.raw}", decoded.FieldConfig.Defaults.Links[0].URL)
+}
+
+func TestTablePanelUnitCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Unit("bytes"))
+
+	req.NoError(err)
+	req.Equal("bytes", panel.fieldConfig.Defaults.Unit)
+}
+
+func TestTablePanelDecimalsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Decimals(2))
+
+	req.NoError(err)
+	req.Equal(2, *panel.fieldConfig.Defaults.Decimals)
+}
+
+func TestInvalidTablePanelDecimalsAreRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", Decimals(-1))
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestTablePanelColumnWidthCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", ColumnWidth(150), MinColumnWidth(50))
+
+	req.NoError(err)
+	req.Equal(150, panel.fieldConfig.Defaults.Custom.Width)
+	req.Equal(50, panel.fieldConfig.Defaults.Custom.MinWidth)
+}
+
+func TestInvalidTablePanelColumnWidthIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", ColumnWidth(0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", MinColumnWidth(-10))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func TestTablePanelCanBeFilterable(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Filterable())
+
+	req.NoError(err)
+	req.True(panel.fieldConfig.Defaults.Custom.Filterable)
+}
+
+func TestTablePanelCellsAlignmentCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Align(fields.AlignRight))
+
+	req.NoError(err)
+	req.Equal(fields.AlignRight, panel.fieldConfig.Defaults.Custom.Align)
+}
+
+func TestTablePanelCellDisplayModeCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", CellDisplay(fields.LCDGauge))
+
+	req.NoError(err)
+	req.Equal("gauge", panel.fieldConfig.Defaults.Custom.CellOptions["type"])
+	req.Equal("lcd", panel.fieldConfig.Defaults.Custom.CellOptions["mode"])
+}
+
+func TestTablePanelHeaderCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", HideHeader())
+
+	req.NoError(err)
+	req.False(panel.options.ShowHeader)
+}
+
+func TestTablePanelCanHaveAFooter(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Footer(transformation.Mean, transformation.Max))
+
+	req.NoError(err)
+	req.True(panel.options.Footer.Show)
+	req.Equal([]transformation.Reducer{transformation.Mean, transformation.Max}, panel.options.Footer.Reducer)
+}
+
+func TestTablePanelCanBePaginated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Pagination())
+
+	req.NoError(err)
+	req.True(panel.options.Footer.EnablePagination)
+}
+
+func TestTablePanelRowsCanBeSorted(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", SortBy("Value", true))
+
+	req.NoError(err)
+	req.Len(panel.options.SortBy, 1)
+	req.Equal("Value", panel.options.SortBy[0].DisplayName)
+	req.True(panel.options.SortBy[0].Desc)
+}
+
+func TestTablePanelFieldsCanBeOverridden(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", FieldOverride(
+		fields.ByName("Status"),
+		fields.CellDisplay(fields.ColoredBackground),
+		fields.Width(80),
+	))
+
+	req.NoError(err)
+	req.Len(panel.fieldConfig.Overrides, 1)
+	req.Equal("byName", panel.fieldConfig.Overrides[0].Matcher.ID)
+	req.Equal("Status", panel.fieldConfig.Overrides[0].Matcher.Options)
+	req.Len(panel.fieldConfig.Overrides[0].Properties, 2)
+}
+
+func TestTablePanelIsAModernTable(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("Table", WithPrometheusTarget("go_threads"), Filterable())
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := map[string]interface{}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("table", decoded["type"])
+	req.NotContains(decoded, "styles")
+	req.NotContains(decoded, "transform")
+	req.Contains(decoded, "options")
+	req.Contains(decoded, "fieldConfig")
+	req.Len(decoded["targets"], 1)
+}
+
+func TestLegacyTablePanelsCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := NewLegacy("Table panel", WithPrometheusTarget("go_threads"))
+
+	req.NoError(err)
+	req.False(panel.Builder.IsNew)
+	req.Equal("Table panel", panel.Builder.Title)
+	req.Equal(sdk.TableType, panel.Builder.OfType)
+	req.NotNil(panel.Builder.TablePanel)
+	req.Equal("timeseries_to_rows", panel.Builder.TablePanel.Transform)
+	req.Len(panel.Builder.TablePanel.Targets, 1)
+}
+
+func TestLegacyTablePanelsCanHideColumns(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := NewLegacy("", HideColumn("Time.*"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.TablePanel.Styles, 2)
+	req.Equal("Time.*", panel.Builder.TablePanel.Styles[0].Pattern)
+	req.Equal("hidden", panel.Builder.TablePanel.Styles[0].Type)
+}
+
+func TestLegacyTablePanelsSupportEveryTransform(t *testing.T) {
+	testCases := []struct {
+		option    Option
+		transform string
+	}{
+		{option: TimeSeriesToRows(), transform: "timeseries_to_rows"},
+		{option: TimeSeriesToColumns(), transform: "timeseries_to_columns"},
+		{option: AsJSON(), transform: "json"},
+		{option: AsTable(), transform: "table"},
+		{option: AsAnnotations(), transform: "annotations"},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.transform, func(t *testing.T) {
+			req := require.New(t)
+
+			panel, err := NewLegacy("", AsJSON(), tc.option)
+
+			req.NoError(err)
+			req.Equal(tc.transform, panel.Builder.TablePanel.Transform)
+		})
+	}
+}
+
+func TestLegacyTablePanelsSupportTimeSeriesAggregations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := NewLegacy("", AsTimeSeriesAggregations([]Aggregation{
+		{Label: "Average", Type: AVG},
+		{Label: "Current", Type: Current},
+	}))
+
+	req.NoError(err)
+	req.Equal("timeseries_aggregations", panel.Builder.TablePanel.Transform)
+	req.Equal([]sdk.Column{
+		{TextType: "Average", Value: "avg"},
+		{TextType: "Current", Value: "current"},
+	}, panel.Builder.TablePanel.Columns)
+}
+
+func TestLegacyTablePanelsCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := NewLegacy("", Transformations(transformation.Limit(10)))
+
+	req.NoError(err)
+	req.Equal(sdk.CustomType, panel.Builder.OfType)
+	req.NotNil(panel.Builder.TablePanel)
+	req.Equal("timeseries_to_rows", (*panel.Builder.CustomPanel)["transform"])
+	req.Len((*panel.Builder.CustomPanel)["transformations"], 1)
+}
+
+func TestLegacyTablePanelsRejectModernOptions(t *testing.T) {
+	req := require.New(t)
+
+	_, err := NewLegacy("", Filterable())
+
+	req.Error(err)
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
+func targets(panel *Table) []sdk.Target {
+	return (*panel.Builder.CustomPanel)["targets"].([]sdk.Target)
+}
+
+func transformationIDs(t *testing.T, panel *Table) []string {
+	t.Helper()
+
+	transformations, ok := (*panel.Builder.CustomPanel)["transformations"].([]transformation.Transformation)
+	if !ok {
+		return nil
+	}
+
+	ids := make([]string, 0, len(transformations))
+	for _, transform := range transformations {
+		ids = append(ids, transform.ID)
+	}
+
+	return ids
+}
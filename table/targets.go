@@ -0,0 +1,116 @@
+package table
+
+import (
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
+	"github.com/K-Phoen/grabana/target/graphite"
+	"github.com/K-Phoen/grabana/target/influxdb"
+	"github.com/K-Phoen/grabana/target/loki"
+	"github.com/K-Phoen/grabana/target/prometheus"
+	"github.com/K-Phoen/grabana/target/sql"
+	"github.com/K-Phoen/grabana/target/stackdriver"
+	"github.com/K-Phoen/sdk"
+)
+
+// WithPrometheusTarget adds a prometheus query to the table.
+func WithPrometheusTarget(query string, options ...prometheus.Option) Option {
+	target := prometheus.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(&sdk.Target{
+			Datasource:     target.Datasource.Internal(),
+			RefID:          target.Ref,
+			Hide:           target.Hidden,
+			Expr:           target.Expr,
+			IntervalFactor: target.IntervalFactor,
+			Interval:       target.Interval,
+			Step:           target.Step,
+			LegendFormat:   target.LegendFormat,
+			Instant:        target.Instant,
+			Format:         target.Format,
+		})
+
+		return nil
+	}
+}
+
+// WithGraphiteTarget adds a Graphite target to the table.
+func WithGraphiteTarget(query string, options ...graphite.Option) Option {
+	target := graphite.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithInfluxDBTarget adds an InfluxDB target to the table.
+func WithInfluxDBTarget(query string, options ...influxdb.Option) Option {
+	target := influxdb.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithCloudWatchTarget adds a CloudWatch metric query to the table.
+func WithCloudWatchTarget(namespace string, metricName string, options ...cloudwatch.Option) Option {
+	target := cloudwatch.New(namespace, metricName, options...)
+
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithElasticsearchTarget adds an Elasticsearch query to the table.
+func WithElasticsearchTarget(query string, options ...elasticsearch.Option) Option {
+	target := elasticsearch.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithSQLTarget adds a SQL query to the table.
+func WithSQLTarget(query string, options ...sql.Option) Option {
+	target := sql.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithStackdriverTarget adds a stackdriver query to the table.
+func WithStackdriverTarget(target *stackdriver.Stackdriver) Option {
+	return func(table *Table) error {
+		table.addTarget(target.Builder)
+
+		return nil
+	}
+}
+
+// WithLokiTarget adds a loki query to the table.
+func WithLokiTarget(query string, options ...loki.Option) Option {
+	target := loki.New(query, options...)
+
+	return func(table *Table) error {
+		table.addTarget(&sdk.Target{
+			Datasource:   target.Datasource.Internal(),
+			RefID:        target.Ref,
+			Hide:         target.Hidden,
+			Expr:         target.Expr,
+			LegendFormat: target.LegendFormat,
+		})
+
+		return nil
+	}
+}
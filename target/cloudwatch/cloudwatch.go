@@ -0,0 +1,116 @@
+package cloudwatch
+
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure a cloudwatch query.
+type Option func(target *CloudWatch)
+
+// Statistic represents the aggregation applied to the data points of a
+// metric, within each period.
+type Statistic string
+
+const (
+	Average     Statistic = "Average"
+	Sum         Statistic = "Sum"
+	Minimum     Statistic = "Minimum"
+	Maximum     Statistic = "Maximum"
+	SampleCount Statistic = "SampleCount"
+)
+
+// Percentile returns the given extended statistic, like p99 or p99.9.
+func Percentile(percentile string) Statistic {
+	return Statistic("p" + percentile)
+}
+
+// CloudWatch represents a cloudwatch metric query.
+type CloudWatch struct {
+	Builder *sdk.Target
+}
+
+// New creates a new CloudWatch query, for the given metric.
+// Example: New("AWS/EC2", "CPUUtilization").
+func New(namespace string, metricName string, options ...Option) *CloudWatch {
+	cloudwatch := &CloudWatch{
+		Builder: &sdk.Target{
+			Namespace:  namespace,
+			MetricName: metricName,
+			Region:     "default",
+			Statistics: []string{string(Average)},
+			Dimensions: map[string]string{},
+		},
+	}
+
+	for _, opt := range options {
+		opt(cloudwatch)
+	}
+
+	return cloudwatch
+}
+
+// Region sets the AWS region to query. The default region of the datasource
+// is used otherwise.
+func Region(region string) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Region = region
+	}
+}
+
+// Dimensions restricts the query to the metrics having the given dimensions.
+// A "*" value matches any value of a dimension.
+func Dimensions(dimensions map[string]string) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Dimensions = dimensions
+	}
+}
+
+// Statistics sets the statistics to compute. Defaults to Average.
+func Statistics(statistics ...Statistic) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Statistics = make([]string, 0, len(statistics))
+
+		for _, statistic := range statistics {
+			cloudwatch.Builder.Statistics = append(cloudwatch.Builder.Statistics, string(statistic))
+		}
+	}
+}
+
+// Period sets the minimum interval between points, in seconds or as a
+// duration (ex: "300", "5m"). Grafana computes it if left empty.
+func Period(period string) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Period = period
+	}
+}
+
+// Legend sets the legend format.
+func Legend(legend string) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Alias = legend
+	}
+}
+
+// Ref sets the reference ID for this query.
+func Ref(ref string) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.RefID = ref
+	}
+}
+
+// Hide the query. Grafana does not send hidden queries to the data source,
+// but they can still be referenced in alerts.
+func Hide() Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Hide = true
+	}
+}
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(cloudwatch *CloudWatch) {
+		cloudwatch.Builder.Datasource = ref.Internal()
+	}
+}
@@ -0,0 +1,84 @@
+package cloudwatch_test
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/target/cloudwatch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("AWS/EC2", "CPUUtilization")
+
+	req.Equal("AWS/EC2", target.Builder.Namespace)
+	req.Equal("CPUUtilization", target.Builder.MetricName)
+	req.Equal("default", target.Builder.Region)
+	req.Equal([]string{"Average"}, target.Builder.Statistics)
+}
+
+func TestRegionCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Region("eu-west-1"))
+
+	req.Equal("eu-west-1", target.Builder.Region)
+}
+
+func TestDimensionsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Dimensions(map[string]string{"InstanceId": "*"}))
+
+	req.Equal(map[string]string{"InstanceId": "*"}, target.Builder.Dimensions)
+}
+
+func TestStatisticsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Statistics(cloudwatch.Maximum, cloudwatch.Percentile("99")))
+
+	req.Equal([]string{"Maximum", "p99"}, target.Builder.Statistics)
+}
+
+func TestPeriodCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Period("300"))
+
+	req.Equal("300", target.Builder.Period)
+}
+
+func TestLegendCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Legend("{{InstanceId}}"))
+
+	req.Equal("{{InstanceId}}", target.Builder.Alias)
+}
+
+func TestRefCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Ref("A"))
+
+	req.Equal("A", target.Builder.RefID)
+}
+
+func TestTargetCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.Hide())
+
+	req.True(target.Builder.Hide)
+}
+
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := cloudwatch.New("", "", cloudwatch.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Builder.Datasource.UID)
+}
@@ -0,0 +1,211 @@
+package elasticsearch
+
+import (
+	"strconv"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure an elasticsearch query.
+type Option func(target *Elasticsearch)
+
+// Metric represents a metric aggregation, computed for each bucket.
+type Metric struct {
+	Type  string
+	Field string
+}
+
+// Count counts the documents in each bucket.
+func Count() Metric {
+	return Metric{Type: "count"}
+}
+
+// Average computes the average of the given field.
+func Average(field string) Metric {
+	return Metric{Type: "avg", Field: field}
+}
+
+// Sum computes the sum of the given field.
+func Sum(field string) Metric {
+	return Metric{Type: "sum", Field: field}
+}
+
+// Min computes the minimum value of the given field.
+func Min(field string) Metric {
+	return Metric{Type: "min", Field: field}
+}
+
+// Max computes the maximum value of the given field.
+func Max(field string) Metric {
+	return Metric{Type: "max", Field: field}
+}
+
+// UniqueCount computes the approximate count of distinct values of the given
+// field.
+func UniqueCount(field string) Metric {
+	return Metric{Type: "cardinality", Field: field}
+}
+
+// BucketAggregation represents a bucket aggregation, grouping documents
+// together.
+type BucketAggregation struct {
+	Type        string
+	Field       string
+	Interval    string
+	Size        string
+	Order       string
+	OrderBy     string
+	MinDocCount string
+}
+
+// DateHistogram groups documents by time intervals (ex: "1m", "auto").
+func DateHistogram(field string, interval string) BucketAggregation {
+	return BucketAggregation{Type: "date_histogram", Field: field, Interval: interval, MinDocCount: "0"}
+}
+
+// Histogram groups documents by numerical intervals.
+func Histogram(field string, interval string) BucketAggregation {
+	return BucketAggregation{Type: "histogram", Field: field, Interval: interval, MinDocCount: "0"}
+}
+
+// Terms groups documents by the values of the given field, keeping the size
+// most frequent ones.
+func Terms(field string, size int) BucketAggregation {
+	return BucketAggregation{
+		Type:        "terms",
+		Field:       field,
+		Size:        strconv.Itoa(size),
+		Order:       "desc",
+		OrderBy:     "_count",
+		MinDocCount: "1",
+	}
+}
+
+// Elasticsearch represents an elasticsearch query.
+type Elasticsearch struct {
+	Builder *sdk.Target
+
+	metrics []Metric
+	groupBy []BucketAggregation
+}
+
+// New creates a new Elasticsearch query, using the Lucene syntax.
+// Documents are counted over time unless configured otherwise.
+func New(query string, options ...Option) *Elasticsearch {
+	elasticsearch := &Elasticsearch{
+		Builder: &sdk.Target{
+			Query:     query,
+			TimeField: "@timestamp",
+		},
+	}
+
+	for _, opt := range options {
+		opt(elasticsearch)
+	}
+
+	if len(elasticsearch.metrics) == 0 {
+		elasticsearch.metrics = []Metric{Count()}
+	}
+	if len(elasticsearch.groupBy) == 0 {
+		elasticsearch.groupBy = []BucketAggregation{DateHistogram(elasticsearch.Builder.TimeField, "auto")}
+	}
+
+	elasticsearch.buildAggregations()
+
+	return elasticsearch
+}
+
+// buildAggregations fills the target with the metrics and bucket
+// aggregations. Their IDs are shared and must be unique within the query.
+func (elasticsearch *Elasticsearch) buildAggregations() {
+	id := 0
+	nextID := func() string {
+		id++
+		return strconv.Itoa(id)
+	}
+
+	for _, metric := range elasticsearch.metrics {
+		elasticsearch.Builder.Metrics = append(elasticsearch.Builder.Metrics, struct {
+			ID    string `json:"id"`
+			Field string `json:"field"`
+			Type  string `json:"type"`
+		}{ID: nextID(), Field: metric.Field, Type: metric.Type})
+	}
+
+	for _, bucket := range elasticsearch.groupBy {
+		aggregation := struct {
+			ID       string `json:"id"`
+			Field    string `json:"field"`
+			Type     string `json:"type"`
+			Settings struct {
+				Interval    string      `json:"interval,omitempty"`
+				MinDocCount interface{} `json:"min_doc_count"`
+				Order       string      `json:"order,omitempty"`
+				OrderBy     string      `json:"orderBy,omitempty"`
+				Size        string      `json:"size,omitempty"`
+			} `json:"settings"`
+		}{ID: nextID(), Field: bucket.Field, Type: bucket.Type}
+
+		aggregation.Settings.Interval = bucket.Interval
+		aggregation.Settings.MinDocCount = bucket.MinDocCount
+		aggregation.Settings.Order = bucket.Order
+		aggregation.Settings.OrderBy = bucket.OrderBy
+		aggregation.Settings.Size = bucket.Size
+
+		elasticsearch.Builder.BucketAggs = append(elasticsearch.Builder.BucketAggs, aggregation)
+	}
+}
+
+// Metrics sets the metrics computed for each bucket. Defaults to Count().
+func Metrics(metrics ...Metric) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.metrics = metrics
+	}
+}
+
+// GroupBy sets the bucket aggregations, applied in order. Defaults to a
+// date histogram on the time field.
+func GroupBy(aggregations ...BucketAggregation) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.groupBy = aggregations
+	}
+}
+
+// TimeField sets the field used to filter documents on the dashboard's time
+// range. Defaults to "@timestamp".
+func TimeField(field string) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.Builder.TimeField = field
+	}
+}
+
+// Legend sets the legend format.
+func Legend(legend string) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.Builder.Alias = legend
+	}
+}
+
+// Ref sets the reference ID for this query.
+func Ref(ref string) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.Builder.RefID = ref
+	}
+}
+
+// Hide the query. Grafana does not send hidden queries to the data source,
+// but they can still be referenced in alerts.
+func Hide() Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.Builder.Hide = true
+	}
+}
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(elasticsearch *Elasticsearch) {
+		elasticsearch.Builder.Datasource = ref.Internal()
+	}
+}
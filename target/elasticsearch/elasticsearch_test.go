@@ -0,0 +1,98 @@
+package elasticsearch_test
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/target/elasticsearch"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("level:error")
+
+	req.Equal("level:error", target.Builder.Query)
+	req.Equal("@timestamp", target.Builder.TimeField)
+}
+
+func TestDocumentsAreCountedOverTimeByDefault(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.TimeField("time"))
+
+	req.Len(target.Builder.Metrics, 1)
+	req.Equal("count", target.Builder.Metrics[0].Type)
+	req.Equal("1", target.Builder.Metrics[0].ID)
+
+	req.Len(target.Builder.BucketAggs, 1)
+	req.Equal("date_histogram", target.Builder.BucketAggs[0].Type)
+	req.Equal("time", target.Builder.BucketAggs[0].Field)
+	req.Equal("auto", target.Builder.BucketAggs[0].Settings.Interval)
+	req.Equal("2", target.Builder.BucketAggs[0].ID)
+}
+
+func TestMetricsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.Metrics(
+		elasticsearch.Average("duration"),
+		elasticsearch.UniqueCount("user"),
+	))
+
+	req.Len(target.Builder.Metrics, 2)
+	req.Equal("avg", target.Builder.Metrics[0].Type)
+	req.Equal("duration", target.Builder.Metrics[0].Field)
+	req.Equal("cardinality", target.Builder.Metrics[1].Type)
+	req.Equal("2", target.Builder.Metrics[1].ID)
+	req.Equal("3", target.Builder.BucketAggs[0].ID)
+}
+
+func TestBucketAggregationsCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.GroupBy(
+		elasticsearch.Terms("host", 5),
+		elasticsearch.DateHistogram("@timestamp", "1m"),
+	))
+
+	req.Len(target.Builder.BucketAggs, 2)
+	req.Equal("terms", target.Builder.BucketAggs[0].Type)
+	req.Equal("host", target.Builder.BucketAggs[0].Field)
+	req.Equal("5", target.Builder.BucketAggs[0].Settings.Size)
+	req.Equal("desc", target.Builder.BucketAggs[0].Settings.Order)
+	req.Equal("1m", target.Builder.BucketAggs[1].Settings.Interval)
+}
+
+func TestLegendCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.Legend("{{term host}}"))
+
+	req.Equal("{{term host}}", target.Builder.Alias)
+}
+
+func TestRefCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.Ref("A"))
+
+	req.Equal("A", target.Builder.RefID)
+}
+
+func TestTargetCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.Hide())
+
+	req.True(target.Builder.Hide)
+}
+
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := elasticsearch.New("", elasticsearch.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Builder.Datasource.UID)
+}
@@ -1,6 +1,9 @@
 package graphite
 
-import "github.com/K-Phoen/sdk"
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
 
 // Option represents an option that can be used to configure a graphite query.
 type Option func(target *Graphite)
@@ -39,3 +42,11 @@ func Hide() Option {
 		graphite.Builder.Hide = true
 	}
 }
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(graphite *Graphite) {
+		graphite.Builder.Datasource = ref.Internal()
+	}
+}
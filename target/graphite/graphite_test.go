@@ -3,6 +3,7 @@ package graphite_test
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/target/graphite"
 	"github.com/stretchr/testify/require"
 )
@@ -30,3 +31,11 @@ func TestTargetCanBeHidden(t *testing.T) {
 
 	req.True(target.Builder.Hide)
 }
+
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := graphite.New("", graphite.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Builder.Datasource.UID)
+}
@@ -1,6 +1,9 @@
 package influxdb
 
-import "github.com/K-Phoen/sdk"
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
 
 // Option represents an option that can be used to configure a influxdb query.
 type Option func(target *InfluxDB)
@@ -38,3 +41,11 @@ func Hide() Option {
 		influxdb.Builder.Hide = true
 	}
 }
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(influxdb *InfluxDB) {
+		influxdb.Builder.Datasource = ref.Internal()
+	}
+}
@@ -1,10 +1,16 @@
 package loki
 
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
 // Option represents an option that can be used to configure a loki query.
 type Option func(target *Loki)
 
 // Loki represents a loki query.
 type Loki struct {
+	Datasource   datasource.Ref
 	Ref          string
 	Hidden       bool
 	Expr         string
@@ -24,6 +30,17 @@ func New(query string, options ...Option) *Loki {
 	return loki
 }
 
+// Internal returns the query as understood by the sdk.
+func (loki *Loki) Internal() *sdk.Target {
+	return &sdk.Target{
+		Datasource:   loki.Datasource.Internal(),
+		RefID:        loki.Ref,
+		Hide:         loki.Hidden,
+		Expr:         loki.Expr,
+		LegendFormat: loki.LegendFormat,
+	}
+}
+
 // Legend sets the legend format.
 func Legend(legend string) Option {
 	return func(loki *Loki) {
@@ -45,3 +62,11 @@ func Hide() Option {
 		loki.Hidden = true
 	}
 }
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(loki *Loki) {
+		loki.Datasource = ref
+	}
+}
@@ -3,6 +3,7 @@ package loki
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,3 +41,23 @@ func TestTargetCanBeHidden(t *testing.T) {
 
 	req.True(target.Hidden)
 }
+
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := New("", DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Datasource.UID)
+}
+
+func TestTargetCanBeConvertedForTheSDK(t *testing.T) {
+	req := require.New(t)
+
+	target := New(`{app="api"}`, Ref("A"), Hide())
+
+	internal := target.Internal()
+
+	req.Equal("A", internal.RefID)
+	req.Equal(`{app="api"}`, internal.Expr)
+	req.True(internal.Hide)
+}
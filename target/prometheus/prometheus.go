@@ -1,5 +1,10 @@
 package prometheus
 
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
 // FormatMode switches between Table, Time series, or Heatmap. Table will only work
 // in the Table panel. Heatmap is suitable for displaying metrics of the
 // Histogram type on a Heatmap panel. Under the hood, it converts cumulative
@@ -17,6 +22,7 @@ type Option func(target *Prometheus)
 
 // Prometheus represents a prometheus query.
 type Prometheus struct {
+	Datasource     datasource.Ref
 	Ref            string
 	Hidden         bool
 	Expr           string
@@ -42,6 +48,22 @@ func New(query string, options ...Option) *Prometheus {
 	return prometheus
 }
 
+// Internal returns the query as understood by the sdk.
+func (prometheus *Prometheus) Internal() *sdk.Target {
+	return &sdk.Target{
+		Datasource:     prometheus.Datasource.Internal(),
+		RefID:          prometheus.Ref,
+		Hide:           prometheus.Hidden,
+		Expr:           prometheus.Expr,
+		IntervalFactor: prometheus.IntervalFactor,
+		Interval:       prometheus.Interval,
+		Step:           prometheus.Step,
+		LegendFormat:   prometheus.LegendFormat,
+		Instant:        prometheus.Instant,
+		Format:         prometheus.Format,
+	}
+}
+
 // Legend sets the legend format.
 func Legend(legend string) Option {
 	return func(prometheus *Prometheus) {
@@ -64,6 +86,14 @@ func Hide() Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(prometheus *Prometheus) {
+		prometheus.Datasource = ref
+	}
+}
+
 // Instant marks the query as "instant, which means Prometheus will only return the latest scrapped value.
 func Instant() Option {
 	return func(prometheus *Prometheus) {
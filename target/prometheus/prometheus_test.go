@@ -3,6 +3,7 @@ package prometheus_test
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/target/prometheus"
 	"github.com/stretchr/testify/require"
 )
@@ -43,6 +44,14 @@ func TestTargetCanBeHidden(t *testing.T) {
 	req.True(target.Hidden)
 }
 
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := prometheus.New("", prometheus.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Datasource.UID)
+}
+
 func TestTargetCanBeSetAsInstant(t *testing.T) {
 	req := require.New(t)
 
@@ -66,3 +75,17 @@ func TestIntervalFactorCanBeConfigured(t *testing.T) {
 
 	req.Equal(1, target.IntervalFactor)
 }
+
+func TestTargetCanBeConvertedForTheSDK(t *testing.T) {
+	req := require.New(t)
+
+	target := prometheus.New("up", prometheus.Ref("A"), prometheus.Legend("{{ instance }}"), prometheus.Instant())
+
+	internal := target.Internal()
+
+	req.Equal("A", internal.RefID)
+	req.Equal("up", internal.Expr)
+	req.Equal("{{ instance }}", internal.LegendFormat)
+	req.True(internal.Instant)
+	req.Nil(internal.Datasource)
+}
@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+// FormatMode switches between Table and Time series. Time series queries
+// must return a column named "time", or the one set with TimeColumn().
+type FormatMode string
+
+const (
+	FormatTable      FormatMode = "table"
+	FormatTimeSeries FormatMode = "time_series"
+)
+
+// Option represents an option that can be used to configure a SQL query.
+type Option func(target *SQL)
+
+// SQL represents a raw SQL query, as understood by the PostgreSQL, MySQL and
+// Microsoft SQL Server datasources.
+type SQL struct {
+	Builder *sdk.Target
+}
+
+// New creates a new SQL query.
+func New(query string, options ...Option) *SQL {
+	sql := &SQL{
+		Builder: &sdk.Target{
+			RawSql:   query,
+			RawQuery: true,
+			Format:   string(FormatTimeSeries),
+		},
+	}
+
+	for _, opt := range options {
+		opt(sql)
+	}
+
+	return sql
+}
+
+// Format indicates how the results should be interpreted.
+func Format(format FormatMode) Option {
+	return func(sql *SQL) {
+		sql.Builder.Format = string(format)
+	}
+}
+
+// TimeColumn sets the name of the column holding the time of each row.
+func TimeColumn(column string) Option {
+	return func(sql *SQL) {
+		sql.Builder.TimeColumn = column
+	}
+}
+
+// Ref sets the reference ID for this query.
+func Ref(ref string) Option {
+	return func(sql *SQL) {
+		sql.Builder.RefID = ref
+	}
+}
+
+// Hide the query. Grafana does not send hidden queries to the data source,
+// but they can still be referenced in alerts.
+func Hide() Option {
+	return func(sql *SQL) {
+		sql.Builder.Hide = true
+	}
+}
+
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(sql *SQL) {
+		sql.Builder.Datasource = ref.Internal()
+	}
+}
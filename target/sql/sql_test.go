@@ -0,0 +1,59 @@
+package sql_test
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/grabana/target/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueriesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("SELECT $__time(created_at), count(*) FROM orders GROUP BY 1")
+
+	req.Equal("SELECT $__time(created_at), count(*) FROM orders GROUP BY 1", target.Builder.RawSql)
+	req.True(target.Builder.RawQuery)
+	req.Equal("time_series", target.Builder.Format)
+}
+
+func TestFormatCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("", sql.Format(sql.FormatTable))
+
+	req.Equal("table", target.Builder.Format)
+}
+
+func TestTimeColumnCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("", sql.TimeColumn("created_at"))
+
+	req.Equal("created_at", target.Builder.TimeColumn)
+}
+
+func TestRefCanBeConfigured(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("", sql.Ref("A"))
+
+	req.Equal("A", target.Builder.RefID)
+}
+
+func TestTargetCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("", sql.Hide())
+
+	req.True(target.Builder.Hide)
+}
+
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := sql.New("", sql.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Builder.Datasource.UID)
+}
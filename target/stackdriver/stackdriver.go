@@ -1,6 +1,9 @@
 package stackdriver
 
-import "github.com/K-Phoen/sdk"
+import (
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
 
 // Option represents an option that can be used to configure a stackdriver query.
 type Option func(target *Stackdriver)
@@ -123,6 +126,14 @@ func Hide() Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the query, referenced by
+// UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(stackdriver *Stackdriver) {
+		stackdriver.Builder.Datasource = ref.Internal()
+	}
+}
+
 // Legend sets the legend format.
 // See https://grafana.com/docs/grafana/latest/features/datasources/stackdriver/#alias-patterns for more
 // information on allowed patterns.
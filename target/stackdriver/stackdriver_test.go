@@ -3,6 +3,7 @@ package stackdriver_test
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/target/stackdriver"
 	"github.com/stretchr/testify/require"
 )
@@ -68,6 +69,14 @@ func TestTargetCanBeHidden(t *testing.T) {
 	req.True(target.Builder.Hide)
 }
 
+func TestDatasourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	target := stackdriver.Delta("", stackdriver.DataSourceRef(datasource.ByUID("ds-uid")))
+
+	req.Equal("ds-uid", target.Builder.Datasource.UID)
+}
+
 func TestAggregationCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 	reducers := []stackdriver.Reducer{
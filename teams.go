@@ -0,0 +1,315 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrTeamNotFound is returned when the given team can not be found.
+var ErrTeamNotFound = errors.New("team not found")
+
+// ErrTeamMemberNotFound is returned when the given user is not a member of
+// the team.
+var ErrTeamMemberNotFound = errors.New("team member not found")
+
+const teamsPerPage = 100
+
+// Team represents a team of users.
+type Team struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Preferences represents the preferences of a team.
+// Empty values mean that the defaults of the organization apply.
+type Preferences struct {
+	// Theme can be "light" or "dark".
+	Theme            string `json:"theme"`
+	HomeDashboardUID string `json:"homeDashboardUID"`
+	// Timezone can be "utc", "browser" or an IANA time zone.
+	Timezone string `json:"timezone"`
+	// WeekStart can be "saturday", "sunday" or "monday".
+	WeekStart string `json:"weekStart"`
+}
+
+// CreateTeam creates a new team.
+func (client *Client) CreateTeam(ctx context.Context, name string, email string) (*Team, error) {
+	buf, err := json.Marshal(Team{Name: name, Email: email})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, "/api/teams", buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var response struct {
+		TeamID uint `json:"teamId"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Team{ID: response.TeamID, Name: name, Email: email}, nil
+}
+
+// UpdateTeam updates the name and email of a team.
+func (client *Client) UpdateTeam(ctx context.Context, team Team) error {
+	buf, err := json.Marshal(team)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/api/teams/%d", team.ID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	return client.teamResponseError(resp)
+}
+
+// DeleteTeam deletes a team.
+func (client *Client) DeleteTeam(ctx context.Context, teamID uint) error {
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/teams/%d", teamID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	return client.teamResponseError(resp)
+}
+
+// Teams lists all the teams.
+func (client *Client) Teams(ctx context.Context) ([]Team, error) {
+	var teams []Team
+
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("perpage", fmt.Sprint(teamsPerPage))
+		params.Set("page", fmt.Sprint(page))
+
+		pageTeams, totalCount, err := client.searchTeams(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, pageTeams...)
+
+		if len(pageTeams) == 0 || len(teams) >= totalCount {
+			return teams, nil
+		}
+	}
+}
+
+// GetTeamByID finds a team, given its ID.
+func (client *Client) GetTeamByID(ctx context.Context, teamID uint) (*Team, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/teams/%d", teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := client.teamResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	team := &Team{}
+	if err := decodeJSON(resp.Body, team); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// GetTeamByName finds a team, given its name.
+func (client *Client) GetTeamByName(ctx context.Context, name string) (*Team, error) {
+	params := url.Values{}
+	params.Set("name", name)
+
+	teams, _, err := client.searchTeams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range teams {
+		if teams[i].Name == name {
+			return &teams[i], nil
+		}
+	}
+
+	return nil, ErrTeamNotFound
+}
+
+func (client *Client) searchTeams(ctx context.Context, params url.Values) ([]Team, int, error) {
+	resp, err := client.get(ctx, "/api/teams/search?"+params.Encode())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, client.httpError(resp)
+	}
+
+	var response struct {
+		TotalCount int    `json:"totalCount"`
+		Teams      []Team `json:"teams"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, 0, err
+	}
+
+	return response.Teams, response.TotalCount, nil
+}
+
+// TeamMembers lists the members of a team.
+func (client *Client) TeamMembers(ctx context.Context, teamID uint) ([]User, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/teams/%d/members", teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := client.teamResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	var members []struct {
+		UserID uint   `json:"userId"`
+		Login  string `json:"login"`
+		Email  string `json:"email"`
+		Name   string `json:"name"`
+	}
+	if err := decodeJSON(resp.Body, &members); err != nil {
+		return nil, err
+	}
+
+	users := make([]User, 0, len(members))
+	for _, member := range members {
+		users = append(users, User{
+			ID:    member.UserID,
+			Login: member.Login,
+			Email: member.Email,
+			Name:  member.Name,
+		})
+	}
+
+	return users, nil
+}
+
+// AddTeamMember adds a user to a team, given its login or email.
+func (client *Client) AddTeamMember(ctx context.Context, teamID uint, loginOrEmail string) error {
+	user, err := client.GetUserByLoginOrEmail(ctx, loginOrEmail)
+	if err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(struct {
+		UserID uint `json:"userId"`
+	}{
+		UserID: user.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/teams/%d/members", teamID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	return client.teamResponseError(resp)
+}
+
+// RemoveTeamMember removes a user from a team, given its login or email.
+func (client *Client) RemoveTeamMember(ctx context.Context, teamID uint, loginOrEmail string) error {
+	user, err := client.GetUserByLoginOrEmail(ctx, loginOrEmail)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.delete(ctx, fmt.Sprintf("/api/teams/%d/members/%d", teamID, user.ID))
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamMemberNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
+
+// TeamPreferences returns the preferences of a team.
+func (client *Client) TeamPreferences(ctx context.Context, teamID uint) (*Preferences, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/teams/%d/preferences", teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := client.teamResponseError(resp); err != nil {
+		return nil, err
+	}
+
+	preferences := &Preferences{}
+	if err := decodeJSON(resp.Body, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// SetTeamPreferences replaces the preferences of a team.
+func (client *Client) SetTeamPreferences(ctx context.Context, teamID uint, preferences Preferences) error {
+	buf, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPut, fmt.Sprintf("/api/teams/%d/preferences", teamID), buf)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	return client.teamResponseError(resp)
+}
+
+func (client *Client) teamResponseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrTeamNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return client.httpError(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,377 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamByName(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/search", r.URL.Path)
+		req.Equal("SRE", r.URL.Query().Get("name"))
+
+		_, _ = fmt.Fprintln(w, `{"totalCount": 1, "teams": [{"id": 3, "name": "SRE", "email": "sre@example.com"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	team, err := client.GetTeamByName(context.TODO(), "SRE")
+
+	req.NoError(err)
+	req.Equal(uint(3), team.ID)
+	req.Equal("sre@example.com", team.Email)
+}
+
+func TestGetTeamByNameReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, `{"totalCount": 0, "teams": []}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetTeamByName(context.TODO(), "SRE")
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
+
+func TestCreateTeam(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/teams", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("SRE", payload["name"])
+		req.Equal("sre@example.com", payload["email"])
+
+		_, _ = fmt.Fprintln(w, `{"message": "Team created", "teamId": 3}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	team, err := client.CreateTeam(context.TODO(), "SRE", "sre@example.com")
+
+	req.NoError(err)
+	req.Equal(uint(3), team.ID)
+	req.Equal("SRE", team.Name)
+}
+
+func TestCreatingAnExistingTeamFails(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = fmt.Fprintln(w, `{"message": "Team name taken"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.CreateTeam(context.TODO(), "SRE", "")
+
+	req.ErrorIs(err, ErrConflict)
+}
+
+func TestUpdateTeam(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/teams/3", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("Site Reliability", payload["name"])
+
+		_, _ = fmt.Fprintln(w, `{"message": "Team updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.UpdateTeam(context.TODO(), Team{ID: 3, Name: "Site Reliability"})
+
+	req.NoError(err)
+}
+
+func TestDeleteTeam(t *testing.T) {
+	req := require.New(t)
+	deleted := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted = true
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/teams/3", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"message": "Team deleted"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteTeam(context.TODO(), 3)
+
+	req.NoError(err)
+	req.True(deleted)
+}
+
+func TestDeletingAnUnknownTeamReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Team not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.DeleteTeam(context.TODO(), 3)
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
+
+func TestTeamsAreListedAcrossPages(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/search", r.URL.Path)
+
+		if r.URL.Query().Get("page") == "1" {
+			_, _ = fmt.Fprintln(w, `{"totalCount": 2, "teams": [{"id": 3, "name": "SRE"}]}`)
+			return
+		}
+
+		_, _ = fmt.Fprintln(w, `{"totalCount": 2, "teams": [{"id": 4, "name": "Backend"}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	teams, err := client.Teams(context.TODO())
+
+	req.NoError(err)
+	req.Equal([]Team{{ID: 3, Name: "SRE"}, {ID: 4, Name: "Backend"}}, teams)
+}
+
+func TestGetTeamByID(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/3", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"id": 3, "name": "SRE"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	team, err := client.GetTeamByID(context.TODO(), 3)
+
+	req.NoError(err)
+	req.Equal("SRE", team.Name)
+}
+
+func TestGetTeamByIDReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Team not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetTeamByID(context.TODO(), 3)
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
+
+func TestTeamMembers(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/teams/3/members", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[{"orgId": 1, "teamId": 3, "userId": 11, "login": "jane", "email": "jane@example.com", "name": "Jane"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	members, err := client.TeamMembers(context.TODO(), 3)
+
+	req.NoError(err)
+	req.Equal([]User{{ID: 11, Login: "jane", Email: "jane@example.com", Name: "Jane"}}, members)
+}
+
+func TestTeamMembersCanBeAddedByLoginOrEmail(t *testing.T) {
+	req := require.New(t)
+	added := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/users/lookup" {
+			req.Equal("jane@example.com", r.URL.Query().Get("loginOrEmail"))
+			_, _ = fmt.Fprintln(w, `{"id": 11, "login": "jane"}`)
+			return
+		}
+
+		added = true
+		req.Equal(http.MethodPost, r.Method)
+		req.Equal("/api/teams/3/members", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.EqualValues(11, payload["userId"])
+
+		_, _ = fmt.Fprintln(w, `{"message": "Member added to Team"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.AddTeamMember(context.TODO(), 3, "jane@example.com")
+
+	req.NoError(err)
+	req.True(added)
+}
+
+func TestAddingAnUnknownUserToATeamFails(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/users/lookup", r.URL.Path)
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "user not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.AddTeamMember(context.TODO(), 3, "jane")
+
+	req.ErrorIs(err, ErrUserNotFound)
+}
+
+func TestTeamMembersCanBeRemoved(t *testing.T) {
+	req := require.New(t)
+	removed := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/users/lookup" {
+			_, _ = fmt.Fprintln(w, `{"id": 11, "login": "jane"}`)
+			return
+		}
+
+		removed = true
+		req.Equal(http.MethodDelete, r.Method)
+		req.Equal("/api/teams/3/members/11", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"message": "Team Member removed"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.RemoveTeamMember(context.TODO(), 3, "jane")
+
+	req.NoError(err)
+	req.True(removed)
+}
+
+func TestRemovingAUserNotInTheTeamReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/users/lookup" {
+			_, _ = fmt.Fprintln(w, `{"id": 11, "login": "jane"}`)
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Team member not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.RemoveTeamMember(context.TODO(), 3, "jane")
+
+	req.ErrorIs(err, ErrTeamMemberNotFound)
+}
+
+func TestTeamPreferences(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodGet, r.Method)
+		req.Equal("/api/teams/3/preferences", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"theme": "dark", "homeDashboardId": 7, "homeDashboardUID": "home", "timezone": "utc", "weekStart": "monday"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	preferences, err := client.TeamPreferences(context.TODO(), 3)
+
+	req.NoError(err)
+	req.Equal(Preferences{Theme: "dark", HomeDashboardUID: "home", Timezone: "utc", WeekStart: "monday"}, *preferences)
+}
+
+func TestTeamPreferencesCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal(http.MethodPut, r.Method)
+		req.Equal("/api/teams/3/preferences", r.URL.Path)
+
+		payload := map[string]interface{}{}
+		req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+		req.Equal("light", payload["theme"])
+		req.Equal("home", payload["homeDashboardUID"])
+		req.Equal("browser", payload["timezone"])
+
+		_, _ = fmt.Fprintln(w, `{"message": "Preferences updated"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetTeamPreferences(context.TODO(), 3, Preferences{Theme: "light", HomeDashboardUID: "home", Timezone: "browser"})
+
+	req.NoError(err)
+}
+
+func TestSettingPreferencesOfAnUnknownTeamReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Team not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	err := client.SetTeamPreferences(context.TODO(), 3, Preferences{})
+
+	req.ErrorIs(err, ErrTeamNotFound)
+}
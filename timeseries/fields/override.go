@@ -1,6 +1,8 @@
 package fields
 
 import (
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/timeseries/axis"
 	"github.com/K-Phoen/sdk"
 )
@@ -91,3 +93,25 @@ func Stack(mode StackMode) OverrideOption {
 			})
 	}
 }
+
+// ValueMappings overrides the value mappings.
+func ValueMappings(mappings ...mapping.Mapping) OverrideOption {
+	return func(field *sdk.FieldConfigOverride) {
+		field.Properties = append(field.Properties,
+			sdk.FieldConfigOverrideProperty{
+				ID:    "mappings",
+				Value: mappings,
+			})
+	}
+}
+
+// DataLinks overrides the links attached to the values.
+func DataLinks(links ...datalink.Link) OverrideOption {
+	return func(field *sdk.FieldConfigOverride) {
+		field.Properties = append(field.Properties,
+			sdk.FieldConfigOverrideProperty{
+				ID:    "links",
+				Value: links,
+			})
+	}
+}
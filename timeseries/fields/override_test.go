@@ -3,6 +3,8 @@ package fields
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/timeseries/axis"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
@@ -79,3 +81,25 @@ func TestStack(t *testing.T) {
 	req.Equal("percent", values["mode"])
 	req.Equal(false, values["group"])
 }
+
+func TestValueMappings(t *testing.T) {
+	req := require.New(t)
+
+	overrideCfg := &sdk.FieldConfigOverride{}
+	ValueMappings(mapping.Value("1", mapping.Text("Up")))(overrideCfg)
+
+	req.Len(overrideCfg.Properties, 1)
+	req.Equal("mappings", overrideCfg.Properties[0].ID)
+	req.Equal([]mapping.Mapping{mapping.Value("1", mapping.Text("Up"))}, overrideCfg.Properties[0].Value)
+}
+
+func TestDataLinks(t *testing.T) {
+	req := require.New(t)
+
+	overrideCfg := &sdk.FieldConfigOverride{}
+	DataLinks(datalink.New("Host", "https://example.org/${__value.raw}"))(overrideCfg)
+
+	req.Len(overrideCfg.Properties, 1)
+	req.Equal("links", overrideCfg.Properties[0].ID)
+	req.Equal([]datalink.Link{datalink.New("Host", "https://example.org/${__value.raw}")}, overrideCfg.Properties[0].Value)
+}
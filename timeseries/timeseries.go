@@ -4,12 +4,18 @@ import (
 	"fmt"
 
 	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
+	"github.com/K-Phoen/grabana/internal/custompanel"
+	"github.com/K-Phoen/grabana/internal/layout"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/scheme"
 	"github.com/K-Phoen/grabana/timeseries/axis"
 	"github.com/K-Phoen/grabana/timeseries/fields"
 	"github.com/K-Phoen/grabana/timeseries/threshold"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -137,6 +143,10 @@ func New(title string, options ...Option) (*TimeSeries, error) {
 		}
 	}
 
+	if err := custompanel.Flatten(panel.Builder); err != nil {
+		return nil, err
+	}
+
 	return panel, nil
 }
 
@@ -179,6 +189,15 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the graph, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(timeseries *TimeSeries) error {
+		timeseries.Builder.Datasource = ref.Internal()
+
+		return nil
+	}
+}
+
 // Tooltip configures the tooltip content.
 func Tooltip(mode TooltipMode) Option {
 	return func(timeseries *TimeSeries) error {
@@ -388,6 +407,22 @@ func Height(height string) Option {
 	}
 }
 
+// At places the panel at the given position, in grid units, relative to the
+// top-left corner of its row. The grid is 24 units wide.
+func At(x int, y int) Option {
+	return func(timeseries *TimeSeries) error {
+		return layout.At(timeseries.Builder, x, y)
+	}
+}
+
+// Size sets the width and height of the panel, in grid units. The width
+// should be between 1 and 24. Takes precedence over Span() and Height().
+func Size(width int, height int) Option {
+	return func(timeseries *TimeSeries) error {
+		return layout.Size(timeseries.Builder, width, height)
+	}
+}
+
 // Description annotates the current visualization with a human-readable description.
 func Description(content string) Option {
 	return func(timeseries *TimeSeries) error {
@@ -406,6 +441,35 @@ func Transparent() Option {
 	}
 }
 
+// Transformations sets the transformations applied to the data returned by
+// the panel's queries, before it is visualized.
+func Transformations(transformations ...transformation.Transformation) Option {
+	return func(timeseries *TimeSeries) error {
+		custompanel.Set(timeseries.Builder, "transformations", transformations)
+
+		return nil
+	}
+}
+
+// ValueMappings maps values to text and/or colors. Example: 0 → "Down",
+// 1 → "Up".
+func ValueMappings(mappings ...mapping.Mapping) Option {
+	return func(timeseries *TimeSeries) error {
+		custompanel.SetIn(timeseries.Builder, []string{"fieldConfig", "defaults", "mappings"}, mappings)
+
+		return nil
+	}
+}
+
+// DataLinks adds links to the values displayed by the panel.
+func DataLinks(links ...datalink.Link) Option {
+	return func(timeseries *TimeSeries) error {
+		custompanel.SetIn(timeseries.Builder, []string{"fieldConfig", "defaults", "links"}, links)
+
+		return nil
+	}
+}
+
 // Alert creates an alert for this graph.
 func Alert(name string, opts ...alert.Option) Option {
 	return func(timeseries *TimeSeries) error {
@@ -1,16 +1,22 @@
 package timeseries
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
+	"github.com/K-Phoen/grabana/datalink"
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/grabana/errors"
 	"github.com/K-Phoen/grabana/links"
+	"github.com/K-Phoen/grabana/mapping"
 	"github.com/K-Phoen/grabana/scheme"
+	"github.com/K-Phoen/grabana/target/prometheus"
 	"github.com/K-Phoen/grabana/target/stackdriver"
 	"github.com/K-Phoen/grabana/timeseries/axis"
 	"github.com/K-Phoen/grabana/timeseries/fields"
 	"github.com/K-Phoen/grabana/timeseries/threshold"
+	"github.com/K-Phoen/grabana/transformation"
 	"github.com/K-Phoen/sdk"
 	"github.com/stretchr/testify/require"
 )
@@ -46,6 +52,18 @@ func TestTimeSeriesPanelCanHavePrometheusTargets(t *testing.T) {
 	req.Len(panel.Builder.TimeseriesPanel.Targets, 1)
 }
 
+func TestTimeSeriesPanelTargetsCanReferenceADatasource(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithPrometheusTarget(
+		"rate(prometheus_http_requests_total[30s])",
+		prometheus.DataSourceRef(datasource.ByName("Prometheus")),
+	))
+
+	req.NoError(err)
+	req.Equal("Prometheus", panel.Builder.TimeseriesPanel.Targets[0].Datasource.LegacyName)
+}
+
 func TestTimeSeriesPanelCanHaveLokiTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -75,6 +93,33 @@ func TestTimeSeriesPanelCanHaveInfluxDBTargets(t *testing.T) {
 	req.Len(panel.Builder.TimeseriesPanel.Targets, 1)
 }
 
+func TestTimeSeriesPanelCanHaveCloudWatchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithCloudWatchTarget("AWS/EC2", "CPUUtilization"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.TimeseriesPanel.Targets, 1)
+}
+
+func TestTimeSeriesPanelCanHaveElasticsearchTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithElasticsearchTarget("level:error"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.TimeseriesPanel.Targets, 1)
+}
+
+func TestTimeSeriesPanelCanHaveSQLTargets(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", WithSQLTarget("SELECT 1"))
+
+	req.NoError(err)
+	req.Len(panel.Builder.TimeseriesPanel.Targets, 1)
+}
+
 func TestTimeSeriesPanelCanHaveStackdriverTargets(t *testing.T) {
 	req := require.New(t)
 
@@ -110,6 +155,28 @@ func TestTimeSeriesPanelHeightCanBeConfigured(t *testing.T) {
 	req.Equal("400px", *(panel.Builder.Height).(*string))
 }
 
+func TestTimeSeriesPanelCanBePlacedOnTheGrid(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", At(6, 2), Size(12, 8))
+
+	req.NoError(err)
+	req.Equal(6, *panel.Builder.GridPos.X)
+	req.Equal(2, *panel.Builder.GridPos.Y)
+	req.Equal(12, *panel.Builder.GridPos.W)
+	req.Equal(8, *panel.Builder.GridPos.H)
+}
+
+func TestInvalidTimeSeriesPanelGridPositionIsRejected(t *testing.T) {
+	req := require.New(t)
+
+	_, err := New("", At(24, 0))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+
+	_, err = New("", Size(25, 8))
+	req.ErrorIs(err, errors.ErrInvalidArgument)
+}
+
 func TestTimeSeriesPanelBackgroundCanBeTransparent(t *testing.T) {
 	req := require.New(t)
 
@@ -138,6 +205,16 @@ func TestTimeSeriesPanelDataSourceCanBeConfigured(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestTimeSeriesPanelDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.NoError(err)
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestAlertsCanBeConfigured(t *testing.T) {
 	req := require.New(t)
 
@@ -468,3 +545,62 @@ func TestValuesCanBeStacked(t *testing.T) {
 	req.NoError(err)
 	req.Equal("percent", panel.Builder.TimeseriesPanel.FieldConfig.Defaults.Custom.Stacking.Mode)
 }
+
+func TestTimeSeriesPanelCanHaveTransformations(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("", Transformations(
+		transformation.Merge(),
+		transformation.RenameByRegex("(.*)-total", "$1"),
+	))
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		Type            string
+		Transformations []struct {
+			ID string
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Equal("timeseries", decoded.Type)
+	req.Len(decoded.Transformations, 2)
+	req.Equal("merge", decoded.Transformations[0].ID)
+	req.Equal("renameByRegex", decoded.Transformations[1].ID)
+}
+
+func TestTimeSeriesPanelCanHaveValueMappingsAndDataLinks(t *testing.T) {
+	req := require.New(t)
+
+	panel, err := New("",
+		ValueMappings(mapping.Value("0", mapping.Text("Down")), mapping.Value("1", mapping.Text("Up"))),
+		DataLinks(datalink.New("Details", "https://example.org/${__value.raw}")),
+	)
+	req.NoError(err)
+
+	payload, err := json.Marshal(panel.Builder)
+	req.NoError(err)
+
+	decoded := struct {
+		FieldConfig struct {
+			Defaults struct {
+				Mappings []struct {
+					Type string
+				}
+				Links []struct {
+					Title string
+					URL   string
+				}
+			}
+		}
+	}{}
+	req.NoError(json.Unmarshal(payload, &decoded))
+
+	req.Len(decoded.FieldConfig.Defaults.Mappings, 2)
+	req.Equal("value", decoded.FieldConfig.Defaults.Mappings[0].Type)
+	req.Len(decoded.FieldConfig.Defaults.Links, 1)
+	req.Equal("https://example.org/${__value.raw}", decoded.FieldConfig.Defaults.Links[0].URL)
+}
@@ -0,0 +1,63 @@
+package transformation
+
+// Calculation represents how a new field is computed.
+type Calculation func(options map[string]interface{})
+
+// CalculateFieldOption represents an option that can be used to configure a
+// "calculate field" transformation.
+type CalculateFieldOption func(options map[string]interface{})
+
+// CalculateField adds a field computed from other ones.
+func CalculateField(alias string, calculation Calculation, options ...CalculateFieldOption) Transformation {
+	calculateOptions := map[string]interface{}{
+		"alias":         alias,
+		"replaceFields": false,
+	}
+
+	calculation(calculateOptions)
+
+	for _, opt := range options {
+		opt(calculateOptions)
+	}
+
+	return Transformation{
+		ID:      "calculateField",
+		Options: calculateOptions,
+	}
+}
+
+// Binary computes a field by applying an operator on two other fields.
+func Binary(left string, operator BinaryOperator, right string) Calculation {
+	return func(options map[string]interface{}) {
+		options["mode"] = "binary"
+		options["binary"] = map[string]interface{}{
+			"left":     left,
+			"operator": operator,
+			"right":    right,
+		}
+	}
+}
+
+// ReduceRow computes a field by reducing the given fields of each row. All
+// the numeric fields are used if none is given.
+func ReduceRow(reducer Reducer, fields ...string) Calculation {
+	return func(options map[string]interface{}) {
+		reduce := map[string]interface{}{
+			"reducer": reducer,
+		}
+
+		if len(fields) != 0 {
+			reduce["include"] = fields
+		}
+
+		options["mode"] = "reduceRow"
+		options["reduce"] = reduce
+	}
+}
+
+// ReplaceFields only keeps the calculated field.
+func ReplaceFields() CalculateFieldOption {
+	return func(options map[string]interface{}) {
+		options["replaceFields"] = true
+	}
+}
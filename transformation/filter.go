@@ -0,0 +1,98 @@
+package transformation
+
+// Condition represents a condition used to filter rows by value.
+type Condition struct {
+	FieldName string          `json:"fieldName"`
+	Config    conditionConfig `json:"config"`
+}
+
+type conditionConfig struct {
+	ID      string                 `json:"id"`
+	Options map[string]interface{} `json:"options"`
+}
+
+// FilterByValue keeps or removes rows depending on the value of their fields.
+func FilterByValue(filterType FilterType, match MatchType, conditions ...Condition) Transformation {
+	return Transformation{
+		ID: "filterByValue",
+		Options: map[string]interface{}{
+			"type":    filterType,
+			"match":   match,
+			"filters": conditions,
+		},
+	}
+}
+
+// Greater matches rows where the given field is greater than the value.
+func Greater(field string, value float64) Condition {
+	return valueCondition("greater", field, value)
+}
+
+// GreaterOrEqual matches rows where the given field is greater than or equal
+// to the value.
+func GreaterOrEqual(field string, value float64) Condition {
+	return valueCondition("greaterOrEqual", field, value)
+}
+
+// Lower matches rows where the given field is lower than the value.
+func Lower(field string, value float64) Condition {
+	return valueCondition("lower", field, value)
+}
+
+// LowerOrEqual matches rows where the given field is lower than or equal to
+// the value.
+func LowerOrEqual(field string, value float64) Condition {
+	return valueCondition("lowerOrEqual", field, value)
+}
+
+// Equal matches rows where the given field is equal to the value.
+func Equal(field string, value interface{}) Condition {
+	return valueCondition("equal", field, value)
+}
+
+// NotEqual matches rows where the given field is not equal to the value.
+func NotEqual(field string, value interface{}) Condition {
+	return valueCondition("notEqual", field, value)
+}
+
+// InRange matches rows where the given field is between from and to.
+func InRange(field string, from float64, to float64) Condition {
+	return Condition{
+		FieldName: field,
+		Config: conditionConfig{
+			ID:      "range",
+			Options: map[string]interface{}{"from": from, "to": to},
+		},
+	}
+}
+
+// Regex matches rows where the given field matches the regular expression.
+func Regex(field string, pattern string) Condition {
+	return valueCondition("regex", field, pattern)
+}
+
+// IsNull matches rows where the given field is null.
+func IsNull(field string) Condition {
+	return Condition{
+		FieldName: field,
+		Config:    conditionConfig{ID: "isNull", Options: map[string]interface{}{}},
+	}
+}
+
+// IsNotNull matches rows where the given field is not null.
+func IsNotNull(field string) Condition {
+	return Condition{
+		FieldName: field,
+		Config:    conditionConfig{ID: "isNotNull", Options: map[string]interface{}{}},
+	}
+}
+
+func valueCondition(id string, field string, value interface{}) Condition {
+	return Condition{
+		FieldName: field,
+		Config: conditionConfig{
+			ID:      id,
+			Options: map[string]interface{}{"value": value},
+		},
+	}
+}
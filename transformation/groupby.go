@@ -0,0 +1,49 @@
+package transformation
+
+// GroupByOption represents an option that can be used to configure a
+// "group by" transformation.
+type GroupByOption func(fields map[string]groupByField)
+
+type groupByField struct {
+	Aggregations []Reducer `json:"aggregations"`
+	Operation    string    `json:"operation"`
+}
+
+// GroupBy groups rows by the values of one or more fields, and aggregates
+// the other ones.
+func GroupBy(options ...GroupByOption) Transformation {
+	fields := map[string]groupByField{}
+
+	for _, opt := range options {
+		opt(fields)
+	}
+
+	return Transformation{
+		ID: "groupBy",
+		Options: map[string]interface{}{
+			"fields": fields,
+		},
+	}
+}
+
+// By groups rows using the values of the given fields.
+func By(fields ...string) GroupByOption {
+	return func(groupByFields map[string]groupByField) {
+		for _, field := range fields {
+			groupByFields[field] = groupByField{
+				Aggregations: []Reducer{},
+				Operation:    "groupby",
+			}
+		}
+	}
+}
+
+// Aggregate aggregates the values of the given field using the reducers.
+func Aggregate(field string, reducers ...Reducer) GroupByOption {
+	return func(groupByFields map[string]groupByField) {
+		groupByFields[field] = groupByField{
+			Aggregations: reducers,
+			Operation:    "aggregate",
+		}
+	}
+}
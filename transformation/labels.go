@@ -0,0 +1,42 @@
+package transformation
+
+// LabelsToFieldsOption represents an option that can be used to configure a
+// "labels to fields" transformation.
+type LabelsToFieldsOption func(options map[string]interface{})
+
+// LabelsToFields turns the labels of time series into fields.
+func LabelsToFields(options ...LabelsToFieldsOption) Transformation {
+	labelsOptions := map[string]interface{}{
+		"mode": "columns",
+	}
+
+	for _, opt := range options {
+		opt(labelsOptions)
+	}
+
+	return Transformation{
+		ID:      "labelsToFields",
+		Options: labelsOptions,
+	}
+}
+
+// LabelsAsRows outputs each label as a row instead of a column.
+func LabelsAsRows() LabelsToFieldsOption {
+	return func(options map[string]interface{}) {
+		options["mode"] = "rows"
+	}
+}
+
+// ValueLabel uses the values of the given label as field names.
+func ValueLabel(label string) LabelsToFieldsOption {
+	return func(options map[string]interface{}) {
+		options["valueLabel"] = label
+	}
+}
+
+// KeepLabels only turns the given labels into fields.
+func KeepLabels(labels ...string) LabelsToFieldsOption {
+	return func(options map[string]interface{}) {
+		options["keepLabels"] = labels
+	}
+}
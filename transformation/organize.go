@@ -0,0 +1,54 @@
+package transformation
+
+// OrganizeOption represents an option that can be used to configure an
+// "organize fields" transformation.
+type OrganizeOption func(options *organizeOptions)
+
+type organizeOptions struct {
+	ExcludeByName map[string]bool   `json:"excludeByName"`
+	IndexByName   map[string]int    `json:"indexByName"`
+	RenameByName  map[string]string `json:"renameByName"`
+}
+
+// OrganizeFields hides, renames and reorders fields.
+func OrganizeFields(options ...OrganizeOption) Transformation {
+	organize := &organizeOptions{
+		ExcludeByName: map[string]bool{},
+		IndexByName:   map[string]int{},
+		RenameByName:  map[string]string{},
+	}
+
+	for _, opt := range options {
+		opt(organize)
+	}
+
+	return Transformation{
+		ID:      "organize",
+		Options: organize,
+	}
+}
+
+// ExcludeFields hides the given fields.
+func ExcludeFields(fields ...string) OrganizeOption {
+	return func(options *organizeOptions) {
+		for _, field := range fields {
+			options.ExcludeByName[field] = true
+		}
+	}
+}
+
+// RenameField renames a field.
+func RenameField(field string, name string) OrganizeOption {
+	return func(options *organizeOptions) {
+		options.RenameByName[field] = name
+	}
+}
+
+// OrderFields sets the order in which fields are displayed.
+func OrderFields(fields ...string) OrganizeOption {
+	return func(options *organizeOptions) {
+		for i, field := range fields {
+			options.IndexByName[field] = i
+		}
+	}
+}
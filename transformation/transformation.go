@@ -0,0 +1,153 @@
+package transformation
+
+// Transformation represents a transformation applied to the data returned by
+// a panel's queries, before it is visualized.
+// See https://grafana.com/docs/grafana/latest/panels-visualizations/query-transform-data/transform-data/
+type Transformation struct {
+	ID      string      `json:"id"`
+	Options interface{} `json:"options"`
+}
+
+// Reducer represents a function used to reduce a set of values into a single
+// one.
+type Reducer string
+
+const (
+	Min          Reducer = "min"
+	Max          Reducer = "max"
+	Mean         Reducer = "mean"
+	First        Reducer = "first"
+	FirstNotNull Reducer = "firstNotNull"
+	Last         Reducer = "last"
+	LastNotNull  Reducer = "lastNotNull"
+	Sum          Reducer = "sum"
+	Count        Reducer = "count"
+	Range        Reducer = "range"
+	Delta        Reducer = "delta"
+	Distinct     Reducer = "distinctCount"
+)
+
+// JoinMode defines how frames are joined together.
+type JoinMode string
+
+const (
+	// OuterJoin keeps every row, even the ones without a match.
+	OuterJoin JoinMode = "outer"
+	// InnerJoin only keeps the rows having a match in every frame.
+	InnerJoin JoinMode = "inner"
+)
+
+// ReduceMode defines the output of the reduce transformation.
+type ReduceMode string
+
+const (
+	// SeriesToRows produces one row per field, and one column per reducer.
+	SeriesToRows ReduceMode = "seriesToRows"
+	// ReduceFields keeps the frames structure, but reduces each field to a
+	// single value.
+	ReduceFields ReduceMode = "reduceFields"
+)
+
+// FilterType defines whether the rows matching a filter are kept or removed.
+type FilterType string
+
+const (
+	// Include keeps the rows matching the conditions.
+	Include FilterType = "include"
+	// Exclude removes the rows matching the conditions.
+	Exclude FilterType = "exclude"
+)
+
+// MatchType defines how conditions are combined.
+type MatchType string
+
+const (
+	// MatchAny matches rows satisfying at least one condition.
+	MatchAny MatchType = "any"
+	// MatchAll matches rows satisfying every condition.
+	MatchAll MatchType = "all"
+)
+
+// BinaryOperator represents an operator used to calculate a field from two
+// other ones.
+type BinaryOperator string
+
+const (
+	Add      BinaryOperator = "+"
+	Subtract BinaryOperator = "-"
+	Multiply BinaryOperator = "*"
+	Divide   BinaryOperator = "/"
+)
+
+// SortOrder defines in which order rows are sorted.
+type SortOrder bool
+
+const (
+	Ascending  SortOrder = false
+	Descending SortOrder = true
+)
+
+// Merge merges many series/tables into a single one.
+func Merge() Transformation {
+	return Transformation{
+		ID:      "merge",
+		Options: map[string]interface{}{},
+	}
+}
+
+// JoinByField joins many series/tables into a single one, using the given
+// field as key.
+func JoinByField(field string, mode JoinMode) Transformation {
+	return Transformation{
+		ID: "joinByField",
+		Options: map[string]interface{}{
+			"byField": field,
+			"mode":    mode,
+		},
+	}
+}
+
+// Reduce reduces each field to a single value, using the given reducers.
+func Reduce(mode ReduceMode, reducers ...Reducer) Transformation {
+	return Transformation{
+		ID: "reduce",
+		Options: map[string]interface{}{
+			"mode":     mode,
+			"reducers": reducers,
+		},
+	}
+}
+
+// RenameByRegex renames series matching the given regular expression.
+// The replacement can refer to capture groups. Example: "$1".
+func RenameByRegex(regex string, replacement string) Transformation {
+	return Transformation{
+		ID: "renameByRegex",
+		Options: map[string]interface{}{
+			"regex":         regex,
+			"renamePattern": replacement,
+		},
+	}
+}
+
+// SortBy sorts the rows using the values of the given field.
+func SortBy(field string, order SortOrder) Transformation {
+	return Transformation{
+		ID: "sortBy",
+		Options: map[string]interface{}{
+			"sort": []map[string]interface{}{
+				{"field": field, "desc": bool(order)},
+			},
+		},
+	}
+}
+
+// Limit limits the number of rows displayed.
+func Limit(count int) Transformation {
+	return Transformation{
+		ID: "limit",
+		Options: map[string]interface{}{
+			"limitField": count,
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package transformation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformationsCanBeMarshalled(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		transformation Transformation
+		expected       string
+	}{
+		{
+			desc:           "merge",
+			transformation: Merge(),
+			expected:       `{"id": "merge", "options": {}}`,
+		},
+		{
+			desc:           "join by field",
+			transformation: JoinByField("time", OuterJoin),
+			expected:       `{"id": "joinByField", "options": {"byField": "time", "mode": "outer"}}`,
+		},
+		{
+			desc:           "reduce",
+			transformation: Reduce(SeriesToRows, Max, LastNotNull),
+			expected:       `{"id": "reduce", "options": {"mode": "seriesToRows", "reducers": ["max", "lastNotNull"]}}`,
+		},
+		{
+			desc:           "rename by regex",
+			transformation: RenameByRegex("(.*)-total", "$1"),
+			expected:       `{"id": "renameByRegex", "options": {"regex": "(.*)-total", "renamePattern": "$1"}}`,
+		},
+		{
+			desc:           "sort by",
+			transformation: SortBy("Value", Descending),
+			expected:       `{"id": "sortBy", "options": {"sort": [{"field": "Value", "desc": true}]}}`,
+		},
+		{
+			desc:           "limit",
+			transformation: Limit(10),
+			expected:       `{"id": "limit", "options": {"limitField": 10}}`,
+		},
+		{
+			desc: "organize fields",
+			transformation: OrganizeFields(
+				ExcludeFields("Time"),
+				RenameField("Value", "Requests"),
+				OrderFields("host", "Value"),
+			),
+			expected: `{"id": "organize", "options": {
+				"excludeByName": {"Time": true},
+				"indexByName": {"host": 0, "Value": 1},
+				"renameByName": {"Value": "Requests"}
+			}}`,
+		},
+		{
+			desc: "filter by value",
+			transformation: FilterByValue(
+				Include,
+				MatchAll,
+				Greater("Value", 10),
+				InRange("Other", 1, 2),
+				IsNotNull("host"),
+			),
+			expected: `{"id": "filterByValue", "options": {
+				"type": "include",
+				"match": "all",
+				"filters": [
+					{"fieldName": "Value", "config": {"id": "greater", "options": {"value": 10}}},
+					{"fieldName": "Other", "config": {"id": "range", "options": {"from": 1, "to": 2}}},
+					{"fieldName": "host", "config": {"id": "isNotNull", "options": {}}}
+				]
+			}}`,
+		},
+		{
+			desc:           "group by",
+			transformation: GroupBy(By("host"), Aggregate("Value", Mean, Max)),
+			expected: `{"id": "groupBy", "options": {"fields": {
+				"host": {"aggregations": [], "operation": "groupby"},
+				"Value": {"aggregations": ["mean", "max"], "operation": "aggregate"}
+			}}}`,
+		},
+		{
+			desc:           "calculate field with binary operation",
+			transformation: CalculateField("ratio", Binary("errors", Divide, "total"), ReplaceFields()),
+			expected: `{"id": "calculateField", "options": {
+				"alias": "ratio",
+				"mode": "binary",
+				"binary": {"left": "errors", "operator": "/", "right": "total"},
+				"replaceFields": true
+			}}`,
+		},
+		{
+			desc:           "calculate field by reducing rows",
+			transformation: CalculateField("total", ReduceRow(Sum, "A", "B")),
+			expected: `{"id": "calculateField", "options": {
+				"alias": "total",
+				"mode": "reduceRow",
+				"reduce": {"reducer": "sum", "include": ["A", "B"]},
+				"replaceFields": false
+			}}`,
+		},
+		{
+			desc:           "labels to fields",
+			transformation: LabelsToFields(LabelsAsRows(), ValueLabel("name"), KeepLabels("host")),
+			expected: `{"id": "labelsToFields", "options": {
+				"mode": "rows",
+				"valueLabel": "name",
+				"keepLabels": ["host"]
+			}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.desc, func(t *testing.T) {
+			req := require.New(t)
+
+			payload, err := json.Marshal(tc.transformation)
+			req.NoError(err)
+
+			req.JSONEq(tc.expected, string(payload))
+		})
+	}
+}
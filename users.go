@@ -0,0 +1,44 @@
+package grabana
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ErrUserNotFound is returned when the given user can not be found.
+var ErrUserNotFound = errors.New("user not found")
+
+// User represents a Grafana user.
+type User struct {
+	ID    uint   `json:"id"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// GetUserByLoginOrEmail finds a user, given its login or email.
+// Note: this requires server admin permissions.
+func (client *Client) GetUserByLoginOrEmail(ctx context.Context, loginOrEmail string) (*User, error) {
+	resp, err := client.get(ctx, "/api/users/lookup?loginOrEmail="+url.QueryEscape(loginOrEmail))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrUserNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	user := &User{}
+	if err := decodeJSON(resp.Body, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
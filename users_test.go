@@ -0,0 +1,47 @@
+package grabana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserByLoginOrEmail(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/users/lookup", r.URL.Path)
+		req.Equal("jane@example.com", r.URL.Query().Get("loginOrEmail"))
+
+		_, _ = fmt.Fprintln(w, `{"id": 11, "login": "jane", "email": "jane@example.com", "name": "Jane"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	user, err := client.GetUserByLoginOrEmail(context.TODO(), "jane@example.com")
+
+	req.NoError(err)
+	req.Equal(uint(11), user.ID)
+	req.Equal("jane", user.Login)
+}
+
+func TestGetUserByLoginOrEmailReturnsASpecificErrorIfNotFound(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "user not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.GetUserByLoginOrEmail(context.TODO(), "jane")
+
+	req.ErrorIs(err, ErrUserNotFound)
+}
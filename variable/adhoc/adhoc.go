@@ -0,0 +1,135 @@
+package adhoc
+
+import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/K-Phoen/sdk"
+)
+
+// Option represents an option that can be used to configure an ad hoc
+// filters variable.
+type Option func(adhoc *AdHoc)
+
+// Operator represents the comparison applied by a filter.
+type Operator string
+
+const (
+	// Equal matches values equal to the filter's value.
+	Equal Operator = "="
+
+	// NotEqual matches values different from the filter's value.
+	NotEqual Operator = "!="
+
+	// MatchesRegex matches values matching the filter's regex.
+	MatchesRegex Operator = "=~"
+
+	// NotMatchesRegex matches values not matching the filter's regex.
+	NotMatchesRegex Operator = "!~"
+)
+
+// Filter represents a key/value filter.
+type Filter struct {
+	Key      string   `json:"key"`
+	Operator Operator `json:"operator"`
+	Value    string   `json:"value"`
+}
+
+// AdHoc represents an "ad hoc filters" templated variable. The filters it
+// holds are automatically added to every query made to its datasource.
+type AdHoc struct {
+	Builder sdk.TemplateVar
+
+	filters     []Filter
+	baseFilters []Filter
+}
+
+// New creates a new "ad hoc filters" templated variable.
+func New(name string, options ...Option) *AdHoc {
+	adhoc := &AdHoc{
+		Builder: sdk.TemplateVar{
+			Name:    name,
+			Label:   name,
+			Type:    "adhoc",
+			Options: []sdk.Option{},
+		},
+		filters:     []Filter{},
+		baseFilters: []Filter{},
+	}
+
+	for _, opt := range options {
+		opt(adhoc)
+	}
+
+	return adhoc
+}
+
+// DataSource sets the data source to which the filters apply.
+func DataSource(source string) Option {
+	return func(adhoc *AdHoc) {
+		adhoc.Builder.Datasource = &sdk.DatasourceRef{LegacyName: source}
+	}
+}
+
+// DataSourceRef sets the data source to which the filters apply, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(adhoc *AdHoc) {
+		adhoc.Builder.Datasource = ref.Internal()
+	}
+}
+
+// Filters sets the filters applied by default, until modified from the
+// dashboard.
+func Filters(filters ...Filter) Option {
+	return func(adhoc *AdHoc) {
+		adhoc.filters = filters
+	}
+}
+
+// BaseFilters sets filters always applied, that can not be modified from the
+// dashboard.
+func BaseFilters(filters ...Filter) Option {
+	return func(adhoc *AdHoc) {
+		adhoc.baseFilters = filters
+	}
+}
+
+// Label sets the label of the variable.
+func Label(label string) Option {
+	return func(adhoc *AdHoc) {
+		adhoc.Builder.Label = label
+	}
+}
+
+// HideLabel ensures that this variable's label will not be displayed.
+func HideLabel() Option {
+	return func(adhoc *AdHoc) {
+		adhoc.Builder.Hide = 1
+	}
+}
+
+// Hide ensures that the variable will not be displayed.
+func Hide() Option {
+	return func(adhoc *AdHoc) {
+		adhoc.Builder.Hide = 2
+	}
+}
+
+// Model returns the representation of the variable expected by Grafana,
+// including the filters that the sdk doesn't model.
+func (adhoc *AdHoc) Model() (map[string]interface{}, error) {
+	content, err := json.Marshal(adhoc.Builder)
+	if err != nil {
+		return nil, err
+	}
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, err
+	}
+
+	model["filters"] = adhoc.filters
+	model["baseFilters"] = adhoc.baseFilters
+
+	return model, nil
+}
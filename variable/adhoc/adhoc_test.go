@@ -0,0 +1,86 @@
+package adhoc
+
+import (
+	"testing"
+
+	"github.com/K-Phoen/grabana/datasource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdHocVariablesCanBeCreated(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("filters")
+
+	req.Equal("filters", adhoc.Builder.Name)
+	req.Equal("filters", adhoc.Builder.Label)
+	req.Equal("adhoc", adhoc.Builder.Type)
+}
+
+func TestLabelCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("", Label("Filters"))
+
+	req.Equal("Filters", adhoc.Builder.Label)
+}
+
+func TestLabelCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("", HideLabel())
+
+	req.Equal(uint8(1), adhoc.Builder.Hide)
+}
+
+func TestVariableCanBeHidden(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("", Hide())
+
+	req.Equal(uint8(2), adhoc.Builder.Hide)
+}
+
+func TestDataSourceCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("", DataSource("prometheus"))
+
+	req.Equal("prometheus", adhoc.Builder.Datasource.LegacyName)
+}
+
+func TestDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.Equal("prom-uid", adhoc.Builder.Datasource.UID)
+	req.Equal("prometheus", adhoc.Builder.Datasource.Type)
+}
+
+func TestFiltersAreIncludedInTheModel(t *testing.T) {
+	req := require.New(t)
+
+	adhoc := New(
+		"filters",
+		Filters(Filter{Key: "job", Operator: Equal, Value: "api"}),
+		BaseFilters(Filter{Key: "env", Operator: NotMatchesRegex, Value: "dev.*"}),
+	)
+
+	model, err := adhoc.Model()
+
+	req.NoError(err)
+	req.Equal("adhoc", model["type"])
+	req.Equal([]Filter{{Key: "job", Operator: Equal, Value: "api"}}, model["filters"])
+	req.Equal([]Filter{{Key: "env", Operator: NotMatchesRegex, Value: "dev.*"}}, model["baseFilters"])
+}
+
+func TestFiltersAreEmptyByDefault(t *testing.T) {
+	req := require.New(t)
+
+	model, err := New("filters").Model()
+
+	req.NoError(err)
+	req.Equal([]Filter{}, model["filters"])
+	req.Equal([]Filter{}, model["baseFilters"])
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"fmt"
+)
+
+// PrometheusQueryType represents the kind of query made to Prometheus to
+// retrieve the values of a variable.
+type PrometheusQueryType int
+
+const (
+	// PrometheusLabelNamesQuery lists label names.
+	PrometheusLabelNamesQuery PrometheusQueryType = 0
+
+	// PrometheusLabelValuesQuery lists the values of a label.
+	PrometheusLabelValuesQuery PrometheusQueryType = 1
+
+	// PrometheusMetricsQuery lists metric names.
+	PrometheusMetricsQuery PrometheusQueryType = 2
+
+	// PrometheusQueryResultQuery lists the results of a PromQL query.
+	PrometheusQueryResultQuery PrometheusQueryType = 3
+
+	// PrometheusSeriesQuery lists the series matching a selector.
+	PrometheusSeriesQuery PrometheusQueryType = 4
+)
+
+// LokiQueryType represents the kind of query made to Loki to retrieve the
+// values of a variable.
+type LokiQueryType int
+
+const (
+	// LokiLabelNamesQuery lists label names.
+	LokiLabelNamesQuery LokiQueryType = 0
+
+	// LokiLabelValuesQuery lists the values of a label.
+	LokiLabelValuesQuery LokiQueryType = 1
+)
+
+const (
+	prometheusRefID = "PrometheusVariableQueryEditor-VariableQuery"
+	lokiRefID       = "LokiVariableQueryEditor-VariableQuery"
+)
+
+// PrometheusLabelNames lists the label names known by Prometheus.
+func PrometheusLabelNames() Option {
+	return prometheusQuery(PrometheusLabelNamesQuery, "label_names()")
+}
+
+// PrometheusLabelValues lists the values of the given label. The series
+// considered can be restricted by a selector, which can be empty.
+// Example: PrometheusLabelValues("instance", `up{job="$job"}`).
+func PrometheusLabelValues(label string, selector string) Option {
+	if selector == "" {
+		return prometheusQuery(PrometheusLabelValuesQuery, fmt.Sprintf("label_values(%s)", label))
+	}
+
+	return prometheusQuery(PrometheusLabelValuesQuery, fmt.Sprintf("label_values(%s,%s)", selector, label))
+}
+
+// PrometheusMetrics lists the metric names matching the given regex.
+// Example: PrometheusMetrics("http_.*").
+func PrometheusMetrics(regex string) Option {
+	return prometheusQuery(PrometheusMetricsQuery, fmt.Sprintf("metrics(%s)", regex))
+}
+
+// PrometheusQueryResult lists the results of the given PromQL query.
+// Example: PrometheusQueryResult("topk(5, sum by (job) (rate(http_requests_total[5m])))").
+func PrometheusQueryResult(expr string) Option {
+	return prometheusQuery(PrometheusQueryResultQuery, fmt.Sprintf("query_result(%s)", expr))
+}
+
+// PrometheusSeries lists the series matching the given selector.
+// Example: PrometheusSeries(`up{job="api"}`).
+func PrometheusSeries(selector string) Option {
+	return prometheusQuery(PrometheusSeriesQuery, selector)
+}
+
+func prometheusQuery(queryType PrometheusQueryType, definition string) Option {
+	return func(query *Query) {
+		query.Builder.Query = map[string]interface{}{
+			"qryType": int(queryType),
+			"query":   definition,
+			"refId":   prometheusRefID,
+		}
+		query.definition = definition
+	}
+}
+
+// LokiLabelNames lists the label names known by Loki.
+func LokiLabelNames() Option {
+	return func(query *Query) {
+		query.Builder.Query = map[string]interface{}{
+			"type":  int(LokiLabelNamesQuery),
+			"refId": lokiRefID,
+		}
+		query.definition = "label_names()"
+	}
+}
+
+// LokiLabelValues lists the values of the given label. The streams
+// considered can be restricted by a stream selector, which can be empty.
+// Example: LokiLabelValues("app", `{namespace="$namespace"}`).
+func LokiLabelValues(label string, streamSelector string) Option {
+	return func(query *Query) {
+		request := map[string]interface{}{
+			"type":  int(LokiLabelValuesQuery),
+			"label": label,
+			"refId": lokiRefID,
+		}
+		query.definition = fmt.Sprintf("label_values(%s)", label)
+
+		if streamSelector != "" {
+			request["stream"] = streamSelector
+			query.definition = fmt.Sprintf("label_values(%s,%s)", streamSelector, label)
+		}
+
+		query.Builder.Query = request
+	}
+}
@@ -1,6 +1,9 @@
 package query
 
 import (
+	"encoding/json"
+
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/K-Phoen/sdk"
 )
 
@@ -53,6 +56,9 @@ const (
 // Query represents a "query" templated variable.
 type Query struct {
 	Builder sdk.TemplateVar
+
+	// human-readable description of the query, displayed by Grafana.
+	definition string
 }
 
 // New creates a new "query" templated variable.
@@ -78,10 +84,18 @@ func DataSource(source string) Option {
 	}
 }
 
+// DataSourceRef sets the data source to be used by the query, referenced by UID, name or type.
+func DataSourceRef(ref datasource.Ref) Option {
+	return func(query *Query) {
+		query.Builder.Datasource = ref.Internal()
+	}
+}
+
 // Request defines the query to be executed.
 func Request(request string) Option {
 	return func(query *Query) {
 		query.Builder.Query = request
+		query.definition = request
 	}
 }
 
@@ -159,3 +173,78 @@ func AllValue(value string) Option {
 		query.Builder.AllValue = value
 	}
 }
+
+// Current sets the values selected by default.
+func Current(values ...string) Option {
+	return func(query *Query) {
+		var value interface{} = values
+		if len(values) == 1 {
+			value = values[0]
+		}
+
+		query.Builder.Current = sdk.Current{
+			Text:  &sdk.StringSliceString{Value: values, Valid: true},
+			Value: value,
+		}
+
+		query.selectCurrent()
+	}
+}
+
+// StaticOptions defines the values proposed by the variable, as a preview of
+// what the query returns. They are used as long as the query isn't refreshed:
+// see Refresh(Never).
+func StaticOptions(values ...string) Option {
+	return func(query *Query) {
+		options := make([]sdk.Option, 0, len(values)+1)
+
+		// keep the "All" option, if any
+		for _, option := range query.Builder.Options {
+			if option.Value == All {
+				options = append(options, option)
+			}
+		}
+
+		for _, value := range values {
+			options = append(options, sdk.Option{Text: value, Value: value})
+		}
+
+		query.Builder.Options = options
+		query.selectCurrent()
+	}
+}
+
+func (query *Query) selectCurrent() {
+	if query.Builder.Current.Text == nil {
+		return
+	}
+
+	selected := make(map[string]bool, len(query.Builder.Current.Text.Value))
+	for _, value := range query.Builder.Current.Text.Value {
+		selected[value] = true
+	}
+
+	for i := range query.Builder.Options {
+		query.Builder.Options[i].Selected = selected[query.Builder.Options[i].Text]
+	}
+}
+
+// Model returns the representation of the variable expected by Grafana,
+// including the definition of the query that the sdk doesn't model.
+func (query *Query) Model() (map[string]interface{}, error) {
+	content, err := json.Marshal(query.Builder)
+	if err != nil {
+		return nil, err
+	}
+
+	model := map[string]interface{}{}
+	if err := json.Unmarshal(content, &model); err != nil {
+		return nil, err
+	}
+
+	if query.definition != "" {
+		model["definition"] = query.definition
+	}
+
+	return model, nil
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"testing"
 
+	"github.com/K-Phoen/grabana/datasource"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,6 +100,15 @@ func TestDataSourceCanBeSet(t *testing.T) {
 	req.Equal("prometheus-default", panel.Builder.Datasource.LegacyName)
 }
 
+func TestDataSourceCanBeReferenced(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", DataSourceRef(datasource.ByUID("prom-uid").OfType("prometheus")))
+
+	req.Equal("prom-uid", panel.Builder.Datasource.UID)
+	req.Equal("prometheus", panel.Builder.Datasource.Type)
+}
+
 func TestRequestCanBeSet(t *testing.T) {
 	req := require.New(t)
 	request := "label_values(prometheus_http_requests_total, code)"
@@ -115,3 +125,122 @@ func TestCustomAllValueCanBeSet(t *testing.T) {
 
 	req.Equal(".*", panel.Builder.AllValue)
 }
+
+func TestCurrentValueCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", StaticOptions("200", "404", "500"), Current("404"))
+
+	req.Equal([]string{"404"}, panel.Builder.Current.Text.Value)
+	req.Equal("404", panel.Builder.Current.Value)
+	req.Len(panel.Builder.Options, 3)
+	req.False(panel.Builder.Options[0].Selected)
+	req.True(panel.Builder.Options[1].Selected)
+}
+
+func TestSeveralCurrentValuesCanBeSet(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", Multiple(), Current("200", "500"))
+
+	req.Equal([]string{"200", "500"}, panel.Builder.Current.Text.Value)
+	req.Equal([]string{"200", "500"}, panel.Builder.Current.Value)
+}
+
+func TestStaticOptionsKeepTheAllOption(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", IncludeAll(), DefaultAll(), StaticOptions("200", "500"))
+
+	req.Len(panel.Builder.Options, 3)
+	req.Equal(All, panel.Builder.Options[0].Value)
+	req.True(panel.Builder.Options[0].Selected)
+	req.Equal("200", panel.Builder.Options[1].Value)
+}
+
+func TestDefinitionIsIncludedInTheModel(t *testing.T) {
+	req := require.New(t)
+
+	model, err := New("", Request("label_values(up, job)")).Model()
+
+	req.NoError(err)
+	req.Equal("label_values(up, job)", model["definition"])
+	req.Equal("label_values(up, job)", model["query"])
+}
+
+func TestPrometheusLabelValuesCanBeQueried(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", PrometheusLabelValues("instance", `up{job="api"}`))
+	model, err := panel.Model()
+
+	req.NoError(err)
+	req.Equal(`label_values(up{job="api"},instance)`, model["definition"])
+	req.Equal(map[string]interface{}{
+		"qryType": int(PrometheusLabelValuesQuery),
+		"query":   `label_values(up{job="api"},instance)`,
+		"refId":   prometheusRefID,
+	}, panel.Builder.Query)
+}
+
+func TestPrometheusLabelValuesCanBeQueriedWithoutSelector(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", PrometheusLabelValues("job", ""))
+
+	req.Equal("label_values(job)", panel.definition)
+}
+
+func TestPrometheusQueriesCanBeBuilt(t *testing.T) {
+	testCases := []struct {
+		option     Option
+		queryType  PrometheusQueryType
+		definition string
+	}{
+		{option: PrometheusLabelNames(), queryType: PrometheusLabelNamesQuery, definition: "label_names()"},
+		{option: PrometheusMetrics("http_.*"), queryType: PrometheusMetricsQuery, definition: "metrics(http_.*)"},
+		{option: PrometheusQueryResult("up"), queryType: PrometheusQueryResultQuery, definition: "query_result(up)"},
+		{option: PrometheusSeries(`up{job="api"}`), queryType: PrometheusSeriesQuery, definition: `up{job="api"}`},
+	}
+
+	for _, testCase := range testCases {
+		tc := testCase
+
+		t.Run(tc.definition, func(t *testing.T) {
+			req := require.New(t)
+
+			panel := New("", tc.option)
+			request := panel.Builder.Query.(map[string]interface{})
+
+			req.Equal(tc.definition, panel.definition)
+			req.Equal(int(tc.queryType), request["qryType"])
+			req.Equal(tc.definition, request["query"])
+		})
+	}
+}
+
+func TestLokiLabelNamesCanBeQueried(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", LokiLabelNames())
+
+	req.Equal("label_names()", panel.definition)
+	req.Equal(map[string]interface{}{
+		"type":  int(LokiLabelNamesQuery),
+		"refId": lokiRefID,
+	}, panel.Builder.Query)
+}
+
+func TestLokiLabelValuesCanBeQueried(t *testing.T) {
+	req := require.New(t)
+
+	panel := New("", LokiLabelValues("app", `{namespace="prod"}`))
+
+	req.Equal(`label_values({namespace="prod"},app)`, panel.definition)
+	req.Equal(map[string]interface{}{
+		"type":   int(LokiLabelValuesQuery),
+		"label":  "app",
+		"stream": `{namespace="prod"}`,
+		"refId":  lokiRefID,
+	}, panel.Builder.Query)
+}
@@ -0,0 +1,346 @@
+package grabana
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/sdk"
+)
+
+// ErrDashboardVersionNotFound is returned when the given version of a
+// dashboard can not be found.
+var ErrDashboardVersionNotFound = errors.New("dashboard version not found")
+
+// alertsSnapshotKey is the key under which the alerts of a dashboard are
+// saved within its JSON model, so that they are restored along with any of
+// its versions.
+const alertsSnapshotKey = "grabanaAlerts"
+
+const dashboardVersionsPerPage = 100
+
+// DashboardVersion describes a saved version of a dashboard.
+type DashboardVersion struct {
+	ID            uint      `json:"id"`
+	Version       uint      `json:"version"`
+	ParentVersion uint      `json:"parentVersion"`
+	RestoredFrom  uint      `json:"restoredFrom"`
+	Created       time.Time `json:"created"`
+	CreatedBy     string    `json:"createdBy"`
+	Message       string    `json:"message"`
+}
+
+// alertSnapshot is an alert saved within the JSON model of a dashboard:
+// either an alert defined by a builder, or a group of rules copied from
+// Grafana by a restoration or a promotion.
+type alertSnapshot struct {
+	Datasource *sdk.DatasourceRef `json:"datasource,omitempty"`
+	Group      *sdk.Alert         `json:"group,omitempty"`
+	RuleGroup  *ruleGroup         `json:"ruleGroup,omitempty"`
+}
+
+// DashboardVersions lists the saved versions of a dashboard, most recent
+// first.
+func (client *Client) DashboardVersions(ctx context.Context, uid string) ([]DashboardVersion, error) {
+	var versions []DashboardVersion
+
+	params := url.Values{}
+	params.Set("limit", fmt.Sprint(dashboardVersionsPerPage))
+
+	for {
+		pageVersions, continueToken, err := client.dashboardVersionsPage(ctx, uid, params)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, pageVersions...)
+
+		switch {
+		case continueToken != "":
+			params.Set("continueToken", continueToken)
+		case len(pageVersions) == dashboardVersionsPerPage && !params.Has("continueToken"):
+			params.Set("start", fmt.Sprint(len(versions)))
+		default:
+			return versions, nil
+		}
+	}
+}
+
+// dashboardVersionsPage fetches a page of versions. Depending on its
+// version, Grafana paginates them with an offset or with a continue token.
+func (client *Client) dashboardVersionsPage(ctx context.Context, uid string, params url.Values) ([]DashboardVersion, string, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/dashboards/uid/%s/versions?%s", url.PathEscape(uid), params.Encode()))
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrDashboardNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", client.httpError(resp)
+	}
+
+	var body json.RawMessage
+	if err := decodeJSON(resp.Body, &body); err != nil {
+		return nil, "", err
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		var versions []DashboardVersion
+		if err := json.Unmarshal(body, &versions); err != nil {
+			return nil, "", err
+		}
+
+		return versions, "", nil
+	}
+
+	var response struct {
+		ContinueToken string             `json:"continueToken"`
+		Versions      []DashboardVersion `json:"versions"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, "", err
+	}
+
+	return response.Versions, response.ContinueToken, nil
+}
+
+// DashboardVersion returns the given version of a dashboard.
+func (client *Client) DashboardVersion(ctx context.Context, uid string, version uint) (*sdk.Board, error) {
+	data, err := client.dashboardVersionData(ctx, uid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &sdk.Board{}
+	if err := json.Unmarshal(data, board); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func (client *Client) dashboardVersionData(ctx context.Context, uid string, version uint) (json.RawMessage, error) {
+	resp, err := client.get(ctx, fmt.Sprintf("/api/dashboards/uid/%s/versions/%d", url.PathEscape(uid), version))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardVersionNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// RestoreDashboardVersion restores a dashboard to the given version, by
+// saving that version as a new one.
+// The alerts saved along with that version replace the current alerts of the
+// dashboard: versions saved by grabana without any alert delete them. Versions
+// saved without snapshot of their alerts, like the ones edited in Grafana or
+// saved before grabana started taking snapshots, leave them untouched.
+func (client *Client) RestoreDashboardVersion(ctx context.Context, uid string, version uint) (*Dashboard, error) {
+	data, err := client.dashboardVersionData(ctx, uid, version)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &sdk.Board{}
+	if err := json.Unmarshal(data, board); err != nil {
+		return nil, err
+	}
+
+	alerts, ruleGroups, snapshotted, err := alertsFromSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not read alerts of version %d: %w", version, err)
+	}
+
+	buf, err := json.Marshal(struct {
+		Version uint `json:"version"`
+	}{
+		Version: version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.sendJSON(ctx, http.MethodPost, fmt.Sprintf("/api/dashboards/uid/%s/restore", url.PathEscape(uid)), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	var dashboardModel Dashboard
+	if err := decodeJSON(resp.Body, &dashboardModel); err != nil {
+		return nil, err
+	}
+
+	if !snapshotted {
+		return &dashboardModel, nil
+	}
+
+	folder, err := client.dashboardFolder(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	// rule groups copied from Grafana, or no alert at all
+	if len(alerts) == 0 {
+		if err := client.replaceDashboardRules(ctx, folder.UID, uid, ruleGroups); err != nil {
+			return nil, err
+		}
+
+		return &dashboardModel, nil
+	}
+
+	board.UID = uid
+	if err := client.replaceDashboardAlerts(ctx, folder.Title, board, alerts, nil); err != nil {
+		return nil, err
+	}
+
+	return &dashboardModel, nil
+}
+
+// dashboardFolder returns the folder holding a dashboard.
+func (client *Client) dashboardFolder(ctx context.Context, uid string) (*Folder, error) {
+	resp, err := client.get(ctx, "/api/dashboards/uid/"+url.PathEscape(uid))
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrDashboardNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, client.httpError(resp)
+	}
+
+	response := struct {
+		Meta struct {
+			FolderID    uint   `json:"folderId"`
+			FolderUID   string `json:"folderUid"`
+			FolderTitle string `json:"folderTitle"`
+		} `json:"meta"`
+	}{}
+	if err := decodeJSON(resp.Body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Folder{
+		ID:    response.Meta.FolderID,
+		UID:   response.Meta.FolderUID,
+		Title: response.Meta.FolderTitle,
+	}, nil
+}
+
+// withAlertsSnapshot saves the given alerts and rule groups within the JSON
+// model of a dashboard. The snapshot is saved even if it is empty: rolling
+// back to a version without alerts deletes the alerts of the dashboard.
+func withAlertsSnapshot(board []byte, alerts []*alert.Alert, ruleGroups []ruleGroup) ([]byte, error) {
+	model := map[string]json.RawMessage{}
+	if err := json.Unmarshal(board, &model); err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]alertSnapshot, 0, len(alerts)+len(ruleGroups))
+	for _, alert := range alerts {
+		snapshots = append(snapshots, alertSnapshot{
+			Datasource: alert.Datasource,
+			Group:      alert.Builder,
+		})
+	}
+	for i := range ruleGroups {
+		group, err := portableRuleGroup(ruleGroups[i])
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, alertSnapshot{RuleGroup: &group})
+	}
+
+	snapshotsJSON, err := json.Marshal(snapshots)
+	if err != nil {
+		return nil, err
+	}
+
+	model[alertsSnapshotKey] = snapshotsJSON
+
+	return json.Marshal(model)
+}
+
+// alertsFromSnapshot reads the alerts and rule groups saved within the JSON
+// model of a dashboard. It also tells whether the model holds a snapshot at
+// all: an empty snapshot means that the dashboard has no alerts, whereas a
+// missing one means that its alerts are unknown.
+func alertsFromSnapshot(board []byte) ([]*alert.Alert, []ruleGroup, bool, error) {
+	model := map[string]json.RawMessage{}
+	if err := json.Unmarshal(board, &model); err != nil {
+		return nil, nil, false, err
+	}
+
+	snapshotsJSON, ok := model[alertsSnapshotKey]
+	if !ok {
+		return nil, nil, false, nil
+	}
+
+	var snapshots []alertSnapshot
+	if err := json.Unmarshal(snapshotsJSON, &snapshots); err != nil {
+		return nil, nil, false, err
+	}
+
+	var alerts []*alert.Alert
+	var ruleGroups []ruleGroup
+	for _, snapshot := range snapshots {
+		if snapshot.RuleGroup != nil {
+			ruleGroups = append(ruleGroups, *snapshot.RuleGroup)
+		}
+		if snapshot.Group == nil {
+			continue
+		}
+
+		// empty annotations are omitted from the JSON model
+		for i := range snapshot.Group.Rules {
+			if snapshot.Group.Rules[i].Annotations == nil {
+				snapshot.Group.Rules[i].Annotations = map[string]string{}
+			}
+		}
+
+		alerts = append(alerts, &alert.Alert{
+			Builder:    snapshot.Group,
+			Datasource: snapshot.Datasource,
+		})
+	}
+
+	return alerts, ruleGroups, true, nil
+}
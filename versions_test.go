@@ -0,0 +1,354 @@
+package grabana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/K-Phoen/grabana/alert"
+	"github.com/K-Phoen/grabana/dashboard"
+	"github.com/K-Phoen/grabana/row"
+	"github.com/K-Phoen/grabana/timeseries"
+	"github.com/K-Phoen/sdk"
+	"github.com/stretchr/testify/require"
+)
+
+func dashboardWithAlert(t *testing.T) dashboard.Builder {
+	t.Helper()
+
+	builder, err := dashboard.New(
+		"Dashboard with alert",
+		dashboard.UID("dashboard-uid"),
+		dashboard.Row(
+			"Row",
+			row.WithTimeSeries(
+				"Heap allocations",
+				timeseries.DataSource("Prometheus"),
+				timeseries.WithPrometheusTarget("sum(go_memstats_heap_alloc_bytes)"),
+				timeseries.Alert(
+					"Too many heap allocations",
+					alert.WithPrometheusQuery("A", "sum(go_memstats_heap_alloc_bytes)"),
+					alert.If(alert.Avg, "A", alert.IsAbove(3)),
+				),
+			),
+		),
+	)
+	require.NoError(t, err)
+
+	return builder
+}
+
+func TestDashboardVersionsCanBeListed(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/dashboards/uid/dashboard-uid/versions", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `[
+	{"id": 12, "version": 3, "parentVersion": 2, "created": "2023-06-01T10:00:00Z", "createdBy": "admin", "message": "fix"},
+	{"id": 11, "version": 2, "parentVersion": 1, "created": "2023-05-01T10:00:00Z", "createdBy": "admin"}
+]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	versions, err := client.DashboardVersions(context.TODO(), "dashboard-uid")
+
+	req.NoError(err)
+	req.Len(versions, 2)
+	req.Equal(uint(3), versions[0].Version)
+	req.Equal(uint(2), versions[0].ParentVersion)
+	req.Equal("fix", versions[0].Message)
+	req.Equal(2023, versions[0].Created.Year())
+}
+
+func TestDashboardVersionsArePaginatedWithContinueTokens(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("continueToken") == "" {
+			_, _ = fmt.Fprintln(w, `{"continueToken": "next", "versions": [{"id": 12, "version": 3}]}`)
+			return
+		}
+
+		req.Equal("next", r.URL.Query().Get("continueToken"))
+		_, _ = fmt.Fprintln(w, `{"continueToken": "", "versions": [{"id": 11, "version": 2}]}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	versions, err := client.DashboardVersions(context.TODO(), "dashboard-uid")
+
+	req.NoError(err)
+	req.Len(versions, 2)
+	req.Equal(uint(2), versions[1].Version)
+}
+
+func TestDashboardVersionsOfAnUnknownDashboardReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Dashboard not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.DashboardVersions(context.TODO(), "dashboard-uid")
+
+	req.ErrorIs(err, ErrDashboardNotFound)
+}
+
+func TestADashboardVersionCanBeFetched(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req.Equal("/api/dashboards/uid/dashboard-uid/versions/2", r.URL.Path)
+
+		_, _ = fmt.Fprintln(w, `{"id": 11, "version": 2, "data": {"uid": "dashboard-uid", "title": "Old title", "version": 2}}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	board, err := client.DashboardVersion(context.TODO(), "dashboard-uid", 2)
+
+	req.NoError(err)
+	req.Equal("Old title", board.Title)
+}
+
+func TestFetchingAnUnknownDashboardVersionReturnsASpecificError(t *testing.T) {
+	req := require.New(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintln(w, `{"message": "Dashboard version not found"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.DashboardVersion(context.TODO(), "dashboard-uid", 42)
+
+	req.ErrorIs(err, ErrDashboardVersionNotFound)
+}
+
+func TestRestoringAVersionWithoutAlertsSnapshotLeavesAlertsUntouched(t *testing.T) {
+	req := require.New(t)
+	restored := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid/versions/2":
+			_, _ = fmt.Fprintln(w, `{"version": 2, "data": {"uid": "dashboard-uid", "title": "Old title"}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/uid/dashboard-uid/restore":
+			restored = true
+
+			payload := map[string]interface{}{}
+			req.NoError(json.NewDecoder(r.Body).Decode(&payload))
+			req.EqualValues(2, payload["version"])
+
+			_, _ = fmt.Fprintln(w, `{"status": "success", "uid": "dashboard-uid", "url": "/d/dashboard-uid/old-title", "version": 4}`)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	dashboardModel, err := client.RestoreDashboardVersion(context.TODO(), "dashboard-uid", 2)
+
+	req.NoError(err)
+	req.True(restored)
+	req.Equal("/d/dashboard-uid/old-title", dashboardModel.URL)
+}
+
+func TestRestoringAVersionRecreatesItsAlerts(t *testing.T) {
+	req := require.New(t)
+
+	builder := dashboardWithAlert(t)
+	boardJSON, err := builder.MarshalJSON()
+	req.NoError(err)
+	versionData, err := withAlertsSnapshot(boardJSON, builder.Alerts(), nil)
+	req.NoError(err)
+
+	previousAlertDeleted := false
+	var createdAlert sdk.Alert
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid/versions/2":
+			_, _ = fmt.Fprintf(w, `{"version": 2, "data": %s}`, versionData)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/uid/dashboard-uid/restore":
+			_, _ = fmt.Fprintln(w, `{"status": "success", "uid": "dashboard-uid", "version": 4}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid":
+			_, _ = fmt.Fprintln(w, `{"meta": {"folderTitle": "Infra"}, "dashboard": {"uid": "dashboard-uid"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			req.Equal("dashboard-uid", r.URL.Query().Get("dashboard_uid"))
+			_, _ = fmt.Fprintln(w, `{"Infra": [{"name": "Newer alert"}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/ruler/grafana/api/v1/rules/Infra/Newer alert":
+			previousAlertDeleted = true
+			w.WriteHeader(http.StatusAccepted)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/ruler/grafana/api/v1/rules/Infra/Heap allocations":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/datasources":
+			_, _ = fmt.Fprintln(w, `[{"uid": "prom-uid", "name": "Prometheus", "type": "prometheus", "isDefault": true}]`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/ruler/grafana/api/v1/rules/Infra":
+			req.NoError(json.NewDecoder(r.Body).Decode(&createdAlert))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.RestoreDashboardVersion(context.TODO(), "dashboard-uid", 2)
+
+	req.NoError(err)
+	req.True(previousAlertDeleted)
+	req.Equal("Heap allocations", createdAlert.Name)
+	req.Len(createdAlert.Rules, 1)
+	req.Equal("dashboard-uid", createdAlert.Rules[0].Annotations["__dashboardUid__"])
+	req.Equal("prom-uid", createdAlert.Rules[0].GrafanaAlert.Data[1].DatasourceUID)
+}
+
+func TestAlertsSnapshotsCanBeReadBack(t *testing.T) {
+	req := require.New(t)
+	builder := dashboardWithAlert(t)
+
+	boardJSON, err := builder.MarshalJSON()
+	req.NoError(err)
+
+	ruleGroups := []ruleGroup{
+		{Name: "Copied", Rules: []json.RawMessage{json.RawMessage(`{"grafana_alert": {"uid": "rule-uid", "title": "Copied"}}`)}},
+	}
+
+	withSnapshot, err := withAlertsSnapshot(boardJSON, builder.Alerts(), ruleGroups)
+	req.NoError(err)
+
+	alerts, snapshotGroups, snapshotted, err := alertsFromSnapshot(withSnapshot)
+	req.NoError(err)
+	req.True(snapshotted)
+	req.Len(alerts, 1)
+	req.Equal("Heap allocations", alerts[0].Builder.Name)
+	req.Equal(builder.Alerts()[0].Datasource, alerts[0].Datasource)
+	req.Len(snapshotGroups, 1)
+	req.Equal("Copied", snapshotGroups[0].Name)
+	// UIDs of rules are specific to the instance they were copied from
+	req.JSONEq(`{"grafana_alert": {"title": "Copied"}}`, string(snapshotGroups[0].Rules[0]))
+
+	alerts, snapshotGroups, snapshotted, err = alertsFromSnapshot(boardJSON)
+	req.NoError(err)
+	req.False(snapshotted)
+	req.Empty(alerts)
+	req.Empty(snapshotGroups)
+}
+
+func TestDashboardsWithoutAlertsHaveAnEmptyAlertsSnapshot(t *testing.T) {
+	req := require.New(t)
+
+	builder, err := dashboard.New("Without alerts")
+	req.NoError(err)
+
+	boardJSON, err := builder.MarshalJSON()
+	req.NoError(err)
+
+	withSnapshot, err := withAlertsSnapshot(boardJSON, builder.Alerts(), nil)
+	req.NoError(err)
+
+	alerts, ruleGroups, snapshotted, err := alertsFromSnapshot(withSnapshot)
+	req.NoError(err)
+	req.True(snapshotted)
+	req.Empty(alerts)
+	req.Empty(ruleGroups)
+}
+
+func TestRestoringAVersionWithAnEmptyAlertsSnapshotDeletesTheAlertsOfTheDashboard(t *testing.T) {
+	req := require.New(t)
+
+	newerAlertDeleted := false
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid/versions/2":
+			_, _ = fmt.Fprintln(w, `{"version": 2, "data": {"uid": "dashboard-uid", "title": "Old title", "grabanaAlerts": []}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/uid/dashboard-uid/restore":
+			_, _ = fmt.Fprintln(w, `{"status": "success", "uid": "dashboard-uid", "version": 4}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid":
+			_, _ = fmt.Fprintln(w, `{"meta": {"folderUid": "infra-uid", "folderTitle": "Infra"}, "dashboard": {"uid": "dashboard-uid"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			req.Equal("dashboard-uid", r.URL.Query().Get("dashboard_uid"))
+			_, _ = fmt.Fprintln(w, `{"Infra": [{"name": "Newer alert", "rules": [{"annotations": {"__dashboardUid__": "dashboard-uid"}, "grafana_alert": {"uid": "newer-rule", "title": "Newer alert", "namespace_uid": "infra-uid"}}]}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/infra-uid/Newer alert":
+			_, _ = fmt.Fprintln(w, `{"name": "Newer alert", "rules": [{"annotations": {"__dashboardUid__": "dashboard-uid"}, "grafana_alert": {"uid": "newer-rule", "title": "Newer alert"}}]}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/ruler/grafana/api/v1/rules/infra-uid/Newer alert":
+			newerAlertDeleted = true
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err := client.RestoreDashboardVersion(context.TODO(), "dashboard-uid", 2)
+
+	req.NoError(err)
+	req.True(newerAlertDeleted)
+}
+
+func TestRestoringAVersionRecreatesItsRuleGroups(t *testing.T) {
+	req := require.New(t)
+
+	boardJSON := []byte(`{"uid": "dashboard-uid", "title": "Promoted"}`)
+	versionData, err := withAlertsSnapshot(boardJSON, nil, []ruleGroup{
+		{Name: "Nodes down", Interval: "1m", Rules: []json.RawMessage{
+			json.RawMessage(`{"annotations": {"__dashboardUid__": "dashboard-uid"}, "grafana_alert": {"title": "Nodes down", "is_paused": true}}`),
+		}},
+	})
+	req.NoError(err)
+
+	var createdGroup ruleGroup
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid/versions/2":
+			_, _ = fmt.Fprintf(w, `{"version": 2, "data": %s}`, versionData)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/dashboards/uid/dashboard-uid/restore":
+			_, _ = fmt.Fprintln(w, `{"status": "success", "uid": "dashboard-uid", "version": 4}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/dashboards/uid/dashboard-uid":
+			_, _ = fmt.Fprintln(w, `{"meta": {"folderUid": "infra-uid", "folderTitle": "Infra"}, "dashboard": {"uid": "dashboard-uid"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules":
+			req.Equal("dashboard-uid", r.URL.Query().Get("dashboard_uid"))
+			_, _ = fmt.Fprintln(w, `{}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/infra-uid/Nodes down":
+			_, _ = fmt.Fprintln(w, `{"name": "Nodes down", "rules": [{"annotations": {"__dashboardUid__": "other"}, "grafana_alert": {"uid": "other-rule", "title": "Other"}}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/ruler/grafana/api/v1/rules/infra-uid":
+			req.NoError(json.NewDecoder(r.Body).Decode(&createdGroup))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(http.DefaultClient, ts.URL)
+
+	_, err = client.RestoreDashboardVersion(context.TODO(), "dashboard-uid", 2)
+
+	req.NoError(err)
+	req.Equal("Nodes down", createdGroup.Name)
+	req.Len(createdGroup.Rules, 2)
+	req.Equal("other-rule", summarizeRule(createdGroup.Rules[0]).GrafanaAlert.UID)
+	req.Contains(string(createdGroup.Rules[1]), `"is_paused":true`)
+}